@@ -0,0 +1,152 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package queryv2
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	"github.com/jaegertracing/jaeger-idl/model/converter/apiv2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+)
+
+var _ api_v2.QueryServiceClient = (*Client)(nil)
+
+// Client implements the api_v2 QueryServiceClient by calling the api_v3
+// QueryService. ArchiveTrace and GetDependencies have no api_v3 equivalent
+// and fail with codes.Unimplemented.
+type Client struct {
+	v3 api_v3.QueryServiceClient
+}
+
+// NewClient returns a Client calling the api_v3 QueryService over cc.
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{v3: api_v3.NewQueryServiceClient(cc)}
+}
+
+// GetTrace returns the spans of a trace. Like the api_v2 QueryService,
+// the stream fails with codes.NotFound if the trace does not exist.
+func (c *Client) GetTrace(ctx context.Context, in *api_v2.GetTraceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[api_v2.SpansResponseChunk], error) {
+	stream, err := c.v3.GetTrace(ctx, &api_v3.GetTraceRequest{
+		TraceId:   hex.EncodeToString(in.GetTraceId()),
+		StartTime: in.GetStartTime(),
+		EndTime:   in.GetEndTime(),
+		RawTraces: in.GetRawTraces(),
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &spansStream{ServerStreamingClient: stream, notFoundIfEmpty: true}, nil
+}
+
+// ArchiveTrace is not supported by api_v3.
+func (*Client) ArchiveTrace(context.Context, *api_v2.ArchiveTraceRequest, ...grpc.CallOption) (*api_v2.ArchiveTraceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "ArchiveTrace is not supported by api_v3")
+}
+
+// FindTraces returns the spans of the traces matching the query.
+// The tags of the query become api_v3 attributes.
+func (c *Client) FindTraces(ctx context.Context, in *api_v2.FindTracesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[api_v2.SpansResponseChunk], error) {
+	query := in.GetQuery()
+	stream, err := c.v3.FindTraces(ctx, &api_v3.FindTracesRequest{
+		Query: &api_v3.TraceQueryParameters{
+			ServiceName:   query.GetServiceName(),
+			OperationName: query.GetOperationName(),
+			Attributes:    query.GetTags(),
+			StartTimeMin:  query.GetStartTimeMin(),
+			StartTimeMax:  query.GetStartTimeMax(),
+			DurationMin:   query.GetDurationMin(),
+			DurationMax:   query.GetDurationMax(),
+			SearchDepth:   query.GetSearchDepth(),
+			RawTraces:     query.GetRawTraces(),
+		},
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &spansStream{ServerStreamingClient: stream}, nil
+}
+
+// GetServices returns the service names.
+func (c *Client) GetServices(ctx context.Context, _ *api_v2.GetServicesRequest, opts ...grpc.CallOption) (*api_v2.GetServicesResponse, error) {
+	resp, err := c.v3.GetServices(ctx, &api_v3.GetServicesRequest{}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &api_v2.GetServicesResponse{Services: resp.GetServices()}, nil
+}
+
+// GetOperations returns the operations of a service, both as operations
+// and as the deprecated list of operation names.
+func (c *Client) GetOperations(ctx context.Context, in *api_v2.GetOperationsRequest, opts ...grpc.CallOption) (*api_v2.GetOperationsResponse, error) {
+	resp, err := c.v3.GetOperations(ctx, &api_v3.GetOperationsRequest{
+		Service:  in.GetService(),
+		SpanKind: in.GetSpanKind(),
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	result := &api_v2.GetOperationsResponse{}
+	for _, op := range resp.GetOperations() {
+		result.OperationNames = append(result.OperationNames, op.GetName())
+		result.Operations = append(result.Operations, &api_v2.Operation{
+			Name:     op.GetName(),
+			SpanKind: op.GetSpanKind(),
+		})
+	}
+	return result, nil
+}
+
+// GetDependencies is not supported by api_v3.
+func (*Client) GetDependencies(context.Context, *api_v2.GetDependenciesRequest, ...grpc.CallOption) (*api_v2.GetDependenciesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "GetDependencies is not supported by api_v3")
+}
+
+// spansStream converts the OTLP traces of an api_v3 stream into api_v2 span chunks.
+type spansStream struct {
+	grpc.ServerStreamingClient[tracev1.TracesData]
+
+	notFoundIfEmpty bool
+	received        bool
+}
+
+func (s *spansStream) Recv() (*api_v2.SpansResponseChunk, error) {
+	td, err := s.ServerStreamingClient.Recv()
+	if errors.Is(err, io.EOF) && s.notFoundIfEmpty && !s.received {
+		return nil, status.Error(codes.NotFound, "trace not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.received = true
+	spans, err := apiv2.SpansToProto(otlp.ToDomain(td))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api_v2.SpansResponseChunk{Spans: spans}, nil
+}
+
+// RecvMsg receives the next chunk into m, which must be a *api_v2.SpansResponseChunk.
+func (s *spansStream) RecvMsg(m any) error {
+	chunk, ok := m.(*api_v2.SpansResponseChunk)
+	if !ok {
+		return status.Errorf(codes.Internal, "cannot receive into %T", m)
+	}
+	next, err := s.Recv()
+	if err != nil {
+		return err
+	}
+	chunk.Spans = next.Spans
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package queryv2
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+var testTraceID = model.NewTraceID(0x0102030405060708, 0x090a0b0c0d0e0f10)
+
+// fakeQueryService is an api_v3 QueryService serving a single trace.
+type fakeQueryService struct {
+	api_v3.UnimplementedQueryServiceServer
+
+	findRequest *api_v3.FindTracesRequest
+}
+
+func (*fakeQueryService) GetTrace(req *api_v3.GetTraceRequest, stream grpc.ServerStreamingServer[tracev1.TracesData]) error {
+	if req.TraceId != testTraceID.String() {
+		return nil
+	}
+	return stream.Send(testTrace())
+}
+
+func (f *fakeQueryService) FindTraces(req *api_v3.FindTracesRequest, stream grpc.ServerStreamingServer[tracev1.TracesData]) error {
+	f.findRequest = req
+	return stream.Send(testTrace())
+}
+
+func (*fakeQueryService) GetServices(context.Context, *api_v3.GetServicesRequest) (*api_v3.GetServicesResponse, error) {
+	return &api_v3.GetServicesResponse{Services: []string{"frontend", "backend"}}, nil
+}
+
+func (*fakeQueryService) GetOperations(_ context.Context, req *api_v3.GetOperationsRequest) (*api_v3.GetOperationsResponse, error) {
+	return &api_v3.GetOperationsResponse{Operations: []*api_v3.Operation{
+		{Name: req.Service + " op", SpanKind: req.SpanKind},
+	}}, nil
+}
+
+func testTrace() *tracev1.TracesData {
+	return otlp.FromDomain([]*model.Span{{
+		TraceID:       testTraceID,
+		SpanID:        model.NewSpanID(1),
+		OperationName: "get /users",
+		StartTime:     time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC),
+		Duration:      time.Millisecond,
+		Process:       model.NewProcess("frontend", nil),
+	}})
+}
+
+func newTestClient(t *testing.T) (*Client, *fakeQueryService) {
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	fake := &fakeQueryService{}
+	api_v3.RegisterQueryServiceServer(server, fake)
+	go server.Serve(lis)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+	return NewClient(conn), fake
+}
+
+func readChunks(t *testing.T, stream grpc.ServerStreamingClient[api_v2.SpansResponseChunk]) []*api_v2.Span {
+	var spans []*api_v2.Span
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return spans
+		}
+		require.NoError(t, err)
+		spans = append(spans, chunk.Spans...)
+	}
+}
+
+func TestGetTrace(t *testing.T) {
+	client, _ := newTestClient(t)
+	traceID := make([]byte, 16)
+	testTraceID.MarshalTo(traceID)
+
+	stream, err := client.GetTrace(context.Background(), &api_v2.GetTraceRequest{TraceId: traceID})
+	require.NoError(t, err)
+	spans := readChunks(t, stream)
+	require.Len(t, spans, 1)
+	assert.Equal(t, traceID, spans[0].TraceId)
+	assert.Equal(t, "get /users", spans[0].OperationName)
+	assert.Equal(t, "frontend", spans[0].Process.ServiceName)
+	assert.Equal(t, time.Millisecond, spans[0].Duration.AsDuration())
+}
+
+func TestGetTraceNotFound(t *testing.T) {
+	client, _ := newTestClient(t)
+	stream, err := client.GetTrace(context.Background(), &api_v2.GetTraceRequest{TraceId: make([]byte, 16)})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestFindTraces(t *testing.T) {
+	client, fake := newTestClient(t)
+	stream, err := client.FindTraces(context.Background(), &api_v2.FindTracesRequest{
+		Query: &api_v2.TraceQueryParameters{
+			ServiceName:   "frontend",
+			OperationName: "get /users",
+			Tags:          map[string]string{"http.status_code": "500"},
+			DurationMin:   durationpb.New(time.Millisecond),
+			SearchDepth:   20,
+		},
+	})
+	require.NoError(t, err)
+	chunk := &api_v2.SpansResponseChunk{}
+	require.NoError(t, stream.RecvMsg(chunk))
+	assert.Len(t, chunk.Spans, 1)
+	assert.Equal(t, io.EOF, stream.RecvMsg(chunk))
+
+	query := fake.findRequest.Query
+	assert.Equal(t, "frontend", query.ServiceName)
+	assert.Equal(t, "get /users", query.OperationName)
+	assert.Equal(t, map[string]string{"http.status_code": "500"}, query.Attributes)
+	assert.Equal(t, time.Millisecond, query.DurationMin.AsDuration())
+	assert.Equal(t, int32(20), query.SearchDepth)
+}
+
+func TestGetServicesAndOperations(t *testing.T) {
+	client, _ := newTestClient(t)
+	services, err := client.GetServices(context.Background(), &api_v2.GetServicesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend", "backend"}, services.Services)
+
+	operations, err := client.GetOperations(context.Background(), &api_v2.GetOperationsRequest{Service: "frontend", SpanKind: "server"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend op"}, operations.OperationNames)
+	require.Len(t, operations.Operations, 1)
+	assert.Equal(t, "server", operations.Operations[0].SpanKind)
+}
+
+func TestUnsupportedMethods(t *testing.T) {
+	client, _ := newTestClient(t)
+	_, err := client.ArchiveTrace(context.Background(), &api_v2.ArchiveTraceRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	_, err = client.GetDependencies(context.Background(), &api_v2.GetDependenciesRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
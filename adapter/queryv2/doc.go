@@ -0,0 +1,7 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package queryv2 implements the api_v2 QueryServiceClient on top of an
+// api_v3 connection, so that code written against api_v2 can migrate to
+// the api_v3 transport first and to the api_v3 types later.
+package queryv2
@@ -0,0 +1,17 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package streamcheck detects truncated or corrupted gRPC response streams,
+// e.g. the chunks of a long FindTraces response going through a proxy that
+// drops the connection but ends the stream cleanly.
+//
+// The server interceptor computes a rolling CRC-32C checksum of the messages
+// it sends and returns it, with the number of messages, in the trailing
+// metadata of the stream. The client interceptor computes the same checksum
+// of the messages it receives and fails the stream with codes.DataLoss when
+// they do not match.
+//
+// The checksum covers the deterministic protobuf encoding of each message
+// prefixed with its length, which both sides compute from the message, so
+// the server and the client must use the same version of the IDL.
+package streamcheck
@@ -0,0 +1,189 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package streamcheck
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Trailing metadata keys of the checksummed streams.
+const (
+	// ChecksumTrailer is the checksum of the messages of the stream, as
+	// "crc32c:" followed by 8 hex digits.
+	ChecksumTrailer = "x-jaeger-stream-checksum"
+	// ChunksTrailer is the number of messages of the stream, in decimal.
+	ChunksTrailer = "x-jaeger-stream-chunks"
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// digest is the rolling checksum of the messages of a stream.
+type digest struct {
+	crc    uint32
+	chunks int
+}
+
+// EncodedMessage is implemented by the messages that a server sends already
+// encoded, through a codec passing their encoding as is. Their checksum
+// covers that encoding, which must be the deterministic encoding of the
+// message decoded by the client.
+type EncodedMessage interface {
+	EncodedMessage() []byte
+}
+
+func (d *digest) add(m any) error {
+	var data []byte
+	switch msg := m.(type) {
+	case EncodedMessage:
+		data = msg.EncodedMessage()
+	case proto.Message:
+		var err error
+		data, err = proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("cannot checksum message: %w", err)
+		}
+	default:
+		return fmt.Errorf("cannot checksum message of type %T", m)
+	}
+	var size [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(size[:], uint64(len(data)))
+	d.crc = crc32.Update(d.crc, crc32c, size[:n])
+	d.crc = crc32.Update(d.crc, crc32c, data)
+	d.chunks++
+	return nil
+}
+
+func (d *digest) checksum() string {
+	return fmt.Sprintf("crc32c:%08x", d.crc)
+}
+
+// StreamServerInterceptor sends the checksum of the messages of the
+// server-streaming RPCs that succeed in their trailing metadata.
+func StreamServerInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if !info.IsServerStream {
+		return handler(srv, ss)
+	}
+	s := &serverStream{ServerStream: ss}
+	if err := handler(srv, s); err != nil {
+		return err
+	}
+	if s.err == nil {
+		ss.SetTrailer(metadata.Pairs(
+			ChecksumTrailer, s.digest.checksum(),
+			ChunksTrailer, strconv.Itoa(s.digest.chunks),
+		))
+	}
+	return nil
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	digest digest
+	// err is why the checksum cannot be computed, in which case no trailer is sent.
+	err error
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	if err := s.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	if s.err == nil {
+		s.err = s.digest.add(m)
+	}
+	return nil
+}
+
+// ClientOptions controls the verification of the streams by the client interceptor.
+type ClientOptions struct {
+	// Required fails the streams without a checksum, e.g. from servers that
+	// do not compute it, instead of accepting them unverified.
+	Required bool
+}
+
+// StreamClientInterceptor verifies the checksum of the messages received on
+// server-streaming RPCs when the stream ends: the final RecvMsg fails with
+// codes.DataLoss instead of io.EOF if messages were lost or altered.
+func StreamClientInterceptor(opts ClientOptions) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		callOpts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil || !desc.ServerStreams {
+			return cs, err
+		}
+		return &clientStream{ClientStream: cs, opts: opts}, nil
+	}
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	opts   ClientOptions
+	digest digest
+	// end is the error returned at the end of the stream, once verified.
+	end error
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	if s.end != nil {
+		return s.end
+	}
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		if err := s.digest.add(m); err != nil {
+			s.end = status.Error(codes.Internal, err.Error())
+			return s.end
+		}
+		return nil
+	case errors.Is(err, io.EOF):
+		s.end = err
+		if verr := s.verify(); verr != nil {
+			s.end = verr
+		}
+		return s.end
+	default:
+		return err
+	}
+}
+
+// verify compares the checksum of the received messages with the trailer.
+func (s *clientStream) verify() error {
+	trailer := s.Trailer()
+	checksums, chunks := trailer.Get(ChecksumTrailer), trailer.Get(ChunksTrailer)
+	if len(checksums) == 0 || len(chunks) == 0 {
+		if s.opts.Required {
+			return status.Error(codes.DataLoss, "stream ended without a checksum")
+		}
+		return nil
+	}
+	got := s.digest.checksum()
+	if checksums[0] != got || chunks[0] != strconv.Itoa(s.digest.chunks) {
+		return status.Errorf(codes.DataLoss,
+			"stream checksum mismatch: received %d messages with checksum %s, the server sent %s messages with checksum %s",
+			s.digest.chunks, got, chunks[0], checksums[0])
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package streamcheck
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+const testChunks = 3
+
+// fakeQueryService streams a trace per chunk.
+type fakeQueryService struct {
+	api_v3.UnimplementedQueryServiceServer
+}
+
+func (*fakeQueryService) FindTraces(req *api_v3.FindTracesRequest, stream grpc.ServerStreamingServer[tracev1.TracesData]) error {
+	if req.Query.GetServiceName() == "" {
+		return status.Error(codes.InvalidArgument, "missing service name")
+	}
+	for i := range testChunks {
+		err := stream.Send(&tracev1.TracesData{ResourceSpans: []*tracev1.ResourceSpans{{
+			ScopeSpans: []*tracev1.ScopeSpans{{Spans: []*tracev1.Span{{Name: "span " + strconv.Itoa(i)}}}},
+		}}})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tamperingStream alters the messages sent after the checksum, like a faulty proxy.
+type tamperingStream struct {
+	grpc.ServerStream
+	sent   int
+	tamper func(i int, td *tracev1.TracesData) bool
+}
+
+func (s *tamperingStream) SendMsg(m any) error {
+	td := proto.Clone(m.(*tracev1.TracesData)).(*tracev1.TracesData)
+	i := s.sent
+	s.sent++
+	if !s.tamper(i, td) {
+		return nil
+	}
+	return s.ServerStream.SendMsg(td)
+}
+
+type serverOptions struct {
+	checksum bool
+	// tamper returns whether to send the message, after changing it.
+	tamper func(i int, td *tracev1.TracesData) bool
+}
+
+func newTestClient(t *testing.T, server serverOptions, client ClientOptions) api_v3.QueryServiceClient {
+	var interceptors []grpc.StreamServerInterceptor
+	if server.tamper != nil {
+		interceptors = append(interceptors, func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, &tamperingStream{ServerStream: ss, tamper: server.tamper})
+		})
+	}
+	if server.checksum {
+		interceptors = append(interceptors, StreamServerInterceptor)
+	}
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(grpc.ChainStreamInterceptor(interceptors...))
+	api_v3.RegisterQueryServiceServer(s, &fakeQueryService{})
+	go s.Serve(lis)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStreamInterceptor(StreamClientInterceptor(client)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+	})
+	return api_v3.NewQueryServiceClient(conn)
+}
+
+// findTraces returns the number of chunks received and the error ending the stream.
+func findTraces(t *testing.T, client api_v3.QueryServiceClient, service string) (int, error) {
+	stream, err := client.FindTraces(context.Background(), &api_v3.FindTracesRequest{
+		Query: &api_v3.TraceQueryParameters{ServiceName: service},
+	})
+	require.NoError(t, err)
+	chunks := 0
+	for {
+		if _, err := stream.Recv(); err != nil {
+			// the end of the stream is stable
+			_, again := stream.Recv()
+			assert.Equal(t, err, again)
+			return chunks, err
+		}
+		chunks++
+	}
+}
+
+func TestChecksumMatches(t *testing.T) {
+	client := newTestClient(t, serverOptions{checksum: true}, ClientOptions{Required: true})
+	chunks, err := findTraces(t, client, "frontend")
+	assert.Equal(t, testChunks, chunks)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestChecksumMismatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(i int, td *tracev1.TracesData) bool
+		chunks int
+	}{
+		{
+			name:   "dropped message",
+			tamper: func(i int, _ *tracev1.TracesData) bool { return i != 1 },
+			chunks: testChunks - 1,
+		},
+		{
+			name: "altered message",
+			tamper: func(i int, td *tracev1.TracesData) bool {
+				if i == 2 {
+					td.ResourceSpans[0].ScopeSpans[0].Spans[0].Name = "altered"
+				}
+				return true
+			},
+			chunks: testChunks,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, serverOptions{checksum: true, tamper: test.tamper}, ClientOptions{})
+			chunks, err := findTraces(t, client, "frontend")
+			assert.Equal(t, test.chunks, chunks)
+			assert.Equal(t, codes.DataLoss, status.Code(err))
+			assert.ErrorContains(t, err, "checksum mismatch")
+		})
+	}
+}
+
+func TestMissingChecksum(t *testing.T) {
+	client := newTestClient(t, serverOptions{}, ClientOptions{})
+	chunks, err := findTraces(t, client, "frontend")
+	assert.Equal(t, testChunks, chunks)
+	assert.Equal(t, io.EOF, err)
+
+	client = newTestClient(t, serverOptions{}, ClientOptions{Required: true})
+	chunks, err = findTraces(t, client, "frontend")
+	assert.Equal(t, testChunks, chunks)
+	assert.Equal(t, codes.DataLoss, status.Code(err))
+}
+
+func TestFailedStreamHasNoChecksum(t *testing.T) {
+	client := newTestClient(t, serverOptions{checksum: true}, ClientOptions{Required: true})
+	_, err := findTraces(t, client, "")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServerTrailer(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(grpc.StreamInterceptor(StreamServerInterceptor))
+	api_v3.RegisterQueryServiceServer(s, &fakeQueryService{})
+	go s.Serve(lis)
+	defer s.Stop()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var trailer metadata.MD
+	stream, err := api_v3.NewQueryServiceClient(conn).FindTraces(context.Background(),
+		&api_v3.FindTracesRequest{Query: &api_v3.TraceQueryParameters{ServiceName: "frontend"}},
+		grpc.Trailer(&trailer))
+	require.NoError(t, err)
+	for {
+		if _, err := stream.Recv(); err != nil {
+			require.True(t, errors.Is(err, io.EOF))
+			break
+		}
+	}
+	assert.Equal(t, []string{strconv.Itoa(testChunks)}, trailer.Get(ChunksTrailer))
+	require.Len(t, trailer.Get(ChecksumTrailer), 1)
+	assert.Regexp(t, `^crc32c:[0-9a-f]{8}$`, trailer.Get(ChecksumTrailer)[0])
+}
+
+func TestDigest(t *testing.T) {
+	var a, b, c digest
+	require.NoError(t, a.add(&tracev1.TracesData{}))
+	require.NoError(t, a.add(&tracev1.Span{Name: "x"}))
+	require.NoError(t, b.add(&tracev1.TracesData{}))
+	require.NoError(t, b.add(&tracev1.Span{Name: "x"}))
+	assert.Equal(t, a, b)
+	// the order of the messages matters
+	require.NoError(t, c.add(&tracev1.Span{Name: "x"}))
+	require.NoError(t, c.add(&tracev1.TracesData{}))
+	assert.NotEqual(t, a.checksum(), c.checksum())
+
+	require.Error(t, a.add("not a message"))
+}
+
+// encodedMessage is a message sent already encoded.
+type encodedMessage []byte
+
+func (m encodedMessage) EncodedMessage() []byte {
+	return m
+}
+
+func TestDigestOfEncodedMessage(t *testing.T) {
+	span := &tracev1.Span{Name: "x"}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(span)
+	require.NoError(t, err)
+	var a, b digest
+	require.NoError(t, a.add(span))
+	require.NoError(t, b.add(encodedMessage(data)))
+	assert.Equal(t, a, b)
+}
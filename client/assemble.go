@@ -0,0 +1,131 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/apiv2"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// Assembler reassembles the chunks of an api_v3 FindTraces or GetTrace
+// response into complete traces. A chunk may hold the spans of several
+// traces, and the spans of a trace may be split across chunks, so the spans
+// are grouped by trace ID, and the ResourceSpans and ScopeSpans of a trace
+// with the same resource and scope are merged.
+//
+// The assembled traces share the resources, scopes and spans of the chunks,
+// which must not be modified afterwards. The zero value is ready to use.
+type Assembler struct {
+	traces []*tracev1.TracesData
+	byID   map[string]*tracev1.TracesData
+}
+
+// Add adds the spans of a chunk.
+func (a *Assembler) Add(chunk *tracev1.TracesData) {
+	if a.byID == nil {
+		a.byID = make(map[string]*tracev1.TracesData)
+	}
+	for _, rs := range chunk.GetResourceSpans() {
+		for _, ss := range rs.GetScopeSpans() {
+			for _, span := range ss.GetSpans() {
+				td, ok := a.byID[string(span.TraceId)]
+				if !ok {
+					td = &tracev1.TracesData{}
+					a.byID[string(span.TraceId)] = td
+					a.traces = append(a.traces, td)
+				}
+				merged := scopeSpans(resourceSpans(td, rs), ss)
+				merged.Spans = append(merged.Spans, span)
+			}
+		}
+	}
+}
+
+// Traces returns a TracesData per trace, in the order of the first span of
+// each trace.
+func (a *Assembler) Traces() []*tracev1.TracesData {
+	return a.traces
+}
+
+// resourceSpans returns the ResourceSpans of td with the resource of rs,
+// adding it if td has none.
+func resourceSpans(td *tracev1.TracesData, rs *tracev1.ResourceSpans) *tracev1.ResourceSpans {
+	for _, existing := range td.ResourceSpans {
+		if existing.SchemaUrl == rs.SchemaUrl && proto.Equal(existing.Resource, rs.Resource) {
+			return existing
+		}
+	}
+	merged := &tracev1.ResourceSpans{Resource: rs.Resource, SchemaUrl: rs.SchemaUrl}
+	td.ResourceSpans = append(td.ResourceSpans, merged)
+	return merged
+}
+
+// scopeSpans returns the ScopeSpans of rs with the scope of ss, adding it
+// if rs has none.
+func scopeSpans(rs *tracev1.ResourceSpans, ss *tracev1.ScopeSpans) *tracev1.ScopeSpans {
+	for _, existing := range rs.ScopeSpans {
+		if existing.SchemaUrl == ss.SchemaUrl && proto.Equal(existing.Scope, ss.Scope) {
+			return existing
+		}
+	}
+	merged := &tracev1.ScopeSpans{Scope: ss.Scope, SchemaUrl: ss.SchemaUrl}
+	rs.ScopeSpans = append(rs.ScopeSpans, merged)
+	return merged
+}
+
+// AssembleTraces receives all the chunks of an api_v3 FindTraces or
+// GetTrace stream and returns the complete traces.
+func AssembleTraces(stream grpc.ServerStreamingClient[tracev1.TracesData]) ([]*tracev1.TracesData, error) {
+	var a Assembler
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return a.Traces(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		a.Add(chunk)
+	}
+}
+
+// AssembleSpans receives all the chunks of an api_v2 FindTraces or
+// GetTrace stream and returns the complete traces, in the order of the
+// first span of each trace.
+func AssembleSpans(stream grpc.ServerStreamingClient[api_v2.SpansResponseChunk]) ([]*model.Trace, error) {
+	var traces []*model.Trace
+	byID := make(map[model.TraceID]*model.Trace)
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return traces, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		spans, err := apiv2.SpansFromProto(chunk.Spans)
+		if err != nil {
+			return nil, fmt.Errorf("invalid spans in the response: %w", err)
+		}
+		for _, span := range spans {
+			t, ok := byID[span.TraceID]
+			if !ok {
+				t = &model.Trace{}
+				byID[span.TraceID] = t
+				traces = append(traces, t)
+			}
+			t.Spans = append(t.Spans, span)
+		}
+	}
+}
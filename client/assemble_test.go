@@ -0,0 +1,135 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/apiv2"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// fakeStream returns the chunks, then err or io.EOF.
+type fakeStream[T any] struct {
+	grpc.ClientStream
+
+	chunks []*T
+	err    error
+}
+
+func (s *fakeStream[T]) Recv() (*T, error) {
+	if len(s.chunks) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return chunk, nil
+}
+
+func otlpResource(service string) *resourcev1.Resource {
+	return &resourcev1.Resource{Attributes: []*commonv1.KeyValue{{
+		Key:   "service.name",
+		Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: service}},
+	}}}
+}
+
+func otlpSpan(trace, span byte) *tracev1.Span {
+	traceID := make([]byte, 16)
+	traceID[15] = trace
+	return &tracev1.Span{TraceId: traceID, SpanId: []byte{0, 0, 0, 0, 0, 0, 0, span}, Name: "op"}
+}
+
+func spanNumbers(ss *tracev1.ScopeSpans) []byte {
+	var ids []byte
+	for _, span := range ss.Spans {
+		ids = append(ids, span.SpanId[7])
+	}
+	return ids
+}
+
+func TestAssembleTraces(t *testing.T) {
+	scope := &commonv1.InstrumentationScope{Name: "http"}
+	chunks := []*tracev1.TracesData{
+		{ResourceSpans: []*tracev1.ResourceSpans{{
+			Resource:   otlpResource("frontend"),
+			ScopeSpans: []*tracev1.ScopeSpans{{Scope: scope, Spans: []*tracev1.Span{otlpSpan(1, 1), otlpSpan(2, 2)}}},
+		}}},
+		{ResourceSpans: []*tracev1.ResourceSpans{
+			{
+				// equal to the resource and scope of the first chunk, not the same pointers
+				Resource: otlpResource("frontend"),
+				ScopeSpans: []*tracev1.ScopeSpans{
+					{Scope: &commonv1.InstrumentationScope{Name: "http"}, Spans: []*tracev1.Span{otlpSpan(1, 3)}},
+					{Scope: &commonv1.InstrumentationScope{Name: "sql"}, Spans: []*tracev1.Span{otlpSpan(1, 4)}},
+				},
+			},
+			{
+				Resource:   otlpResource("backend"),
+				ScopeSpans: []*tracev1.ScopeSpans{{Scope: scope, Spans: []*tracev1.Span{otlpSpan(1, 5), otlpSpan(2, 6)}}},
+			},
+		}},
+	}
+	traces, err := AssembleTraces(&fakeStream[tracev1.TracesData]{chunks: chunks})
+	require.NoError(t, err)
+	require.Len(t, traces, 2)
+
+	first := traces[0]
+	require.Len(t, first.ResourceSpans, 2)
+	frontend := first.ResourceSpans[0]
+	assert.Equal(t, "frontend", frontend.Resource.Attributes[0].Value.GetStringValue())
+	require.Len(t, frontend.ScopeSpans, 2)
+	assert.Equal(t, "http", frontend.ScopeSpans[0].Scope.Name)
+	assert.Equal(t, []byte{1, 3}, spanNumbers(frontend.ScopeSpans[0]))
+	assert.Equal(t, "sql", frontend.ScopeSpans[1].Scope.Name)
+	assert.Equal(t, []byte{4}, spanNumbers(frontend.ScopeSpans[1]))
+	backend := first.ResourceSpans[1]
+	assert.Equal(t, "backend", backend.Resource.Attributes[0].Value.GetStringValue())
+	assert.Equal(t, []byte{5}, spanNumbers(backend.ScopeSpans[0]))
+
+	second := traces[1]
+	require.Len(t, second.ResourceSpans, 2)
+	assert.Equal(t, []byte{2}, spanNumbers(second.ResourceSpans[0].ScopeSpans[0]))
+	assert.Equal(t, []byte{6}, spanNumbers(second.ResourceSpans[1].ScopeSpans[0]))
+
+	_, err = AssembleTraces(&fakeStream[tracev1.TracesData]{chunks: chunks, err: status.Error(codes.Unavailable, "failed")})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestAssembleSpans(t *testing.T) {
+	chunk := func(spans ...*model.Span) *api_v2.SpansResponseChunk {
+		protoSpans, err := apiv2.SpansToProto(spans)
+		require.NoError(t, err)
+		return &api_v2.SpansResponseChunk{Spans: protoSpans}
+	}
+	stream := &fakeStream[api_v2.SpansResponseChunk]{chunks: []*api_v2.SpansResponseChunk{
+		chunk(testSpan(traceA, 1), testSpan(traceB, 2)),
+		chunk(testSpan(traceA, 3)),
+	}}
+	traces, err := AssembleSpans(stream)
+	require.NoError(t, err)
+	require.Len(t, traces, 2)
+	assert.Equal(t, []model.SpanID{1, 3}, spanIDs(traces[0]))
+	assert.Equal(t, []model.SpanID{2}, spanIDs(traces[1]))
+
+	invalid := &fakeStream[api_v2.SpansResponseChunk]{chunks: []*api_v2.SpansResponseChunk{
+		{Spans: []*api_v2.Span{{TraceId: []byte{1}}}},
+	}}
+	_, err = AssembleSpans(invalid)
+	require.Error(t, err)
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// DefaultTimeout is the deadline of the calls whose context has none.
+const DefaultTimeout = 30 * time.Second
+
+// Options configures a Client. The zero value uses the api_v3 query API
+// without transport security, with the default deadline and retries.
+type Options struct {
+	// APIv2 selects the api_v2 query API, for servers not serving api_v3.
+	APIv2 bool
+	// Timeout is the deadline of the calls, including their retries, whose
+	// context has none. DefaultTimeout if zero.
+	Timeout time.Duration
+	// Retry configures the retries of the calls.
+	Retry RetryPolicy
+	// DialOptions are the options of the connection opened by New, which
+	// uses insecure credentials unless they set other ones.
+	DialOptions []grpc.DialOption
+}
+
+// Operation is an operation of a service.
+type Operation struct {
+	Name string
+	// SpanKind is empty for the spans of an unspecified kind.
+	SpanKind string
+}
+
+// Query selects the traces of FindTraces. ServiceName is required, the
+// other fields are ignored when zero.
+type Query struct {
+	ServiceName   string
+	OperationName string
+	// Tags are the attributes of a span of the traces.
+	Tags         map[string]string
+	StartTimeMin time.Time
+	StartTimeMax time.Time
+	DurationMin  time.Duration
+	DurationMax  time.Duration
+	// SearchDepth is the maximum number of traces.
+	SearchDepth int
+}
+
+// Client calls a Jaeger query service. It is safe for concurrent use.
+type Client struct {
+	conn    *grpc.ClientConn
+	v3      api_v3.QueryServiceClient
+	v2      api_v2.QueryServiceClient
+	timeout time.Duration
+	retry   RetryPolicy
+}
+
+// New connects to the query service at target. The connection is closed by
+// Close.
+func New(target string, opts Options) (*Client, error) {
+	dialOptions := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts.DialOptions...)
+	conn, err := grpc.NewClient(target, dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to %s: %w", target, err)
+	}
+	c := NewFromConn(conn, opts)
+	c.conn = conn
+	return c, nil
+}
+
+// NewFromConn returns a Client calling the query service over cc, which
+// the caller keeps ownership of. The DialOptions of opts are ignored.
+func NewFromConn(cc grpc.ClientConnInterface, opts Options) *Client {
+	c := &Client{
+		timeout: opts.Timeout,
+		retry:   opts.Retry.withDefaults(),
+	}
+	if c.timeout <= 0 {
+		c.timeout = DefaultTimeout
+	}
+	if opts.APIv2 {
+		c.v2 = api_v2.NewQueryServiceClient(cc)
+	} else {
+		c.v3 = api_v3.NewQueryServiceClient(cc)
+	}
+	return c
+}
+
+// Close closes the connection opened by New.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// call runs the attempts of a call within the default deadline.
+func (c *Client) call(ctx context.Context, attempt func(ctx context.Context) error) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	return c.retry.retry(ctx, attempt)
+}
+
+// GetServices returns the names of the services.
+func (c *Client) GetServices(ctx context.Context) ([]string, error) {
+	var services []string
+	err := c.call(ctx, func(ctx context.Context) error {
+		if c.v2 != nil {
+			resp, err := c.v2.GetServices(ctx, &api_v2.GetServicesRequest{})
+			services = resp.GetServices()
+			return err
+		}
+		resp, err := c.v3.GetServices(ctx, &api_v3.GetServicesRequest{})
+		services = resp.GetServices()
+		return err
+	})
+	return services, err
+}
+
+// GetOperations returns the operations of the service, only those of the
+// spans of spanKind if it is set.
+func (c *Client) GetOperations(ctx context.Context, service, spanKind string) ([]Operation, error) {
+	var operations []Operation
+	err := c.call(ctx, func(ctx context.Context) error {
+		operations = nil
+		if c.v2 != nil {
+			resp, err := c.v2.GetOperations(ctx, &api_v2.GetOperationsRequest{Service: service, SpanKind: spanKind})
+			for _, op := range resp.GetOperations() {
+				operations = append(operations, Operation{Name: op.Name, SpanKind: op.SpanKind})
+			}
+			return err
+		}
+		resp, err := c.v3.GetOperations(ctx, &api_v3.GetOperationsRequest{Service: service, SpanKind: spanKind})
+		for _, op := range resp.GetOperations() {
+			operations = append(operations, Operation{Name: op.Name, SpanKind: op.SpanKind})
+		}
+		return err
+	})
+	return operations, err
+}
+
+// GetTrace returns the trace. It fails with codes.NotFound if the trace
+// does not exist.
+func (c *Client) GetTrace(ctx context.Context, traceID model.TraceID) (*model.Trace, error) {
+	var id [16]byte
+	traceID.MarshalTo(id[:])
+	traces, err := c.traces(ctx,
+		func(ctx context.Context) (grpc.ServerStreamingClient[api_v2.SpansResponseChunk], error) {
+			return c.v2.GetTrace(ctx, &api_v2.GetTraceRequest{TraceId: id[:]})
+		},
+		func(ctx context.Context) (grpc.ServerStreamingClient[tracev1.TracesData], error) {
+			return c.v3.GetTrace(ctx, &api_v3.GetTraceRequest{TraceId: hex.EncodeToString(id[:])})
+		})
+	if err != nil {
+		return nil, err
+	}
+	if len(traces) == 0 {
+		return nil, status.Errorf(codes.NotFound, "trace not found: %s", traceID)
+	}
+	return traces[0], nil
+}
+
+// FindTraces returns the traces matching the query, in the order of the
+// response.
+func (c *Client) FindTraces(ctx context.Context, query Query) ([]*model.Trace, error) {
+	return c.traces(ctx,
+		func(ctx context.Context) (grpc.ServerStreamingClient[api_v2.SpansResponseChunk], error) {
+			return c.v2.FindTraces(ctx, &api_v2.FindTracesRequest{Query: query.toV2()})
+		},
+		func(ctx context.Context) (grpc.ServerStreamingClient[tracev1.TracesData], error) {
+			return c.v3.FindTraces(ctx, &api_v3.FindTracesRequest{Query: query.toV3()})
+		})
+}
+
+// traces opens the stream of the API of the client and assembles the traces.
+func (c *Client) traces(
+	ctx context.Context,
+	openV2 func(context.Context) (grpc.ServerStreamingClient[api_v2.SpansResponseChunk], error),
+	openV3 func(context.Context) (grpc.ServerStreamingClient[tracev1.TracesData], error),
+) ([]*model.Trace, error) {
+	var traces []*model.Trace
+	err := c.call(ctx, func(ctx context.Context) error {
+		if c.v2 != nil {
+			stream, err := openV2(ctx)
+			if err != nil {
+				return err
+			}
+			traces, err = AssembleSpans(stream)
+			return err
+		}
+		stream, err := openV3(ctx)
+		if err != nil {
+			return err
+		}
+		assembled, err := AssembleTraces(stream)
+		if err != nil {
+			return err
+		}
+		traces = make([]*model.Trace, len(assembled))
+		for i, td := range assembled {
+			traces[i] = &model.Trace{Spans: otlp.ToDomain(td)}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+func (q Query) toV3() *api_v3.TraceQueryParameters {
+	params := &api_v3.TraceQueryParameters{
+		ServiceName:   q.ServiceName,
+		OperationName: q.OperationName,
+		Attributes:    q.Tags,
+		SearchDepth:   int32(q.SearchDepth),
+	}
+	params.StartTimeMin, params.StartTimeMax = timestamp(q.StartTimeMin), timestamp(q.StartTimeMax)
+	params.DurationMin, params.DurationMax = duration(q.DurationMin), duration(q.DurationMax)
+	return params
+}
+
+func (q Query) toV2() *api_v2.TraceQueryParameters {
+	params := &api_v2.TraceQueryParameters{
+		ServiceName:   q.ServiceName,
+		OperationName: q.OperationName,
+		Tags:          q.Tags,
+		SearchDepth:   int32(q.SearchDepth),
+	}
+	params.StartTimeMin, params.StartTimeMax = timestamp(q.StartTimeMin), timestamp(q.StartTimeMax)
+	params.DurationMin, params.DurationMax = duration(q.DurationMin), duration(q.DurationMax)
+	return params
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func duration(d time.Duration) *durationpb.Duration {
+	if d == 0 {
+		return nil
+	}
+	return durationpb.New(d)
+}
@@ -0,0 +1,308 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	"github.com/jaegertracing/jaeger-idl/model/converter/apiv2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+var (
+	traceA = model.NewTraceID(1, 1)
+	traceB = model.NewTraceID(0, 2)
+)
+
+func testSpan(traceID model.TraceID, spanID uint64) *model.Span {
+	return &model.Span{
+		TraceID:       traceID,
+		SpanID:        model.NewSpanID(spanID),
+		OperationName: "get /users",
+		StartTime:     time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC),
+		Duration:      time.Millisecond,
+		Process:       model.NewProcess("frontend", nil),
+	}
+}
+
+// testChunks are the chunks of the responses, where the spans of traceA
+// are split across the chunks.
+func testChunks() [][]*model.Span {
+	return [][]*model.Span{
+		{testSpan(traceA, 1), testSpan(traceB, 2)},
+		{testSpan(traceA, 3)},
+	}
+}
+
+// fakeQueryService serves the test chunks with both APIs, failing the
+// first calls with failCode after sending the first chunk.
+type fakeQueryService struct {
+	calls    atomic.Int32
+	failures int32
+	failCode codes.Code
+	// block makes the calls wait for the end of their context.
+	block bool
+
+	findQuery *api_v3.TraceQueryParameters
+}
+
+// send sends the chunks matching traceID, all of them if it is empty.
+func (f *fakeQueryService) send(ctx context.Context, traceID string, send func([]*model.Span) error) error {
+	call := f.calls.Add(1)
+	if f.block {
+		<-ctx.Done()
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	for i, chunk := range testChunks() {
+		if i == 1 && call <= f.failures {
+			return status.Error(f.failCode, "failed")
+		}
+		var spans []*model.Span
+		for _, span := range chunk {
+			if traceID == "" || span.TraceID.String() == traceID {
+				spans = append(spans, span)
+			}
+		}
+		if len(spans) == 0 {
+			continue
+		}
+		if err := send(spans); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fakeV3 struct {
+	api_v3.UnimplementedQueryServiceServer
+	*fakeQueryService
+}
+
+func (f fakeV3) GetTrace(req *api_v3.GetTraceRequest, stream grpc.ServerStreamingServer[tracev1.TracesData]) error {
+	traceID, err := model.TraceIDFromString(req.TraceId)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return f.send(stream.Context(), traceID.String(), func(spans []*model.Span) error {
+		return stream.Send(otlp.FromDomain(spans))
+	})
+}
+
+func (f fakeV3) FindTraces(req *api_v3.FindTracesRequest, stream grpc.ServerStreamingServer[tracev1.TracesData]) error {
+	f.findQuery = req.Query
+	return f.send(stream.Context(), "", func(spans []*model.Span) error {
+		return stream.Send(otlp.FromDomain(spans))
+	})
+}
+
+func (f fakeV3) GetServices(ctx context.Context, _ *api_v3.GetServicesRequest) (*api_v3.GetServicesResponse, error) {
+	if call := f.calls.Add(1); call <= f.failures {
+		return nil, status.Error(f.failCode, "failed")
+	}
+	return &api_v3.GetServicesResponse{Services: []string{"frontend"}}, nil
+}
+
+func (fakeV3) GetOperations(_ context.Context, req *api_v3.GetOperationsRequest) (*api_v3.GetOperationsResponse, error) {
+	return &api_v3.GetOperationsResponse{Operations: []*api_v3.Operation{{Name: "get /users", SpanKind: req.SpanKind}}}, nil
+}
+
+type fakeV2 struct {
+	api_v2.UnimplementedQueryServiceServer
+	*fakeQueryService
+}
+
+func (f fakeV2) GetTrace(req *api_v2.GetTraceRequest, stream grpc.ServerStreamingServer[api_v2.SpansResponseChunk]) error {
+	traceID, err := model.TraceIDFromBytes(req.TraceId)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return f.send(stream.Context(), traceID.String(), func(spans []*model.Span) error {
+		return sendV2(stream, spans)
+	})
+}
+
+func (f fakeV2) FindTraces(_ *api_v2.FindTracesRequest, stream grpc.ServerStreamingServer[api_v2.SpansResponseChunk]) error {
+	return f.send(stream.Context(), "", func(spans []*model.Span) error {
+		return sendV2(stream, spans)
+	})
+}
+
+func (fakeV2) GetServices(context.Context, *api_v2.GetServicesRequest) (*api_v2.GetServicesResponse, error) {
+	return &api_v2.GetServicesResponse{Services: []string{"frontend"}}, nil
+}
+
+func (fakeV2) GetOperations(_ context.Context, req *api_v2.GetOperationsRequest) (*api_v2.GetOperationsResponse, error) {
+	return &api_v2.GetOperationsResponse{Operations: []*api_v2.Operation{{Name: "get /users", SpanKind: req.SpanKind}}}, nil
+}
+
+func sendV2(stream grpc.ServerStreamingServer[api_v2.SpansResponseChunk], spans []*model.Span) error {
+	protoSpans, err := apiv2.SpansToProto(spans)
+	if err != nil {
+		return err
+	}
+	return stream.Send(&api_v2.SpansResponseChunk{Spans: protoSpans})
+}
+
+func newTestClient(t *testing.T, fake *fakeQueryService, opts Options) *Client {
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	api_v3.RegisterQueryServiceServer(server, fakeV3{fakeQueryService: fake})
+	api_v2.RegisterQueryServiceServer(server, fakeV2{fakeQueryService: fake})
+	go server.Serve(lis)
+	opts.DialOptions = append(opts.DialOptions, grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }))
+	opts.Retry.InitialBackoff = time.Millisecond
+	c, err := New("passthrough:///bufnet", opts)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		c.Close()
+		server.Stop()
+	})
+	return c
+}
+
+func spanIDs(trace *model.Trace) []model.SpanID {
+	var ids []model.SpanID
+	for _, span := range trace.Spans {
+		ids = append(ids, span.SpanID)
+	}
+	return ids
+}
+
+func TestClient(t *testing.T) {
+	for _, apiV2 := range []bool{false, true} {
+		name := "api_v3"
+		if apiV2 {
+			name = "api_v2"
+		}
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, &fakeQueryService{}, Options{APIv2: apiV2})
+			ctx := context.Background()
+
+			traces, err := c.FindTraces(ctx, Query{ServiceName: "frontend", SearchDepth: 20})
+			require.NoError(t, err)
+			require.Len(t, traces, 2)
+			assert.Equal(t, []model.SpanID{1, 3}, spanIDs(traces[0]))
+			assert.Equal(t, traceA, traces[0].Spans[0].TraceID)
+			assert.Equal(t, []model.SpanID{2}, spanIDs(traces[1]))
+			assert.Equal(t, "frontend", traces[1].Spans[0].Process.ServiceName)
+
+			trace, err := c.GetTrace(ctx, traceB)
+			require.NoError(t, err)
+			assert.Equal(t, []model.SpanID{2}, spanIDs(trace))
+			_, err = c.GetTrace(ctx, model.NewTraceID(0, 3))
+			assert.Equal(t, codes.NotFound, status.Code(err))
+
+			services, err := c.GetServices(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"frontend"}, services)
+			operations, err := c.GetOperations(ctx, "frontend", "server")
+			require.NoError(t, err)
+			assert.Equal(t, []Operation{{Name: "get /users", SpanKind: "server"}}, operations)
+		})
+	}
+}
+
+func TestFindTracesQuery(t *testing.T) {
+	fake := &fakeQueryService{}
+	c := newTestClient(t, fake, Options{})
+	start := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	_, err := c.FindTraces(context.Background(), Query{
+		ServiceName:  "frontend",
+		Tags:         map[string]string{"error": "true"},
+		StartTimeMin: start,
+		DurationMin:  time.Millisecond,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", fake.findQuery.ServiceName)
+	assert.Equal(t, map[string]string{"error": "true"}, fake.findQuery.Attributes)
+	assert.Equal(t, start, fake.findQuery.StartTimeMin.AsTime())
+	assert.Nil(t, fake.findQuery.StartTimeMax)
+	assert.Equal(t, time.Millisecond, fake.findQuery.DurationMin.AsDuration())
+	assert.Nil(t, fake.findQuery.DurationMax)
+}
+
+func TestRetries(t *testing.T) {
+	// the partial responses of the failed attempts are discarded
+	fake := &fakeQueryService{failures: 2, failCode: codes.Unavailable}
+	c := newTestClient(t, fake, Options{})
+	traces, err := c.FindTraces(context.Background(), Query{ServiceName: "frontend"})
+	require.NoError(t, err)
+	require.Len(t, traces, 2)
+	assert.Equal(t, []model.SpanID{1, 3}, spanIDs(traces[0]))
+	assert.Equal(t, int32(3), fake.calls.Load())
+
+	fake = &fakeQueryService{failures: 2, failCode: codes.Unavailable}
+	c = newTestClient(t, fake, Options{Retry: RetryPolicy{MaxAttempts: 2}})
+	_, err = c.GetServices(context.Background())
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int32(2), fake.calls.Load())
+
+	fake = &fakeQueryService{failures: 2, failCode: codes.InvalidArgument}
+	c = newTestClient(t, fake, Options{})
+	_, err = c.FindTraces(context.Background(), Query{ServiceName: "frontend"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, int32(1), fake.calls.Load())
+
+	fake = &fakeQueryService{failures: 1, failCode: codes.InvalidArgument}
+	c = newTestClient(t, fake, Options{Retry: RetryPolicy{RetryableCodes: []codes.Code{codes.InvalidArgument}}})
+	_, err = c.GetServices(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), fake.calls.Load())
+}
+
+func TestDefaultTimeout(t *testing.T) {
+	c := newTestClient(t, &fakeQueryService{block: true}, Options{Timeout: 50 * time.Millisecond})
+	start := time.Now()
+	_, err := c.FindTraces(context.Background(), Query{ServiceName: "frontend"})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// the deadline of the caller takes precedence
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	c = newTestClient(t, &fakeQueryService{block: true}, Options{Timeout: time.Hour})
+	_, err = c.FindTraces(ctx, Query{ServiceName: "frontend"})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 4, InitialBackoff: 10 * time.Millisecond, MaxBackoff: 20 * time.Millisecond}.withDefaults()
+	var attempts []time.Time
+	err := p.retry(context.Background(), func(context.Context) error {
+		attempts = append(attempts, time.Now())
+		return status.Error(codes.Unavailable, "failed")
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	require.Len(t, attempts, 4)
+	for i, minWait := range []time.Duration{5 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond} {
+		assert.GreaterOrEqual(t, attempts[i+1].Sub(attempts[i]), minWait)
+	}
+
+	// the retries stop with the context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var calls int
+	err = p.retry(ctx, func(context.Context) error {
+		calls++
+		return status.Error(codes.Unavailable, "failed")
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package client is a Go client of the Jaeger query service. It wraps the
+// generated api_v3 and api_v2 stubs so that callers get complete traces
+// instead of stream handles, with a default deadline for every call and
+// retries of the calls failing with a transient status code.
+//
+// A streaming call is retried as a whole, discarding the chunks received
+// before the failure, as the query API has no way to resume a stream.
+//
+// The Assembler, AssembleTraces and AssembleSpans reassemble the chunks of
+// the streams of the generated stubs into complete traces, for the callers
+// using the stubs directly.
+//
+// For example:
+//
+//	c, err := client.New("localhost:16685", client.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//	traces, err := c.FindTraces(ctx, client.Query{ServiceName: "frontend", SearchDepth: 20})
+package client
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Defaults of RetryPolicy.
+const (
+	DefaultMaxAttempts    = 3
+	DefaultInitialBackoff = 100 * time.Millisecond
+	DefaultMaxBackoff     = 5 * time.Second
+	DefaultMultiplier     = 2.0
+)
+
+// DefaultRetryableCodes are the status codes of the transient failures.
+var DefaultRetryableCodes = []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.Aborted}
+
+// RetryPolicy configures the retries of the calls failing with a transient
+// status code. The zero value uses the defaults.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts of a call, including the first
+	// one. 1 disables the retries.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, multiplied by
+	// Multiplier before each of the next ones, up to MaxBackoff. A random
+	// jitter of up to half of it is taken off each wait.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// RetryableCodes are the status codes for which a call is retried,
+	// DefaultRetryableCodes if empty.
+	RetryableCodes []codes.Code
+}
+
+// withDefaults returns the policy with the unset fields set to the defaults.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultMaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultInitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = DefaultMaxBackoff
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = DefaultMultiplier
+	}
+	if len(p.RetryableCodes) == 0 {
+		p.RetryableCodes = DefaultRetryableCodes
+	}
+	return p
+}
+
+// retry calls call until it succeeds, fails with a status code that is not
+// retryable, the attempts are exhausted or ctx is done. It returns the error
+// of the last attempt.
+func (p RetryPolicy) retry(ctx context.Context, call func(ctx context.Context) error) error {
+	backoff := p.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := call(ctx)
+		if err == nil || attempt == p.MaxAttempts || !slices.Contains(p.RetryableCodes, status.Code(err)) {
+			return err
+		}
+		wait := backoff - rand.N(backoff/2+1)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff = min(time.Duration(float64(backoff)*p.Multiplier), p.MaxBackoff)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// newAdminHandler returns the HTTP handler for the admin and auxiliary endpoints of the demo.
+func newAdminHandler(q *QueryService, usage *usageTracker, privacy *privacyConfig) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/admin/retention", func(w http.ResponseWriter, r *http.Request) {
+		q.handleRetention(w, r, privacy.noiseFor(r))
+	})
+	mux.HandleFunc("GET /api/admin/usage", usage.handleUsage)
+	mux.HandleFunc("POST /api/admin/operations/rename", q.handleRenameOperations)
+	mux.HandleFunc("GET /api/admin/services", q.handleListServiceVisibility)
+	mux.HandleFunc("PUT /api/admin/services/{service}/visibility", q.handleSetServiceVisibility)
+	mux.HandleFunc("GET /api/traces", q.handleSearchTraces)
+	mux.HandleFunc("POST /api/experimental/query", q.handleStructuralQuery)
+	mux.HandleFunc("GET /api/traces/{traceID}/stats", q.handleTraceStats)
+	mux.HandleFunc("GET /api/traces/{traceID}/breakdown", q.handleTraceBreakdown)
+	mux.HandleFunc("GET /api/traces/{traceA}/diff/{traceB}", q.handleTraceDiff)
+	mux.HandleFunc("GET /api/operations/compare", func(w http.ResponseWriter, r *http.Request) {
+		q.handleCompareOperations(w, r, privacy.noiseFor(r))
+	})
+	mux.HandleFunc("POST /api/v2/spans", q.handleZipkinSpans)
+	mux.HandleFunc("GET /api/admin/forwarding", q.handleForwardStats)
+	mux.HandleFunc("GET /api/admin/memory", q.handleMemoryStats)
+	mux.HandleFunc("GET /api/admin/retention/purges", q.handlePurgeStats)
+	mux.HandleFunc("GET /api/admin/ingest/filter", q.handleIngestFilter)
+	mux.HandleFunc("PUT /api/admin/ingest/filter", q.handleSetIngestFilter)
+	mux.HandleFunc("GET /api/admin/sampling/tail", q.handleTailSamplingStats)
+	mux.HandleFunc("GET /api/admin/dependencies/aggregation", q.handleDependencyAggregation)
+	mux.HandleFunc("GET /api/admin/traces/{traceID}/raw", q.handleRawTrace)
+	mux.HandleFunc("GET /api/admin/store", q.handleStoreStats)
+	mux.HandleFunc("DELETE /api/admin/traces", q.handlePurgeTraces)
+	mux.HandleFunc("DELETE /api/admin/traces/{traceID}", q.handleDeleteTrace)
+	mux.HandleFunc("GET /api/admin/export", q.handleExport)
+	mux.HandleFunc("GET /credits", q.handleCredits)
+	mux.HandleFunc("GET /baggageRestrictions", q.handleBaggageRestrictions)
+	return mux
+}
+
+// handleRetention serves the retention report. The optional query parameters
+// window, ttl (durations) and maxSpans (integer) tune the projection.
+func (q *QueryService) handleRetention(w http.ResponseWriter, r *http.Request, noise *laplaceNoise) {
+	opts := retentionOptions{Window: time.Hour, Noise: noise}
+	var err error
+	params := r.URL.Query()
+	if v := params.Get("window"); v != "" {
+		if opts.Window, err = time.ParseDuration(v); err != nil {
+			writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid window: %w", err))
+			return
+		}
+	}
+	if v := params.Get("ttl"); v != "" {
+		if opts.TTL, err = time.ParseDuration(v); err != nil {
+			writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid ttl: %w", err))
+			return
+		}
+	}
+	if v := params.Get("maxSpans"); v != "" {
+		if opts.MaxSpans, err = strconv.Atoi(v); err != nil {
+			writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid maxSpans: %w", err))
+			return
+		}
+	}
+	writeAdminJSON(w, q.retentionReport(opts))
+}
+
+func writeAdminJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		log.Printf("[ADMIN] Failed to write response: %v\n", err)
+	}
+}
+
+func writeAdminError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// serveAdmin starts the admin HTTP server on the listeners in the background.
+func serveAdmin(listeners []net.Listener, handler http.Handler) *http.Server {
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	for _, lis := range listeners {
+		log.Printf("Admin endpoints listening on %s\n", lis.Addr())
+		go func() {
+			if err := server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatalf("Failed to serve admin endpoints: %v", err)
+			}
+		}()
+	}
+	return server
+}
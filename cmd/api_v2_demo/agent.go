@@ -0,0 +1,70 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+
+	"github.com/apache/thrift/lib/go/thrift"
+
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	jaegerconv "github.com/jaegertracing/jaeger-idl/model/converter/thrift/jaeger"
+	"github.com/jaegertracing/jaeger-idl/thrift-gen/agent"
+	"github.com/jaegertracing/jaeger-idl/thrift-gen/jaeger"
+	"github.com/jaegertracing/jaeger-idl/thrift-gen/zipkincore"
+)
+
+// maxAgentPacketSize is the largest UDP packet sent by the Jaeger client libraries.
+const maxAgentPacketSize = 65000
+
+// agentHandler stores the batches that Jaeger client libraries emit to the
+// agent, so that they can report to the demo without a real agent and collector.
+type agentHandler struct {
+	q *QueryService
+}
+
+func (h agentHandler) EmitBatch(_ context.Context, batch *jaeger.Batch) error {
+	if err := checkBatchSize(len(batch.GetSpans())); err != nil {
+		log.Printf("[AGENT] Rejected batch from %s: %v\n", batch.GetProcess().GetServiceName(), err)
+		return err
+	}
+	spans := jaegerconv.ToDomain(batch.GetSpans(), batch.GetProcess())
+	rejected := h.q.receiveTraces(otlp.FromDomain(spans))
+	log.Printf("[AGENT] Received %d spans from %s, rejected %d\n",
+		len(spans), batch.GetProcess().GetServiceName(), len(rejected))
+	for _, err := range rejected {
+		log.Printf("[AGENT] Rejected span: %v\n", err)
+	}
+	return nil
+}
+
+func (agentHandler) EmitZipkinBatch(context.Context, []*zipkincore.Span) error {
+	return errors.New("zipkin.thrift batches are not supported, send Zipkin JSON v2 spans to /api/v2/spans instead")
+}
+
+// serveAgent decodes every packet received on conn as a Thrift compact
+// encoded Agent call, until conn is closed. Like the Jaeger agent it never
+// replies, since the client libraries do not read from the socket.
+func serveAgent(conn net.PacketConn, q *QueryService) {
+	processor := agent.NewAgentProcessor(agentHandler{q: q})
+	buf := make([]byte, maxAgentPacketSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Printf("[AGENT] Failed to read packet: %v\n", err)
+			}
+			return
+		}
+		transport := thrift.NewTMemoryBufferLen(n)
+		transport.Write(buf[:n])
+		protocol := thrift.NewTCompactProtocolConf(transport, &thrift.TConfiguration{})
+		if _, err := processor.Process(context.Background(), protocol, protocol); err != nil {
+			log.Printf("[AGENT] Failed to process packet from %s: %v\n", addr, err)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxThrottleBuckets bounds the credit buckets kept by the throttler before
+// the full ones are dropped.
+const maxThrottleBuckets = 10_000
+
+// agentConfig configures the throttling credits and the baggage restrictions
+// that the Jaeger agent serves to the client libraries, on the same HTTP
+// endpoints, so that the legacy SDK features can be exercised against the
+// demo. The settings of a service replace the default ones.
+//
+// Example:
+//
+//	{
+//	  "throttling": {
+//	    "default": {"creditsPerSecond": 1, "maxBalance": 10},
+//	    "services": {"frontend": {"creditsPerSecond": 10, "maxBalance": 100}}
+//	  },
+//	  "baggage": {
+//	    "default": [{"baggageKey": "tenant", "maxValueLength": 64}],
+//	    "services": {"frontend": [{"baggageKey": "session", "maxValueLength": 128}]}
+//	  }
+//	}
+type agentConfig struct {
+	Throttling *throttlingConfig `json:"throttling,omitempty"`
+	Baggage    *baggageConfig    `json:"baggage,omitempty"`
+}
+
+// throttlingConfig sets the credits of the debug spans of each service.
+type throttlingConfig struct {
+	Default  *creditsConfig           `json:"default,omitempty"`
+	Services map[string]creditsConfig `json:"services,omitempty"`
+}
+
+// creditsConfig is the token bucket of the debug spans of an operation of
+// a client: a debug span costs a credit.
+type creditsConfig struct {
+	// CreditsPerSecond is the rate at which the credits accrue.
+	CreditsPerSecond float64 `json:"creditsPerSecond"`
+	// MaxBalance is the number of credits above which none accrue.
+	MaxBalance float64 `json:"maxBalance"`
+}
+
+// baggageConfig lists the baggage keys allowed for each service.
+type baggageConfig struct {
+	Default  []baggageRestriction            `json:"default,omitempty"`
+	Services map[string][]baggageRestriction `json:"services,omitempty"`
+}
+
+// baggageRestriction is an allowed baggage key, in the JSON format of the
+// BaggageRestriction of the baggage.thrift IDL served by the agent.
+type baggageRestriction struct {
+	BaggageKey     string `json:"baggageKey"`
+	MaxValueLength int    `json:"maxValueLength"`
+}
+
+func loadAgentConfig(path string) (*agentConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read agent config: %w", err)
+	}
+	var cfg agentConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse agent config: %w", err)
+	}
+	if t := cfg.Throttling; t != nil {
+		if t.Default != nil {
+			if err := t.Default.validate(); err != nil {
+				return nil, fmt.Errorf("invalid default throttling config: %w", err)
+			}
+		}
+		for service, cc := range t.Services {
+			if err := cc.validate(); err != nil {
+				return nil, fmt.Errorf("invalid throttling config for service %q: %w", service, err)
+			}
+		}
+	}
+	if b := cfg.Baggage; b != nil {
+		if err := validateBaggage(b.Default); err != nil {
+			return nil, fmt.Errorf("invalid default baggage restrictions: %w", err)
+		}
+		for service, restrictions := range b.Services {
+			if err := validateBaggage(restrictions); err != nil {
+				return nil, fmt.Errorf("invalid baggage restrictions for service %q: %w", service, err)
+			}
+		}
+	}
+	return &cfg, nil
+}
+
+func (cc creditsConfig) validate() error {
+	if cc.CreditsPerSecond < 0 || cc.MaxBalance < 0 {
+		return errors.New("the credits per second and the max balance cannot be negative")
+	}
+	return nil
+}
+
+func validateBaggage(restrictions []baggageRestriction) error {
+	for i, r := range restrictions {
+		if r.BaggageKey == "" || r.MaxValueLength <= 0 {
+			return fmt.Errorf("restriction %d: the baggage key must be set and the max value length positive", i)
+		}
+	}
+	return nil
+}
+
+// creditsFor returns the credits of the service, and false if it has none.
+func (c *throttlingConfig) creditsFor(service string) (creditsConfig, bool) {
+	if cc, ok := c.Services[service]; ok {
+		return cc, true
+	}
+	if c.Default == nil {
+		return creditsConfig{}, false
+	}
+	return *c.Default, true
+}
+
+// restrictionsFor returns the baggage keys allowed for the service.
+func (c *baggageConfig) restrictionsFor(service string) []baggageRestriction {
+	if restrictions, ok := c.Services[service]; ok {
+		return restrictions
+	}
+	return c.Default
+}
+
+// throttler grants the credits of the debug spans to the client libraries.
+// Each operation of each client has a bucket, which starts full and is
+// emptied by every request for credits.
+type throttler struct {
+	config *throttlingConfig
+	now    func() time.Time
+
+	mu      sync.Mutex
+	buckets map[throttleKey]*creditBucket
+}
+
+type throttleKey struct {
+	service, client, operation string
+}
+
+type creditBucket struct {
+	balance float64
+	updated time.Time
+}
+
+func newThrottler(config *throttlingConfig) *throttler {
+	return &throttler{config: config, now: time.Now, buckets: make(map[throttleKey]*creditBucket)}
+}
+
+// operationBalance is the credits granted for an operation, in the JSON
+// format of the responses of the agent.
+type operationBalance struct {
+	Operation string  `json:"operation"`
+	Balance   float64 `json:"balance"`
+}
+
+// withdraw returns the credits accrued for the operations of the client
+// since its last request, and empties their buckets.
+func (t *throttler) withdraw(service, client string, operations []string) []operationBalance {
+	cc, ok := t.config.creditsFor(service)
+	balances := make([]operationBalance, 0, len(operations))
+	if !ok {
+		for _, op := range operations {
+			balances = append(balances, operationBalance{Operation: op})
+		}
+		return balances
+	}
+	now := t.now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.buckets) > maxThrottleBuckets {
+		t.dropFullBuckets(now)
+	}
+	for _, op := range operations {
+		key := throttleKey{service: service, client: client, operation: op}
+		balance := cc.MaxBalance
+		if b, ok := t.buckets[key]; ok {
+			balance = min(cc.MaxBalance, b.balance+cc.CreditsPerSecond*now.Sub(b.updated).Seconds())
+		}
+		t.buckets[key] = &creditBucket{updated: now}
+		balances = append(balances, operationBalance{Operation: op, Balance: balance})
+	}
+	return balances
+}
+
+// dropFullBuckets drops the buckets that would be full, which are the same
+// as the new ones.
+func (t *throttler) dropFullBuckets(now time.Time) {
+	for key, b := range t.buckets {
+		cc, _ := t.config.creditsFor(key.service)
+		if b.balance+cc.CreditsPerSecond*now.Sub(b.updated).Seconds() >= cc.MaxBalance {
+			delete(t.buckets, key)
+		}
+	}
+}
+
+// handleCredits implements the GET /credits endpoint of the agent, polled
+// by the throttlers of the client libraries with the service, the uuid of
+// the client and the operations, e.g.
+//
+//	GET /credits?service=frontend&uuid=1f3c&operations=GET%20/users&operations=GET%20/orders
+func (q *QueryService) handleCredits(w http.ResponseWriter, r *http.Request) {
+	if q.throttler == nil {
+		writeAdminError(w, http.StatusNotFound, errors.New("no throttling is configured"))
+		return
+	}
+	params := r.URL.Query()
+	service, client := params.Get("service"), params.Get("uuid")
+	if service == "" || client == "" {
+		writeAdminError(w, http.StatusBadRequest, errors.New("the service and uuid parameters are required"))
+		return
+	}
+	writeAdminJSON(w, map[string]any{"balances": q.throttler.withdraw(service, client, params["operations"])})
+}
+
+// handleBaggageRestrictions implements the GET /baggageRestrictions
+// endpoint of the agent, polled by the baggage restriction managers of the
+// client libraries, e.g.
+//
+//	GET /baggageRestrictions?service=frontend
+func (q *QueryService) handleBaggageRestrictions(w http.ResponseWriter, r *http.Request) {
+	if q.baggage == nil {
+		writeAdminError(w, http.StatusNotFound, errors.New("no baggage restrictions are configured"))
+		return
+	}
+	service := r.URL.Query().Get("service")
+	if service == "" {
+		writeAdminError(w, http.StatusBadRequest, errors.New("the service parameter is required"))
+		return
+	}
+	restrictions := q.baggage.restrictionsFor(service)
+	if restrictions == nil {
+		restrictions = []baggageRestriction{}
+	}
+	writeAdminJSON(w, restrictions)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAgentConfig = `{
+  "throttling": {
+    "default": {"creditsPerSecond": 1, "maxBalance": 10},
+    "services": {"frontend": {"creditsPerSecond": 10, "maxBalance": 100}}
+  },
+  "baggage": {
+    "default": [{"baggageKey": "tenant", "maxValueLength": 64}],
+    "services": {"frontend": [{"baggageKey": "session", "maxValueLength": 128}]}
+  }
+}`
+
+func writeAgentConfig(t *testing.T, config string) string {
+	path := filepath.Join(t.TempDir(), "agent.json")
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+	return path
+}
+
+func TestLoadAgentConfig(t *testing.T) {
+	cfg, err := loadAgentConfig(writeAgentConfig(t, testAgentConfig))
+	require.NoError(t, err)
+	credits, ok := cfg.Throttling.creditsFor("frontend")
+	require.True(t, ok)
+	assert.Equal(t, creditsConfig{CreditsPerSecond: 10, MaxBalance: 100}, credits)
+	credits, ok = cfg.Throttling.creditsFor("backend")
+	require.True(t, ok)
+	assert.Equal(t, creditsConfig{CreditsPerSecond: 1, MaxBalance: 10}, credits)
+	assert.Equal(t, []baggageRestriction{{BaggageKey: "session", MaxValueLength: 128}}, cfg.Baggage.restrictionsFor("frontend"))
+	assert.Equal(t, []baggageRestriction{{BaggageKey: "tenant", MaxValueLength: 64}}, cfg.Baggage.restrictionsFor("backend"))
+
+	_, ok = (&throttlingConfig{}).creditsFor("frontend")
+	assert.False(t, ok)
+
+	for _, invalid := range []string{
+		`{`,
+		`{"throttling": {"default": {"creditsPerSecond": -1}}}`,
+		`{"throttling": {"services": {"frontend": {"maxBalance": -1}}}}`,
+		`{"baggage": {"default": [{"maxValueLength": 10}]}}`,
+		`{"baggage": {"services": {"frontend": [{"baggageKey": "tenant"}]}}}`,
+	} {
+		_, err := loadAgentConfig(writeAgentConfig(t, invalid))
+		require.Error(t, err, invalid)
+	}
+	_, err = loadAgentConfig(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestThrottler(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	th := newThrottler(&throttlingConfig{
+		Default: &creditsConfig{CreditsPerSecond: 2, MaxBalance: 10},
+	})
+	th.now = func() time.Time { return now }
+
+	// new buckets start full, and are emptied by each withdrawal
+	assert.Equal(t, []operationBalance{{"a", 10}, {"b", 10}}, th.withdraw("frontend", "client-1", []string{"a", "b"}))
+	assert.Equal(t, []operationBalance{{"a", 0}}, th.withdraw("frontend", "client-1", []string{"a"}))
+	now = now.Add(1500 * time.Millisecond)
+	assert.Equal(t, []operationBalance{{"a", 3}}, th.withdraw("frontend", "client-1", []string{"a"}))
+	now = now.Add(time.Minute)
+	assert.Equal(t, []operationBalance{{"a", 10}}, th.withdraw("frontend", "client-1", []string{"a"}))
+	// the buckets are per client
+	assert.Equal(t, []operationBalance{{"a", 10}}, th.withdraw("frontend", "client-2", []string{"a"}))
+
+	// the full buckets are dropped when there are too many
+	for i := range maxThrottleBuckets {
+		th.withdraw("frontend", "client-3", []string{time.Duration(i).String()})
+	}
+	now = now.Add(time.Minute)
+	th.withdraw("frontend", "client-4", []string{"a"})
+	th.mu.Lock()
+	assert.Len(t, th.buckets, 1)
+	th.mu.Unlock()
+
+	none := newThrottler(&throttlingConfig{Services: map[string]creditsConfig{"frontend": {MaxBalance: 1}}})
+	assert.Equal(t, []operationBalance{{"a", 0}}, none.withdraw("backend", "client-1", []string{"a"}))
+}
+
+func TestAgentEndpoints(t *testing.T) {
+	q := NewQueryService()
+	handler := newAdminHandler(q, newUsageTracker(), nil)
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		return w
+	}
+
+	assert.Equal(t, http.StatusNotFound, get("/credits?service=frontend&uuid=1").Code)
+	assert.Equal(t, http.StatusNotFound, get("/baggageRestrictions?service=frontend").Code)
+
+	cfg, err := loadAgentConfig(writeAgentConfig(t, testAgentConfig))
+	require.NoError(t, err)
+	q.throttler = newThrottler(cfg.Throttling)
+	q.baggage = cfg.Baggage
+
+	w := get("/credits?service=frontend&uuid=1&operations=GET%20/users&operations=GET%20/orders")
+	require.Equal(t, http.StatusOK, w.Code)
+	var credits struct {
+		Balances []operationBalance `json:"balances"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &credits))
+	assert.Equal(t, []operationBalance{{"GET /users", 100}, {"GET /orders", 100}}, credits.Balances)
+	assert.Equal(t, http.StatusBadRequest, get("/credits?service=frontend").Code)
+
+	w = get("/baggageRestrictions?service=frontend")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"baggageKey": "session", "maxValueLength": 128}]`, w.Body.String())
+	assert.Equal(t, http.StatusBadRequest, get("/baggageRestrictions").Code)
+
+	q.baggage = &baggageConfig{}
+	assert.JSONEq(t, `[]`, get("/baggageRestrictions?service=frontend").Body.String())
+}
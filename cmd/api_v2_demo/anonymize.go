@@ -0,0 +1,169 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	common "go.opentelemetry.io/proto/otlp/common/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Anonymization actions.
+const (
+	// actionHash replaces the value with a keyed hash, so equal values stay
+	// equal across spans and traces without revealing the original.
+	actionHash = "hash"
+	// actionRedact replaces the value with redactedValue.
+	actionRedact = "redact"
+	// actionDrop removes the attribute.
+	actionDrop = "drop"
+)
+
+// Anonymization modes, i.e. where the anonymizer is applied.
+const (
+	anonymizeOnIngest = "ingest"
+	anonymizeOnExport = "export"
+)
+
+const redactedValue = "<redacted>"
+
+// anonymizeConfig describes which attributes to scrub and how.
+//
+// Example:
+//
+//	{
+//	  "salt": "demo",
+//	  "rules": [
+//	    {"key": "user.*", "action": "hash"},
+//	    {"key": "db.statement", "action": "redact"},
+//	    {"key": "hostname", "action": "drop"}
+//	  ]
+//	}
+type anonymizeConfig struct {
+	// Salt is the key of the hash action. Sharing datasets anonymized with
+	// the same salt keeps the hashed values comparable between them.
+	Salt  string          `json:"salt,omitempty"`
+	Rules []anonymizeRule `json:"rules"`
+}
+
+// anonymizeRule applies Action to the attributes whose key matches Key,
+// a pattern in the syntax of path.Match, e.g. "user.*" or "http.*.header".
+type anonymizeRule struct {
+	Key    string `json:"key"`
+	Action string `json:"action"`
+}
+
+// anonymizer scrubs the span, event, link and resource attributes of traces.
+type anonymizer struct {
+	salt  []byte
+	rules []anonymizeRule
+}
+
+func loadAnonymizer(configPath string) (*anonymizer, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read anonymizer config: %w", err)
+	}
+	var cfg anonymizeConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse anonymizer config: %w", err)
+	}
+	return newAnonymizer(cfg)
+}
+
+func newAnonymizer(cfg anonymizeConfig) (*anonymizer, error) {
+	if len(cfg.Rules) == 0 {
+		return nil, errors.New("no anonymizer rules given")
+	}
+	for i, rule := range cfg.Rules {
+		if _, err := path.Match(rule.Key, ""); err != nil {
+			return nil, fmt.Errorf("rule %d: invalid key pattern %q: %w", i, rule.Key, err)
+		}
+		switch rule.Action {
+		case actionHash, actionRedact, actionDrop:
+		default:
+			return nil, fmt.Errorf("rule %d: unknown action %q", i, rule.Action)
+		}
+	}
+	return &anonymizer{salt: []byte(cfg.Salt), rules: cfg.Rules}, nil
+}
+
+// anonymize scrubs td in place.
+func (a *anonymizer) anonymize(td *trace.TracesData) {
+	for _, rs := range td.ResourceSpans {
+		if rs.Resource != nil {
+			rs.Resource.Attributes = a.scrub(rs.Resource.Attributes)
+		}
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				span.Attributes = a.scrub(span.Attributes)
+				for _, event := range span.Events {
+					event.Attributes = a.scrub(event.Attributes)
+				}
+				for _, link := range span.Links {
+					link.Attributes = a.scrub(link.Attributes)
+				}
+			}
+		}
+	}
+}
+
+// anonymized returns an anonymized copy of td.
+func (a *anonymizer) anonymized(td *trace.TracesData) *trace.TracesData {
+	clone := proto.Clone(td).(*trace.TracesData)
+	a.anonymize(clone)
+	return clone
+}
+
+func (a *anonymizer) scrub(attrs []*common.KeyValue) []*common.KeyValue {
+	kept := attrs[:0]
+	for _, attr := range attrs {
+		switch a.actionFor(attr.Key) {
+		case actionHash:
+			attr.Value = stringValue(a.hash(attr.Value))
+		case actionRedact:
+			attr.Value = stringValue(redactedValue)
+		case actionDrop:
+			continue
+		}
+		kept = append(kept, attr)
+	}
+	return kept
+}
+
+// actionFor returns the action of the first rule matching key, or "" if none does.
+func (a *anonymizer) actionFor(key string) string {
+	for _, rule := range a.rules {
+		if ok, _ := path.Match(rule.Key, key); ok {
+			return rule.Action
+		}
+	}
+	return ""
+}
+
+func (a *anonymizer) hash(value *common.AnyValue) string {
+	var data []byte
+	if s, ok := value.GetValue().(*common.AnyValue_StringValue); ok {
+		data = []byte(s.StringValue)
+	} else {
+		// values of other types are hashed in their wire form
+		data, _ = proto.MarshalOptions{Deterministic: true}.Marshal(value)
+	}
+	mac := hmac.New(sha256.New, a.salt)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+func stringValue(s string) *common.AnyValue {
+	return &common.AnyValue{Value: &common.AnyValue_StringValue{StringValue: s}}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	common "go.opentelemetry.io/proto/otlp/common/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+func testAnonymizer(t *testing.T) *anonymizer {
+	a, err := newAnonymizer(anonymizeConfig{
+		Salt: "test",
+		Rules: []anonymizeRule{
+			{Key: "user.*", Action: actionHash},
+			{Key: "db.statement", Action: actionRedact},
+			{Key: "hostname", Action: actionDrop},
+		},
+	})
+	require.NoError(t, err)
+	return a
+}
+
+// sensitiveBatch returns a span of trace 0 with attributes to scrub.
+func sensitiveBatch() *trace.TracesData {
+	td := testBatch(0, 0, 0)
+	rs := td.ResourceSpans[0]
+	rs.ScopeSpans[0].Spans = rs.ScopeSpans[0].Spans[:1]
+	rs.Resource.Attributes = append(rs.Resource.Attributes, &common.KeyValue{Key: "hostname", Value: stringValue("db-1")})
+	rs.ScopeSpans[0].Spans[0].Attributes = []*common.KeyValue{
+		{Key: "user.email", Value: stringValue("alice@example.com")},
+		{Key: "db.statement", Value: stringValue("SELECT * FROM users WHERE email = 'alice@example.com'")},
+		{Key: "http.method", Value: stringValue("GET")},
+	}
+	return td
+}
+
+func attributeValues(attrs []*common.KeyValue) map[string]string {
+	values := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		values[attr.Key] = attr.Value.GetStringValue()
+	}
+	return values
+}
+
+func TestAnonymizeOnIngest(t *testing.T) {
+	q := NewQueryService()
+	a := testAnonymizer(t)
+	q.ingestAnonymizer = a
+	conn := newTestConn(t, q)
+
+	td := sensitiveBatch()
+	_, err := collectortrace.NewTraceServiceClient(conn).Export(context.Background(), &collectortrace.ExportTraceServiceRequest{ResourceSpans: td.ResourceSpans})
+	require.NoError(t, err)
+
+	q.mu.RLock()
+	stored := q.traces[hex.EncodeToString(testTraceID(0))]
+	q.mu.RUnlock()
+	require.NotNil(t, stored)
+	rs := stored.ResourceSpans[0]
+	assert.Equal(t, map[string]string{"service.name": "service-0"}, attributeValues(rs.Resource.Attributes))
+	assert.Equal(t, map[string]string{
+		"user.email":   a.hash(stringValue("alice@example.com")),
+		"db.statement": redactedValue,
+		"http.method":  "GET",
+	}, attributeValues(rs.ScopeSpans[0].Spans[0].Attributes))
+}
+
+func TestAnonymizeOnExport(t *testing.T) {
+	q := NewQueryService()
+	q.exportAnonymizer = testAnonymizer(t)
+	require.Empty(t, q.importTraces(sensitiveBatch()))
+
+	q.mu.RLock()
+	stored := q.traces[hex.EncodeToString(testTraceID(0))]
+	q.mu.RUnlock()
+	anonymized := q.exportAnonymizer.anonymized(stored)
+	assert.Equal(t, "alice@example.com", attributeValues(stored.ResourceSpans[0].ScopeSpans[0].Spans[0].Attributes)["user.email"], "the stored trace is kept")
+	assert.NotContains(t, attributeValues(anonymized.ResourceSpans[0].Resource.Attributes), "hostname")
+	assert.Equal(t, redactedValue, attributeValues(anonymized.ResourceSpans[0].ScopeSpans[0].Spans[0].Attributes)["db.statement"])
+}
+
+func TestNewAnonymizerErrors(t *testing.T) {
+	_, err := newAnonymizer(anonymizeConfig{})
+	require.ErrorContains(t, err, "no anonymizer rules")
+	_, err = newAnonymizer(anonymizeConfig{Rules: []anonymizeRule{{Key: "[", Action: actionHash}}})
+	require.ErrorContains(t, err, "invalid key pattern")
+	_, err = newAnonymizer(anonymizeConfig{Rules: []anonymizeRule{{Key: "user.*", Action: "encrypt"}}})
+	require.ErrorContains(t, err, "unknown action")
+}
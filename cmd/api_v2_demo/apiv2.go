@@ -0,0 +1,222 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"log"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	"github.com/jaegertracing/jaeger-idl/model/converter/apiv2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// queryServiceV2 serves the legacy api_v2 Query Service from the same
+// in-memory data, for clients that have not migrated to api_v3 yet.
+type queryServiceV2 struct {
+	api_v2.UnimplementedQueryServiceServer
+
+	q *QueryService
+}
+
+// GetTrace returns a single trace by ID (streaming), or several of them if
+// more IDs are listed in the batch request header.
+func (s *queryServiceV2) GetTrace(req *api_v2.GetTraceRequest, stream grpc.ServerStreamingServer[api_v2.SpansResponseChunk]) error {
+	traceIDs, err := batchTraceIDs(stream.Context(), hex.EncodeToString(req.TraceId))
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	log.Printf("[QUERY v2] GetTrace called for traceID: %s\n", strings.Join(traceIDs, ","))
+	maxSize, err := s.q.messageSizeLimit(stream.Context())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var found []string
+	var traces []*trace.TracesData
+	s.q.mu.RLock()
+	for _, traceID := range traceIDs {
+		if td, ok := s.q.traces[traceID]; ok {
+			found = append(found, traceID)
+			traces = append(traces, td)
+		}
+	}
+	s.q.mu.RUnlock()
+	if len(found) == 0 {
+		log.Printf("[QUERY v2] Trace not found: %s\n", strings.Join(traceIDs, ","))
+		return status.Errorf(codes.NotFound, "trace not found: %s", strings.Join(traceIDs, ","))
+	}
+	if len(traceIDs) > 1 {
+		log.Printf("[QUERY v2] Returning %d of %d traces\n", len(found), len(traceIDs))
+		stream.SetHeader(metadata.Pairs(batchTraceIDsHeader, strings.Join(found, ",")))
+	}
+	for i, td := range traces {
+		s.q.memory.read(found[i])
+		if err := s.sendTrace(td, maxSize, stream); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindTraces searches for traces matching the query (streaming).
+// The tags of the query are the equivalent of the api_v3 attributes.
+// At most SearchDepth traces are returned, picked as the sample option says,
+// after the stride of the downsampling options.
+func (s *queryServiceV2) FindTraces(req *api_v2.FindTracesRequest, stream grpc.ServerStreamingServer[api_v2.SpansResponseChunk]) error {
+	query := &api_v3.TraceQueryParameters{
+		ServiceName:   req.GetQuery().GetServiceName(),
+		OperationName: req.GetQuery().GetOperationName(),
+		Attributes:    req.GetQuery().GetTags(),
+		StartTimeMin:  req.GetQuery().GetStartTimeMin(),
+		StartTimeMax:  req.GetQuery().GetStartTimeMax(),
+	}
+	log.Printf("[QUERY v2] FindTraces called - service: %s, operation: %s\n",
+		query.ServiceName, query.OperationName)
+	s.q.warnIfDeprecated(apiV2, query.ServiceName)
+
+	maxSize, err := s.q.messageSizeLimit(stream.Context())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	hints, err := parseQueryHints(query.Attributes, s.q.hintsConfig)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	sampling, err := parseSampleOption(query.Attributes)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	downsample, err := parseDownsampling(query.Attributes)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	query, filters, err := s.q.queryFilters(query)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if hints != (queryHints{}) {
+		log.Printf("[QUERY v2] Query plan: %s\n", hints)
+		stream.SetHeader(metadata.Pairs(queryPlanHeader, hints.String()))
+	}
+
+	ctx, cancel := s.q.regex.withTimeout(stream.Context(), filters)
+	defer cancel()
+	found, err := s.q.findTraces(ctx, query, filters, hints)
+	if err != nil {
+		return status.FromContextError(err).Err()
+	}
+	log.Printf("[QUERY v2] Matched %d traces\n", len(found))
+	found = downsample.strided(found)
+	if header := downsample.String(); header != "" {
+		log.Printf("[QUERY v2] Downsampled to %d traces: %s\n", len(found), header)
+		stream.SetHeader(metadata.Pairs(downsampleHeader, header))
+	}
+	found, sample := limitTraces(found, int(req.GetQuery().GetSearchDepth()), sampling)
+	if sample != nil {
+		log.Printf("[QUERY v2] Returning a sample of %d traces: %s\n", len(found), sample)
+		stream.SetHeader(metadata.Pairs(resultSampleHeader, sample.String()))
+	}
+
+	for _, td := range found {
+		if err := s.sendTrace(downsample.result(td), maxSize, stream); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendTrace sends the spans of the trace as one chunk, encoded by the
+// spans of the domain model, or as several chunks of at most maxSize bytes.
+func (s *queryServiceV2) sendTrace(td *trace.TracesData, maxSize int, stream grpc.ServerStreamingServer[api_v2.SpansResponseChunk]) error {
+	if s.q.exportAnonymizer != nil {
+		td = s.q.exportAnonymizer.anonymized(td)
+	}
+	spans := otlp.ToDomain(td)
+	groups := model.SplitSpans(spans, maxSize)
+	if len(groups) > 1 {
+		log.Printf("Split a trace of %d spans into %d chunks of at most %d bytes\n", len(spans), len(groups), maxSize)
+	}
+	for _, group := range groups {
+		chunk, err := encodeSpansChunk(group)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		if err := stream.SendMsg(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetServices returns all known service names
+func (s *queryServiceV2) GetServices(context.Context, *api_v2.GetServicesRequest) (*api_v2.GetServicesResponse, error) {
+	log.Println("[QUERY v2] GetServices called")
+	services := s.q.listedServices()
+	log.Printf("[QUERY v2] Returning %d services: %v\n", len(services), services)
+	return &api_v2.GetServicesResponse{Services: services}, nil
+}
+
+// GetOperations returns the operations of a service, of the requested span
+// kind if any, both as the deprecated list of names and as operations.
+func (s *queryServiceV2) GetOperations(_ context.Context, req *api_v2.GetOperationsRequest) (*api_v2.GetOperationsResponse, error) {
+	log.Printf("[QUERY v2] GetOperations called for service: %s\n", req.Service)
+	s.q.warnIfDeprecated(apiV2, req.Service)
+
+	resp := &api_v2.GetOperationsResponse{}
+	for _, op := range s.q.operations(req.Service, req.SpanKind) {
+		// the operations of several kinds are listed once by name
+		if !slices.Contains(resp.OperationNames, op.name) {
+			resp.OperationNames = append(resp.OperationNames, op.name)
+		}
+		resp.Operations = append(resp.Operations, &api_v2.Operation{Name: op.name, SpanKind: op.spanKind})
+	}
+
+	log.Printf("[QUERY v2] Returning %d operations for service %s\n", len(resp.Operations), req.Service)
+	return resp, nil
+}
+
+// collectorServiceV2 accepts spans with the legacy api_v2 Collector Service.
+type collectorServiceV2 struct {
+	api_v2.UnimplementedCollectorServiceServer
+
+	q *QueryService
+}
+
+// PostSpans stores the spans of the batch. Spans without a process use the
+// process of the batch. Like the agent emulator, invalid spans are logged
+// and skipped.
+func (s *collectorServiceV2) PostSpans(_ context.Context, req *api_v2.PostSpansRequest) (*api_v2.PostSpansResponse, error) {
+	if err := checkBatchSize(len(req.GetBatch().GetSpans())); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	batch, err := apiv2.BatchFromProto(req.GetBatch())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	spans := batch.Spans
+	for _, span := range spans {
+		if span.Process == nil {
+			span.Process = batch.Process
+		}
+	}
+	rejected := s.q.receiveTraces(otlp.FromDomain(spans))
+	log.Printf("[COLLECTOR v2] Received %d spans, rejected %d\n", len(spans), len(rejected))
+	for _, err := range rejected {
+		log.Printf("[COLLECTOR v2] Rejected span: %v\n", err)
+	}
+	return &api_v2.PostSpansResponse{}, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Query API versions, as used in logs and usage reports.
+const (
+	apiV2 = "v2"
+	apiV3 = "v3"
+	// apiOther is the version of the other gRPC services, e.g. reflection.
+	apiOther = "other"
+)
+
+// deprecationHeader is the response header warning api_v2 clients of the deprecation.
+const deprecationHeader = "x-jaeger-deprecation-warning"
+
+const deprecationWarning = "jaeger.api_v2.QueryService is deprecated, migrate to jaeger.api_v3.QueryService"
+
+// apiVersion returns the query API version of a full gRPC method name.
+func apiVersion(fullMethod string) string {
+	switch {
+	case strings.HasPrefix(fullMethod, "/jaeger.api_v2."):
+		return apiV2
+	case strings.HasPrefix(fullMethod, "/jaeger.api_v3."):
+		return apiV3
+	default:
+		return apiOther
+	}
+}
+
+// deprecationUnaryInterceptor adds the deprecation warning to unary api_v2 calls.
+func deprecationUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if apiVersion(info.FullMethod) == apiV2 {
+		grpc.SetHeader(ctx, metadata.Pairs(deprecationHeader, deprecationWarning))
+	}
+	return handler(ctx, req)
+}
+
+// deprecationStreamInterceptor adds the deprecation warning to streaming api_v2 calls.
+func deprecationStreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if apiVersion(info.FullMethod) == apiV2 {
+		ss.SetHeader(metadata.Pairs(deprecationHeader, deprecationWarning))
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	common "go.opentelemetry.io/proto/otlp/common/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+// Operators of the attribute predicates. The value of a query attribute
+// either matches the attribute value exactly, or is a typed predicate:
+//
+//	http.status_code: ">=500"      numeric comparison, also >, < and <=
+//	db.rows:          "10..100"    inclusive numeric range
+//	http.url:         "~^/api/"    regular expression if enabled, else exact
+//	note:             "=>=500"     a leading = matches the rest literally
+//
+// Numeric predicates match int and double attributes, and string attributes
+// holding a number. A value whose operands are not numbers, e.g. "<nil>" or
+// "1.2..beta", is matched exactly, as before the predicates were supported.
+const (
+	opEqual        = "="
+	opGreater      = ">"
+	opGreaterEqual = ">="
+	opLess         = "<"
+	opLessEqual    = "<="
+	opRange        = ".."
+)
+
+// errorAttribute is the attribute that flags failed spans in Jaeger.
+const errorAttribute = "error"
+
+// attributeFilter is a predicate on the value of an attribute.
+type attributeFilter struct {
+	Key string
+	// Name is set if the predicate applies to the span name instead of an attribute.
+	Name bool
+	Op   string
+	// Value is the operand of opEqual.
+	Value string
+	// Min and Max are the numeric operands, Min alone for the comparisons.
+	Min, Max float64
+	// Regexp is the operand of opRegex.
+	Regexp *regexp.Regexp
+}
+
+// queryFilters returns the predicates of the query: those of the attributes
+// and, if the operation name is a pattern, the one of the span name. In that
+// case the returned query is a copy without the operation name.
+func (q *QueryService) queryFilters(query *api_v3.TraceQueryParameters) (*api_v3.TraceQueryParameters, []attributeFilter, error) {
+	filters, err := parseAttributeFilters(query.GetAttributes(), q.regex)
+	if err != nil {
+		return nil, nil, err
+	}
+	nameFilter, operation, err := q.regex.operationFilter(query.GetOperationName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if nameFilter != nil {
+		query = proto.CloneOf(query)
+		query.OperationName = operation
+		filters = append(filters, *nameFilter)
+	}
+	return query, filters, nil
+}
+
+// parseAttributeFilters returns the predicates of the query attributes,
+// ignoring the query hints, the sample and the downsampling options, sorted by key. Patterns are compiled by regex,
+// which is nil if they are not enabled, in which case ~ is matched literally.
+func parseAttributeFilters(attributes map[string]string, regex *regexMatcher) ([]attributeFilter, error) {
+	var filters []attributeFilter
+	for key, value := range attributes {
+		if strings.HasPrefix(key, queryHintPrefix) || key == sampleOption || isDownsampleOption(key) {
+			continue
+		}
+		f, err := parseAttributeFilter(key, value, regex)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+	sort.Slice(filters, func(i, j int) bool {
+		return filters[i].Key < filters[j].Key
+	})
+	return filters, nil
+}
+
+func parseAttributeFilter(key, value string, regex *regexMatcher) (attributeFilter, error) {
+	f := attributeFilter{Key: key}
+	var operand string
+	switch {
+	case strings.HasPrefix(value, opEqual):
+		f.Op, f.Value = opEqual, value[len(opEqual):]
+		return f, nil
+	case regex != nil && strings.HasPrefix(value, opRegex):
+		re, err := regex.compile(value[len(opRegex):])
+		if err != nil {
+			return f, fmt.Errorf("attribute %s: %w", key, err)
+		}
+		f.Op, f.Regexp = opRegex, re
+		return f, nil
+	case strings.HasPrefix(value, opGreaterEqual):
+		f.Op, operand = opGreaterEqual, value[len(opGreaterEqual):]
+	case strings.HasPrefix(value, opLessEqual):
+		f.Op, operand = opLessEqual, value[len(opLessEqual):]
+	case strings.HasPrefix(value, opGreater):
+		f.Op, operand = opGreater, value[len(opGreater):]
+	case strings.HasPrefix(value, opLess):
+		f.Op, operand = opLess, value[len(opLess):]
+	default:
+		lo, hi, ok := strings.Cut(value, opRange)
+		if !ok {
+			f.Op, f.Value = opEqual, value
+			return f, nil
+		}
+		minValue, err1 := strconv.ParseFloat(strings.TrimSpace(lo), 64)
+		maxValue, err2 := strconv.ParseFloat(strings.TrimSpace(hi), 64)
+		if err1 != nil || err2 != nil {
+			f.Op, f.Value = opEqual, value
+			return f, nil
+		}
+		if minValue > maxValue {
+			return f, fmt.Errorf("invalid range %q for attribute %s, the min is above the max", value, key)
+		}
+		f.Op, f.Min, f.Max = opRange, minValue, maxValue
+		return f, nil
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(operand), 64)
+	if err != nil {
+		return attributeFilter{Key: key, Op: opEqual, Value: value}, nil
+	}
+	f.Min = n
+	return f, nil
+}
+
+// matches reports whether the attribute value satisfies the predicate.
+func (f attributeFilter) matches(value *common.AnyValue) bool {
+	switch f.Op {
+	case opEqual:
+		return attributeString(value) == f.Value
+	case opRegex:
+		return f.Regexp.MatchString(attributeString(value))
+	}
+	n, ok := attributeNumber(value)
+	if !ok {
+		return false
+	}
+	switch f.Op {
+	case opGreater:
+		return n > f.Min
+	case opGreaterEqual:
+		return n >= f.Min
+	case opLess:
+		return n < f.Min
+	case opLessEqual:
+		return n <= f.Min
+	case opRange:
+		return n >= f.Min && n <= f.Max
+	default:
+		return false
+	}
+}
+
+// spanMatches reports whether every predicate is satisfied by an attribute
+// of the span or, failing that, of its resource. Like in Jaeger, the error
+// attribute also matches the status of the span, e.g. error=true matches
+// the spans with an error status.
+func spanMatches(span *trace.Span, rs *trace.ResourceSpans, filters []attributeFilter) bool {
+	for _, f := range filters {
+		if f.Name {
+			if !f.Regexp.MatchString(span.Name) {
+				return false
+			}
+			continue
+		}
+		if f.Key == errorAttribute && f.Op == opEqual {
+			isError := span.GetStatus().GetCode() == trace.Status_STATUS_CODE_ERROR
+			if f.Value == strconv.FormatBool(isError) {
+				continue
+			}
+		}
+		if !anyAttributeMatches(span.Attributes, f) && !anyAttributeMatches(rs.GetResource().GetAttributes(), f) {
+			return false
+		}
+	}
+	return true
+}
+
+func anyAttributeMatches(attrs []*common.KeyValue, f attributeFilter) bool {
+	for _, attr := range attrs {
+		if attr.Key == f.Key && f.matches(attr.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func attributeString(value *common.AnyValue) string {
+	switch v := value.GetValue().(type) {
+	case *common.AnyValue_StringValue:
+		return v.StringValue
+	case *common.AnyValue_IntValue:
+		return strconv.FormatInt(v.IntValue, 10)
+	case *common.AnyValue_DoubleValue:
+		return strconv.FormatFloat(v.DoubleValue, 'g', -1, 64)
+	case *common.AnyValue_BoolValue:
+		return strconv.FormatBool(v.BoolValue)
+	default:
+		return ""
+	}
+}
+
+func attributeNumber(value *common.AnyValue) (float64, bool) {
+	switch v := value.GetValue().(type) {
+	case *common.AnyValue_IntValue:
+		return float64(v.IntValue), true
+	case *common.AnyValue_DoubleValue:
+		return v.DoubleValue, true
+	case *common.AnyValue_StringValue:
+		n, err := strconv.ParseFloat(v.StringValue, 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
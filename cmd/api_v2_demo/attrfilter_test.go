@@ -0,0 +1,45 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAttributeFilter(t *testing.T) {
+	for value, expected := range map[string]attributeFilter{
+		">=500":     {Op: opGreaterEqual, Min: 500},
+		"< 1.5":     {Op: opLess, Min: 1.5},
+		"10..100":   {Op: opRange, Min: 10, Max: 100},
+		"=>=500":    {Op: opEqual, Value: ">=500"},
+		"GET":       {Op: opEqual, Value: "GET"},
+		"<nil>":     {Op: opEqual, Value: "<nil>"},
+		">foo":      {Op: opEqual, Value: ">foo"},
+		"<=":        {Op: opEqual, Value: "<="},
+		"1.2..beta": {Op: opEqual, Value: "1.2..beta"},
+		"..":        {Op: opEqual, Value: ".."},
+	} {
+		f, err := parseAttributeFilter("key", value, nil)
+		require.NoError(t, err, value)
+		expected.Key = "key"
+		assert.Equal(t, expected, f, value)
+	}
+
+	_, err := parseAttributeFilter("key", "100..10", nil)
+	assert.ErrorContains(t, err, "the min is above the max")
+}
+
+func TestAttributeFilterLiteralFallback(t *testing.T) {
+	f, err := parseAttributeFilter("version", "1.2..beta", nil)
+	require.NoError(t, err)
+	assert.True(t, f.matches(stringValue("1.2..beta")))
+	assert.False(t, f.matches(stringValue("1.5")))
+
+	f, err = parseAttributeFilter("result", "<nil>", nil)
+	require.NoError(t, err)
+	assert.True(t, f.matches(stringValue("<nil>")))
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// batchTraceIDsHeader extends the api_v2 GetTrace request to a list of trace
+// IDs, without changing its message. The request header lists the hex trace
+// IDs to return after the one of the request, comma separated or repeated,
+// for example
+//
+//	grpcurl -H 'x-jaeger-trace-ids: 00000000000000000000000000000002,00000000000000000000000000000003' ...
+//
+// Each trace is sent in its own chunks, and the response header lists the
+// IDs of the returned traces in the order they are sent, so that clients can
+// tell where a trace ends. Traces that are not found are left out.
+const batchTraceIDsHeader = "x-jaeger-trace-ids"
+
+// maxBatchTraces caps the number of traces of a batch GetTrace.
+const maxBatchTraces = 1000
+
+// batchTraceIDs returns the distinct trace IDs requested: traceID and those
+// of the batch request header, in order.
+func batchTraceIDs(ctx context.Context, traceID string) ([]string, error) {
+	traceIDs := []string{traceID}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(batchTraceIDsHeader) {
+		for _, id := range strings.Split(value, ",") {
+			id = strings.ToLower(strings.TrimSpace(id))
+			if b, err := hex.DecodeString(id); err != nil || len(b) != 16 {
+				return nil, fmt.Errorf("invalid trace ID %q in %s", id, batchTraceIDsHeader)
+			}
+			if slices.Contains(traceIDs, id) {
+				continue
+			}
+			if len(traceIDs) == maxBatchTraces {
+				return nil, fmt.Errorf("too many trace IDs, at most %d traces can be requested at once", maxBatchTraces)
+			}
+			traceIDs = append(traceIDs, id)
+		}
+	}
+	return traceIDs, nil
+}
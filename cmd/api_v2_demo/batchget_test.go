@@ -0,0 +1,86 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+)
+
+// getTraces returns the trace ID of the spans of each chunk and the batch
+// response header.
+func getTraces(ctx context.Context, client api_v2.QueryServiceClient, traceID []byte, batch string) ([]string, []string, error) {
+	if batch != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, batchTraceIDsHeader, batch)
+	}
+	stream, err := client.GetTrace(ctx, &api_v2.GetTraceRequest{TraceId: traceID})
+	if err != nil {
+		return nil, nil, err
+	}
+	var chunks []string
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		ids := make(map[string]bool)
+		for _, span := range chunk.Spans {
+			ids[hex.EncodeToString(span.TraceId)] = true
+		}
+		if len(ids) != 1 {
+			return nil, nil, errors.New("chunk with spans of several traces")
+		}
+		for id := range ids {
+			chunks = append(chunks, id)
+		}
+	}
+	header, err := stream.Header()
+	return chunks, header.Get(batchTraceIDsHeader), err
+}
+
+func TestBatchGetTrace(t *testing.T) {
+	q := NewQueryService()
+	require.Empty(t, q.importTraces(testBatch(0, 0, 0)))
+	require.Empty(t, q.importTraces(testBatch(1, 0, 1)))
+	client := api_v2.NewQueryServiceClient(newTestConn(t, q))
+	ctx := context.Background()
+	id := func(n int) string { return hex.EncodeToString(testTraceID(n)) }
+
+	chunks, header, err := getTraces(ctx, client, testTraceID(2), "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{id(2)}, chunks)
+	assert.Empty(t, header)
+
+	missing := id(999)
+	chunks, header, err = getTraces(ctx, client, testTraceID(2), strings.Join([]string{id(5), id(2), missing}, ",")+", "+strings.ToUpper(id(7)))
+	require.NoError(t, err)
+	assert.Equal(t, []string{id(2), id(5), id(7)}, chunks)
+	assert.Equal(t, []string{strings.Join(chunks, ",")}, header)
+
+	_, _, err = getTraces(ctx, client, testTraceID(999), id(998))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, _, err = getTraces(ctx, client, testTraceID(2), "not-a-trace-id")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	many := make([]string, maxBatchTraces)
+	for i := range many {
+		many[i] = id(i + 1000)
+	}
+	_, _, err = getTraces(ctx, client, testTraceID(2), strings.Join(many, ","))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"cmp"
+	"slices"
+)
+
+// serviceCatalog lists the services and operations of the stored spans.
+// It is derived from the index when first needed after a write, and must
+// not be modified.
+type serviceCatalog struct {
+	// services are sorted by name.
+	services []string
+	// operations of each service are sorted by name and span kind, and
+	// capped at the maximum number of operations.
+	operations map[string][]catalogOperation
+}
+
+// catalogOperation is an operation of the spans of a kind. An operation of
+// spans of several kinds is listed once per kind.
+type catalogOperation struct {
+	name string
+	// spanKind is empty for the spans of an unspecified kind.
+	spanKind string
+}
+
+// buildCatalog derives the catalog from the terms of the index. Spans
+// without a service are left out.
+func buildCatalog(x *traceIndex, maxOperations int) *serviceCatalog {
+	c := &serviceCatalog{operations: make(map[string][]catalogOperation)}
+	for term := range x.postings {
+		switch {
+		case term.service == "":
+		case term.operation == "" && term.key == "":
+			c.services = append(c.services, term.service)
+		case term.operation != "" && term.key == spanKindKey:
+			c.operations[term.service] = append(c.operations[term.service], catalogOperation{name: term.operation, spanKind: term.value})
+		}
+	}
+	slices.Sort(c.services)
+	for service, ops := range c.operations {
+		slices.SortFunc(ops, func(a, b catalogOperation) int {
+			return cmp.Or(cmp.Compare(a.name, b.name), cmp.Compare(a.spanKind, b.spanKind))
+		})
+		if maxOperations > 0 && len(ops) > maxOperations {
+			c.operations[service] = ops[:maxOperations]
+		}
+	}
+	return c
+}
+
+// catalogLocked returns the catalog, deriving it if the traces changed
+// since it was last derived. It must be called with q.mu held, which keeps
+// writers from invalidating a catalog before it is cached.
+func (q *QueryService) catalogLocked() *serviceCatalog {
+	if c := q.catalog.Load(); c != nil {
+		return c
+	}
+	c := buildCatalog(q.index, q.maxOperations)
+	q.catalog.Store(c)
+	return c
+}
+
+// serviceCatalog returns the catalog, taking q.mu only to derive it.
+func (q *QueryService) serviceCatalog() *serviceCatalog {
+	if c := q.catalog.Load(); c != nil {
+		return c
+	}
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.catalogLocked()
+}
+
+// operations returns the operations of the service, only those of the
+// spans of spanKind if it is set.
+func (q *QueryService) operations(service, spanKind string) []catalogOperation {
+	ops := q.serviceCatalog().operations[service]
+	if spanKind == "" {
+		return ops
+	}
+	var matching []catalogOperation
+	for _, op := range ops {
+		if op.spanKind == spanKind {
+			matching = append(matching, op)
+		}
+	}
+	return matching
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+)
+
+func operationNames(ops []catalogOperation) []string {
+	var names []string
+	for _, op := range ops {
+		names = append(names, op.name)
+	}
+	return names
+}
+
+func TestCatalogFollowsWrites(t *testing.T) {
+	q := NewQueryService()
+	assert.Empty(t, q.serviceCatalog().services)
+
+	require.Empty(t, q.importTraces(indexedBatch(1, 0, 3)))
+	require.Empty(t, q.importTraces(indexedBatch(0, 10, 2)))
+	assert.Equal(t, []string{"service-0", "service-1"}, q.serviceCatalog().services)
+	assert.Equal(t, []string{"op-0", "op-1", "op-2"}, operationNames(q.operations("service-1", "")))
+	assert.Equal(t, []string{"op-0", "op-1"}, operationNames(q.operations("service-0", "")))
+
+	// the cached catalog is reused until the next write
+	assert.Same(t, q.serviceCatalog(), q.serviceCatalog())
+
+	q.mu.Lock()
+	q.deleteTrace(hex.EncodeToString(testTraceID(10)))
+	q.deleteTrace(hex.EncodeToString(testTraceID(11)))
+	q.mu.Unlock()
+	assert.Equal(t, []string{"service-1"}, q.serviceCatalog().services)
+	assert.Empty(t, operationNames(q.operations("service-0", "")))
+
+	q.renameOperations(&renameRequest{Rules: []*renameRule{{From: "op-2", To: "renamed"}}})
+	assert.Equal(t, []string{"op-0", "op-1", "renamed"}, operationNames(q.operations("service-1", "")))
+}
+
+func TestMaxOperations(t *testing.T) {
+	q := NewQueryService()
+	q.maxOperations = 2
+	require.Empty(t, q.importTraces(indexedBatch(0, 0, 5)))
+
+	resp, err := q.GetOperations(context.Background(), &api_v3.GetOperationsRequest{Service: "service-0"})
+	require.NoError(t, err)
+	var names []string
+	for _, op := range resp.Operations {
+		names = append(names, op.Name)
+	}
+	assert.Equal(t, []string{"op-0", "op-1"}, names)
+
+	services, err := q.GetServices(context.Background(), &api_v3.GetServicesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"service-0"}, services.Services)
+}
+
+func TestOperationsBySpanKind(t *testing.T) {
+	q := NewQueryService()
+	td := indexedBatch(0, 0, 4)
+	spans := td.ResourceSpans[0].ScopeSpans[0].Spans
+	spans[0].Kind = trace.Span_SPAN_KIND_SERVER
+	spans[1].Kind = trace.Span_SPAN_KIND_CLIENT
+	spans[2].Kind = trace.Span_SPAN_KIND_SERVER
+	spans[2].Name = spans[1].Name
+	require.Empty(t, q.importTraces(td))
+
+	all := []catalogOperation{
+		{name: "op-0", spanKind: "server"},
+		{name: "op-1", spanKind: "client"},
+		{name: "op-1", spanKind: "server"},
+		{name: "op-3"},
+	}
+	assert.Equal(t, all, q.operations("service-0", ""))
+	assert.Equal(t, all[1:2], q.operations("service-0", "client"))
+
+	resp, err := q.GetOperations(context.Background(), &api_v3.GetOperationsRequest{Service: "service-0", SpanKind: "server"})
+	require.NoError(t, err)
+	require.Len(t, resp.Operations, 2)
+	assert.Equal(t, "op-0", resp.Operations[0].Name)
+	assert.Equal(t, "server", resp.Operations[0].SpanKind)
+	assert.Equal(t, "op-1", resp.Operations[1].Name)
+
+	v2, err := (&queryServiceV2{q: q}).GetOperations(context.Background(), &api_v2.GetOperationsRequest{Service: "service-0"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"op-0", "op-1", "op-3"}, v2.OperationNames)
+	assert.Len(t, v2.Operations, 4)
+	assert.Equal(t, "client", v2.Operations[1].SpanKind)
+
+	none, err := (&storageTraceReader{q: q}).GetOperations(context.Background(), &storagev2.GetOperationsRequest{Service: "service-0", SpanKind: "consumer"})
+	require.NoError(t, err)
+	assert.Empty(t, none.Operations)
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/model/comparator"
+)
+
+// Defaults of the operation comparison.
+const (
+	defaultCompareWindow = time.Hour
+	defaultCompareAlpha  = 0.05
+)
+
+// timeWindow is a half-open time range [Start, End).
+type timeWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+func (tw timeWindow) contains(t time.Time) bool {
+	return !t.Before(tw.Start) && t.Before(tw.End)
+}
+
+// operationComparison is the JSON representation of comparator.CompareWindows.
+type operationComparison struct {
+	Before     timeWindow       `json:"before"`
+	After      timeWindow       `json:"after"`
+	Alpha      float64          `json:"alpha"`
+	Operations []operationDelta `json:"operations"`
+}
+
+type operationDelta struct {
+	Service         string         `json:"service"`
+	Operation       string         `json:"operation"`
+	Before          operationStats `json:"before"`
+	After           operationStats `json:"after"`
+	P50DeltaNs      int64          `json:"p50DeltaNs"`
+	P99DeltaNs      int64          `json:"p99DeltaNs"`
+	ErrorRateDelta  float64        `json:"errorRateDelta"`
+	LatencyPValue   float64        `json:"latencyPValue"`
+	ErrorRatePValue float64        `json:"errorRatePValue"`
+	// LatencySignificant and ErrorRateSignificant report whether the
+	// p-values are below alpha.
+	LatencySignificant   bool `json:"latencySignificant"`
+	ErrorRateSignificant bool `json:"errorRateSignificant"`
+}
+
+type operationStats struct {
+	Count     int     `json:"count"`
+	Errors    int     `json:"errors"`
+	ErrorRate float64 `json:"errorRate"`
+	P50Ns     int64   `json:"p50Ns"`
+	P95Ns     int64   `json:"p95Ns"`
+	P99Ns     int64   `json:"p99Ns"`
+}
+
+func toOperationStats(s comparator.LatencyStats) operationStats {
+	return operationStats{
+		Count:     s.Count,
+		Errors:    s.Errors,
+		ErrorRate: s.ErrorRate,
+		P50Ns:     s.P50.Nanoseconds(),
+		P95Ns:     s.P95.Nanoseconds(),
+		P99Ns:     s.P99.Nanoseconds(),
+	}
+}
+
+// parseCompareWindows returns the windows of the query parameters, either
+// deploy, the time of a deployment, with window, the length of the windows
+// on each side of it, or before and after, each given as start,end. Times
+// are in RFC 3339 format.
+func parseCompareWindows(params url.Values) (before, after timeWindow, err error) {
+	if deploy := params.Get("deploy"); deploy != "" {
+		t, err := time.Parse(time.RFC3339Nano, deploy)
+		if err != nil {
+			return before, after, fmt.Errorf("invalid deploy %q: %w", deploy, err)
+		}
+		length := defaultCompareWindow
+		if v := params.Get("window"); v != "" {
+			if length, err = time.ParseDuration(v); err != nil || length <= 0 {
+				return before, after, fmt.Errorf("invalid window %q", v)
+			}
+		}
+		return timeWindow{t.Add(-length), t}, timeWindow{t, t.Add(length)}, nil
+	}
+	parse := func(key string) (timeWindow, error) {
+		v := params.Get(key)
+		start, end, ok := strings.Cut(v, ",")
+		if !ok {
+			return timeWindow{}, fmt.Errorf("invalid %s %q, expected start,end", key, v)
+		}
+		var tw timeWindow
+		var err error
+		if tw.Start, err = time.Parse(time.RFC3339Nano, start); err != nil {
+			return tw, fmt.Errorf("invalid %s start: %w", key, err)
+		}
+		if tw.End, err = time.Parse(time.RFC3339Nano, end); err != nil {
+			return tw, fmt.Errorf("invalid %s end: %w", key, err)
+		}
+		if !tw.End.After(tw.Start) {
+			return tw, fmt.Errorf("invalid %s, the end is not after the start", key)
+		}
+		return tw, nil
+	}
+	if params.Get("before") == "" || params.Get("after") == "" {
+		return before, after, errors.New("missing deploy, or before and after")
+	}
+	if before, err = parse("before"); err != nil {
+		return before, after, err
+	}
+	after, err = parse("after")
+	return before, after, err
+}
+
+// handleCompareOperations serves the change of the latency and error rate
+// of each operation between two time windows, e.g. before and after a
+// deployment, computed from the stored spans started within the windows.
+// An optional service parameter restricts the comparison to a service and
+// alpha sets the significance level of the tests. The counts are perturbed by
+// the differential privacy noise of the tenant, if any.
+func (q *QueryService) handleCompareOperations(w http.ResponseWriter, r *http.Request, noise *laplaceNoise) {
+	params := r.URL.Query()
+	before, after, err := parseCompareWindows(params)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+	alpha := defaultCompareAlpha
+	if v := params.Get("alpha"); v != "" {
+		if alpha, err = strconv.ParseFloat(v, 64); err != nil || alpha <= 0 || alpha >= 1 {
+			writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid alpha %q, expected a number between 0 and 1", v))
+			return
+		}
+	}
+	service := params.Get("service")
+
+	ctx := r.Context()
+	windowBefore, windowAfter := make(comparator.Window), make(comparator.Window)
+	q.mu.RLock()
+	for _, td := range q.traces {
+		if ctx.Err() != nil {
+			break
+		}
+		forEachSpan(td, func(spanService string, span *trace.Span) {
+			if service != "" && spanService != service {
+				return
+			}
+			start := time.Unix(0, int64(span.StartTimeUnixNano))
+			duration := time.Duration(span.EndTimeUnixNano - span.StartTimeUnixNano)
+			isError := span.GetStatus().GetCode() == trace.Status_STATUS_CODE_ERROR
+			switch {
+			case before.contains(start):
+				windowBefore.Add(spanService, span.Name, duration, isError)
+			case after.contains(start):
+				windowAfter.Add(spanService, span.Name, duration, isError)
+			}
+		})
+	}
+	q.mu.RUnlock()
+	if ctx.Err() != nil {
+		writeAdminError(w, http.StatusServiceUnavailable, fmt.Errorf("comparison aborted: %w", ctx.Err()))
+		return
+	}
+
+	resp := operationComparison{Before: before, After: after, Alpha: alpha, Operations: []operationDelta{}}
+	for _, d := range comparator.CompareWindows(windowBefore, windowAfter) {
+		resp.Operations = append(resp.Operations, operationDelta{
+			Service:              d.Service,
+			Operation:            d.Operation.Operation,
+			Before:               toOperationStats(d.Before),
+			After:                toOperationStats(d.After),
+			P50DeltaNs:           (d.After.P50 - d.Before.P50).Nanoseconds(),
+			P99DeltaNs:           (d.After.P99 - d.Before.P99).Nanoseconds(),
+			ErrorRateDelta:       d.After.ErrorRate - d.Before.ErrorRate,
+			LatencyPValue:        d.LatencyPValue,
+			ErrorRatePValue:      d.ErrorRatePValue,
+			LatencySignificant:   d.LatencyPValue < alpha,
+			ErrorRateSignificant: d.ErrorRatePValue < alpha,
+		})
+	}
+	resp.applyNoise(noise)
+	writeAdminJSON(w, resp)
+}
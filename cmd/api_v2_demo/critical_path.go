@@ -0,0 +1,42 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	modeltrace "github.com/jaegertracing/jaeger-idl/model/trace"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// criticalPathAttribute is the synthetic span attribute holding the time,
+// in nanoseconds, that the span contributes to the critical path of its trace.
+const criticalPathAttribute = "jaeger.critical_path.duration_ns"
+
+// withCriticalPath returns a copy of td where every span on the critical
+// path of the trace is annotated with criticalPathAttribute.
+func withCriticalPath(td *trace.TracesData) *trace.TracesData {
+	tree := modeltrace.NewTree(otlp.ToDomain(td))
+	durations := modeltrace.CriticalPathDurations(tree.CriticalPath())
+	if len(durations) == 0 {
+		return td
+	}
+	annotated := proto.Clone(td).(*trace.TracesData)
+	for _, rs := range annotated.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				spanID, err := model.SpanIDFromBytes(span.SpanId)
+				if err != nil {
+					continue
+				}
+				if d, ok := durations[spanID]; ok {
+					span.Attributes = append(span.Attributes, intAttr(criticalPathAttribute, d.Nanoseconds()))
+				}
+			}
+		}
+	}
+	return annotated
+}
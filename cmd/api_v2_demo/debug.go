@@ -0,0 +1,101 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"expvar"
+	"log"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// rateWindow is the window of the rates of the debug variables.
+const rateWindow = time.Minute
+
+// rateMeter counts events, such as the received spans, in one second
+// buckets to compute their rate over the last rateWindow.
+type rateMeter struct {
+	mu    sync.Mutex
+	total int64
+	// counts[i] is the number of events of the second seconds[i], with
+	// i the second modulo the number of buckets.
+	counts  [int(rateWindow / time.Second)]int64
+	seconds [int(rateWindow / time.Second)]int64
+}
+
+// rateStats is the value of a rateMeter in the debug variables.
+type rateStats struct {
+	Total     int64   `json:"total"`
+	PerSecond float64 `json:"perSecond"`
+}
+
+func (m *rateMeter) add(n int, now time.Time) {
+	sec := now.Unix()
+	i := sec % int64(len(m.counts))
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.seconds[i] != sec {
+		m.seconds[i], m.counts[i] = sec, 0
+	}
+	m.counts[i] += int64(n)
+	m.total += int64(n)
+}
+
+// stats returns the total and the rate over the last rateWindow, the
+// current second excluded as it is not over yet.
+func (m *rateMeter) stats(now time.Time) rateStats {
+	sec := now.Unix()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var n int64
+	for i, s := range m.seconds {
+		if s < sec && s >= sec-int64(len(m.counts)) {
+			n += m.counts[i]
+		}
+	}
+	return rateStats{Total: m.total, PerSecond: float64(n) / rateWindow.Seconds()}
+}
+
+// publishDebugVars publishes the debug variables of q with expvar, along
+// with the command line and the memory statistics published by expvar
+// itself. It must only be called once.
+func publishDebugVars(q *QueryService) {
+	expvar.Publish("store", expvar.Func(func() any { return q.storeStats() }))
+	expvar.Publish("goroutines", expvar.Func(func() any { return runtime.NumGoroutine() }))
+	expvar.Publish("ingest", expvar.Func(func() any { return q.ingestRate.stats(time.Now()) }))
+}
+
+// newDebugHandler returns the HTTP handler of the pprof profiles and of the
+// expvar variables. They are served apart from the admin endpoints, as the
+// profiles are costly and expose the internals of the process.
+func newDebugHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("GET /debug/vars", expvar.Handler())
+	return mux
+}
+
+// serveDebug starts the debug HTTP server on lis in the background.
+func serveDebug(lis net.Listener, handler http.Handler) *http.Server {
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Printf("Debug endpoints (pprof and expvar) listening on %s\n", lis.Addr())
+	go func() {
+		if err := server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to serve debug endpoints: %v", err)
+		}
+	}()
+	return server
+}
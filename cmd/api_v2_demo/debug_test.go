@@ -0,0 +1,53 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateMeter(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	m := &rateMeter{}
+	assert.Equal(t, rateStats{}, m.stats(start))
+
+	m.add(30, start)
+	m.add(30, start.Add(500*time.Millisecond))
+	// the current second is not counted yet
+	assert.Equal(t, rateStats{Total: 60}, m.stats(start.Add(900*time.Millisecond)))
+	assert.Equal(t, rateStats{Total: 60, PerSecond: 1}, m.stats(start.Add(time.Second)))
+
+	// the buckets are reused once out of the window
+	m.add(120, start.Add(rateWindow+10*time.Second))
+	assert.Equal(t, rateStats{Total: 180, PerSecond: 2}, m.stats(start.Add(rateWindow+11*time.Second)))
+	assert.Equal(t, rateStats{Total: 180}, m.stats(start.Add(3*rateWindow)))
+}
+
+func TestDebugHandler(t *testing.T) {
+	q := NewQueryService()
+	require.Empty(t, q.receiveTraces(testBatch(0, 0, 0)))
+	publishDebugVars(q)
+	handler := newDebugHandler()
+
+	var vars struct {
+		Store      storeStats `json:"store"`
+		Goroutines int        `json:"goroutines"`
+		Ingest     rateStats  `json:"ingest"`
+	}
+	require.Equal(t, http.StatusOK, adminCall(t, handler, http.MethodGet, "/debug/vars", &vars))
+	assert.Equal(t, testTraces, vars.Store.Traces)
+	assert.Positive(t, vars.Goroutines)
+	assert.Equal(t, int64(vars.Store.Spans), vars.Ingest.Total)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "goroutine")
+}
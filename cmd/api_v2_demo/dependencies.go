@@ -0,0 +1,438 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+	"github.com/jaegertracing/jaeger-idl/model/comparator"
+)
+
+// Stores of the aggregated dependency links.
+const (
+	dependencyStoreMemory = "memory"
+	dependencyStoreFile   = "file"
+)
+
+// dependencyOptions configures the aggregation of the dependency links.
+type dependencyOptions struct {
+	// Interval is how often the links of the spans started since the
+	// previous run are aggregated, 0 to compute the links on each read.
+	Interval time.Duration
+	// Store is where the aggregated links are kept, memory or file.
+	Store string
+	// Path is the file of the file store.
+	Path string
+}
+
+// DependenciesWriter persists the dependency links aggregated over a time
+// window, like spark-dependencies writes the links of the past days to the
+// storage of Jaeger.
+type DependenciesWriter interface {
+	WriteDependencies(window timeWindow, dependencies []*storagev2.Dependency) error
+}
+
+// dependencyStore keeps the aggregated dependency links and reads them back.
+type dependencyStore interface {
+	DependenciesWriter
+	// GetDependencies merges the links of the windows overlapping the time
+	// range, unbounded on the side of a zero time.
+	GetDependencies(start, end time.Time) []*storagev2.Dependency
+	// lastEnd returns the end of the last window written, or the zero time.
+	lastEnd() time.Time
+	// snapshot returns the windows written, for the handoff.
+	snapshot() []dependencyWindow
+}
+
+func newDependencyStore(opts dependencyOptions) (dependencyStore, error) {
+	switch opts.Store {
+	case dependencyStoreMemory:
+		return &memoryDependencyStore{}, nil
+	case dependencyStoreFile:
+		if opts.Path == "" {
+			return nil, errors.New("the file dependency store needs a path")
+		}
+		return openFileDependencyStore(opts.Path)
+	default:
+		return nil, fmt.Errorf("unknown dependency store %q, expected %q or %q", opts.Store, dependencyStoreMemory, dependencyStoreFile)
+	}
+}
+
+// dependencyWindow holds the links aggregated over a time window.
+type dependencyWindow struct {
+	window       timeWindow
+	dependencies []*storagev2.Dependency
+}
+
+// memoryDependencyStore keeps the aggregated links in memory.
+type memoryDependencyStore struct {
+	mu      sync.RWMutex
+	windows []dependencyWindow
+}
+
+func (s *memoryDependencyStore) WriteDependencies(window timeWindow, dependencies []*storagev2.Dependency) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.windows = append(s.windows, dependencyWindow{window: window, dependencies: dependencies})
+	return nil
+}
+
+func (s *memoryDependencyStore) GetDependencies(start, end time.Time) []*storagev2.Dependency {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var windows [][]*storagev2.Dependency
+	for _, w := range s.windows {
+		if (start.IsZero() || w.window.End.After(start)) && (end.IsZero() || !w.window.Start.After(end)) {
+			windows = append(windows, w.dependencies)
+		}
+	}
+	return mergeDependencies(windows)
+}
+
+func (s *memoryDependencyStore) lastEnd() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.windows) == 0 {
+		return time.Time{}
+	}
+	return s.windows[len(s.windows)-1].window.End
+}
+
+func (s *memoryDependencyStore) snapshot() []dependencyWindow {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.windows)
+}
+
+// fileDependencyStore appends the aggregated links to a file, a window per
+// line, and keeps them in memory to serve the reads. The links written by
+// the previous processes are loaded when the file is opened.
+type fileDependencyStore struct {
+	memoryDependencyStore
+
+	fileMu sync.Mutex
+	file   *os.File
+}
+
+// dependencyFileLine is a line of the file of a fileDependencyStore.
+type dependencyFileLine struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Links is a storage v2 GetDependenciesResponse in the protobuf JSON
+	// encoding.
+	Links json.RawMessage `json:"links"`
+}
+
+func openFileDependencyStore(path string) (*fileDependencyStore, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open dependency store: %w", err)
+	}
+	s := &fileDependencyStore{file: f}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 64<<20)
+	for n := 1; scanner.Scan(); n++ {
+		var line dependencyFileLine
+		links := &storagev2.GetDependenciesResponse{}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("invalid line %d of %s: %w", n, path, err)
+		}
+		if err := protojson.Unmarshal(line.Links, links); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("invalid links on line %d of %s: %w", n, path, err)
+		}
+		s.windows = append(s.windows, dependencyWindow{window: timeWindow{line.Start, line.End}, dependencies: links.Dependencies})
+	}
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("cannot read %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *fileDependencyStore) WriteDependencies(window timeWindow, dependencies []*storagev2.Dependency) error {
+	links, err := protojson.Marshal(&storagev2.GetDependenciesResponse{Dependencies: dependencies})
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(dependencyFileLine{Start: window.Start, End: window.End, Links: links})
+	if err != nil {
+		return err
+	}
+	s.fileMu.Lock()
+	defer s.fileMu.Unlock()
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("cannot write dependencies: %w", err)
+	}
+	return s.memoryDependencyStore.WriteDependencies(window, dependencies)
+}
+
+func (s *fileDependencyStore) Close() error {
+	s.fileMu.Lock()
+	defer s.fileMu.Unlock()
+	return s.file.Close()
+}
+
+// mergeDependencies merges the links of several windows. The call and
+// error counts are summed, and the latency percentiles are approximated by
+// their means weighted by the call counts, as the windows do not keep the
+// latencies of the calls.
+func mergeDependencies(windows [][]*storagev2.Dependency) []*storagev2.Dependency {
+	type edge struct{ parent, child string }
+	type weighted struct{ p50, p95, p99 float64 }
+	merged := make(map[edge]*storagev2.Dependency)
+	sums := make(map[edge]*weighted)
+	for _, dependencies := range windows {
+		for _, dep := range dependencies {
+			e := edge{dep.Parent, dep.Child}
+			m, ok := merged[e]
+			if !ok {
+				m = &storagev2.Dependency{Parent: dep.Parent, Child: dep.Child, Source: dep.Source}
+				merged[e] = m
+				sums[e] = &weighted{}
+			}
+			m.CallCount += dep.CallCount
+			m.ErrorCount += dep.ErrorCount
+			calls := float64(dep.CallCount)
+			sums[e].p50 += calls * float64(dep.GetLatencyP50().AsDuration())
+			sums[e].p95 += calls * float64(dep.GetLatencyP95().AsDuration())
+			sums[e].p99 += calls * float64(dep.GetLatencyP99().AsDuration())
+		}
+	}
+	result := make([]*storagev2.Dependency, 0, len(merged))
+	for e, m := range merged {
+		if m.CallCount > 0 {
+			calls := float64(m.CallCount)
+			m.LatencyP50 = durationpb.New(time.Duration(sums[e].p50 / calls))
+			m.LatencyP95 = durationpb.New(time.Duration(sums[e].p95 / calls))
+			m.LatencyP99 = durationpb.New(time.Duration(sums[e].p99 / calls))
+		}
+		result = append(result, m)
+	}
+	sortDependencies(result)
+	return result
+}
+
+func sortDependencies(dependencies []*storagev2.Dependency) {
+	sort.Slice(dependencies, func(i, j int) bool {
+		a, b := dependencies[i], dependencies[j]
+		return a.Parent < b.Parent || (a.Parent == b.Parent && a.Child < b.Child)
+	})
+}
+
+// dependencyLinks returns the calls between services, see
+// computeDependencies, among the spans that started within [start, end],
+// either bound being optional. The links are read from the store of the
+// dependency aggregation if it is enabled, and computed from the spans
+// otherwise.
+func (q *QueryService) dependencyLinks(start, end *timestamppb.Timestamp) []*storagev2.Dependency {
+	if q.dependencies != nil {
+		var from, to time.Time
+		if start != nil {
+			from = start.AsTime()
+		}
+		if end != nil {
+			to = end.AsTime()
+		}
+		return q.dependencies.store.GetDependencies(from, to)
+	}
+	var from, to uint64
+	if start != nil {
+		from = uint64(start.AsTime().UnixNano())
+	}
+	if end != nil {
+		to = uint64(end.AsTime().UnixNano())
+	}
+	return q.computeDependencies(from, to)
+}
+
+// computeDependencies counts the calls between services, i.e. the spans
+// whose parent span belongs to another service, among the spans that
+// started within [from, to], in Unix nanoseconds, with to 0 for no upper
+// bound. The failed calls and the latency percentiles of each dependency
+// are those of the child spans.
+func (q *QueryService) computeDependencies(from, to uint64) []*storagev2.Dependency {
+	type edge struct{ parent, child string }
+	samples := make(map[edge]*comparator.Sample)
+
+	q.mu.RLock()
+	for _, td := range q.traces {
+		services := make(map[string]string)
+		forEachSpan(td, func(service string, span *trace.Span) {
+			services[string(span.SpanId)] = service
+		})
+		forEachSpan(td, func(service string, span *trace.Span) {
+			if span.StartTimeUnixNano < from || (to != 0 && span.StartTimeUnixNano > to) {
+				return
+			}
+			parent, ok := services[string(span.ParentSpanId)]
+			if !ok || parent == service {
+				return
+			}
+			sample, ok := samples[edge{parent, service}]
+			if !ok {
+				sample = &comparator.Sample{}
+				samples[edge{parent, service}] = sample
+			}
+			sample.Durations = append(sample.Durations, time.Duration(span.EndTimeUnixNano-span.StartTimeUnixNano))
+			if span.GetStatus().GetCode() == trace.Status_STATUS_CODE_ERROR {
+				sample.Errors++
+			}
+		})
+	}
+	q.mu.RUnlock()
+
+	dependencies := make([]*storagev2.Dependency, 0, len(samples))
+	for e, sample := range samples {
+		stats := sample.Stats()
+		dependencies = append(dependencies, &storagev2.Dependency{
+			Parent:     e.parent,
+			Child:      e.child,
+			CallCount:  uint64(stats.Count),
+			ErrorCount: uint64(stats.Errors),
+			LatencyP50: durationpb.New(stats.P50),
+			LatencyP95: durationpb.New(stats.P95),
+			LatencyP99: durationpb.New(stats.P99),
+		})
+	}
+	sortDependencies(dependencies)
+	return dependencies
+}
+
+// dependencyAggregator aggregates the dependency links of the spans
+// started since its previous run, and writes them to its store. The spans
+// received after the window of their start time was aggregated are left
+// out, as they are by the daily runs of spark-dependencies.
+type dependencyAggregator struct {
+	store    dependencyStore
+	interval time.Duration
+
+	mu sync.Mutex
+	// last is the end of the last window aggregated, the zero time before
+	// the first run, which aggregates all the spans stored so far.
+	last  time.Time
+	stats dependencyAggregationStats
+}
+
+// dependencyAggregationStats is the report of the admin endpoint.
+type dependencyAggregationStats struct {
+	Store    string `json:"store"`
+	Interval string `json:"interval"`
+	Runs     int64  `json:"runs"`
+	Failures int64  `json:"failures"`
+	// LastWindow is the window of the last run, and LastLinks the number of
+	// links it aggregated.
+	LastWindow *timeWindow `json:"lastWindow,omitempty"`
+	LastLinks  int         `json:"lastLinks"`
+	LastError  string      `json:"lastError,omitempty"`
+}
+
+func newDependencyAggregator(opts dependencyOptions) (*dependencyAggregator, error) {
+	if opts.Interval <= 0 {
+		return nil, errors.New("the aggregation interval must be positive")
+	}
+	store, err := newDependencyStore(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &dependencyAggregator{
+		store:    store,
+		interval: opts.Interval,
+		last:     store.lastEnd(),
+		stats: dependencyAggregationStats{
+			Store:    opts.Store,
+			Interval: opts.Interval.String(),
+		},
+	}, nil
+}
+
+// aggregate writes the links of the spans started since the previous run
+// and before now.
+func (a *dependencyAggregator) aggregate(q *QueryService, now time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	window := timeWindow{Start: a.last, End: now}
+	if !window.End.After(window.Start) {
+		return nil
+	}
+	var from uint64
+	if !window.Start.IsZero() {
+		from = uint64(window.Start.UnixNano())
+	}
+	// the windows are half-open, unlike the reads
+	dependencies := q.computeDependencies(from, uint64(window.End.UnixNano())-1)
+	a.stats.Runs++
+	if err := a.store.WriteDependencies(window, dependencies); err != nil {
+		a.stats.Failures++
+		a.stats.LastError = err.Error()
+		return err
+	}
+	a.last = now
+	a.stats.LastWindow = &window
+	a.stats.LastLinks = len(dependencies)
+	a.stats.LastError = ""
+	return nil
+}
+
+// run aggregates the links every interval until stop is called, which
+// aggregates the last window and closes the store.
+func (a *dependencyAggregator) run(q *QueryService) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if err := a.aggregate(q, now); err != nil {
+					log.Printf("[DEPENDENCIES] Failed to write the dependency links: %v\n", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+		if err := a.aggregate(q, time.Now()); err != nil {
+			log.Printf("[DEPENDENCIES] Failed to write the dependency links: %v\n", err)
+		}
+		if closer, ok := a.store.(interface{ Close() error }); ok {
+			closer.Close()
+		}
+	}
+}
+
+// handleDependencyAggregation serves the runs of the dependency aggregation.
+func (q *QueryService) handleDependencyAggregation(w http.ResponseWriter, _ *http.Request) {
+	if q.dependencies == nil {
+		writeAdminError(w, http.StatusNotFound, errors.New("the dependency links are computed on each read"))
+		return
+	}
+	q.dependencies.mu.Lock()
+	stats := q.dependencies.stats
+	q.dependencies.mu.Unlock()
+	writeAdminJSON(w, stats)
+}
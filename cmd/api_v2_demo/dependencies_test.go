@@ -0,0 +1,163 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+)
+
+// callBatch returns a call of service-0 to service-1 of duration in each
+// trace from the first one, started at start.
+func callBatch(first int, start time.Time, duration time.Duration) *trace.TracesData {
+	td := testBatch(0, first, 0)
+	calls := testBatch(1, first, 1)
+	for i, span := range calls.ResourceSpans[0].ScopeSpans[0].Spans {
+		span.ParentSpanId = testSpanID(first+i, 0)
+		span.StartTimeUnixNano = uint64(start.UnixNano())
+		span.EndTimeUnixNano = uint64(start.Add(duration).UnixNano())
+	}
+	td.ResourceSpans = append(td.ResourceSpans, calls.ResourceSpans...)
+	return td
+}
+
+// dependencyStrings describes the dependencies for the comparisons.
+func dependencyStrings(deps []*storagev2.Dependency) []string {
+	var s []string
+	for _, dep := range deps {
+		s = append(s, fmt.Sprintf("%s -> %s: %d calls, p50 %v", dep.Parent, dep.Child, dep.CallCount, dep.LatencyP50.AsDuration()))
+	}
+	return s
+}
+
+func TestDependencyAggregator(t *testing.T) {
+	q := NewQueryService()
+	require.Empty(t, q.importTraces(callBatch(0, testStart, 10*time.Millisecond)))
+	var err error
+	q.dependencies, err = newDependencyAggregator(dependencyOptions{Interval: time.Minute, Store: dependencyStoreMemory})
+	require.NoError(t, err)
+	reader := &storageDependencyReader{q: q}
+
+	// the first run aggregates the spans stored so far
+	require.NoError(t, q.dependencies.aggregate(q, testStart.Add(time.Minute)))
+	require.Empty(t, q.importTraces(callBatch(testTraces, testStart.Add(time.Minute), 30*time.Millisecond)))
+	resp, err := reader.GetDependencies(t.Context(), &storagev2.GetDependenciesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"service-0 -> service-1: 8 calls, p50 10ms"}, dependencyStrings(resp.Dependencies), "the links are not computed on read")
+
+	// the call started at the end of the first window is in the second one
+	require.NoError(t, q.dependencies.aggregate(q, testStart.Add(2*time.Minute)))
+	resp, err = reader.GetDependencies(t.Context(), &storagev2.GetDependenciesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"service-0 -> service-1: 16 calls, p50 20ms"}, dependencyStrings(resp.Dependencies))
+	resp, err = reader.GetDependencies(t.Context(), &storagev2.GetDependenciesRequest{
+		StartTime: timestamppb.New(testStart.Add(time.Minute)),
+		EndTime:   timestamppb.New(testStart.Add(2 * time.Minute)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"service-0 -> service-1: 8 calls, p50 30ms"}, dependencyStrings(resp.Dependencies))
+
+	var stats dependencyAggregationStats
+	require.Equal(t, http.StatusOK, adminCall(t, newAdminHandler(q, nil, nil), http.MethodGet, "/api/admin/dependencies/aggregation", &stats))
+	assert.Equal(t, int64(2), stats.Runs)
+	assert.Equal(t, 1, stats.LastLinks)
+	assert.Equal(t, &timeWindow{testStart.Add(time.Minute), testStart.Add(2 * time.Minute)}, stats.LastWindow)
+}
+
+func TestQueryServiceGetDependencies(t *testing.T) {
+	q := NewQueryService()
+	require.Empty(t, q.importTraces(callBatch(0, testStart, 10*time.Millisecond)))
+	require.Empty(t, q.importTraces(callBatch(testTraces, testStart.Add(time.Hour), 30*time.Millisecond)))
+	client := api_v3.NewQueryServiceClient(newTestConn(t, q))
+
+	resp, err := client.GetDependencies(t.Context(), &api_v3.GetDependenciesRequest{
+		StartTime: timestamppb.New(testStart),
+		EndTime:   timestamppb.New(testStart.Add(time.Minute)),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Dependencies, 1)
+	dep := resp.Dependencies[0]
+	assert.Equal(t, "service-0 -> service-1", dep.Parent+" -> "+dep.Child)
+	assert.Equal(t, uint64(testTraces), dep.CallCount)
+	assert.Equal(t, 10*time.Millisecond, dep.LatencyP99.AsDuration())
+
+	for _, req := range []*api_v3.GetDependenciesRequest{
+		{StartTime: timestamppb.New(testStart)},
+		{StartTime: timestamppb.New(testStart), EndTime: timestamppb.New(testStart.Add(-time.Second))},
+	} {
+		_, err := client.GetDependencies(t.Context(), req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "%v", req)
+	}
+}
+
+func TestDependencyAggregatorErrors(t *testing.T) {
+	for _, opts := range []dependencyOptions{
+		{Store: dependencyStoreMemory},
+		{Interval: time.Minute, Store: "badger"},
+		{Interval: time.Minute, Store: dependencyStoreFile},
+	} {
+		_, err := newDependencyAggregator(opts)
+		assert.Error(t, err, "%+v", opts)
+	}
+	assert.Equal(t, http.StatusNotFound, adminCall(t, newAdminHandler(NewQueryService(), nil, nil), http.MethodGet, "/api/admin/dependencies/aggregation", nil))
+}
+
+func TestFileDependencyStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dependencies.jsonl")
+	s, err := openFileDependencyStore(path)
+	require.NoError(t, err)
+	assert.True(t, s.lastEnd().IsZero())
+	window := timeWindow{testStart, testStart.Add(time.Minute)}
+	deps := []*storagev2.Dependency{{Parent: "frontend", Child: "driver", CallCount: 3, ErrorCount: 1, LatencyP50: durationpb.New(time.Millisecond)}}
+	require.NoError(t, s.WriteDependencies(window, deps))
+	require.NoError(t, s.Close())
+
+	// the links are loaded by the next process
+	s, err = openFileDependencyStore(path)
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Equal(t, window.End, s.lastEnd())
+	got := s.GetDependencies(time.Time{}, time.Time{})
+	require.Len(t, got, 1)
+	assert.Equal(t, uint64(1), got[0].ErrorCount)
+	assert.Equal(t, dependencyStrings(deps), dependencyStrings(got))
+	assert.Empty(t, s.GetDependencies(window.End, time.Time{}))
+
+	require.NoError(t, os.WriteFile(path, []byte("{\n"), 0o644))
+	_, err = openFileDependencyStore(path)
+	assert.ErrorContains(t, err, "invalid line 1")
+}
+
+func TestMergeDependencies(t *testing.T) {
+	merged := mergeDependencies([][]*storagev2.Dependency{
+		{
+			{Parent: "frontend", Child: "driver", CallCount: 1, ErrorCount: 1, LatencyP50: durationpb.New(40 * time.Millisecond)},
+			{Parent: "driver", Child: "redis", CallCount: 2},
+		},
+		{
+			{Parent: "frontend", Child: "driver", CallCount: 3, LatencyP50: durationpb.New(20 * time.Millisecond)},
+		},
+	})
+	assert.Equal(t, []string{
+		"driver -> redis: 2 calls, p50 0s",
+		"frontend -> driver: 4 calls, p50 25ms",
+	}, dependencyStrings(merged))
+	assert.Equal(t, uint64(1), merged[1].ErrorCount)
+}
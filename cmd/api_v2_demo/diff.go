@@ -0,0 +1,74 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jaegertracing/jaeger-idl/model/comparator"
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	modeltrace "github.com/jaegertracing/jaeger-idl/model/trace"
+)
+
+// traceDiff is the JSON representation of comparator.Diff.
+type traceDiff struct {
+	TraceA  string          `json:"traceA"`
+	TraceB  string          `json:"traceB"`
+	Matched int             `json:"matched"`
+	Added   int             `json:"added"`
+	Removed int             `json:"removed"`
+	Roots   []*traceDiffRow `json:"roots"`
+}
+
+type traceDiffRow struct {
+	Service     string          `json:"service"`
+	Operation   string          `json:"operation"`
+	Status      string          `json:"status"`
+	DurationANs int64           `json:"durationANs"`
+	DurationBNs int64           `json:"durationBNs"`
+	DeltaNs     int64           `json:"deltaNs"`
+	Children    []*traceDiffRow `json:"children,omitempty"`
+}
+
+func toDiffRows(nodes []*comparator.Node) []*traceDiffRow {
+	rows := make([]*traceDiffRow, 0, len(nodes))
+	for _, n := range nodes {
+		rows = append(rows, &traceDiffRow{
+			Service:     n.Service,
+			Operation:   n.Operation,
+			Status:      string(n.Status),
+			DurationANs: n.DurationA.Nanoseconds(),
+			DurationBNs: n.DurationB.Nanoseconds(),
+			DeltaNs:     n.Delta().Nanoseconds(),
+			Children:    toDiffRows(n.Children),
+		})
+	}
+	return rows
+}
+
+// handleTraceDiff serves the structural difference between two stored traces.
+func (q *QueryService) handleTraceDiff(w http.ResponseWriter, r *http.Request) {
+	idA, idB := r.PathValue("traceA"), r.PathValue("traceB")
+	var trees [2]*modeltrace.Tree
+	for i, id := range []string{idA, idB} {
+		q.mu.RLock()
+		td, ok := q.traces[id]
+		q.mu.RUnlock()
+		if !ok {
+			writeAdminError(w, http.StatusNotFound, fmt.Errorf("trace not found: %s", id))
+			return
+		}
+		trees[i] = modeltrace.NewTree(otlp.ToDomain(td))
+	}
+	diff := comparator.Compare(trees[0], trees[1])
+	writeAdminJSON(w, traceDiff{
+		TraceA:  idA,
+		TraceB:  idB,
+		Matched: diff.Matched,
+		Added:   diff.Added,
+		Removed: diff.Removed,
+		Roots:   toDiffRows(diff.Roots),
+	})
+}
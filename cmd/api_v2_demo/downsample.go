@@ -0,0 +1,167 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// Query attributes downsampling the result of FindTraces, so that a UI can
+// list many matches without fetching all their spans. Like the sample
+// option, they are not matched against the spans. For example
+//
+//	{"query": {"service_name": "frontend", "attributes": {"jaeger.summary": "true", "jaeger.stride": "10"}}}
+const (
+	// summaryOption is "true" to return the root span of each trace, with
+	// the summary attributes below, instead of all its spans.
+	summaryOption = "jaeger.summary"
+	// strideOption is N to return every Nth of the matching traces by trace
+	// ID, before the search depth is applied.
+	strideOption = "jaeger.stride"
+)
+
+// Synthetic attributes of the root span of a trace summary.
+const (
+	summarySpanCount    = "jaeger.summary.span_count"
+	summaryServiceCount = "jaeger.summary.service_count"
+	summaryErrorCount   = "jaeger.summary.error_count"
+	// summaryDuration is the time from the first span start to the last
+	// span end, in nanoseconds.
+	summaryDuration = "jaeger.summary.duration_ns"
+)
+
+// downsampleHeader is the response header reporting a downsampled result.
+const downsampleHeader = "x-jaeger-downsample"
+
+// downsampling is the validated downsampling of a query. The zero value
+// returns all the spans of all the matching traces.
+type downsampling struct {
+	Summary bool
+	Stride  int
+	// Matched is the number of matching traces before the stride, only
+	// set by strided.
+	Matched int
+}
+
+// parseDownsampling returns the downsampling of the query attributes.
+func parseDownsampling(attributes map[string]string) (downsampling, error) {
+	var d downsampling
+	if value, ok := attributes[summaryOption]; ok {
+		summary, err := strconv.ParseBool(value)
+		if err != nil {
+			return d, fmt.Errorf("invalid %s value %q, expected true or false", summaryOption, value)
+		}
+		d.Summary = summary
+	}
+	if value, ok := attributes[strideOption]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return d, fmt.Errorf("invalid %s value %q, expected a positive integer", strideOption, value)
+		}
+		d.Stride = n
+	}
+	return d, nil
+}
+
+// isDownsampleOption reports whether the query attribute is a downsampling option.
+func isDownsampleOption(key string) bool {
+	return key == summaryOption || key == strideOption
+}
+
+// strided returns every Nth of the found traces in the order of their
+// trace IDs, starting with the first one, and records how many were found.
+func (d *downsampling) strided(found []*trace.TracesData) []*trace.TracesData {
+	d.Matched = len(found)
+	if d.Stride <= 1 {
+		return found
+	}
+	sorted := slices.Clone(found)
+	sortByTraceID(sorted)
+	kept := make([]*trace.TracesData, 0, (len(sorted)+d.Stride-1)/d.Stride)
+	for i := 0; i < len(sorted); i += d.Stride {
+		kept = append(kept, sorted[i])
+	}
+	return kept
+}
+
+// result returns the trace to send, its summary if it was asked for.
+func (d downsampling) result(td *trace.TracesData) *trace.TracesData {
+	if !d.Summary {
+		return td
+	}
+	return traceSummary(td)
+}
+
+// String describes the downsampling for the downsample header, or is empty
+// if the result is not downsampled.
+func (d downsampling) String() string {
+	if !d.Summary && d.Stride <= 1 {
+		return ""
+	}
+	return fmt.Sprintf("summary=%t stride=%d matched=%d", d.Summary, max(d.Stride, 1), d.Matched)
+}
+
+// traceSummary returns a trace holding a copy of the root span of td, or of
+// its earliest span if it has no root, in its resource and scope, annotated
+// with the summary attributes of the whole trace.
+func traceSummary(td *trace.TracesData) *trace.TracesData {
+	var root *trace.Span
+	var rootResource *trace.ResourceSpans
+	var rootScope *trace.ScopeSpans
+	var spans, failed int
+	var start, end uint64
+	services := make(map[string]struct{})
+	for _, rs := range td.ResourceSpans {
+		services[getServiceName(rs.Resource)] = struct{}{}
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				spans++
+				if span.GetStatus().GetCode() == trace.Status_STATUS_CODE_ERROR {
+					failed++
+				}
+				if start == 0 || span.StartTimeUnixNano < start {
+					start = span.StartTimeUnixNano
+				}
+				end = max(end, span.EndTimeUnixNano)
+				if root == nil || summaryRootPrecedes(span, root) {
+					root, rootResource, rootScope = span, rs, ss
+				}
+			}
+		}
+	}
+	if root == nil {
+		return td
+	}
+	summary := proto.CloneOf(root)
+	summary.Attributes = append(summary.Attributes,
+		intAttr(summarySpanCount, int64(spans)),
+		intAttr(summaryServiceCount, int64(len(services))),
+		intAttr(summaryErrorCount, int64(failed)),
+		intAttr(summaryDuration, int64(end-start)),
+	)
+	return &trace.TracesData{ResourceSpans: []*trace.ResourceSpans{{
+		Resource:  rootResource.Resource,
+		SchemaUrl: rootResource.SchemaUrl,
+		ScopeSpans: []*trace.ScopeSpans{{
+			Scope:     rootScope.Scope,
+			SchemaUrl: rootScope.SchemaUrl,
+			Spans:     []*trace.Span{summary},
+		}},
+	}}}
+}
+
+// summaryRootPrecedes reports whether a rather than b is the root of a
+// summary: a span without parent, else the earliest one.
+func summaryRootPrecedes(a, b *trace.Span) bool {
+	if aRoot, bRoot := len(a.ParentSpanId) == 0, len(b.ParentSpanId) == 0; aRoot != bRoot {
+		return aRoot
+	}
+	return a.StartTimeUnixNano < b.StartTimeUnixNano
+}
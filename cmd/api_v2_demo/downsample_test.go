@@ -0,0 +1,150 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+)
+
+func TestParseDownsampling(t *testing.T) {
+	d, err := parseDownsampling(map[string]string{summaryOption: "true", strideOption: "3", "http.method": "GET"})
+	require.NoError(t, err)
+	assert.Equal(t, downsampling{Summary: true, Stride: 3}, d)
+
+	for _, attributes := range []map[string]string{
+		{summaryOption: "yes"},
+		{strideOption: "0"},
+		{strideOption: "every"},
+	} {
+		_, err := parseDownsampling(attributes)
+		assert.Error(t, err, "%v", attributes)
+	}
+}
+
+func TestTraceSummary(t *testing.T) {
+	td := callBatch(0, testStart.Add(time.Millisecond), 2*time.Millisecond)
+	// keep the first trace, with its root and the failed call
+	for _, rs := range td.ResourceSpans {
+		rs.ScopeSpans[0].Spans = rs.ScopeSpans[0].Spans[:1]
+	}
+	call := td.ResourceSpans[1].ScopeSpans[0].Spans[0]
+	call.Status = &trace.Status{Code: trace.Status_STATUS_CODE_ERROR}
+
+	summary := traceSummary(td)
+	require.Len(t, summary.ResourceSpans, 1)
+	assert.Equal(t, "service-0", getServiceName(summary.ResourceSpans[0].Resource))
+	spans := summary.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 1)
+	assert.Equal(t, testSpanID(0, 0), spans[0].SpanId)
+	attributes := make(map[string]int64)
+	for _, kv := range spans[0].Attributes {
+		attributes[kv.Key] = kv.Value.GetIntValue()
+	}
+	assert.Equal(t, map[string]int64{
+		summarySpanCount:    2,
+		summaryServiceCount: 2,
+		summaryErrorCount:   1,
+		summaryDuration:     int64(3 * time.Millisecond),
+	}, attributes)
+	assert.Empty(t, td.ResourceSpans[0].ScopeSpans[0].Spans[0].Attributes, "the stored span is not modified")
+}
+
+func TestFindTracesDownsampling(t *testing.T) {
+	q := NewQueryService()
+	require.Empty(t, q.importTraces(callBatch(0, testStart, time.Millisecond)))
+	client := api_v3.NewQueryServiceClient(newTestConn(t, q))
+
+	find := func(attributes map[string]string, opts ...grpc.CallOption) (map[string]int, error) {
+		stream, err := client.FindTraces(context.Background(), &api_v3.FindTracesRequest{
+			Query: &api_v3.TraceQueryParameters{ServiceName: "service-0", SearchDepth: 3, Attributes: attributes},
+		}, opts...)
+		require.NoError(t, err)
+		spans := make(map[string]int)
+		for {
+			td, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return spans, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			spans[hex.EncodeToString(firstTraceID(td))] += countSpans(td)
+		}
+	}
+
+	// every third of the 8 traces, of which the search depth keeps 3
+	var md metadata.MD
+	found, err := find(map[string]string{strideOption: "3", summaryOption: "true"}, grpc.Header(&md))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		hex.EncodeToString(testTraceID(0)): 1,
+		hex.EncodeToString(testTraceID(3)): 1,
+		hex.EncodeToString(testTraceID(6)): 1,
+	}, found)
+	assert.Equal(t, []string{"summary=true stride=3 matched=8"}, md.Get(downsampleHeader))
+
+	found, err = find(nil)
+	require.NoError(t, err)
+	assert.Len(t, found, 3)
+	for _, spans := range found {
+		assert.Equal(t, 2, spans)
+	}
+
+	_, err = find(map[string]string{strideOption: "-1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStorageDownsamplingHeader(t *testing.T) {
+	q := NewQueryService()
+	require.Empty(t, q.importTraces(callBatch(0, testStart, time.Millisecond)))
+	client := storagev2.NewTraceReaderClient(newTestConn(t, q))
+	query := func(summary string) *storagev2.FindTracesRequest {
+		return &storagev2.FindTracesRequest{Query: &storagev2.TraceQueryParameters{
+			ServiceName: "service-0",
+			SearchDepth: 3,
+			Attributes: []*storagev2.KeyValue{{
+				Key:   summaryOption,
+				Value: &storagev2.AnyValue{Value: &storagev2.AnyValue_StringValue{StringValue: summary}},
+			}},
+		}}
+	}
+
+	// both RPCs send the header of the summaries without a stride
+	for _, summary := range []string{"true", "false"} {
+		var findMD, idsMD metadata.MD
+		stream, err := client.FindTraces(context.Background(), query(summary), grpc.Header(&findMD))
+		require.NoError(t, err)
+		for {
+			if _, err := stream.Recv(); err != nil {
+				require.ErrorIs(t, err, io.EOF)
+				break
+			}
+		}
+		_, err = client.FindTraceIDs(context.Background(), query(summary), grpc.Header(&idsMD))
+		require.NoError(t, err)
+		assert.Equal(t, findMD.Get(downsampleHeader), idsMD.Get(downsampleHeader), summary)
+		if summary == "true" {
+			assert.Equal(t, []string{"summary=true stride=1 matched=8"}, idsMD.Get(downsampleHeader))
+		} else {
+			assert.Empty(t, idsMD.Get(downsampleHeader))
+		}
+	}
+}
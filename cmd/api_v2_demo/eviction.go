@@ -0,0 +1,212 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"container/list"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// memoryOptions bounds the traces kept in memory, for demos receiving real
+// traffic. Zero values mean no bound.
+type memoryOptions struct {
+	// MaxTraces is the number of traces above which the least recently
+	// written or read traces are evicted.
+	MaxTraces int
+	// TraceTTL is how long a trace is kept after it was last written.
+	TraceTTL time.Duration
+}
+
+// memoryLimits tracks the use of the traces to evict them; a nil
+// *memoryLimits does not bound the traces. It has its own lock, as the
+// traces are read under the read lock of the QueryService; when both are
+// needed, the lock of the QueryService is taken first.
+type memoryLimits struct {
+	opts memoryOptions
+
+	mu sync.Mutex
+	// lru lists the *lruEntry of the traces, least recently used first.
+	lru     *list.List
+	entries map[string]*list.Element
+	// writes holds the last write time of the traces, so that the TTL
+	// expiry only visits the buckets of the expired traces.
+	writes *timeBuckets
+
+	lruEvictions int64
+	ttlEvictions int64
+}
+
+type lruEntry struct {
+	traceID string
+}
+
+// memoryStats is the report of the admin endpoint.
+type memoryStats struct {
+	Traces       int    `json:"traces"`
+	MaxTraces    int    `json:"maxTraces,omitempty"`
+	TraceTTL     string `json:"traceTTL,omitempty"`
+	LRUEvictions int64  `json:"lruEvictions"`
+	TTLEvictions int64  `json:"ttlEvictions"`
+}
+
+func newMemoryLimits(opts memoryOptions) (*memoryLimits, error) {
+	if opts.MaxTraces < 0 || opts.TraceTTL < 0 {
+		return nil, errors.New("max traces and trace TTL must not be negative")
+	}
+	return &memoryLimits{opts: opts, lru: list.New(), entries: make(map[string]*list.Element), writes: newTimeBuckets()}, nil
+}
+
+// written records that spans of the trace were stored.
+func (m *memoryLimits) written(traceID string, now time.Time) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.writes.set(traceID, now.UnixNano())
+	if e, ok := m.entries[traceID]; ok {
+		m.lru.MoveToBack(e)
+		return
+	}
+	m.entries[traceID] = m.lru.PushBack(&lruEntry{traceID: traceID})
+}
+
+// read records that the trace was returned by a query.
+func (m *memoryLimits) read(traceID string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[traceID]; ok {
+		m.lru.MoveToBack(e)
+	}
+}
+
+// forget stops tracking a trace removed otherwise.
+func (m *memoryLimits) forget(traceID string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.remove(traceID)
+}
+
+// remove stops tracking a trace. It must be called with m.mu held.
+func (m *memoryLimits) remove(traceID string) {
+	if e, ok := m.entries[traceID]; ok {
+		m.lru.Remove(e)
+		delete(m.entries, traceID)
+	}
+	m.writes.remove(traceID)
+}
+
+// reset stops tracking all the traces, which were removed.
+func (m *memoryLimits) reset() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lru.Init()
+	clear(m.entries)
+	m.writes.reset()
+}
+
+// evictOverflow removes the least recently used traces above the maximum.
+// The entries of the traces removed otherwise, e.g. of deleted fixture
+// files, are dropped on the way. It must be called with q.mu held.
+func (m *memoryLimits) evictOverflow(q *QueryService) {
+	if m == nil || m.opts.MaxTraces == 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for len(q.traces) > m.opts.MaxTraces {
+		e := m.lru.Front()
+		if e == nil {
+			// the remaining traces were stored before the tracking started
+			return
+		}
+		traceID := e.Value.(*lruEntry).traceID
+		m.remove(traceID)
+		if _, ok := q.traces[traceID]; ok {
+			q.deleteTrace(traceID)
+			m.lruEvictions++
+		}
+	}
+}
+
+// expire removes the traces last written before the TTL, visiting only
+// the time buckets of their writes.
+func (m *memoryLimits) expire(q *QueryService, now time.Time) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expired := 0
+	for _, traceID := range m.writes.before(now.Add(-m.opts.TraceTTL).Add(1)) {
+		m.remove(traceID)
+		if _, ok := q.traces[traceID]; ok {
+			q.deleteTrace(traceID)
+			expired++
+		}
+	}
+	m.ttlEvictions += int64(expired)
+	return expired
+}
+
+// expireEvery removes the expired traces every interval until stop is called.
+func (m *memoryLimits) expireEvery(q *QueryService, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if n := m.expire(q, now); n > 0 {
+					log.Printf("[MEMORY] Expired %d traces older than %v\n", n, m.opts.TraceTTL)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// ttlCheckInterval returns how often the expired traces are removed: a
+// tenth of the TTL, between a second and a minute.
+func ttlCheckInterval(ttl time.Duration) time.Duration {
+	return min(max(ttl/10, time.Second), time.Minute)
+}
+
+// handleMemoryStats serves the number of traces in memory and of evictions.
+func (q *QueryService) handleMemoryStats(w http.ResponseWriter, _ *http.Request) {
+	q.mu.RLock()
+	stats := memoryStats{Traces: len(q.traces)}
+	q.mu.RUnlock()
+	if m := q.memory; m != nil {
+		m.mu.Lock()
+		stats.MaxTraces = m.opts.MaxTraces
+		if m.opts.TraceTTL > 0 {
+			stats.TraceTTL = m.opts.TraceTTL.String()
+		}
+		stats.LRUEvictions = m.lruEvictions
+		stats.TTLEvictions = m.ttlEvictions
+		m.mu.Unlock()
+	}
+	writeAdminJSON(w, stats)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpireByLastWrite(t *testing.T) {
+	q := NewQueryService()
+	var err error
+	q.memory, err = newMemoryLimits(memoryOptions{TraceTTL: time.Minute})
+	require.NoError(t, err)
+	require.Empty(t, q.importTraces(testBatch(0, 0, 0)))
+	traceID := func(i int) string { return hex.EncodeToString(testTraceID(i)) }
+	for i := 4; i < testTraces; i++ {
+		q.memory.written(traceID(i), testStart.Add(time.Hour))
+	}
+	q.memory.written(traceID(0), testStart)
+	q.memory.written(traceID(1), testStart.Add(30*time.Second))
+	q.memory.written(traceID(2), testStart.Add(90*time.Second))
+	q.memory.written(traceID(3), testStart)
+	// a new write moves the trace to a later bucket
+	q.memory.written(traceID(3), testStart.Add(2*time.Minute))
+
+	assert.Equal(t, 2, q.memory.expire(q, testStart.Add(90*time.Second)), "the traces written a TTL ago are expired")
+	q.mu.RLock()
+	assert.Len(t, q.traces, testTraces-2)
+	assert.Contains(t, q.traces, traceID(2))
+	assert.Contains(t, q.traces, traceID(3))
+	q.mu.RUnlock()
+	assert.Len(t, q.memory.entries, testTraces-2)
+	assert.Len(t, q.memory.writes.times, testTraces-2)
+
+	assert.Zero(t, q.memory.expire(q, testStart.Add(2*time.Minute)))
+	assert.Equal(t, 2, q.memory.expire(q, testStart.Add(3*time.Minute)))
+	assert.Equal(t, int64(4), q.memory.ttlEvictions)
+}
+
+func TestTimeBuckets(t *testing.T) {
+	b := newTimeBuckets()
+	b.set("a", int64(bucketWidth)/2)
+	b.set("b", 3*int64(bucketWidth))
+	b.set("c", 3*int64(bucketWidth)+1)
+	assert.Equal(t, []int64{0, 3}, b.keys)
+	assert.ElementsMatch(t, []string{"a", "b"}, b.before(time.Unix(0, 3*int64(bucketWidth)+1)))
+	assert.Equal(t, map[string]struct{}{"c": {}}, b.between(3*int64(bucketWidth)+1, 4*int64(bucketWidth)))
+
+	b.set("a", 5*int64(bucketWidth))
+	assert.Equal(t, []int64{3, 5}, b.keys, "the emptied bucket is dropped")
+	b.remove("b")
+	b.remove("c")
+	assert.Equal(t, []int64{5}, b.keys)
+	b.reset()
+	assert.Empty(t, b.keys)
+	assert.Empty(t, b.times)
+}
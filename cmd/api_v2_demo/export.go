@@ -0,0 +1,150 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"cmp"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// defaultExportChunkSize is the number of traces of each file of an export.
+const defaultExportChunkSize = 100
+
+// exportOptions selects the traces of an export.
+type exportOptions struct {
+	// Start and End, if set, bound the start times of the exported traces.
+	Start, End time.Time
+	// ChunkSize is the number of traces of each file of the archive.
+	ChunkSize int
+}
+
+// exportSnapshot returns the traces starting in the time range of opts,
+// ordered by start time and trace ID. The stored TracesData are never
+// modified in place, so they can be written out without the lock.
+func (q *QueryService) exportSnapshot(opts exportOptions) []*trace.TracesData {
+	minStart, maxStart := int64(0), int64(math.MaxInt64)
+	if !opts.Start.IsZero() {
+		minStart = opts.Start.UnixNano()
+	}
+	if !opts.End.IsZero() {
+		maxStart = opts.End.UnixNano()
+	}
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	var traceIDs []string
+	for traceID := range q.index.startedBetween(minStart, maxStart) {
+		traceIDs = append(traceIDs, traceID)
+	}
+	slices.SortFunc(traceIDs, func(a, b string) int {
+		return cmp.Or(cmp.Compare(q.index.starts.times[a], q.index.starts.times[b]), cmp.Compare(a, b))
+	})
+	traces := make([]*trace.TracesData, len(traceIDs))
+	for i, traceID := range traceIDs {
+		traces[i] = q.traces[traceID]
+	}
+	return traces
+}
+
+// writeExport writes the traces as a gzipped tarball of OTLP JSON files of
+// opts.ChunkSize traces each, the format of --seed-url, flushing w after
+// each file. It returns the number of files written.
+func (q *QueryService) writeExport(w io.Writer, traces []*trace.TracesData, opts exportOptions) (int, error) {
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+	flusher, _ := w.(http.Flusher)
+	var files int
+	for chunk := range slices.Chunk(traces, opts.ChunkSize) {
+		td := &trace.TracesData{}
+		for _, t := range chunk {
+			if q.exportAnonymizer != nil {
+				t = q.exportAnonymizer.anonymized(t)
+			}
+			td.ResourceSpans = append(td.ResourceSpans, t.ResourceSpans...)
+		}
+		doc, err := protojson.Marshal(td)
+		if err != nil {
+			return files, fmt.Errorf("cannot serialize the traces: %w", err)
+		}
+		hdr := &tar.Header{
+			Name:    fmt.Sprintf("traces-%05d.json", files),
+			Mode:    0o644,
+			Size:    int64(len(doc)),
+			ModTime: time.Now(),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return files, err
+		}
+		if _, err := tw.Write(doc); err != nil {
+			return files, err
+		}
+		files++
+		if err := zw.Flush(); err != nil {
+			return files, err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return files, err
+	}
+	return files, zw.Close()
+}
+
+// handleExport streams all the stored traces, or those starting between the
+// optional start and end query parameters, as a tarball that another
+// instance of the demo can import with --seed-url. The chunkSize query
+// parameter sets the number of traces of each file.
+func (q *QueryService) handleExport(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	opts := exportOptions{ChunkSize: defaultExportChunkSize}
+	var err error
+	if v := params.Get("start"); v != "" {
+		if opts.Start, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid start: %w", err))
+			return
+		}
+	}
+	if v := params.Get("end"); v != "" {
+		if opts.End, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid end: %w", err))
+			return
+		}
+	}
+	if !opts.Start.IsZero() && !opts.End.IsZero() && opts.End.Before(opts.Start) {
+		writeAdminError(w, http.StatusBadRequest, errors.New("invalid time range, the end is before the start"))
+		return
+	}
+	if v := params.Get("chunkSize"); v != "" {
+		if opts.ChunkSize, err = strconv.Atoi(v); err != nil || opts.ChunkSize < 1 {
+			writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid chunkSize %q", v))
+			return
+		}
+	}
+
+	traces := q.exportSnapshot(opts)
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", `attachment; filename="traces.tar.gz"`)
+	files, err := q.writeExport(w, traces, opts)
+	if err != nil {
+		// the status is already sent, the client gets a truncated archive
+		log.Printf("[ADMIN] Failed to export the traces after %d files: %v\n", files, err)
+		return
+	}
+	log.Printf("[ADMIN] Exported %d traces in %d files\n", len(traces), files)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+func TestExportSeedsAnotherStore(t *testing.T) {
+	q := NewQueryService()
+	require.Empty(t, q.importTraces(indexedBatch(0, 0, 180)))
+	handler := newAdminHandler(q, nil, nil)
+
+	start := testStart.Add(30 * time.Second).Format(time.RFC3339Nano)
+	end := testStart.Add(149 * time.Second).Format(time.RFC3339Nano)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/export?chunkSize=50&start="+start+"&end="+end, nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/gzip", w.Header().Get("Content-Type"))
+
+	docs, err := unpackSeed(w.Body.Bytes())
+	require.NoError(t, err)
+	assert.Len(t, docs, 3)
+	for _, name := range []string{"traces-00000.json", "traces-00001.json", "traces-00002.json"} {
+		assert.Contains(t, docs, name)
+	}
+	first := &trace.TracesData{}
+	require.NoError(t, protojson.Unmarshal(docs["traces-00000.json"], first))
+	assert.Len(t, first.ResourceSpans, 50)
+
+	seeded := NewQueryService()
+	for _, doc := range docs {
+		td := &trace.TracesData{}
+		require.NoError(t, protojson.Unmarshal(doc, td))
+		require.Empty(t, seeded.importTraces(td))
+	}
+	query := &api_v3.TraceQueryParameters{ServiceName: "service-0"}
+	exported := foundTraceIDs(t, seeded, query, nil)
+	require.Len(t, exported, 120)
+	query.StartTimeMin = timestamppb.New(testStart.Add(30 * time.Second))
+	query.StartTimeMax = timestamppb.New(testStart.Add(149 * time.Second))
+	assert.Equal(t, foundTraceIDs(t, q, query, nil), exported)
+}
+
+func TestExportErrors(t *testing.T) {
+	handler := newAdminHandler(NewQueryService(), nil, nil)
+	for _, query := range []string{
+		"start=yesterday",
+		"end=today",
+		"start=2026-01-02T00:00:00Z&end=2026-01-01T00:00:00Z",
+		"chunkSize=0",
+	} {
+		assert.Equal(t, http.StatusBadRequest, adminCall(t, handler, http.MethodGet, "/api/admin/export?"+query, nil), query)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/hex"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/internal/fixtures"
+)
+
+// fixturesWatcher keeps the traces of the .json and .pb files of a directory
+// loaded, for interactive demos: a new file is loaded, a modified file
+// replaces its traces, and a deleted file removes them. The directory is
+// polled; as a file may be written over several polls, a file that cannot
+// be parsed is retried once it changes.
+//
+// The traces are shifted to start when their file is loaded, so that they
+// are found by searches of the recent traces, or at a fixed base time for
+// reproducible data.
+type fixturesWatcher struct {
+	q        *QueryService
+	dir      string
+	baseTime time.Time // zero for the load time
+	files    map[string]fixtureFile
+}
+
+// fixtureFile is the state of a file of the directory as of its last load.
+type fixtureFile struct {
+	modTime  time.Time
+	size     int64
+	traceIDs []string
+}
+
+func newFixturesWatcher(q *QueryService, dir string, baseTime time.Time) *fixturesWatcher {
+	return &fixturesWatcher{q: q, dir: dir, baseTime: baseTime, files: make(map[string]fixtureFile)}
+}
+
+// scan loads the new and modified files, and unloads the deleted ones.
+func (w *fixturesWatcher) scan() {
+	var paths []string
+	for _, pattern := range []string{"*.json", "*.pb"} {
+		matches, err := filepath.Glob(filepath.Join(w.dir, pattern))
+		if err != nil {
+			log.Printf("[FIXTURES] Cannot list %s: %v\n", w.dir, err)
+			return
+		}
+		paths = append(paths, matches...)
+	}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			// deleted since it was listed, it is unloaded on the next scan
+			continue
+		}
+		if f, ok := w.files[path]; ok && f.modTime.Equal(info.ModTime()) && f.size == info.Size() {
+			continue
+		}
+		w.load(path, info)
+	}
+	for path, f := range w.files {
+		if !slices.Contains(paths, path) {
+			w.unload(f.traceIDs)
+			delete(w.files, path)
+			log.Printf("[FIXTURES] Removed %d traces of deleted %s\n", len(f.traceIDs), path)
+		}
+	}
+}
+
+// load replaces the traces of the file, including those of its previous
+// version, with its current ones.
+func (w *fixturesWatcher) load(path string, info os.FileInfo) {
+	previous := w.files[path].traceIDs
+	w.files[path] = fixtureFile{modTime: info.ModTime(), size: info.Size(), traceIDs: previous}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Printf("[FIXTURES] Cannot read %s: %v\n", path, err)
+		return
+	}
+	td, err := fixtures.ParseTraces(path, data)
+	if err != nil {
+		log.Printf("[FIXTURES] Cannot parse %s, waiting for it to change: %v\n", path, err)
+		return
+	}
+	start := w.baseTime
+	if start.IsZero() {
+		start = time.Now()
+	}
+	fixtures.Rebase(td, start)
+
+	var traceIDs []string
+	forEachSpan(td, func(_ string, span *trace.Span) {
+		if id := hex.EncodeToString(span.TraceId); !slices.Contains(traceIDs, id) {
+			traceIDs = append(traceIDs, id)
+		}
+	})
+	w.unload(slices.Concat(previous, traceIDs))
+	for _, err := range w.q.importTraces(td) {
+		log.Printf("[FIXTURES] Rejected span from %s: %v\n", path, err)
+	}
+	w.files[path] = fixtureFile{modTime: info.ModTime(), size: info.Size(), traceIDs: traceIDs}
+	log.Printf("[FIXTURES] Loaded %d traces from %s\n", len(traceIDs), path)
+}
+
+// unload removes the traces, whichever way their spans were received.
+func (w *fixturesWatcher) unload(traceIDs []string) {
+	w.q.mu.Lock()
+	defer w.q.mu.Unlock()
+	for _, id := range traceIDs {
+		w.q.deleteTrace(id)
+	}
+}
+
+// watch scans the directory every interval until stop is called.
+func (w *fixturesWatcher) watch(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				w.scan()
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// Backoff between attempts to export a batch.
+const (
+	forwardInitialBackoff = 100 * time.Millisecond
+	forwardMaxBackoff     = 5 * time.Second
+	forwardExportTimeout  = 10 * time.Second
+)
+
+// forwardOptions configures the forwarding of stored spans to a downstream
+// OTLP gRPC endpoint.
+type forwardOptions struct {
+	Endpoint string
+	TLS      bool
+	// QueueSize is the number of batches waiting to be exported,
+	// further batches are dropped.
+	QueueSize  int
+	MaxRetries int
+}
+
+// forwarder exports the batches written to the store to a downstream
+// collector, for tee deployments during migrations. Batches are exported
+// in order by a single worker, so that a slow collector cannot slow down
+// ingestion beyond filling the queue.
+type forwarder struct {
+	opts   forwardOptions
+	conn   *grpc.ClientConn
+	client collectortrace.TraceServiceClient
+	done   chan struct{}
+
+	// mu guards queue against sends after Close.
+	mu     sync.RWMutex
+	queue  chan *trace.TracesData
+	closed bool
+
+	exported atomic.Int64
+	failed   atomic.Int64
+	dropped  atomic.Int64
+}
+
+// forwardStats are the counters of the forwarder, in batches.
+type forwardStats struct {
+	Endpoint string `json:"endpoint"`
+	Queued   int    `json:"queued"`
+	Exported int64  `json:"exported"`
+	Failed   int64  `json:"failed"`
+	Dropped  int64  `json:"dropped"`
+}
+
+func newForwarder(opts forwardOptions) (*forwarder, error) {
+	if opts.QueueSize < 0 || opts.MaxRetries < 0 {
+		return nil, errors.New("queue size and max retries must not be negative")
+	}
+	creds := insecure.NewCredentials()
+	if opts.TLS {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	conn, err := grpc.NewClient(opts.Endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create client for %s: %w", opts.Endpoint, err)
+	}
+	f := &forwarder{
+		opts:   opts,
+		conn:   conn,
+		client: collectortrace.NewTraceServiceClient(conn),
+		queue:  make(chan *trace.TracesData, opts.QueueSize),
+		done:   make(chan struct{}),
+	}
+	go f.run()
+	return f, nil
+}
+
+// enqueue schedules td for export without blocking. The batch is dropped
+// if the queue is full or the forwarder is closed. td must not be modified afterwards.
+func (f *forwarder) enqueue(td *trace.TracesData) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.closed {
+		return
+	}
+	select {
+	case f.queue <- td:
+	default:
+		if f.dropped.Add(1) == 1 {
+			log.Printf("[FORWARD] Queue is full, dropping batches\n")
+		}
+	}
+}
+
+func (f *forwarder) run() {
+	defer close(f.done)
+	for td := range f.queue {
+		if err := f.export(td); err != nil {
+			f.failed.Add(1)
+			log.Printf("[FORWARD] Failed to export batch to %s: %v\n", f.opts.Endpoint, err)
+			continue
+		}
+		f.exported.Add(1)
+	}
+}
+
+// export sends td, retrying with exponential backoff on the errors that
+// the OTLP specification defines as retryable.
+func (f *forwarder) export(td *trace.TracesData) error {
+	req := &collectortrace.ExportTraceServiceRequest{ResourceSpans: td.ResourceSpans}
+	backoff := forwardInitialBackoff
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), forwardExportTimeout)
+		resp, err := f.client.Export(ctx, req)
+		cancel()
+		if err == nil {
+			if rejected := resp.GetPartialSuccess().GetRejectedSpans(); rejected > 0 {
+				log.Printf("[FORWARD] %s rejected %d spans: %s\n",
+					f.opts.Endpoint, rejected, resp.GetPartialSuccess().GetErrorMessage())
+			}
+			return nil
+		}
+		if !isRetryable(err) || attempt >= f.opts.MaxRetries {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff = min(2*backoff, forwardMaxBackoff)
+	}
+}
+
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Canceled, codes.DeadlineExceeded, codes.Aborted, codes.OutOfRange,
+		codes.Unavailable, codes.DataLoss, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close exports the queued batches until ctx is done, then closes the connection.
+func (f *forwarder) Close(ctx context.Context) {
+	f.mu.Lock()
+	f.closed = true
+	close(f.queue)
+	f.mu.Unlock()
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+		log.Printf("[FORWARD] Abandoning %d queued batches\n", len(f.queue))
+	}
+	f.conn.Close()
+}
+
+func (f *forwarder) stats() forwardStats {
+	return forwardStats{
+		Endpoint: f.opts.Endpoint,
+		Queued:   len(f.queue),
+		Exported: f.exported.Load(),
+		Failed:   f.failed.Load(),
+		Dropped:  f.dropped.Load(),
+	}
+}
+
+// handleForwardStats reports the counters of the forwarder.
+func (q *QueryService) handleForwardStats(w http.ResponseWriter, _ *http.Request) {
+	if q.forwarder == nil {
+		writeAdminError(w, http.StatusNotFound, errors.New("forwarding is not enabled"))
+		return
+	}
+	writeAdminJSON(w, q.forwarder.stats())
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// fakeCollector is a downstream OTLP collector failing its first exports.
+type fakeCollector struct {
+	collectortrace.UnimplementedTraceServiceServer
+
+	// attempts receives a value on each export.
+	attempts chan struct{}
+	// block, if set, holds the exports until it is closed.
+	block chan struct{}
+
+	mu       sync.Mutex
+	failures int
+	code     codes.Code
+	received []*collectortrace.ExportTraceServiceRequest
+}
+
+// startFakeCollector serves a collector failing its first failures exports
+// with code, and returns its endpoint.
+func startFakeCollector(t *testing.T, failures int, code codes.Code) (*fakeCollector, string) {
+	c := &fakeCollector{attempts: make(chan struct{}, 100), failures: failures, code: code}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	collectortrace.RegisterTraceServiceServer(server, c)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return c, lis.Addr().String()
+}
+
+func (c *fakeCollector) Export(_ context.Context, req *collectortrace.ExportTraceServiceRequest) (*collectortrace.ExportTraceServiceResponse, error) {
+	c.attempts <- struct{}{}
+	if c.block != nil {
+		<-c.block
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failures > 0 {
+		c.failures--
+		return nil, status.Error(c.code, "the collector is failing")
+	}
+	c.received = append(c.received, req)
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+func (c *fakeCollector) receivedSpans() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	spans := 0
+	for _, req := range c.received {
+		spans += countSpans(&trace.TracesData{ResourceSpans: req.ResourceSpans})
+	}
+	return spans
+}
+
+func closeForwarder(t *testing.T, f *forwarder) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	f.Close(ctx)
+	require.NoError(t, ctx.Err(), "the queue is drained")
+}
+
+func TestForwardRetry(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		failures   int
+		code       codes.Code
+		maxRetries int
+		attempts   int
+		exported   bool
+	}{
+		{name: "retried", failures: 2, code: codes.Unavailable, maxRetries: 3, attempts: 3, exported: true},
+		{name: "retries exhausted", failures: 5, code: codes.ResourceExhausted, maxRetries: 1, attempts: 2},
+		{name: "not retryable", failures: 1, code: codes.InvalidArgument, maxRetries: 3, attempts: 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c, endpoint := startFakeCollector(t, tt.failures, tt.code)
+			f, err := newForwarder(forwardOptions{Endpoint: endpoint, QueueSize: 10, MaxRetries: tt.maxRetries})
+			require.NoError(t, err)
+			q := NewQueryService()
+			q.forwarder = f
+			require.Empty(t, q.importTraces(testBatch(0, 0, 0)))
+			closeForwarder(t, f)
+
+			assert.Len(t, c.attempts, tt.attempts)
+			stats := f.stats()
+			if tt.exported {
+				assert.Equal(t, testTraces, c.receivedSpans(), "the imported spans are forwarded")
+				assert.Equal(t, forwardStats{Endpoint: endpoint, Exported: 1}, stats)
+			} else {
+				assert.Zero(t, c.receivedSpans())
+				assert.Equal(t, forwardStats{Endpoint: endpoint, Failed: 1}, stats)
+			}
+		})
+	}
+}
+
+func TestForwardQueueFull(t *testing.T) {
+	c, endpoint := startFakeCollector(t, 0, codes.OK)
+	c.block = make(chan struct{})
+	f, err := newForwarder(forwardOptions{Endpoint: endpoint, QueueSize: 1})
+	require.NoError(t, err)
+
+	f.enqueue(testBatch(0, 0, 0))
+	// the worker holds the first batch, the queue the second one
+	<-c.attempts
+	f.enqueue(testBatch(0, 10, 0))
+	f.enqueue(testBatch(0, 20, 0))
+	assert.Equal(t, forwardStats{Endpoint: endpoint, Queued: 1, Dropped: 1}, f.stats())
+
+	close(c.block)
+	closeForwarder(t, f)
+	assert.Equal(t, 2*testTraces, c.receivedSpans())
+	assert.Equal(t, forwardStats{Endpoint: endpoint, Exported: 2, Dropped: 1}, f.stats())
+}
+
+func TestForwardCloseDrain(t *testing.T) {
+	c, endpoint := startFakeCollector(t, 1, codes.Unavailable)
+	c.block = make(chan struct{})
+	f, err := newForwarder(forwardOptions{Endpoint: endpoint, QueueSize: 10, MaxRetries: 1})
+	require.NoError(t, err)
+	for i := range 3 {
+		f.enqueue(testBatch(0, 10*i, 0))
+	}
+	<-c.attempts
+
+	closed := make(chan struct{})
+	go func() {
+		f.Close(context.Background())
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		t.Fatal("Close returned before the queue was drained")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(c.block)
+	<-closed
+	assert.Equal(t, 3*testTraces, c.receivedSpans(), "the failed export is retried while draining")
+	assert.Equal(t, forwardStats{Endpoint: endpoint, Exported: 3}, f.stats())
+
+	// the batches after Close are ignored
+	f.enqueue(testBatch(0, 30, 0))
+	assert.Equal(t, forwardStats{Endpoint: endpoint, Exported: 3}, f.stats())
+}
+
+func TestForwardCloseTimeout(t *testing.T) {
+	c, endpoint := startFakeCollector(t, 0, codes.OK)
+	c.block = make(chan struct{})
+	defer close(c.block)
+	f, err := newForwarder(forwardOptions{Endpoint: endpoint, QueueSize: 10})
+	require.NoError(t, err)
+	f.enqueue(testBatch(0, 0, 0))
+	f.enqueue(testBatch(0, 10, 0))
+	<-c.attempts
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	f.Close(ctx)
+	// the connection is closed under the worker, which fails the batches
+	assert.Eventually(t, func() bool {
+		return f.stats().Failed == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Zero(t, c.receivedSpans())
+}
@@ -0,0 +1,411 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"maps"
+	"net"
+	"os"
+	"slices"
+	"syscall"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+)
+
+// handoffVersion is the version of the handoff protocol. Both processes must
+// use the same version, otherwise the old process refuses the handoff.
+const handoffVersion = 3
+
+// handoffTimeout bounds the wait for the old process to stop serving and
+// send its state.
+const handoffTimeout = time.Minute
+
+// handoffRequest is sent by the new process when it connects to the handoff socket.
+type handoffRequest struct {
+	Version int `json:"version"`
+}
+
+// handoffState is the in-memory data sent by the old process in reply.
+// Traces are in the OTLP protobuf encoding. The state of the optional
+// components is nil when the old process does not run them, and is ignored
+// when the new process does not.
+type handoffState struct {
+	Version      int                  `json:"version"`
+	Traces       map[string][]byte    `json:"traces"`
+	Visibility   map[string]string    `json:"visibility,omitempty"`
+	Dependencies *dependencyHandoff   `json:"dependencies,omitempty"`
+	IngestFilter *ingestFilterHandoff `json:"ingestFilter,omitempty"`
+	TailSampling *tailSamplingHandoff `json:"tailSampling,omitempty"`
+}
+
+// dependencyHandoff is the state of the dependency aggregation: the end of
+// the last window aggregated and the windows, with the links in the
+// encoding of the file store. A new process with a file store keeps the
+// windows it reloaded from its file.
+type dependencyHandoff struct {
+	Last    time.Time            `json:"last"`
+	Windows []dependencyFileLine `json:"windows,omitempty"`
+}
+
+// ingestFilterHandoff is the state of the ingest filter: the decisions per
+// service, and the config if it was set through the admin API, which
+// replaces the one of the flags of the new process.
+type ingestFilterHandoff struct {
+	Config *ingestFilterConfig          `json:"config,omitempty"`
+	Stats  map[string]ingestFilterStats `json:"stats,omitempty"`
+}
+
+// tailSamplingHandoff is the state of the tail sampler. The spans of the
+// pending traces are in the OTLP protobuf encoding. A new process without
+// tail sampling stores the pending traces.
+type tailSamplingHandoff struct {
+	Pending   map[string]pendingTraceHandoff `json:"pending,omitempty"`
+	Decisions map[string]decisionHandoff     `json:"decisions,omitempty"`
+	Stats     tailSamplingStats              `json:"stats"`
+}
+
+type pendingTraceHandoff struct {
+	Spans    []byte    `json:"spans"`
+	Received time.Time `json:"received"`
+}
+
+type decisionHandoff struct {
+	Keep    bool      `json:"keep"`
+	Decided time.Time `json:"decided"`
+}
+
+// A restart with state handoff works as follows:
+//
+//  1. The new process connects to the handoff socket of the old process
+//     and sends a handoffRequest, before it opens its own listeners.
+//  2. The old process stops serving, completing in-flight requests, so that
+//     no write is accepted after its state is captured. It closes its
+//     listeners and the handoff socket, sends its state and exits. Its tail
+//     sampler stops without deciding the pending traces, which are handed
+//     off with their state.
+//  3. The new process restores the state, listens on the handoff socket for
+//     the next restart and starts serving.
+//
+// Between 2 and 3 neither process serves. When the sockets are inherited
+// from a supervisor (see fdPrefix), connections queue up in the kernel in
+// the meantime, so clients see a delay instead of refused connections.
+
+// receiveHandoff asks the process listening on the handoff socket at path
+// for its state. It returns nil if no process is listening.
+func receiveHandoff(path string) (*handoffState, error) {
+	conn, err := net.Dial("unix", path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		// left behind by a process that did not exit cleanly
+		log.Printf("Removing stale handoff socket %s\n", path)
+		return nil, os.Remove(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to handoff socket: %w", err)
+	}
+	defer conn.Close()
+	return requestHandoff(conn)
+}
+
+// requestHandoff sends a handoffRequest on conn and returns the state
+// received in reply.
+func requestHandoff(conn net.Conn) (*handoffState, error) {
+	conn.SetDeadline(time.Now().Add(handoffTimeout))
+	if err := json.NewEncoder(conn).Encode(handoffRequest{Version: handoffVersion}); err != nil {
+		return nil, fmt.Errorf("cannot request handoff: %w", err)
+	}
+	var state handoffState
+	if err := json.NewDecoder(conn).Decode(&state); err != nil {
+		return nil, fmt.Errorf("cannot receive handoff state: %w", err)
+	}
+	if state.Version != handoffVersion {
+		return nil, fmt.Errorf("unsupported handoff state version %d", state.Version)
+	}
+	return &state, nil
+}
+
+// acceptHandoff accepts connections on the handoff socket until a valid
+// request arrives, and returns that connection on the channel.
+// Connections with an invalid request are closed.
+func acceptHandoff(lis net.Listener) <-chan net.Conn {
+	connc := make(chan net.Conn, 1)
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			if err := readHandoffRequest(conn); err != nil {
+				log.Printf("[HANDOFF] Refusing handoff request: %v\n", err)
+				conn.Close()
+				continue
+			}
+			connc <- conn
+			return
+		}
+	}()
+	return connc
+}
+
+// readHandoffRequest reads the handoffRequest of the new process on conn.
+func readHandoffRequest(conn net.Conn) error {
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	defer conn.SetReadDeadline(time.Time{})
+	var req handoffRequest
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		return fmt.Errorf("cannot read handoff request: %w", err)
+	}
+	if req.Version != handoffVersion {
+		return fmt.Errorf("unsupported handoff request version %d", req.Version)
+	}
+	return nil
+}
+
+// handOff stops serving with shutdown, which must release the listeners
+// for the new process, and sends the state on conn.
+func (q *QueryService) handOff(conn net.Conn, shutdown func()) error {
+	shutdown()
+	return q.sendHandoff(conn)
+}
+
+// sendHandoff writes the state to conn. The caller must have stopped
+// serving, so that the state does not change afterwards.
+func (q *QueryService) sendHandoff(conn net.Conn) error {
+	defer conn.Close()
+	state, err := q.handoffState()
+	if err != nil {
+		return err
+	}
+	conn.SetWriteDeadline(time.Now().Add(handoffTimeout))
+	if err := json.NewEncoder(conn).Encode(state); err != nil {
+		return fmt.Errorf("cannot send handoff state: %w", err)
+	}
+	log.Printf("[HANDOFF] Handed off %d traces\n", len(state.Traces))
+	return nil
+}
+
+func (q *QueryService) handoffState() (*handoffState, error) {
+	state := &handoffState{
+		Version:      handoffVersion,
+		IngestFilter: q.ingestFilter.handoffState(),
+	}
+	// the components are captured before taking q.mu, which the dependency
+	// aggregation takes under its own lock
+	var err error
+	if q.dependencies != nil {
+		if state.Dependencies, err = q.dependencies.handoffState(); err != nil {
+			return nil, err
+		}
+	}
+	if q.tailSampler != nil {
+		if state.TailSampling, err = q.tailSampler.handoffState(); err != nil {
+			return nil, err
+		}
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	state.Traces = make(map[string][]byte, len(q.traces))
+	state.Visibility = q.visibility
+	for traceID, td := range q.traces {
+		data, err := proto.Marshal(td)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode trace %s: %w", traceID, err)
+		}
+		state.Traces[traceID] = data
+	}
+	return state, nil
+}
+
+// restoreHandoff replaces the in-memory data with the handed off state.
+func (q *QueryService) restoreHandoff(state *handoffState) error {
+	traces := make(map[string]*trace.TracesData, len(state.Traces))
+	for traceID, data := range state.Traces {
+		td := &trace.TracesData{}
+		if err := proto.Unmarshal(data, td); err != nil {
+			return fmt.Errorf("cannot decode trace %s: %w", traceID, err)
+		}
+		traces[traceID] = td
+	}
+	// the traces are restored first, as the tail sampler may store the
+	// pending traces as soon as they are restored
+	q.restoreTraces(traces, state.Visibility)
+	if state.Dependencies != nil && q.dependencies != nil {
+		if err := q.dependencies.restoreHandoff(state.Dependencies); err != nil {
+			return fmt.Errorf("cannot restore the dependency links: %w", err)
+		}
+	}
+	if state.IngestFilter != nil {
+		q.ingestFilter.restoreHandoff(state.IngestFilter)
+	}
+	var undecided []*trace.TracesData
+	if state.TailSampling != nil {
+		var err error
+		if undecided, err = q.tailSampler.restoreHandoff(state.TailSampling); err != nil {
+			return fmt.Errorf("cannot restore the tail sampler: %w", err)
+		}
+	}
+	for _, td := range undecided {
+		q.storeTraces(td)
+	}
+	return nil
+}
+
+func (q *QueryService) restoreTraces(traces map[string]*trace.TracesData, visibility map[string]string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.traces = traces
+	q.index = newTraceIndex()
+	for traceID, td := range traces {
+		q.index.update(traceID, td)
+	}
+	q.catalog.Store(nil)
+	// the age of the handed off traces is not known, their TTL starts now
+	now := time.Now()
+	for traceID := range traces {
+		q.memory.written(traceID, now)
+	}
+	q.memory.evictOverflow(q)
+	q.visibility = visibility
+	if q.visibility == nil {
+		q.visibility = make(map[string]string)
+	}
+}
+
+func (a *dependencyAggregator) handoffState() (*dependencyHandoff, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	h := &dependencyHandoff{Last: a.last}
+	for _, w := range a.store.snapshot() {
+		links, err := protojson.Marshal(&storagev2.GetDependenciesResponse{Dependencies: w.dependencies})
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode the dependency links: %w", err)
+		}
+		h.Windows = append(h.Windows, dependencyFileLine{Start: w.window.Start, End: w.window.End, Links: links})
+	}
+	return h, nil
+}
+
+func (a *dependencyAggregator) restoreHandoff(h *dependencyHandoff) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if store, ok := a.store.(*memoryDependencyStore); ok {
+		windows := make([]dependencyWindow, 0, len(h.Windows))
+		for i, line := range h.Windows {
+			links := &storagev2.GetDependenciesResponse{}
+			if err := protojson.Unmarshal(line.Links, links); err != nil {
+				return fmt.Errorf("invalid links of window %d: %w", i, err)
+			}
+			windows = append(windows, dependencyWindow{window: timeWindow{line.Start, line.End}, dependencies: links.Dependencies})
+		}
+		store.mu.Lock()
+		store.windows = windows
+		store.mu.Unlock()
+	}
+	// the windows aggregated by the old process are not aggregated again
+	if h.Last.After(a.last) {
+		a.last = h.Last
+	}
+	return nil
+}
+
+func (f *ingestFilter) handoffState() *ingestFilterHandoff {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	h := &ingestFilterHandoff{Stats: make(map[string]ingestFilterStats, len(f.stats))}
+	if f.overridden {
+		h.Config = f.config
+	}
+	for service, stats := range f.stats {
+		h.Stats[service] = *stats
+	}
+	return h
+}
+
+func (f *ingestFilter) restoreHandoff(h *ingestFilterHandoff) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if h.Config != nil {
+		f.config = h.Config
+		f.overridden = true
+		log.Printf("[HANDOFF] Ingest filter set to the drop rates %v (default %v) and %d rules of the previous process\n", h.Config.DropRates, h.Config.DefaultDropRate, len(h.Config.Rules))
+	}
+	for service, handed := range h.Stats {
+		stats := f.serviceStats(service)
+		stats.Kept += handed.Kept
+		stats.DroppedByRate += handed.DroppedByRate
+		stats.DroppedByRule += handed.DroppedByRule
+	}
+}
+
+func (s *tailSampler) handoffState() (*tailSamplingHandoff, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := &tailSamplingHandoff{
+		Pending:   make(map[string]pendingTraceHandoff, len(s.pending)),
+		Decisions: make(map[string]decisionHandoff, len(s.decisions)),
+		Stats:     s.stats,
+	}
+	for traceID, p := range s.pending {
+		spans, err := proto.Marshal(p.td)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode pending trace %s: %w", traceID, err)
+		}
+		h.Pending[traceID] = pendingTraceHandoff{Spans: spans, Received: p.received}
+	}
+	for traceID, d := range s.decisions {
+		h.Decisions[traceID] = decisionHandoff{Keep: d.keep, Decided: d.decided}
+	}
+	return h, nil
+}
+
+// restoreHandoff adds the pending traces and the decisions of the old
+// process to s. A nil s returns the pending traces, to store them.
+func (s *tailSampler) restoreHandoff(h *tailSamplingHandoff) ([]*trace.TracesData, error) {
+	pending := make(map[string]*pendingTrace, len(h.Pending))
+	for traceID, p := range h.Pending {
+		td := &trace.TracesData{}
+		if err := proto.Unmarshal(p.Spans, td); err != nil {
+			return nil, fmt.Errorf("cannot decode pending trace %s: %w", traceID, err)
+		}
+		pending[traceID] = &pendingTrace{td: td, received: p.Received}
+	}
+	if s == nil {
+		undecided := make([]*trace.TracesData, 0, len(pending))
+		for _, p := range pending {
+			undecided = append(undecided, p.td)
+		}
+		return undecided, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	byReceived := func(a, b string) int { return pending[a].received.Compare(pending[b].received) }
+	for _, traceID := range slices.SortedFunc(maps.Keys(pending), byReceived) {
+		s.track(traceID, pending[traceID])
+	}
+	for traceID, d := range h.Decisions {
+		s.decisions[traceID] = samplingDecision{keep: d.Keep, decided: d.Decided}
+	}
+	s.stats.KeptTraces += h.Stats.KeptTraces
+	s.stats.DroppedTraces += h.Stats.DroppedTraces
+	s.stats.DroppedSpans += h.Stats.DroppedSpans
+	s.stats.ForcedDecisions += h.Stats.ForcedDecisions
+	for name, n := range h.Stats.KeptBy {
+		s.stats.KeptBy[name] += n
+	}
+	return nil, nil
+}
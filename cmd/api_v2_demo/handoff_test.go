@@ -0,0 +1,224 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"maps"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+// socketPair returns the ends of a connected unix socket pair, for the old
+// and the new process.
+func socketPair(t *testing.T) (net.Conn, net.Conn) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	require.NoError(t, err)
+	conns := make([]net.Conn, len(fds))
+	for i, fd := range fds {
+		f := os.NewFile(uintptr(fd), "handoff")
+		conns[i], err = net.FileConn(f)
+		f.Close()
+		require.NoError(t, err)
+		t.Cleanup(func() { conns[i].Close() })
+	}
+	return conns[0], conns[1]
+}
+
+// transferHandoff hands the state of old off over a socket pair.
+func transferHandoff(t *testing.T, old *QueryService) *handoffState {
+	oldConn, newConn := socketPair(t)
+	errc := make(chan error, 1)
+	go func() {
+		if err := readHandoffRequest(oldConn); err != nil {
+			errc <- err
+			return
+		}
+		errc <- old.sendHandoff(oldConn)
+	}()
+	state, err := requestHandoff(newConn)
+	require.NoError(t, err)
+	require.NoError(t, <-errc)
+	return state
+}
+
+// handoffProcess returns a query service with the components carrying a
+// state across the handoffs.
+func handoffProcess(t *testing.T) *QueryService {
+	q := NewQueryService()
+	var err error
+	q.dependencies, err = newDependencyAggregator(dependencyOptions{Interval: time.Minute, Store: dependencyStoreMemory})
+	require.NoError(t, err)
+	cfg, err := loadTestTailSampling(t, testTailSamplingConfig)
+	require.NoError(t, err)
+	q.tailSampler = newTailSampler(cfg)
+	return q
+}
+
+func TestHandoffRoundTrip(t *testing.T) {
+	old := handoffProcess(t)
+	require.Empty(t, old.importTraces(callBatch(0, testStart, 10*time.Millisecond)))
+	old.visibility["service-1"] = serviceDeprecated
+	require.NoError(t, old.dependencies.aggregate(old, testStart.Add(time.Hour)))
+	old.ingestFilter.overrideConfig(&ingestFilterConfig{DropRates: map[string]float64{"service-2": 1}})
+	require.Empty(t, old.receiveTraces(testBatch(2, 100, 0)))
+	// the first trace is decided, the second one is pending
+	require.Empty(t, old.receiveTraces(sampledSpan(200, 0, time.Second)))
+	kept := old.tailSampler.decide(time.Now().Add(time.Hour), false)
+	require.Len(t, kept, 1)
+	require.Empty(t, old.importTraces(kept[0]))
+	require.Empty(t, old.receiveTraces(sampledSpan(201, 0, time.Millisecond)))
+
+	restored := handoffProcess(t)
+	require.NoError(t, restored.restoreHandoff(transferHandoff(t, old)))
+
+	require.Len(t, restored.traces, len(old.traces))
+	for traceID, td := range old.traces {
+		assert.True(t, proto.Equal(td, restored.traces[traceID]), traceID)
+	}
+	assert.Equal(t, old.visibility, restored.visibility)
+	assert.Equal(t, foundTraceIDs(t, old, &api_v3.TraceQueryParameters{ServiceName: "service-1"}, nil),
+		foundTraceIDs(t, restored, &api_v3.TraceQueryParameters{ServiceName: "service-1"}, nil), "the index is rebuilt")
+
+	assert.Equal(t, old.dependencies.last, restored.dependencies.last, "the aggregated windows are not aggregated again")
+	assert.Equal(t, dependencyStrings(old.dependencyLinks(nil, nil)), dependencyStrings(restored.dependencyLinks(nil, nil)))
+
+	assert.Equal(t, old.ingestFilter.config, restored.ingestFilter.config)
+	assert.True(t, restored.ingestFilter.overridden, "the config is handed off again")
+	assert.Equal(t, ingestFilterStats{DroppedByRate: testTraces}, *restored.ingestFilter.stats["service-2"])
+
+	s, restoredSampler := old.tailSampler, restored.tailSampler
+	assert.Equal(t, slices.Sorted(maps.Keys(s.pending)), slices.Sorted(maps.Keys(restoredSampler.pending)))
+	for traceID, p := range s.pending {
+		assert.True(t, proto.Equal(p.td, restoredSampler.pending[traceID].td))
+		assert.True(t, p.received.Equal(restoredSampler.pending[traceID].received))
+	}
+	require.Len(t, restoredSampler.decisions, 1)
+	for traceID, d := range s.decisions {
+		assert.Equal(t, d.keep, restoredSampler.decisions[traceID].keep)
+		assert.True(t, d.decided.Equal(restoredSampler.decisions[traceID].decided))
+	}
+	assert.Equal(t, s.stats, restoredSampler.stats)
+
+	// the decisions of the old process apply to the late spans
+	require.Empty(t, restored.receiveTraces(sampledSpan(200, 1, time.Second)))
+	assert.Equal(t, 2, countSpans(restored.traces[hex.EncodeToString(testTraceID(200))]))
+}
+
+func TestHandoffWithoutComponents(t *testing.T) {
+	old := handoffProcess(t)
+	require.Empty(t, old.receiveTraces(sampledSpan(0, 0, time.Millisecond)))
+	state := transferHandoff(t, old)
+
+	// the pending traces are stored without tail sampling
+	restored := NewQueryService()
+	require.NoError(t, restored.restoreHandoff(state))
+	assert.Contains(t, restored.traces, hex.EncodeToString(testTraceID(0)))
+	assert.Nil(t, state.IngestFilter.Config, "the config of the flags is not handed off")
+	assert.False(t, restored.ingestFilter.overridden)
+
+	// a process without the components hands off none of their state, the
+	// ingest filter always runs
+	state = transferHandoff(t, restored)
+	assert.Nil(t, state.Dependencies)
+	assert.Nil(t, state.TailSampling)
+	assert.Equal(t, map[string]ingestFilterStats{"service-0": {Kept: 1}}, state.IngestFilter.Stats)
+}
+
+func TestHandoffMismatch(t *testing.T) {
+	oldConn, newConn := socketPair(t)
+	go json.NewEncoder(newConn).Encode(handoffRequest{Version: handoffVersion - 1})
+	assert.ErrorContains(t, readHandoffRequest(oldConn), "unsupported handoff request version")
+
+	oldConn, newConn = socketPair(t)
+	go func() {
+		if readHandoffRequest(oldConn) == nil {
+			json.NewEncoder(oldConn).Encode(handoffState{Version: handoffVersion - 1})
+		}
+	}()
+	_, err := requestHandoff(newConn)
+	assert.ErrorContains(t, err, "unsupported handoff state version")
+
+	// the old process refused the handoff, or exited
+	oldConn, newConn = socketPair(t)
+	go func() {
+		readHandoffRequest(oldConn)
+		oldConn.Close()
+	}()
+	_, err = requestHandoff(newConn)
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestReceiveHandoffWithoutProcess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "handoff.sock")
+	state, err := receiveHandoff(path)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	// a socket left behind by a process that did not exit cleanly
+	lis, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	lis.(*net.UnixListener).SetUnlinkOnClose(false)
+	lis.Close()
+	state, err = receiveHandoff(path)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+	assert.NoFileExists(t, path)
+}
+
+func TestHandOffReleasesListeners(t *testing.T) {
+	old := NewQueryService()
+	require.Empty(t, old.importTraces(testBatch(0, 0, 0)))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	api_v3.RegisterQueryServiceServer(grpcServer, old)
+	go grpcServer.Serve(lis)
+	path := filepath.Join(t.TempDir(), "handoff.sock")
+	handoffListener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	connc := acceptHandoff(handoffListener)
+
+	// a request of another version is refused, and the next one accepted
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	require.NoError(t, json.NewEncoder(conn).Encode(handoffRequest{Version: handoffVersion - 1}))
+	_, err = conn.Read(make([]byte, 1))
+	require.ErrorIs(t, err, io.EOF)
+	conn.Close()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- old.handOff(<-connc, func() {
+			handoffListener.Close()
+			grpcServer.GracefulStop()
+		})
+	}()
+	state, err := receiveHandoff(path)
+	require.NoError(t, err)
+	require.NoError(t, <-errc)
+	assert.Len(t, state.Traces, testTraces)
+
+	// the new process listens on the released addresses
+	lis, err = net.Listen("tcp", lis.Addr().String())
+	require.NoError(t, err)
+	lis.Close()
+	handoffListener, err = net.Listen("unix", path)
+	require.NoError(t, err)
+	handoffListener.Close()
+}
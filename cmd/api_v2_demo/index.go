@@ -0,0 +1,295 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+// indexTerm is a term of the inverted index: a service, an operation of a
+// service, an operation of a service with a span kind, or an attribute value
+// of the spans of a service.
+type indexTerm struct {
+	service   string
+	operation string
+	key       string
+	value     string
+}
+
+// spanKindKey is the key of the terms of the operations by span kind, whose
+// value is the span kind, empty if it is unspecified. The attribute terms
+// have no operation, so they cannot be confused with them.
+const spanKindKey = "span.kind"
+
+// bucketWidth is the time range of the buckets of trace start times.
+const bucketWidth = time.Minute
+
+// traceIndex maps the terms of the queries to the traces with a span
+// having them, and the start times of the traces to time buckets, so that
+// FindTraces only scans the traces that may match and time-based expiry
+// only visits the old traces.
+// Like the traces, it is guarded by the lock of the QueryService.
+type traceIndex struct {
+	postings map[indexTerm]map[string]struct{}
+	// terms lists the terms of each trace, to remove them.
+	terms map[string][]indexTerm
+	// starts holds the start times of the traces.
+	starts *timeBuckets
+}
+
+func newTraceIndex() *traceIndex {
+	return &traceIndex{
+		postings: make(map[indexTerm]map[string]struct{}),
+		terms:    make(map[string][]indexTerm),
+		starts:   newTimeBuckets(),
+	}
+}
+
+// timeBuckets holds a time per trace, grouped in buckets of bucketWidth so
+// that the traces of a time range are found by visiting only its buckets.
+type timeBuckets struct {
+	// buckets maps the start of each bucket, in units of bucketWidth since
+	// the epoch, to the traces in it. keys lists them in order.
+	buckets map[int64]map[string]struct{}
+	keys    []int64
+	// times is the time of each trace, in nanoseconds since the epoch.
+	times map[string]int64
+}
+
+func newTimeBuckets() *timeBuckets {
+	return &timeBuckets{
+		buckets: make(map[int64]map[string]struct{}),
+		times:   make(map[string]int64),
+	}
+}
+
+func bucketOf(t int64) int64 {
+	return t / int64(bucketWidth)
+}
+
+// set records the time of the trace, replacing the previous one.
+func (b *timeBuckets) set(traceID string, t int64) {
+	b.remove(traceID)
+	bucket := bucketOf(t)
+	traces, ok := b.buckets[bucket]
+	if !ok {
+		traces = make(map[string]struct{})
+		b.buckets[bucket] = traces
+		i, _ := slices.BinarySearch(b.keys, bucket)
+		b.keys = slices.Insert(b.keys, i, bucket)
+	}
+	traces[traceID] = struct{}{}
+	b.times[traceID] = t
+}
+
+// remove drops the time of the trace.
+func (b *timeBuckets) remove(traceID string) {
+	t, ok := b.times[traceID]
+	if !ok {
+		return
+	}
+	bucket := bucketOf(t)
+	traces := b.buckets[bucket]
+	delete(traces, traceID)
+	if len(traces) == 0 {
+		delete(b.buckets, bucket)
+		if i, found := slices.BinarySearch(b.keys, bucket); found {
+			b.keys = slices.Delete(b.keys, i, i+1)
+		}
+	}
+	delete(b.times, traceID)
+}
+
+// reset drops all the times.
+func (b *timeBuckets) reset() {
+	clear(b.buckets)
+	clear(b.times)
+	b.keys = b.keys[:0]
+}
+
+// between returns the traces whose time is in [minTime, maxTime], in
+// nanoseconds since the epoch, visiting only the buckets of that range.
+func (b *timeBuckets) between(minTime, maxTime int64) map[string]struct{} {
+	found := make(map[string]struct{})
+	first, _ := slices.BinarySearch(b.keys, bucketOf(minTime))
+	for _, bucket := range b.keys[first:] {
+		if bucket > bucketOf(maxTime) {
+			break
+		}
+		for traceID := range b.buckets[bucket] {
+			if t := b.times[traceID]; t >= minTime && t <= maxTime {
+				found[traceID] = struct{}{}
+			}
+		}
+	}
+	return found
+}
+
+// before returns the traces whose time is before cutoff.
+func (b *timeBuckets) before(cutoff time.Time) []string {
+	var traceIDs []string
+	for traceID := range b.between(0, cutoff.UnixNano()-1) {
+		traceIDs = append(traceIDs, traceID)
+	}
+	return traceIDs
+}
+
+// traceStart returns the earliest start time of the spans of the trace.
+func traceStart(td *trace.TracesData) int64 {
+	var start uint64
+	forEachSpan(td, func(_ string, span *trace.Span) {
+		if start == 0 || span.StartTimeUnixNano < start {
+			start = span.StartTimeUnixNano
+		}
+	})
+	return int64(start)
+}
+
+// update indexes the trace, replacing its previous terms.
+func (x *traceIndex) update(traceID string, td *trace.TracesData) {
+	x.remove(traceID)
+	seen := make(map[indexTerm]bool)
+	var terms []indexTerm
+	add := func(term indexTerm) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	for _, rs := range td.ResourceSpans {
+		service := getServiceName(rs.Resource)
+		add(indexTerm{service: service})
+		for _, attr := range rs.GetResource().GetAttributes() {
+			add(indexTerm{service: service, key: attr.Key, value: attributeString(attr.Value)})
+		}
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				add(indexTerm{service: service, operation: span.Name})
+				add(indexTerm{service: service, operation: span.Name, key: spanKindKey, value: spanKindName(span.Kind)})
+				for _, attr := range span.Attributes {
+					add(indexTerm{service: service, key: attr.Key, value: attributeString(attr.Value)})
+				}
+				// the error attribute also matches the status of the span
+				isError := span.GetStatus().GetCode() == trace.Status_STATUS_CODE_ERROR
+				add(indexTerm{service: service, key: errorAttribute, value: strconv.FormatBool(isError)})
+			}
+		}
+	}
+	for _, term := range terms {
+		traces, ok := x.postings[term]
+		if !ok {
+			traces = make(map[string]struct{})
+			x.postings[term] = traces
+		}
+		traces[traceID] = struct{}{}
+	}
+	x.terms[traceID] = terms
+	x.starts.set(traceID, traceStart(td))
+}
+
+// remove drops the trace from the index.
+func (x *traceIndex) remove(traceID string) {
+	for _, term := range x.terms[traceID] {
+		traces := x.postings[term]
+		delete(traces, traceID)
+		if len(traces) == 0 {
+			delete(x.postings, term)
+		}
+	}
+	delete(x.terms, traceID)
+	x.starts.remove(traceID)
+}
+
+// startedBetween returns the traces starting in [minStart, maxStart], in
+// nanoseconds since the epoch, visiting only the buckets of that range.
+func (x *traceIndex) startedBetween(minStart, maxStart int64) map[string]struct{} {
+	return x.starts.between(minStart, maxStart)
+}
+
+// startedBefore returns the traces starting before cutoff.
+func (x *traceIndex) startedBefore(cutoff time.Time) []string {
+	return x.starts.before(cutoff)
+}
+
+// posting is the set of traces of an index term.
+type posting struct {
+	index  string
+	traces map[string]struct{}
+}
+
+// candidates returns the IDs of the traces that have all the terms of the
+// query: its service, its operation and its attribute equalities, and that
+// start in its time range. The traces must still be matched, as the terms
+// may come from different spans, and the other predicates are not indexed.
+// The traces of the given index are iterated if the query has a term of it,
+// otherwise those of the term with the fewest.
+func (x *traceIndex) candidates(query *api_v3.TraceQueryParameters, filters []attributeFilter, index string) []string {
+	service := query.GetServiceName()
+	postings := []posting{{indexService, x.postings[indexTerm{service: service}]}}
+	if query.GetOperationName() != "" {
+		postings = append(postings, posting{indexOperation, x.postings[indexTerm{service: service, operation: query.GetOperationName()}]})
+	}
+	for _, f := range filters {
+		if !f.Name && f.Op == opEqual {
+			postings = append(postings, posting{indexAttribute, x.postings[indexTerm{service: service, key: f.Key, value: f.Value}]})
+		}
+	}
+	if query.GetStartTimeMin() != nil || query.GetStartTimeMax() != nil {
+		maxStart := int64(math.MaxInt64)
+		if query.GetStartTimeMax() != nil {
+			maxStart = query.GetStartTimeMax().AsTime().UnixNano()
+		}
+		postings = append(postings, posting{indexTime, x.startedBetween(query.GetStartTimeMin().AsTime().UnixNano(), maxStart)})
+	}
+	// the first posting is iterated, and the others looked up
+	planPostings(postings, index)
+	var traceIDs []string
+	for traceID := range postings[0].traces {
+		inAll := true
+		for _, other := range postings[1:] {
+			if _, ok := other.traces[traceID]; !ok {
+				inAll = false
+				break
+			}
+		}
+		if inAll {
+			traceIDs = append(traceIDs, traceID)
+		}
+	}
+	return traceIDs
+}
+
+// planPostings orders the postings by size, with the first one of the
+// given index first if there is one.
+func planPostings(postings []posting, index string) {
+	slices.SortStableFunc(postings, func(a, b posting) int { return len(a.traces) - len(b.traces) })
+	if i := slices.IndexFunc(postings, func(p posting) bool { return p.index == index }); i > 0 {
+		hinted := postings[i]
+		copy(postings[1:i+1], postings[:i])
+		postings[0] = hinted
+	}
+}
+
+// storeTrace stores td under traceID and indexes it. It must be called
+// with q.mu held.
+func (q *QueryService) storeTrace(traceID string, td *trace.TracesData) {
+	q.traces[traceID] = td
+	q.index.update(traceID, td)
+	q.catalog.Store(nil)
+}
+
+// deleteTrace removes the trace and its index terms. It must be called
+// with q.mu held.
+func (q *QueryService) deleteTrace(traceID string) {
+	delete(q.traces, traceID)
+	q.index.remove(traceID)
+	q.catalog.Store(nil)
+}
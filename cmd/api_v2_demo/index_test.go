@@ -0,0 +1,253 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	common "go.opentelemetry.io/proto/otlp/common/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+// indexedBatch returns n traces with varied attributes, a span each,
+// starting a second apart.
+func indexedBatch(service, first, n int) *trace.TracesData {
+	td := testBatch(service, first, 0)
+	spans := td.ResourceSpans[0].ScopeSpans[0].Spans[:0]
+	for i := first; i < first+n; i++ {
+		span := &trace.Span{
+			TraceId:           testTraceID(i),
+			SpanId:            testSpanID(i, 0),
+			Name:              fmt.Sprintf("op-%d", i%5),
+			StartTimeUnixNano: uint64(testStart.Add(time.Duration(i) * time.Second).UnixNano()),
+			EndTimeUnixNano:   uint64(testStart.Add(time.Duration(i)*time.Second + time.Millisecond).UnixNano()),
+			Attributes: []*common.KeyValue{
+				{Key: "tenant", Value: stringValue(fmt.Sprintf("tenant-%d", i%7))},
+				intAttr("http.status_code", int64(200+100*(i%4))),
+			},
+		}
+		if i%11 == 0 {
+			span.Status = &trace.Status{Code: trace.Status_STATUS_CODE_ERROR}
+		}
+		spans = append(spans, span)
+	}
+	td.ResourceSpans[0].ScopeSpans[0].Spans = spans
+	return td
+}
+
+// scanTraces returns the IDs of the matching traces without the index.
+func scanTraces(q *QueryService, query *api_v3.TraceQueryParameters, filters []attributeFilter) []string {
+	var traceIDs []string
+	for traceID, td := range q.traces {
+		if traceMatches(td, query, filters) {
+			traceIDs = append(traceIDs, traceID)
+		}
+	}
+	slices.Sort(traceIDs)
+	return traceIDs
+}
+
+func foundTraceIDs(t *testing.T, q *QueryService, query *api_v3.TraceQueryParameters, filters []attributeFilter) []string {
+	return foundTraceIDsWithHints(t, q, query, filters, queryHints{})
+}
+
+func foundTraceIDsWithHints(t *testing.T, q *QueryService, query *api_v3.TraceQueryParameters, filters []attributeFilter, hints queryHints) []string {
+	found, err := q.findTraces(context.Background(), query, filters, hints)
+	require.NoError(t, err)
+	var traceIDs []string
+	for _, td := range found {
+		traceIDs = append(traceIDs, hex.EncodeToString(firstTraceID(td)))
+	}
+	slices.Sort(traceIDs)
+	return traceIDs
+}
+
+func TestIndexMatchesScan(t *testing.T) {
+	q := NewQueryService()
+	for service := range testServices {
+		require.Empty(t, q.importTraces(indexedBatch(service, service*100, 100)))
+	}
+	// spans added to existing traces are indexed too
+	require.Empty(t, q.importTraces(indexedBatch(1, 0, 50)))
+
+	tests := []struct {
+		name       string
+		operation  string
+		attributes map[string]string
+	}{
+		{name: "service"},
+		{name: "operation", operation: "op-3"},
+		{name: "string attribute", attributes: map[string]string{"tenant": "tenant-2"}},
+		{name: "int attribute", attributes: map[string]string{"http.status_code": "500"}},
+		{name: "error status", attributes: map[string]string{"error": "true"}},
+		{name: "no error", attributes: map[string]string{"error": "false"}},
+		{name: "predicate", attributes: map[string]string{"http.status_code": ">=400", "tenant": "tenant-3"}},
+		{name: "all", operation: "op-1", attributes: map[string]string{"tenant": "tenant-1", "http.status_code": "300"}},
+		{name: "unknown value", attributes: map[string]string{"tenant": "tenant-9"}},
+	}
+	for _, test := range tests {
+		for service := range testServices {
+			t.Run(fmt.Sprintf("%s/service-%d", test.name, service), func(t *testing.T) {
+				query, filters, err := q.queryFilters(&api_v3.TraceQueryParameters{
+					ServiceName:   fmt.Sprintf("service-%d", service),
+					OperationName: test.operation,
+					Attributes:    test.attributes,
+				})
+				require.NoError(t, err)
+				expected := scanTraces(q, query, filters)
+				assert.Equal(t, expected, foundTraceIDs(t, q, query, filters))
+				// the index hints change the plan, not the result
+				for _, index := range knownIndexes {
+					assert.Equal(t, expected, foundTraceIDsWithHints(t, q, query, filters, queryHints{Index: index}), index)
+				}
+			})
+		}
+	}
+}
+
+func TestIndexFollowsUpdates(t *testing.T) {
+	q := NewQueryService()
+	require.Empty(t, q.importTraces(indexedBatch(0, 0, 20)))
+	query := &api_v3.TraceQueryParameters{ServiceName: "service-0"}
+	require.Len(t, foundTraceIDs(t, q, query, nil), 20)
+
+	q.renameOperations(&renameRequest{Rules: []*renameRule{{Service: "service-0", From: "op-1", To: "renamed"}}})
+	renamed := &api_v3.TraceQueryParameters{ServiceName: "service-0", OperationName: "renamed"}
+	assert.Len(t, foundTraceIDs(t, q, renamed, nil), 4)
+
+	q.mu.Lock()
+	for traceID := range q.traces {
+		q.deleteTrace(traceID)
+	}
+	q.mu.Unlock()
+	assert.Empty(t, foundTraceIDs(t, q, query, nil))
+	assert.Empty(t, q.index.postings)
+	assert.Empty(t, q.index.terms)
+}
+
+func TestIndexTimeRange(t *testing.T) {
+	q := NewQueryService()
+	require.Empty(t, q.importTraces(indexedBatch(0, 0, 300)))
+	at := func(i int) *timestamppb.Timestamp {
+		return timestamppb.New(testStart.Add(time.Duration(i) * time.Second))
+	}
+	traceIDs := func(first, last int) []string {
+		var ids []string
+		for i := first; i <= last; i++ {
+			ids = append(ids, hex.EncodeToString(testTraceID(i)))
+		}
+		slices.Sort(ids)
+		return ids
+	}
+	tests := []struct {
+		name     string
+		min, max *timestamppb.Timestamp
+		want     []string
+	}{
+		{name: "within a bucket", min: at(61), max: at(65), want: traceIDs(61, 65)},
+		{name: "across buckets", min: at(50), max: at(130), want: traceIDs(50, 130)},
+		{name: "open end", min: at(290), want: traceIDs(290, 299)},
+		{name: "open start", max: at(3), want: traceIDs(0, 3)},
+		{name: "no trace", min: at(400), max: at(500)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query := &api_v3.TraceQueryParameters{ServiceName: "service-0", StartTimeMin: test.min, StartTimeMax: test.max}
+			assert.Equal(t, test.want, foundTraceIDs(t, q, query, nil))
+		})
+	}
+
+	// spans starting earlier move the trace to an earlier bucket
+	moved := indexedBatch(0, 200, 1)
+	moved.ResourceSpans[0].ScopeSpans[0].Spans[0].SpanId = testSpanID(200, 1)
+	moved.ResourceSpans[0].ScopeSpans[0].Spans[0].StartTimeUnixNano = uint64(testStart.UnixNano())
+	require.Empty(t, q.importTraces(moved))
+	query := &api_v3.TraceQueryParameters{ServiceName: "service-0", StartTimeMax: at(3)}
+	assert.Equal(t, append(traceIDs(0, 3), hex.EncodeToString(testTraceID(200))), foundTraceIDs(t, q, query, nil))
+}
+
+// BenchmarkFindTraces measures tag and time range queries on a large store.
+func BenchmarkFindTraces(b *testing.B) {
+	const traces = 100_000
+	q := NewQueryService()
+	for batch := 0; batch < traces; batch += 1000 {
+		q.importTraces(indexedBatch(batch/1000%testServices, batch, 1000))
+	}
+	queries := map[string]*api_v3.TraceQueryParameters{
+		"tags": {
+			ServiceName: "service-0",
+			Attributes:  map[string]string{"tenant": "tenant-3", "http.status_code": "500"},
+		},
+		"time range": {
+			ServiceName:  "service-0",
+			StartTimeMin: timestamppb.New(testStart.Add(10 * time.Hour)),
+			StartTimeMax: timestamppb.New(testStart.Add(10*time.Hour + 5*time.Minute)),
+		},
+	}
+	for name, query := range queries {
+		b.Run(name, func(b *testing.B) {
+			query, filters, err := q.queryFilters(query)
+			require.NoError(b, err)
+			for b.Loop() {
+				if _, err := q.findTraces(context.Background(), query, filters, queryHints{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestPlanPostings(t *testing.T) {
+	traces := func(n int) map[string]struct{} {
+		m := make(map[string]struct{}, n)
+		for i := range n {
+			m[fmt.Sprint(i)] = struct{}{}
+		}
+		return m
+	}
+	indexes := func(postings []posting) []string {
+		var names []string
+		for _, p := range postings {
+			names = append(names, p.index)
+		}
+		return names
+	}
+	newPostings := func() []posting {
+		return []posting{
+			{indexService, traces(100)},
+			{indexOperation, traces(20)},
+			{indexAttribute, traces(5)},
+			{indexAttribute, traces(50)},
+			{indexTime, traces(10)},
+		}
+	}
+
+	postings := newPostings()
+	planPostings(postings, "")
+	assert.Equal(t, []string{indexAttribute, indexTime, indexOperation, indexAttribute, indexService}, indexes(postings))
+
+	postings = newPostings()
+	planPostings(postings, indexService)
+	assert.Equal(t, []string{indexService, indexAttribute, indexTime, indexOperation, indexAttribute}, indexes(postings))
+
+	postings = newPostings()
+	planPostings(postings, indexOperation)
+	assert.Equal(t, []string{indexOperation, indexAttribute, indexTime, indexAttribute, indexService}, indexes(postings))
+	assert.Len(t, postings[0].traces, 20)
+
+	postings = newPostings()[:1]
+	planPostings(postings, indexTime)
+	assert.Equal(t, []string{indexService}, indexes(postings), "the hint is ignored without a term of its index")
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+
+	common "go.opentelemetry.io/proto/otlp/common/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// Actions of the ingest filter rules.
+const (
+	// ingestAllow keeps the matching spans whatever the drop rate.
+	ingestAllow = "allow"
+	// ingestDeny drops the matching spans.
+	ingestDeny = "deny"
+)
+
+// maxIngestFilterServices caps the services with their own decision counts,
+// the services received afterwards are counted together under
+// otherServices, so that spans with random service names cannot grow the
+// counts without bound.
+const (
+	maxIngestFilterServices = 1000
+	otherServices           = "(other)"
+)
+
+// ingestFilterConfig configures the head-based filtering of the spans
+// received by the collector endpoints, before they are validated, tail
+// sampled or stored. The first rule matching a span decides whether it is
+// kept, and the spans matching no rule are dropped at the drop rate of their
+// service. The drop decision is made by trace ID, so the spans of a service
+// in a trace are all kept or all dropped.
+//
+// Example:
+//
+//	{
+//	  "defaultDropRate": 0,
+//	  "dropRates": {"load-generator": 0.9},
+//	  "rules": [
+//	    {"action": "allow", "key": "debug", "values": ["true"]},
+//	    {"action": "deny", "service": "frontend", "key": "http.target", "values": ["/health"]}
+//	  ]
+//	}
+type ingestFilterConfig struct {
+	DefaultDropRate float64            `json:"defaultDropRate,omitempty"`
+	DropRates       map[string]float64 `json:"dropRates,omitempty"`
+	Rules           []ingestRule       `json:"rules,omitempty"`
+}
+
+// ingestRule allows or denies the spans with a span or resource attribute
+// with one of the values, of the service if it is set.
+type ingestRule struct {
+	Action  string   `json:"action"`
+	Service string   `json:"service,omitempty"`
+	Key     string   `json:"key"`
+	Values  []string `json:"values"`
+}
+
+func loadIngestFilterConfig(path string) (*ingestFilterConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read ingest filter config: %w", err)
+	}
+	var cfg ingestFilterConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse ingest filter config: %w", err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *ingestFilterConfig) validate() error {
+	if c.DefaultDropRate < 0 || c.DefaultDropRate > 1 {
+		return errors.New("the default drop rate must be between 0 and 1")
+	}
+	for service, rate := range c.DropRates {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("the drop rate of service %q must be between 0 and 1", service)
+		}
+	}
+	for i, rule := range c.Rules {
+		if rule.Action != ingestAllow && rule.Action != ingestDeny {
+			return fmt.Errorf("rule %d: invalid action %q, expected %s or %s", i, rule.Action, ingestAllow, ingestDeny)
+		}
+		if rule.Key == "" || len(rule.Values) == 0 {
+			return fmt.Errorf("rule %d: the key and values must be set", i)
+		}
+	}
+	return nil
+}
+
+// dropRate returns the drop rate of the service.
+func (c *ingestFilterConfig) dropRate(service string) float64 {
+	if rate, ok := c.DropRates[service]; ok {
+		return rate
+	}
+	return c.DefaultDropRate
+}
+
+// action returns the action of the first rule matching the span, or "" if
+// none does.
+func (c *ingestFilterConfig) action(service string, rs *trace.ResourceSpans, span *trace.Span) string {
+	for _, rule := range c.Rules {
+		if rule.Service != "" && rule.Service != service {
+			continue
+		}
+		matches := func(attr *common.KeyValue) bool {
+			return attr.Key == rule.Key && slices.Contains(rule.Values, attributeString(attr.Value))
+		}
+		if slices.ContainsFunc(span.Attributes, matches) || slices.ContainsFunc(rs.GetResource().GetAttributes(), matches) {
+			return rule.Action
+		}
+	}
+	return ""
+}
+
+// ingestFilter drops the received spans by the rules and drop rates of its
+// config, which can be replaced at runtime through the admin API.
+type ingestFilter struct {
+	mu     sync.Mutex
+	config *ingestFilterConfig
+	// overridden is set once the config was set through the admin API, so
+	// that it is handed off instead of the one of the flags.
+	overridden bool
+	stats      map[string]*ingestFilterStats
+}
+
+// ingestFilterStats counts the decisions of the filter for a service.
+type ingestFilterStats struct {
+	Kept          int64 `json:"kept"`
+	DroppedByRate int64 `json:"droppedByRate"`
+	DroppedByRule int64 `json:"droppedByRule"`
+}
+
+func newIngestFilter(config *ingestFilterConfig) *ingestFilter {
+	return &ingestFilter{config: config, stats: make(map[string]*ingestFilterStats)}
+}
+
+// filter returns the spans of td kept by the filter.
+func (f *ingestFilter) filter(td *trace.TracesData) *trace.TracesData {
+	kept := &trace.TracesData{}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, rs := range td.ResourceSpans {
+		service := getServiceName(rs.Resource)
+		stats := f.serviceStats(service)
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				switch f.config.action(service, rs, span) {
+				case ingestDeny:
+					stats.DroppedByRule++
+					continue
+				case "":
+					if rate := f.config.dropRate(service); rate > 0 && !ingestKept(span.TraceId, 1-rate) {
+						stats.DroppedByRate++
+						continue
+					}
+				}
+				stats.Kept++
+				appendAccepted(kept, rs, ss, span)
+			}
+		}
+	}
+	return kept
+}
+
+// ingestSalt is hashed after the trace IDs of the ingest filter decisions,
+// spreading their last bytes over the high bits compared to the threshold.
+const ingestSalt = "jaeger-ingest-filter"
+
+// ingestKept keeps the proportion rate of the trace IDs, by a salted hash
+// rather than by the trace ID ordering of traceIDSampled, so that the drop
+// rates of the ingest filter and the tail sampling rates multiply.
+func ingestKept(traceID []byte, rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+	h := fnv.New64a()
+	h.Write(traceID)
+	h.Write([]byte(ingestSalt))
+	return h.Sum64() < uint64(rate*math.MaxUint64)
+}
+
+// serviceStats returns the decision counts of the service, or of the other
+// services once maxIngestFilterServices have their own. It must be called
+// with f.mu held.
+func (f *ingestFilter) serviceStats(service string) *ingestFilterStats {
+	if stats, ok := f.stats[service]; ok {
+		return stats
+	}
+	if len(f.stats) >= maxIngestFilterServices {
+		service = otherServices
+		if stats, ok := f.stats[service]; ok {
+			return stats
+		}
+	}
+	stats := &ingestFilterStats{}
+	f.stats[service] = stats
+	return stats
+}
+
+// setConfig replaces the config of the filter.
+func (f *ingestFilter) setConfig(config *ingestFilterConfig) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config = config
+}
+
+// overrideConfig replaces the config of the filter through the admin API.
+func (f *ingestFilter) overrideConfig(config *ingestFilterConfig) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config = config
+	f.overridden = true
+}
+
+// handleIngestFilter serves the config of the ingest filter and the
+// decisions per service.
+func (q *QueryService) handleIngestFilter(w http.ResponseWriter, _ *http.Request) {
+	f := q.ingestFilter
+	f.mu.Lock()
+	resp := struct {
+		Config   *ingestFilterConfig          `json:"config"`
+		Services map[string]ingestFilterStats `json:"services"`
+	}{Config: f.config, Services: make(map[string]ingestFilterStats, len(f.stats))}
+	for service, stats := range f.stats {
+		resp.Services[service] = *stats
+	}
+	f.mu.Unlock()
+	writeAdminJSON(w, resp)
+}
+
+// handleSetIngestFilter replaces the config of the ingest filter, e.g.
+//
+//	curl -X PUT -d '{"dropRates": {"load-generator": 0.9}}' localhost:17272/api/admin/ingest/filter
+func (q *QueryService) handleSetIngestFilter(w http.ResponseWriter, r *http.Request) {
+	var cfg ingestFilterConfig
+	if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
+		writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if err := cfg.validate(); err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+	q.ingestFilter.overrideConfig(&cfg)
+	log.Printf("[ADMIN] Ingest filter set to the drop rates %v (default %v) and %d rules\n", cfg.DropRates, cfg.DefaultDropRate, len(cfg.Rules))
+	writeAdminJSON(w, &cfg)
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	common "go.opentelemetry.io/proto/otlp/common/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+func TestLoadIngestFilterConfig(t *testing.T) {
+	load := func(config string) (*ingestFilterConfig, error) {
+		path := filepath.Join(t.TempDir(), "filter.json")
+		require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+		return loadIngestFilterConfig(path)
+	}
+	cfg, err := load(`{"dropRates": {"service-0": 0.5}, "rules": [{"action": "deny", "key": "http.target", "values": ["/health"]}]}`)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, cfg.dropRate("service-0"), 0)
+	assert.Zero(t, cfg.dropRate("service-1"))
+
+	for _, invalid := range []string{
+		`{`,
+		`{"defaultDropRate": 2}`,
+		`{"dropRates": {"service-0": -0.5}}`,
+		`{"rules": [{"action": "keep", "key": "debug", "values": ["true"]}]}`,
+		`{"rules": [{"action": "deny", "values": ["true"]}]}`,
+		`{"rules": [{"action": "deny", "key": "debug"}]}`,
+	} {
+		_, err := load(invalid)
+		require.Error(t, err, invalid)
+	}
+	_, err = loadIngestFilterConfig(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+// ingestTraceID returns a test trace ID kept, or dropped, at a 50% drop rate.
+func ingestTraceID(t *testing.T, kept bool) []byte {
+	for i := range 100 {
+		if ingestKept(testTraceID(i), 0.5) == kept {
+			return testTraceID(i)
+		}
+	}
+	require.FailNow(t, "no trace ID found")
+	return nil
+}
+
+func TestIngestFilter(t *testing.T) {
+	// the first trace ID is kept and the second dropped at a 50% drop rate
+	keptID, droppedID := ingestTraceID(t, true), ingestTraceID(t, false)
+	batch := func(service int) *trace.TracesData {
+		td := testBatch(service, 0, 0)
+		spans := td.ResourceSpans[0].ScopeSpans[0].Spans[:2]
+		spans[0].TraceId, spans[1].TraceId = keptID, droppedID
+		spans[0].Attributes = []*common.KeyValue{{Key: "http.target", Value: stringValue("/health")}}
+		spans[1].Attributes = []*common.KeyValue{{Key: "debug", Value: &common.AnyValue{Value: &common.AnyValue_BoolValue{BoolValue: true}}}}
+		td.ResourceSpans[0].ScopeSpans[0].Spans = spans
+		return td
+	}
+
+	f := newIngestFilter(&ingestFilterConfig{})
+	assert.Equal(t, 2, countSpans(f.filter(batch(0))))
+
+	f.setConfig(&ingestFilterConfig{DropRates: map[string]float64{"service-0": 0.5}})
+	kept := f.filter(batch(0))
+	require.Equal(t, 1, countSpans(kept))
+	assert.Equal(t, keptID, firstTraceID(kept))
+	assert.Equal(t, 2, countSpans(f.filter(batch(1))))
+
+	f.setConfig(&ingestFilterConfig{
+		DefaultDropRate: 1,
+		Rules: []ingestRule{
+			{Action: ingestAllow, Key: "debug", Values: []string{"true"}},
+			{Action: ingestDeny, Service: "service-0", Key: "http.target", Values: []string{"/health"}},
+			{Action: ingestAllow, Key: "http.target", Values: []string{"/health"}},
+		},
+	})
+	// the allowed span is kept despite the drop rate, the denied one is dropped
+	kept = f.filter(batch(0))
+	require.Equal(t, 1, countSpans(kept))
+	assert.Equal(t, "debug", kept.ResourceSpans[0].ScopeSpans[0].Spans[0].Attributes[0].Key)
+	// the deny rule is scoped to service-0
+	assert.Equal(t, 2, countSpans(f.filter(batch(1))))
+
+	assert.Equal(t, map[string]*ingestFilterStats{
+		"service-0": {Kept: 4, DroppedByRate: 1, DroppedByRule: 1},
+		"service-1": {Kept: 4},
+	}, f.stats)
+}
+
+func TestIngestFilterIndependentOfTailSampling(t *testing.T) {
+	const traces = 100000
+	for _, tt := range []struct {
+		dropRate, tailRate float64
+	}{
+		{dropRate: 0.9, tailRate: 0.5},
+		{dropRate: 0.5, tailRate: 0.5},
+		{dropRate: 0.2, tailRate: 0.1},
+	} {
+		kept := 0
+		traceID := make([]byte, 16)
+		for i := range traces {
+			// random looking trace IDs, as the clients generate them
+			binary.BigEndian.PutUint64(traceID, uint64(i)*0x9e3779b97f4a7c15)
+			binary.BigEndian.PutUint64(traceID[8:], uint64(i)*0xbf58476d1ce4e5b9)
+			if ingestKept(traceID, 1-tt.dropRate) && traceIDSampled(traceID, tt.tailRate) {
+				kept++
+			}
+		}
+		want := (1 - tt.dropRate) * tt.tailRate
+		assert.InDelta(t, want, float64(kept)/traces, want*0.05, "drop rate %v, tail rate %v", tt.dropRate, tt.tailRate)
+	}
+}
+
+func TestIngestFilterEndpoints(t *testing.T) {
+	q := NewQueryService()
+	handler := newAdminHandler(q, newUsageTracker(), nil)
+	do := func(method, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, "/api/admin/ingest/filter", strings.NewReader(body)))
+		return w
+	}
+
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, `{"defaultDropRate": -1}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, `{`).Code)
+	require.Equal(t, http.StatusOK, do(http.MethodPut, `{"dropRates": {"service-0": 1}}`).Code)
+
+	require.Empty(t, q.receiveTraces(testBatch(0, 0, 0)))
+	require.Empty(t, q.receiveTraces(testBatch(1, 0, 0)))
+	q.mu.RLock()
+	assert.Len(t, q.traces, testTraces)
+	spans := countSpans(q.traces[hex.EncodeToString(testTraceID(0))])
+	q.mu.RUnlock()
+	assert.Equal(t, 1, spans)
+
+	w := do(http.MethodGet, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Config   ingestFilterConfig           `json:"config"`
+		Services map[string]ingestFilterStats `json:"services"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, map[string]float64{"service-0": 1}, resp.Config.DropRates)
+	assert.Equal(t, map[string]ingestFilterStats{
+		"service-0": {DroppedByRate: testTraces},
+		"service-1": {Kept: testTraces},
+	}, resp.Services)
+}
+
+func TestIngestFilterStatsCap(t *testing.T) {
+	f := newIngestFilter(&ingestFilterConfig{})
+	for service := range maxIngestFilterServices {
+		f.filter(testBatch(service, 0, 0))
+	}
+	require.Len(t, f.stats, maxIngestFilterServices)
+
+	// the services beyond the cap are counted together
+	f.filter(testBatch(maxIngestFilterServices, 0, 0))
+	f.filter(testBatch(maxIngestFilterServices+1, 0, 0))
+	assert.Len(t, f.stats, maxIngestFilterServices+1)
+	assert.Equal(t, ingestFilterStats{Kept: 2 * testTraces}, *f.stats[otherServices])
+	// and the known services keep their own counts
+	f.filter(testBatch(0, 0, 0))
+	assert.Equal(t, ingestFilterStats{Kept: 2 * testTraces}, *f.stats["service-0"])
+}
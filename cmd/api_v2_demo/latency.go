@@ -0,0 +1,117 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// latencyBounds are the upper bounds of the buckets of the latency
+// histograms, the last bucket counts the slower calls.
+var latencyBounds = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// latencyHistogram is the distribution of the latency of the calls of an
+// RPC method.
+type latencyHistogram struct {
+	Count int     `json:"count"`
+	SumMs float64 `json:"sumMs"`
+	MaxMs float64 `json:"maxMs"`
+	// P50Ms and P99Ms are estimated by the upper bounds of the buckets
+	// holding the quantiles, or by MaxMs for the last bucket. They are only
+	// set in the reports.
+	P50Ms   float64         `json:"p50Ms"`
+	P99Ms   float64         `json:"p99Ms"`
+	Buckets []latencyBucket `json:"buckets"`
+}
+
+// latencyBucket counts the calls slower than the upper bound of the
+// previous bucket.
+type latencyBucket struct {
+	// UpperMs is the upper bound of the bucket, absent for the last one.
+	UpperMs float64 `json:"upperMs,omitempty"`
+	Count   int     `json:"count"`
+}
+
+func newLatencyHistogram() *latencyHistogram {
+	h := &latencyHistogram{Buckets: make([]latencyBucket, len(latencyBounds)+1)}
+	for i, bound := range latencyBounds {
+		h.Buckets[i].UpperMs = durationMs(bound)
+	}
+	return h
+}
+
+func (h *latencyHistogram) observe(d time.Duration) {
+	ms := durationMs(d)
+	h.Count++
+	h.SumMs += ms
+	h.MaxMs = max(h.MaxMs, ms)
+	i, _ := slices.BinarySearch(latencyBounds, d)
+	h.Buckets[i].Count++
+}
+
+// quantile returns the upper bound of the bucket holding the quantile q.
+func (h *latencyHistogram) quantile(q float64) float64 {
+	rank := q * float64(h.Count)
+	seen := 0
+	for _, b := range h.Buckets {
+		seen += b.Count
+		if float64(seen) >= rank && b.UpperMs != 0 {
+			return min(b.UpperMs, h.MaxMs)
+		}
+	}
+	return h.MaxMs
+}
+
+// report returns a copy of h with its quantiles.
+func (h *latencyHistogram) report() *latencyHistogram {
+	c := *h
+	c.Buckets = slices.Clone(h.Buckets)
+	c.P50Ms = h.quantile(0.5)
+	c.P99Ms = h.quantile(0.99)
+	return &c
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// requestService returns the service name parameter of a query API
+// request, either a service field or the service_name of its query, or ""
+// if it has none.
+func requestService(req any) string {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return ""
+	}
+	m := msg.ProtoReflect()
+	if fd := m.Descriptor().Fields().ByName("query"); fd != nil && fd.Message() != nil {
+		if !m.Has(fd) {
+			return ""
+		}
+		m = m.Get(fd).Message()
+	}
+	for _, name := range []protoreflect.Name{"service_name", "service"} {
+		if fd := m.Descriptor().Fields().ByName(name); fd != nil && fd.Kind() == protoreflect.StringKind && !fd.IsList() {
+			return m.Get(fd).String()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+)
+
+func TestLatencyHistogram(t *testing.T) {
+	h := newLatencyHistogram()
+	assert.Equal(t, 0.0, h.report().P99Ms)
+	for range 98 {
+		h.observe(3 * time.Millisecond)
+	}
+	h.observe(5 * time.Millisecond)
+	h.observe(30 * time.Second)
+
+	report := h.report()
+	assert.Equal(t, 100, report.Count)
+	assert.InDelta(t, 30299.0, report.SumMs, 1e-6)
+	assert.Equal(t, 30000.0, report.MaxMs)
+	assert.Equal(t, 5.0, report.P50Ms)
+	assert.Equal(t, 5.0, report.P99Ms)
+	require.Len(t, report.Buckets, len(latencyBounds)+1)
+	assert.Equal(t, latencyBucket{UpperMs: 5, Count: 99}, report.Buckets[1])
+	assert.Equal(t, latencyBucket{Count: 1}, report.Buckets[len(latencyBounds)])
+
+	// the slowest calls are estimated by the maximum
+	h.observe(30 * time.Second)
+	assert.Equal(t, 30000.0, h.report().P99Ms)
+	assert.Equal(t, 0.0, h.P99Ms, "the quantiles are only set in the reports")
+}
+
+func TestRequestService(t *testing.T) {
+	for _, tc := range []struct {
+		req  any
+		want string
+	}{
+		{&api_v3.FindTracesRequest{Query: &api_v3.TraceQueryParameters{ServiceName: "frontend"}}, "frontend"},
+		{&api_v3.FindTracesRequest{}, ""},
+		{&api_v3.GetOperationsRequest{Service: "frontend"}, "frontend"},
+		{&api_v2.FindTracesRequest{Query: &api_v2.TraceQueryParameters{ServiceName: "frontend"}}, "frontend"},
+		{&storagev2.GetOperationsRequest{Service: "frontend"}, "frontend"},
+		{&api_v3.GetTraceRequest{TraceId: "1234"}, ""},
+		{nil, ""},
+	} {
+		assert.Equal(t, tc.want, requestService(tc.req), "%T", tc.req)
+	}
+}
+
+func TestUsageLatency(t *testing.T) {
+	u := newUsageTracker()
+	u.record(t.Context(), "/jaeger.api_v3.QueryService/FindTraces", &api_v3.FindTracesRequest{Query: &api_v3.TraceQueryParameters{ServiceName: "frontend"}}, nil, 3*time.Millisecond)
+	u.record(t.Context(), "/jaeger.api_v3.QueryService/FindTraces", &api_v3.FindTracesRequest{Query: &api_v3.TraceQueryParameters{ServiceName: "driver"}}, nil, time.Second)
+	u.record(t.Context(), "/jaeger.api_v3.QueryService/GetServices", &api_v3.GetServicesRequest{}, nil, time.Millisecond)
+
+	report := u.report()
+	findTraces := report.Methods["/jaeger.api_v3.QueryService/FindTraces"]
+	assert.Equal(t, 2, findTraces.Latency.Count)
+	require.Len(t, findTraces.ServiceLatency, 2)
+	assert.Equal(t, 1000.0, findTraces.ServiceLatency["driver"].MaxMs)
+	assert.Equal(t, 1, findTraces.ServiceLatency["frontend"].Count)
+	assert.Empty(t, report.Methods["/jaeger.api_v3.QueryService/GetServices"].ServiceLatency)
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fdPrefix marks the address of an inherited listening socket: fd:N for a file
+// descriptor passed by a supervisor, or fd:NAME for a socket passed by systemd
+// socket activation with FileDescriptorName=NAME.
+const fdPrefix = "fd:"
+
+// sdListenFDsStart is the first file descriptor passed by systemd.
+const sdListenFDsStart = 3
+
+// listenSpec describes one address a server listens on, with optional TLS.
+// Its flag syntax is ADDR[,cert=FILE,key=FILE[,client-ca=FILE]], e.g.
+//
+//	--grpc-listen 127.0.0.1:17271 --grpc-listen '[::1]:17271'
+//	--grpc-listen 0.0.0.0:17443,cert=server.pem,key=server-key.pem
+//	--grpc-listen fd:3 --admin-listen fd:admin
+type listenSpec struct {
+	Addr     string
+	CertFile string
+	KeyFile  string
+	// ClientCAFile, if set, requires clients to present a certificate signed by one of these CAs.
+	ClientCAFile string
+}
+
+// listenSpecs collects the values of a repeated listen flag.
+type listenSpecs []listenSpec
+
+func (l *listenSpecs) String() string {
+	addrs := make([]string, len(*l))
+	for i, spec := range *l {
+		addrs[i] = spec.Addr
+	}
+	return strings.Join(addrs, " ")
+}
+
+func (l *listenSpecs) Set(value string) error {
+	spec, err := parseListenSpec(value)
+	if err != nil {
+		return err
+	}
+	*l = append(*l, spec)
+	return nil
+}
+
+func parseListenSpec(value string) (listenSpec, error) {
+	parts := strings.Split(value, ",")
+	spec := listenSpec{Addr: parts[0]}
+	if strings.HasPrefix(spec.Addr, fdPrefix) {
+		if spec.Addr == fdPrefix {
+			return spec, fmt.Errorf("invalid listen address %q: missing file descriptor", spec.Addr)
+		}
+	} else if _, _, err := net.SplitHostPort(spec.Addr); err != nil {
+		return spec, fmt.Errorf("invalid listen address %q: %w", spec.Addr, err)
+	}
+	for _, option := range parts[1:] {
+		key, val, ok := strings.Cut(option, "=")
+		if !ok || val == "" {
+			return spec, fmt.Errorf("invalid listen option %q, expected key=value", option)
+		}
+		switch key {
+		case "cert":
+			spec.CertFile = val
+		case "key":
+			spec.KeyFile = val
+		case "client-ca":
+			spec.ClientCAFile = val
+		default:
+			return spec, fmt.Errorf("unknown listen option %q", key)
+		}
+	}
+	if (spec.CertFile == "") != (spec.KeyFile == "") {
+		return spec, fmt.Errorf("listen address %s: cert and key must be set together", spec.Addr)
+	}
+	if spec.ClientCAFile != "" && spec.CertFile == "" {
+		return spec, fmt.Errorf("listen address %s: client-ca requires cert and key", spec.Addr)
+	}
+	return spec, nil
+}
+
+// listen opens a listener for every spec. Listeners with a certificate
+// accept TLS connections and offer nextProtos via ALPN.
+func listen(specs []listenSpec, nextProtos []string) ([]net.Listener, error) {
+	listeners := make([]net.Listener, 0, len(specs))
+	for _, spec := range specs {
+		lis, err := spec.listen(nextProtos)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return nil, err
+		}
+		listeners = append(listeners, lis)
+	}
+	return listeners, nil
+}
+
+func (s listenSpec) listen(nextProtos []string) (net.Listener, error) {
+	var tlsConfig *tls.Config
+	if s.CertFile != "" {
+		var err error
+		if tlsConfig, err = s.tlsConfig(nextProtos); err != nil {
+			return nil, err
+		}
+	}
+	var lis net.Listener
+	var err error
+	if fd, ok := strings.CutPrefix(s.Addr, fdPrefix); ok {
+		lis, err = inheritedListener(fd)
+	} else {
+		lis, err = net.Listen(listenNetwork(s.Addr), s.Addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot listen on %s: %w", s.Addr, err)
+	}
+	if tlsConfig != nil {
+		lis = tls.NewListener(lis, tlsConfig)
+	}
+	return lis, nil
+}
+
+// inheritedListener returns the listener of an inherited file descriptor,
+// given by number or by its systemd socket activation name.
+func inheritedListener(fdOrName string) (net.Listener, error) {
+	fd, err := strconv.Atoi(fdOrName)
+	if err != nil {
+		var ok bool
+		if fd, ok = activatedSockets()[fdOrName]; !ok {
+			return nil, fmt.Errorf("no socket named %q was passed by systemd", fdOrName)
+		}
+	}
+	f := os.NewFile(uintptr(fd), fdPrefix+fdOrName)
+	if f == nil {
+		return nil, fmt.Errorf("invalid file descriptor %d", fd)
+	}
+	// FileListener duplicates the descriptor, the original is no longer needed
+	defer f.Close()
+	return net.FileListener(f)
+}
+
+// activatedSockets returns the file descriptors passed by systemd socket
+// activation, keyed by their FileDescriptorName, following sd_listen_fds(3).
+// The environment variables are cleared so that child processes do not inherit them.
+var activatedSockets = sync.OnceValue(func() map[string]int {
+	defer func() {
+		os.Unsetenv("LISTEN_PID")
+		os.Unsetenv("LISTEN_FDS")
+		os.Unsetenv("LISTEN_FDNAMES")
+	}()
+	if pid, err := strconv.Atoi(os.Getenv("LISTEN_PID")); err != nil || pid != os.Getpid() {
+		return nil
+	}
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count <= 0 {
+		return nil
+	}
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+	sockets := make(map[string]int, count)
+	for i := range count {
+		if i < len(names) && names[i] != "" {
+			sockets[names[i]] = sdListenFDsStart + i
+		}
+	}
+	return sockets
+})
+
+// listenNetwork restricts IP literals to their address family, so that
+// 0.0.0.0 and [::] can be bound side by side for dual-stack setups.
+// Host names and empty hosts keep the default dual-stack behavior.
+func listenNetwork(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "tcp"
+	}
+	ip, err := netip.ParseAddr(host)
+	switch {
+	case err != nil:
+		return "tcp"
+	case ip.Is4():
+		return "tcp4"
+	default:
+		return "tcp6"
+	}
+}
+
+func (s listenSpec) tlsConfig(nextProtos []string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(s.CertFile, s.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load TLS certificate for %s: %w", s.Addr, err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   nextProtos,
+	}
+	if s.ClientCAFile != "" {
+		pem, err := os.ReadFile(s.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read client CA for %s: %w", s.Addr, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in client CA file " + s.ClientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// displayAddr returns the address clients on this host can use to reach
+// the listener, for the usage hints printed on startup.
+func displayAddr(lis net.Listener) string {
+	addr := lis.Addr().String()
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	if ip, err := netip.ParseAddr(host); host == "" || (err == nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
@@ -2,102 +2,201 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
+	"flag"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
+	channelz "google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	common "go.opentelemetry.io/proto/otlp/common/v1"
 	resource "go.opentelemetry.io/proto/otlp/resource/v1"
 	trace "go.opentelemetry.io/proto/otlp/trace/v1"
 
+	"github.com/jaegertracing/jaeger-idl/adapter/streamcheck"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
 	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+	"github.com/jaegertracing/jaeger-idl/internal/fixtures"
 )
 
 // QueryService implements the Jaeger api_v3 Query Service (read path)
 type QueryService struct {
 	api_v3.UnimplementedQueryServiceServer
 
-	// In-memory data for demo purposes
+	// In-memory data for demo purposes. Once the server is running the stored
+	// TracesData must not be modified in place, writers replace them under mu.
+	// Readers only hold mu to look up the traces, and process them unlocked.
+	mu         sync.RWMutex
 	traces     map[string]*trace.TracesData
-	services   []string
-	operations map[string][]string // service -> operations
+	visibility map[string]string // service -> hidden or deprecated
+	// index is kept up to date with traces by storeTrace and deleteTrace.
+	index *traceIndex
+	// catalog caches the services and operations of the traces, it is
+	// reset by the writers.
+	catalog atomic.Pointer[serviceCatalog]
+	// maxOperations, if set, caps the operations listed for a service.
+	maxOperations int
+	// maxMessageSize is the maximum size of the response messages, see
+	// splitTraces.
+	maxMessageSize int
+
+	// privacy, if set, adds noise to the dependencies returned to the tenants.
+	privacy *privacyConfig
+	// ingestAnonymizer, if set, scrubs the spans before they are stored.
+	ingestAnonymizer *anonymizer
+	// exportAnonymizer, if set, scrubs the traces returned by the queries.
+	exportAnonymizer *anonymizer
+	// hintsConfig, if set, enables query plan hints within its bounds.
+	hintsConfig *queryHintsConfig
+	// regex, if set, enables regular expressions in the queries.
+	regex *regexMatcher
+	// forwarder, if set, exports the imported spans to a downstream collector.
+	forwarder *forwarder
+	// memory, if set, evicts traces to bound the memory use.
+	memory *memoryLimits
+	// purger, if set, purges the traces older than the retention policy.
+	purger *retentionPurger
+	// truncation, if set, truncates the long attribute values of the
+	// imported spans.
+	truncation *truncatePolicy
+	// throttler and baggage, if set, serve the throttling credits and the
+	// baggage restrictions of the agent to the client libraries.
+	throttler *throttler
+	baggage   *baggageConfig
+	// ingestFilter drops the received spans by service and attributes before
+	// they are validated and stored.
+	ingestFilter *ingestFilter
+	// tailSampler, if set, holds the received spans until their traces are
+	// kept or dropped by the tail sampling policies.
+	tailSampler *tailSampler
+	// dependencies, if set, aggregates the dependency links periodically,
+	// instead of computing them on each read.
+	dependencies *dependencyAggregator
+	// ingestRate counts the received spans, for the debug endpoints.
+	ingestRate *rateMeter
 }
 
 func NewQueryService() *QueryService {
 	return &QueryService{
-		traces:     make(map[string]*trace.TracesData),
-		operations: make(map[string][]string),
+		traces:         make(map[string]*trace.TracesData),
+		visibility:     make(map[string]string),
+		index:          newTraceIndex(),
+		maxMessageSize: defaultMaxMessageSize,
+		ingestFilter:   newIngestFilter(&ingestFilterConfig{}),
+		ingestRate:     &rateMeter{},
 	}
 }
 
 // GetTrace returns a single trace by ID (streaming)
 func (q *QueryService) GetTrace(req *api_v3.GetTraceRequest, stream api_v3.QueryService_GetTraceServer) error {
-	log.Printf("[QUERY] GetTrace called for traceID: %s\n", req.TraceId)
+	log.Printf("[QUERY v3] GetTrace called for traceID: %s\n", req.TraceId)
+	maxSize, err := q.messageSizeLimit(stream.Context())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
 
-	if traces, ok := q.traces[req.TraceId]; ok {
-		log.Printf("[QUERY] Found trace with spans\n")
+	q.mu.RLock()
+	traces, ok := q.traces[req.TraceId]
+	q.mu.RUnlock()
+	if ok {
+		q.memory.read(req.TraceId)
+	}
 
-		err := stream.Send(
-			&trace.TracesData{
-				ResourceSpans: traces.ResourceSpans,
-			})
-		if err != nil {
-			return err
+	if ok {
+		log.Printf("[QUERY v3] Found trace with spans\n")
+
+		if !req.RawTraces {
+			traces = withCriticalPath(traces)
+		}
+		if q.exportAnonymizer != nil {
+			traces = q.exportAnonymizer.anonymized(traces)
+		}
+		for _, chunk := range splitTraces(&trace.TracesData{ResourceSpans: traces.ResourceSpans}, maxSize) {
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
 		}
 	} else {
-		log.Printf("[QUERY] Trace not found: %s\n", req.TraceId)
+		log.Printf("[QUERY v3] Trace not found: %s\n", req.TraceId)
 	}
 
 	return nil
 }
 
-// FindTraces searches for traces matching the query (streaming)
+// FindTraces searches for traces matching the query (streaming). The time
+// range of the query applies to the start time of the traces.
+// The downsampling options of the query attributes return every Nth match
+// or only the trace summaries.
 func (q *QueryService) FindTraces(req *api_v3.FindTracesRequest, stream api_v3.QueryService_FindTracesServer) error {
-	log.Printf("[QUERY] FindTraces called - service: %s, operation: %s\n",
+	log.Printf("[QUERY v3] FindTraces called - service: %s, operation: %s\n",
 		req.Query.ServiceName, req.Query.OperationName)
+	q.warnIfDeprecated(apiV3, req.Query.ServiceName)
 
-	// Search through traces
-	for traceID, traces := range q.traces {
-		matched := false
-
-		// Check if any span matches the query
-		for _, rs := range traces.ResourceSpans {
-			serviceName := getServiceName(rs.Resource)
-
-			if serviceName == req.Query.ServiceName {
-				if req.Query.OperationName == "" {
-					matched = true
-					break
-				}
-
-				// Check operation name in spans
-				for _, ss := range rs.ScopeSpans {
-					for _, span := range ss.Spans {
-						if span.Name == req.Query.OperationName {
-							matched = true
-							break
-						}
-					}
-					if matched {
-						break
-					}
-				}
-			}
-			if matched {
-				break
-			}
-		}
+	maxSize, err := q.messageSizeLimit(stream.Context())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	hints, err := parseQueryHints(req.GetQuery().GetAttributes(), q.hintsConfig)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	sampling, err := parseSampleOption(req.GetQuery().GetAttributes())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	downsample, err := parseDownsampling(req.GetQuery().GetAttributes())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	query, filters, err := q.queryFilters(req.GetQuery())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if hints != (queryHints{}) {
+		log.Printf("[QUERY v3] Query plan: %s\n", hints)
+		stream.SetHeader(metadata.Pairs(queryPlanHeader, hints.String()))
+	}
 
-		if matched {
-			log.Printf("[QUERY] Matched trace: %s\n", traceID)
-			err := stream.Send(&trace.TracesData{
-				ResourceSpans: traces.ResourceSpans,
-			})
-			if err != nil {
+	ctx, cancel := q.regex.withTimeout(stream.Context(), filters)
+	defer cancel()
+	found, err := q.findTraces(ctx, query, filters, hints)
+	if err != nil {
+		return status.FromContextError(err).Err()
+	}
+	log.Printf("[QUERY v3] Matched %d traces\n", len(found))
+	found = downsample.strided(found)
+	if header := downsample.String(); header != "" {
+		log.Printf("[QUERY v3] Downsampled to %d traces: %s\n", len(found), header)
+		stream.SetHeader(metadata.Pairs(downsampleHeader, header))
+	}
+	found, sample := limitTraces(found, int(req.GetQuery().GetSearchDepth()), sampling)
+	if sample != nil {
+		log.Printf("[QUERY v3] Returning a sample of %d traces: %s\n", len(found), sample)
+		stream.SetHeader(metadata.Pairs(resultSampleHeader, sample.String()))
+	}
+
+	for _, traces := range found {
+		traces = downsample.result(traces)
+		if q.exportAnonymizer != nil {
+			traces = q.exportAnonymizer.anonymized(traces)
+		}
+		for _, chunk := range splitTraces(&trace.TracesData{ResourceSpans: traces.ResourceSpans}, maxSize) {
+			if err := stream.Send(chunk); err != nil {
 				return err
 			}
 		}
@@ -108,35 +207,81 @@ func (q *QueryService) FindTraces(req *api_v3.FindTracesRequest, stream api_v3.Q
 
 // GetServices returns all known service names
 func (q *QueryService) GetServices(ctx context.Context, req *api_v3.GetServicesRequest) (*api_v3.GetServicesResponse, error) {
-	log.Println("[QUERY] GetServices called")
-	log.Printf("[QUERY] Returning %d services: %v\n", len(q.services), q.services)
-
+	log.Println("[QUERY v3] GetServices called")
+	services := q.listedServices()
+	log.Printf("[QUERY v3] Returning %d services: %v\n", len(services), services)
 	return &api_v3.GetServicesResponse{
-		Services: q.services,
+		Services: services,
 	}, nil
 }
 
-// GetOperations returns all operations for a given service
+// listedServices returns the services that are not hidden or deprecated.
+func (q *QueryService) listedServices() []string {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	catalog := q.catalogLocked()
+	services := make([]string, 0, len(catalog.services))
+	for _, service := range catalog.services {
+		if q.isListed(service) {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
+// GetOperations returns the operations of a given service, only those of
+// the requested span kind if it is set
 func (q *QueryService) GetOperations(ctx context.Context, req *api_v3.GetOperationsRequest) (*api_v3.GetOperationsResponse, error) {
-	log.Printf("[QUERY] GetOperations called for service: %s\n", req.Service)
+	log.Printf("[QUERY v3] GetOperations called for service: %s\n", req.Service)
+	q.warnIfDeprecated(apiV3, req.Service)
 
 	operations := make([]*api_v3.Operation, 0)
-
-	if ops, ok := q.operations[req.Service]; ok {
-		for _, op := range ops {
-			operations = append(operations,
-				&api_v3.Operation{
-					Name: op,
-				})
-		}
+	for _, op := range q.operations(req.Service, req.SpanKind) {
+		operations = append(operations,
+			&api_v3.Operation{
+				Name:     op.name,
+				SpanKind: op.spanKind,
+			})
 	}
 
-	log.Printf("[QUERY] Returning %d operations for service %s\n", len(operations), req.Service)
+	log.Printf("[QUERY v3] Returning %d operations for service %s\n", len(operations), req.Service)
 	return &api_v3.GetOperationsResponse{
 		Operations: operations,
 	}, nil
 }
 
+// GetDependencies returns the calls between services among the spans that
+// started within the requested time range, the same links as the storage v2
+// DependencyReader.
+func (q *QueryService) GetDependencies(ctx context.Context, req *api_v3.GetDependenciesRequest) (*api_v3.GetDependenciesResponse, error) {
+	log.Printf("[QUERY v3] GetDependencies called - start: %v, end: %v\n", req.GetStartTime().AsTime(), req.GetEndTime().AsTime())
+	if req.StartTime == nil || req.EndTime == nil {
+		return nil, status.Error(codes.InvalidArgument, "start_time and end_time are required")
+	}
+	if req.EndTime.AsTime().Before(req.StartTime.AsTime()) {
+		return nil, status.Error(codes.InvalidArgument, "end_time is before start_time")
+	}
+
+	links := noisyDependencies(q.privacy.noiseForContext(ctx), q.dependencyLinks(req.StartTime, req.EndTime))
+	dependencies := make([]*api_v3.Dependency, 0, len(links))
+	for _, link := range links {
+		dependencies = append(dependencies, &api_v3.Dependency{
+			Parent:     link.Parent,
+			Child:      link.Child,
+			CallCount:  link.CallCount,
+			ErrorCount: link.ErrorCount,
+			LatencyP50: link.LatencyP50,
+			LatencyP95: link.LatencyP95,
+			LatencyP99: link.LatencyP99,
+		})
+	}
+
+	log.Printf("[QUERY v3] Returning %d dependencies\n", len(dependencies))
+	return &api_v3.GetDependenciesResponse{
+		Dependencies: dependencies,
+	}, nil
+}
+
 // Helper function to extract service name from resource
 func getServiceName(resource *resource.Resource) string {
 	if resource == nil {
@@ -152,18 +297,6 @@ func getServiceName(resource *resource.Resource) string {
 	return ""
 }
 
-// Helper function to create a string attribute
-func stringAttr(key, value string) *common.KeyValue {
-	return &common.KeyValue{
-		Key: key,
-		Value: &common.AnyValue{
-			Value: &common.AnyValue_StringValue{
-				StringValue: value,
-			},
-		},
-	}
-}
-
 // Helper function to create an int attribute
 func intAttr(key string, value int64) *common.KeyValue {
 	return &common.KeyValue{
@@ -177,243 +310,640 @@ func intAttr(key string, value int64) *common.KeyValue {
 }
 
 // Initialize with demo data
-func (q *QueryService) initDemoData() {
+func (q *QueryService) initDemoData(base time.Time) {
 	log.Println("Initializing demo data...")
 
-	// Set up services
-	q.services = []string{"frontend", "auth-service", "database"}
+	// Load the sample traces, which define the services and operations, the second one five minutes earlier
+	q.loadSampleTrace("trace1.json", base)
+	q.loadSampleTrace("trace2.json", base.Add(-5*time.Minute))
 
-	// Set up operations
-	q.operations["frontend"] = []string{
-		"HTTP GET /api/users",
-		"HTTP POST /api/login",
-		"HTTP GET /health",
+	log.Printf("Demo data initialized with %d traces\n", len(q.traces))
+}
+
+// loadSampleTrace adds a trace of the fixtures, shifted to start at start.
+func (q *QueryService) loadSampleTrace(name string, start time.Time) {
+	td, err := fixtures.ReadTraces(name)
+	if err != nil {
+		log.Fatalf("Failed to load sample trace %s: %v", name, err)
 	}
-	q.operations["auth-service"] = []string{
-		"authenticate",
-		"validate-token",
-		"refresh-token",
+	fixtures.Rebase(td, start)
+	if q.ingestAnonymizer != nil {
+		q.ingestAnonymizer.anonymize(td)
 	}
-	q.operations["database"] = []string{
-		"SELECT users",
-		"INSERT session",
-		"UPDATE last_login",
+	traceID := hex.EncodeToString(firstTraceID(td))
+	q.storeTrace(traceID, td)
+	q.memory.written(traceID, time.Now())
+	log.Println("Created sample trace:", traceID)
+}
+
+func main() {
+	port := 17271
+
+	adminPort := flag.Int("admin-port", 17272, "port for the admin HTTP endpoints on 127.0.0.1 when --admin-listen is not set, 0 to disable; use --admin-listen :PORT to serve them on all interfaces")
+	var grpcListen, adminListen, sharedListen listenSpecs
+	flag.Var(&grpcListen, "grpc-listen", "address of the gRPC query service as ADDR[,cert=FILE,key=FILE[,client-ca=FILE]], where ADDR is HOST:PORT, fd:N for an inherited file descriptor or fd:NAME for a systemd socket; repeat to listen on several addresses (default :17271)")
+	flag.Var(&adminListen, "admin-listen", "address of the admin HTTP endpoints, with the same syntax as --grpc-listen; repeatable")
+	flag.Var(&sharedListen, "shared-listen", "address serving both the gRPC query service and the admin HTTP/1.1 endpoints, told apart by the HTTP/2 preface, with the same syntax as --grpc-listen; repeatable, the default gRPC and admin ports are then only opened with --grpc-listen and --admin-listen")
+	seedURL := flag.String("seed-url", "", "URL of a JSON file or tarball with OTLP traces to import on startup")
+	seedSHA256 := flag.String("seed-sha256", "", "expected SHA-256 checksum (hex) of the data at --seed-url")
+	seedTimeout := flag.Duration("seed-timeout", time.Minute, "timeout for downloading the data at --seed-url")
+	fixturesDir := flag.String("fixtures-dir", "", "directory of trace files (OTLP JSON, Jaeger UI JSON or binary OTLP) kept loaded while the server runs: new files are loaded, modified files reloaded and the traces of deleted files removed")
+	fixturesPoll := flag.Duration("fixtures-poll", time.Second, "interval of the checks of --fixtures-dir for changes")
+	demoSeed := flag.Uint64("demo-seed", 0, "seed of the random values of the demo, i.e. the differential privacy noise of --privacy-config, for reproducible responses; 0 for a random seed")
+	demoBaseTime := flag.String("demo-base-time", "", "start time (RFC 3339) of the sample traces and of the traces of --fixtures-dir, for reproducible data, e.g. in golden-file tests (default: the time they are loaded)")
+	importDir := flag.String("import-dir", "", "directory of traces in the JSON format of the Jaeger UI, e.g. downloaded from a Jaeger UI, to import on startup")
+	otlpFile := flag.String("otlp-file", "", "file written by the file exporter of the OpenTelemetry Collector, one OTLP JSON batch per line, possibly gzipped, to import on startup; a glob pattern imports the rotated files too, e.g. 'traces*.jsonl'")
+	privacyConfigPath := flag.String("privacy-config", "", "JSON file with per-tenant differential privacy noise settings for aggregate endpoints")
+	anonymizeConfigPath := flag.String("anonymize-config", "", "JSON file with rules for hashing, redacting or dropping sensitive attributes")
+	anonymizeOn := flag.String("anonymize-on", anonymizeOnExport, "where to apply --anonymize-config: 'ingest' scrubs the spans before they are stored, whichever way they are received, 'export' scrubs query results")
+	visibilityConfigPath := flag.String("service-visibility", "", "JSON file listing hidden and deprecated services, which are left out of GetServices")
+	agentConfigPath := flag.String("agent-config", "", "JSON file with the per-service throttling credits and baggage restrictions served to the client libraries on the /credits and /baggageRestrictions endpoints of the agent")
+	agentPort := flag.Int("agent-udp-port", 6831, "UDP port accepting jaeger.thrift spans in the compact encoding, as emitted by Jaeger client libraries to the agent, 0 to disable")
+	renameRulesPath := flag.String("rename-rules", "", "JSON file with operation rename rules to apply to the data on startup")
+	var memory memoryOptions
+	flag.IntVar(&memory.MaxTraces, "memory.max-traces", 0, "maximum number of traces kept in memory, evicting the least recently written or read traces; 0 for no limit")
+	flag.DurationVar(&memory.TraceTTL, "memory.trace-ttl", 0, "time after which a trace that received no spans is removed from memory, e.g. 1h; 0 to keep the traces")
+	maxAttributeValueLength := flag.Int("ingest.max-attribute-value-length", 0, "length in bytes above which the string and bytes attribute values of the received spans are truncated, marking the spans with a truncated=true attribute; 0 to reject the spans with values over 32KiB")
+	ingestFilterPath := flag.String("ingest.filter-config", "", "JSON file with the per-service drop rates and the attribute allow and deny rules applied to the received spans, replaceable at runtime with PUT /api/admin/ingest/filter")
+	var streamWrite streamWriteOptions
+	flag.IntVar(&streamWrite.MaxBatchSpans, "ingest.stream-max-batch-spans", 1000, "number of spans received on a storage v2 WriteTraces stream above which they are written to the store and acknowledged")
+	flag.DurationVar(&streamWrite.FlushInterval, "ingest.stream-flush-interval", time.Second, "interval of the writes and acknowledgements of the spans received on a storage v2 WriteTraces stream, when there are fewer than --ingest.stream-max-batch-spans")
+	tailSamplingConfigPath := flag.String("tail-sampling-config", "", "JSON file with the decision wait and the policies (error, latency, attribute, probabilistic) of the tail sampling of the received traces")
+	var retention retentionPolicy
+	flag.DurationVar(&retention.MaxAge, "retention.max-age", 0, "time after the start of a trace after which it is purged, e.g. 24h; 0 to keep the traces")
+	flag.DurationVar(&retention.Interval, "retention.interval", 0, "interval of the purges of --retention.max-age (default a tenth of the max age, between 1s and 1m)")
+	var dependencies dependencyOptions
+	flag.DurationVar(&dependencies.Interval, "dependencies.interval", 0, "interval of the aggregation of the dependency links of the spans started since the previous run, read back by GetDependencies, e.g. 1m; 0 to compute the links on each read")
+	flag.StringVar(&dependencies.Store, "dependencies.store", dependencyStoreMemory, "store of the aggregated dependency links, memory or file")
+	flag.StringVar(&dependencies.Path, "dependencies.path", "", "file of the file store of --dependencies.store, where the links are appended and loaded from on startup")
+	var forward forwardOptions
+	flag.StringVar(&forward.Endpoint, "forward-otlp", "", "HOST:PORT of an OTLP gRPC endpoint to forward the received spans to, e.g. a collector during a migration")
+	flag.BoolVar(&forward.TLS, "forward-otlp-tls", false, "use TLS for --forward-otlp")
+	flag.IntVar(&forward.QueueSize, "forward-queue-size", 1000, "number of batches waiting to be forwarded before new batches are dropped")
+	flag.IntVar(&forward.MaxRetries, "forward-max-retries", 5, "number of retries of a batch that failed to be forwarded with a retryable error")
+	v2DeprecationWarning := flag.Bool("api-v2-deprecation-warning", false, "add a deprecation warning header to the responses of api_v2 calls")
+	maxMessageSize := flag.Int("grpc-max-message-size", defaultMaxMessageSize, "maximum size in bytes of the messages sent by the gRPC server; the traces that do not fit are split into consecutive messages, and clients can lower it with the "+maxMessageSizeHeader+" request header")
+	maxOperations := flag.Int("query-max-operations", 0, "maximum number of operations listed for a service by GetOperations, the first ones by name; 0 for no limit")
+	queryHintsConfigPath := flag.String("query-hints-config", "", "JSON file enabling query plan hints passed as jaeger.hint.* query attributes, with their bounds")
+	var regex regexOptions
+	flag.BoolVar(&regex.Enabled, "regex-match", false, "accept regular expressions, prefixed with ~, as query attribute values and operation names")
+	flag.IntVar(&regex.MaxLength, "regex-max-length", 256, "maximum length of the regular expressions of a query")
+	flag.DurationVar(&regex.Timeout, "regex-timeout", time.Second, "maximum duration of the scan of a query with regular expressions")
+	queryLogPath := flag.String("query-log", "", "file to append the query API calls to, in the JSON Lines format read by query-replay")
+	slowQueryThreshold := flag.Duration("query-slow-threshold", 0, "log the searches (FindTraces, FindTraceIDs) slower than this with their query parameters and the number of spans they scanned, e.g. 1s; 0 to disable")
+	queryLogMinDuration := flag.Duration("query-log-min-duration", 0, "log only the calls slower than this to --query-log, e.g. 500ms for a slow-query log")
+	debugListen := flag.String("debug-listen", "", "address of the pprof profiles and the expvar variables (store size, goroutines, ingest rate), with the same syntax as --grpc-listen, e.g. localhost:17273 to keep them internal; empty to disable")
+	enableReflection := flag.Bool("enable-reflection", false, "register the gRPC reflection service, e.g. for grpcurl without the proto files; off by default as some deployments forbid reflection")
+	handoffSocket := flag.String("handoff-socket", "", "unix socket for restarts without data loss: on startup the state of the process listening on it is taken over, then the socket is served for the next restart")
+	flag.Parse()
+
+	// The IDs and durations of the sample traces are fixed, so that they are
+	// reproducible with a fixed base time.
+	var baseTime time.Time
+	if *demoBaseTime != "" {
+		t, err := time.Parse(time.RFC3339Nano, *demoBaseTime)
+		if err != nil {
+			log.Fatalf("Invalid --demo-base-time: %v", err)
+		}
+		baseTime = t
 	}
 
-	// Create sample traces
-	q.createSampleTrace1()
-	q.createSampleTrace2()
+	var privacy *privacyConfig
+	if *privacyConfigPath != "" {
+		cfg, err := loadPrivacyConfig(*privacyConfigPath)
+		if err != nil {
+			log.Fatalf("Failed to load privacy config: %v", err)
+		}
+		if *demoSeed != 0 {
+			cfg.seed(*demoSeed)
+		}
+		privacy = cfg
+	}
 
-	log.Printf("Demo data initialized with %d traces\n", len(q.traces))
-}
+	// Take over the state of the previous process before opening the
+	// listeners, which it holds until it has stopped serving.
+	var handoff *handoffState
+	if *handoffSocket != "" {
+		var err error
+		if handoff, err = receiveHandoff(*handoffSocket); err != nil {
+			log.Fatalf("Failed to take over the state of the previous process: %v", err)
+		}
+	}
+	restored := handoff != nil
 
-func (q *QueryService) createSampleTrace1() {
-	traceID := "1234567890abcdef1234567890abcdef"
-
-	now := time.Now()
-	startTimeNano := uint64(now.UnixNano())
-
-	// Create TracesData with ResourceSpans
-	traces := &trace.TracesData{
-		ResourceSpans: []*trace.ResourceSpans{
-			// Frontend service
-			{
-				Resource: &resource.Resource{
-					Attributes: []*common.KeyValue{
-						stringAttr("service.name", "frontend"),
-						stringAttr("hostname", "frontend-01"),
-					},
-				},
-				ScopeSpans: []*trace.ScopeSpans{
-					{
-						Spans: []*trace.Span{
-							{
-								TraceId:           []byte(traceID)[:16],
-								SpanId:            []byte{0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11},
-								Name:              "HTTP GET /api/users",
-								StartTimeUnixNano: startTimeNano,
-								EndTimeUnixNano:   startTimeNano + uint64(120*time.Millisecond),
-								Kind:              trace.Span_SPAN_KIND_SERVER,
-								Attributes: []*common.KeyValue{
-									stringAttr("http.method", "GET"),
-									stringAttr("http.url", "/api/users"),
-									intAttr("http.status_code", 200),
-								},
-							},
-						},
-					},
-				},
-			},
-			// Auth service
-			{
-				Resource: &resource.Resource{
-					Attributes: []*common.KeyValue{
-						stringAttr("service.name", "auth-service"),
-						stringAttr("hostname", "auth-01"),
-					},
-				},
-				ScopeSpans: []*trace.ScopeSpans{
-					{
-						Spans: []*trace.Span{
-							{
-								TraceId:           []byte(traceID)[:16],
-								SpanId:            []byte{0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22},
-								ParentSpanId:      []byte{0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11},
-								Name:              "authenticate",
-								StartTimeUnixNano: startTimeNano + uint64(10*time.Millisecond),
-								EndTimeUnixNano:   startTimeNano + uint64(60*time.Millisecond),
-								Kind:              trace.Span_SPAN_KIND_SERVER,
-								Attributes: []*common.KeyValue{
-									stringAttr("user.id", "user123"),
-								},
-							},
-						},
-					},
-				},
-			},
-			// Database
-			{
-				Resource: &resource.Resource{
-					Attributes: []*common.KeyValue{
-						stringAttr("service.name", "database"),
-						stringAttr("hostname", "db-01"),
-					},
-				},
-				ScopeSpans: []*trace.ScopeSpans{
-					{
-						Spans: []*trace.Span{
-							{
-								TraceId:           []byte(traceID)[:16],
-								SpanId:            []byte{0x33, 0x33, 0x33, 0x33, 0x33, 0x33, 0x33, 0x33},
-								ParentSpanId:      []byte{0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22},
-								Name:              "SELECT users",
-								StartTimeUnixNano: startTimeNano + uint64(20*time.Millisecond),
-								EndTimeUnixNano:   startTimeNano + uint64(50*time.Millisecond),
-								Kind:              trace.Span_SPAN_KIND_CLIENT,
-								Attributes: []*common.KeyValue{
-									stringAttr("db.type", "postgresql"),
-									stringAttr("db.statement", "SELECT * FROM users WHERE id = $1"),
-								},
-							},
-						},
-					},
-				},
-			},
-		},
+	if len(grpcListen) == 0 && len(sharedListen) == 0 {
+		grpcListen = listenSpecs{{Addr: fmt.Sprintf(":%d", port)}}
+	}
+	// The admin endpoints can change and delete the data, so their default
+	// port is only open to the local clients.
+	if len(adminListen) == 0 && len(sharedListen) == 0 && *adminPort != 0 {
+		adminListen = listenSpecs{{Addr: fmt.Sprintf("127.0.0.1:%d", *adminPort)}}
+	}
+	grpcListeners, err := listen(grpcListen, []string{"h2"})
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+	// The shared listeners hand their gRPC connections to the gRPC server
+	// and their HTTP/1.1 ones to the admin server.
+	sharedListeners, err := listen(sharedListen, sharedNextProtos)
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+	var sharedAdminListeners []net.Listener
+	for _, lis := range sharedListeners {
+		mux := newConnMux(lis)
+		grpcListeners = append(grpcListeners, mux.grpc)
+		sharedAdminListeners = append(sharedAdminListeners, mux.http)
+		go mux.serve()
 	}
 
-	q.traces[traceID] = traces
-	log.Println("Created sample trace 1:", traceID)
-}
+	usage := newUsageTracker()
+	unaryInterceptors := []grpc.UnaryServerInterceptor{usage.UnaryInterceptor}
+	streamInterceptors := []grpc.StreamServerInterceptor{usage.StreamInterceptor}
+	if *v2DeprecationWarning {
+		unaryInterceptors = append(unaryInterceptors, deprecationUnaryInterceptor)
+		streamInterceptors = append(streamInterceptors, deprecationStreamInterceptor)
+	}
+	var queryLog *queryLogger
+	if *queryLogPath != "" {
+		queryLog, err = newQueryLogger(*queryLogPath, *queryLogMinDuration)
+		if err != nil {
+			log.Fatalf("Failed to open query log: %v", err)
+		}
+		defer queryLog.Close()
+		unaryInterceptors = append(unaryInterceptors, queryLog.UnaryInterceptor)
+		streamInterceptors = append(streamInterceptors, queryLog.StreamInterceptor)
+		log.Printf("Logging query API calls to %s, replay them with query-replay --log %s\n", *queryLogPath, *queryLogPath)
+	}
+	if *slowQueryThreshold > 0 {
+		slowQueries := &slowQueryLogger{threshold: *slowQueryThreshold}
+		unaryInterceptors = append(unaryInterceptors, slowQueries.UnaryInterceptor)
+		streamInterceptors = append(streamInterceptors, slowQueries.StreamInterceptor)
+		log.Printf("Logging the searches slower than %v\n", *slowQueryThreshold)
+	}
+	// The checksum trailer lets the clients detect truncated response streams.
+	streamInterceptors = append(streamInterceptors, streamcheck.StreamServerInterceptor)
+	if *maxMessageSize < 1 {
+		log.Fatalf("Invalid --grpc-max-message-size %d", *maxMessageSize)
+	}
+	grpcServer := grpc.NewServer(
+		grpc.ForceServerCodecV2(newSpansChunkCodec()),
+		grpc.MaxSendMsgSize(*maxMessageSize),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+	queryService := NewQueryService()
+	if *maxOperations < 0 {
+		log.Fatalf("Invalid --query-max-operations %d", *maxOperations)
+	}
+	queryService.maxOperations = *maxOperations
+	queryService.maxMessageSize = *maxMessageSize
+	queryService.privacy = privacy
+	if forward.Endpoint != "" {
+		queryService.forwarder, err = newForwarder(forward)
+		if err != nil {
+			log.Fatalf("Failed to set up forwarding: %v", err)
+		}
+		log.Printf("Forwarding received spans to %s\n", forward.Endpoint)
+	}
+	var stopExpiry func()
+	if memory != (memoryOptions{}) {
+		queryService.memory, err = newMemoryLimits(memory)
+		if err != nil {
+			log.Fatalf("Invalid memory limits: %v", err)
+		}
+		if memory.TraceTTL > 0 {
+			stopExpiry = queryService.memory.expireEvery(queryService, ttlCheckInterval(memory.TraceTTL))
+		}
+	}
+	if *maxAttributeValueLength != 0 {
+		queryService.truncation, err = newTruncatePolicy(*maxAttributeValueLength)
+		if err != nil {
+			log.Fatalf("Invalid --ingest.max-attribute-value-length: %v", err)
+		}
+		log.Printf("Truncating the attribute values longer than %d bytes\n", *maxAttributeValueLength)
+	}
+	if *agentConfigPath != "" {
+		cfg, err := loadAgentConfig(*agentConfigPath)
+		if err != nil {
+			log.Fatalf("Failed to load agent config: %v", err)
+		}
+		if cfg.Throttling != nil {
+			queryService.throttler = newThrottler(cfg.Throttling)
+		}
+		queryService.baggage = cfg.Baggage
+	}
+	if *ingestFilterPath != "" {
+		cfg, err := loadIngestFilterConfig(*ingestFilterPath)
+		if err != nil {
+			log.Fatalf("Failed to load ingest filter config: %v", err)
+		}
+		queryService.ingestFilter.setConfig(cfg)
+		log.Printf("Filtering the received spans with the drop rates %v (default %v) and %d rules\n", cfg.DropRates, cfg.DefaultDropRate, len(cfg.Rules))
+	}
+	var stopTailSampler func(decidePending bool)
+	if *tailSamplingConfigPath != "" {
+		cfg, err := loadTailSamplingConfig(*tailSamplingConfigPath)
+		if err != nil {
+			log.Fatalf("Failed to load tail sampling config: %v", err)
+		}
+		queryService.tailSampler = newTailSampler(cfg)
+		stopTailSampler = queryService.tailSampler.run(queryService)
+		logTailSampling(cfg)
+	}
+	var stopPurger func()
+	if retention != (retentionPolicy{}) {
+		queryService.purger, err = newRetentionPurger(retention)
+		if err != nil {
+			log.Fatalf("Invalid retention policy: %v", err)
+		}
+		stopPurger = queryService.purger.run(queryService)
+		log.Printf("Purging the traces older than %v every %v\n", retention.MaxAge, queryService.purger.policy.Interval)
+	}
 
-func (q *QueryService) createSampleTrace2() {
-	traceID := "fedcba0987654321fedcba0987654321"
-
-	now := time.Now().Add(-5 * time.Minute)
-	startTimeNano := uint64(now.UnixNano())
-
-	traces := &trace.TracesData{
-		ResourceSpans: []*trace.ResourceSpans{
-			{
-				Resource: &resource.Resource{
-					Attributes: []*common.KeyValue{
-						stringAttr("service.name", "frontend"),
-						stringAttr("hostname", "frontend-01"),
-					},
-				},
-				ScopeSpans: []*trace.ScopeSpans{
-					{
-						Spans: []*trace.Span{
-							{
-								TraceId:           []byte(traceID)[:16],
-								SpanId:            []byte{0x44, 0x44, 0x44, 0x44, 0x44, 0x44, 0x44, 0x44},
-								Name:              "HTTP POST /api/login",
-								StartTimeUnixNano: startTimeNano,
-								EndTimeUnixNano:   startTimeNano + uint64(200*time.Millisecond),
-								Kind:              trace.Span_SPAN_KIND_SERVER,
-								Attributes: []*common.KeyValue{
-									stringAttr("http.method", "POST"),
-									stringAttr("http.url", "/api/login"),
-									intAttr("http.status_code", 200),
-								},
-							},
-						},
-					},
-				},
-			},
-			{
-				Resource: &resource.Resource{
-					Attributes: []*common.KeyValue{
-						stringAttr("service.name", "auth-service"),
-					},
-				},
-				ScopeSpans: []*trace.ScopeSpans{
-					{
-						Spans: []*trace.Span{
-							{
-								TraceId:           []byte(traceID)[:16],
-								SpanId:            []byte{0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55},
-								ParentSpanId:      []byte{0x44, 0x44, 0x44, 0x44, 0x44, 0x44, 0x44, 0x44},
-								Name:              "validate-token",
-								StartTimeUnixNano: startTimeNano + uint64(15*time.Millisecond),
-								EndTimeUnixNano:   startTimeNano + uint64(95*time.Millisecond),
-								Kind:              trace.Span_SPAN_KIND_SERVER,
-								Attributes: []*common.KeyValue{
-									stringAttr("user.email", "user@example.com"),
-								},
-							},
-						},
-					},
-				},
-			},
-		},
+	streamWriter, err := newStorageStreamWriter(queryService, streamWrite)
+	if err != nil {
+		log.Fatalf("Invalid stream write options: %v", err)
 	}
 
-	q.traces[traceID] = traces
-	log.Println("Created sample trace 2:", traceID)
-}
+	if dependencies.Interval != 0 {
+		queryService.dependencies, err = newDependencyAggregator(dependencies)
+		if err != nil {
+			log.Fatalf("Invalid dependency aggregation: %v", err)
+		}
+		log.Printf("Aggregating the dependency links every %v into the %s store\n", dependencies.Interval, dependencies.Store)
+	}
 
-func main() {
-	port := 17271
+	// The ingest anonymizer is set before any data is loaded, so that no
+	// span is stored unscrubbed.
+	if *anonymizeConfigPath != "" {
+		a, err := loadAnonymizer(*anonymizeConfigPath)
+		if err != nil {
+			log.Fatalf("Failed to load anonymizer config: %v", err)
+		}
+		switch *anonymizeOn {
+		case anonymizeOnIngest:
+			queryService.ingestAnonymizer = a
+		case anonymizeOnExport:
+			queryService.exportAnonymizer = a
+		default:
+			log.Fatalf("Invalid --anonymize-on value %q, expected %q or %q", *anonymizeOn, anonymizeOnIngest, anonymizeOnExport)
+		}
+		log.Printf("Anonymizing attributes on %s\n", *anonymizeOn)
+	}
+
+	if restored {
+		if err := queryService.restoreHandoff(handoff); err != nil {
+			log.Fatalf("Failed to restore the handed off state: %v", err)
+		}
+		log.Printf("Restored %d traces handed off by the previous process\n", len(handoff.Traces))
+	} else {
+		start := baseTime
+		if start.IsZero() {
+			start = time.Now()
+		}
+		queryService.initDemoData(start)
+	}
+
+	// The handed off state already includes the seed data and the startup
+	// changes, and the changes made at runtime that must not be reverted.
+	if *seedURL != "" && !restored {
+		err := queryService.loadSeed(context.Background(), seedOptions{
+			URL:     *seedURL,
+			SHA256:  *seedSHA256,
+			Timeout: *seedTimeout,
+		})
+		if err != nil {
+			log.Fatalf("Failed to load seed data: %v", err)
+		}
+	}
+	if *importDir != "" && !restored {
+		if err := queryService.importDir(*importDir); err != nil {
+			log.Fatalf("Failed to import the traces of --import-dir: %v", err)
+		}
+	}
+	if *otlpFile != "" && !restored {
+		if _, err := queryService.importOTLPFiles(*otlpFile); err != nil {
+			log.Fatalf("Failed to import the traces of --otlp-file: %v", err)
+		}
+	}
+
+	// The files are reloaded even with a handed off state, as they replace
+	// their traces.
+	var stopFixtures func()
+	if *fixturesDir != "" {
+		if _, err := os.Stat(*fixturesDir); err != nil {
+			log.Fatalf("Invalid --fixtures-dir: %v", err)
+		}
+		w := newFixturesWatcher(queryService, *fixturesDir, baseTime)
+		w.scan()
+		stopFixtures = w.watch(*fixturesPoll)
+		log.Printf("Watching %s for trace files\n", *fixturesDir)
+	}
+
+	if *visibilityConfigPath != "" && !restored {
+		cfg, err := loadVisibilityConfig(*visibilityConfigPath)
+		if err != nil {
+			log.Fatalf("Failed to load service visibility config: %v", err)
+		}
+		queryService.applyVisibilityConfig(cfg)
+	}
+
+	if *renameRulesPath != "" && !restored {
+		rules, err := loadRenameRules(*renameRulesPath)
+		if err != nil {
+			log.Fatalf("Failed to load rename rules: %v", err)
+		}
+		result := queryService.renameOperations(rules)
+		log.Printf("Renamed %d spans in %d traces\n", result.Spans, result.Traces)
+	}
+
+	if *queryHintsConfigPath != "" {
+		cfg, err := loadQueryHintsConfig(*queryHintsConfigPath)
+		if err != nil {
+			log.Fatalf("Failed to load query hints config: %v", err)
+		}
+		queryService.hintsConfig = cfg
+	}
+
+	if regex.Enabled {
+		matcher, err := newRegexMatcher(regex)
+		if err != nil {
+			log.Fatalf("Invalid regular expression options: %v", err)
+		}
+		queryService.regex = matcher
+	}
+
+	// The first run aggregates the links of all the spans loaded so far.
+	var stopDependencies func()
+	if queryService.dependencies != nil {
+		stopDependencies = queryService.dependencies.run(queryService)
+	}
 
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	var adminServer *http.Server
+	adminListeners, err := listen(adminListen, []string{"http/1.1"})
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
+	adminListeners = append(adminListeners, sharedAdminListeners...)
+	if len(adminListeners) > 0 {
+		adminServer = serveAdmin(adminListeners, newAdminHandler(queryService, usage, privacy))
+	}
 
-	grpcServer := grpc.NewServer()
-	queryService := NewQueryService()
-	queryService.initDemoData()
+	var debugServer *http.Server
+	var debugListener net.Listener
+	if *debugListen != "" {
+		spec, err := parseListenSpec(*debugListen)
+		if err != nil {
+			log.Fatalf("Invalid --debug-listen: %v", err)
+		}
+		debugListener, err = spec.listen([]string{"http/1.1"})
+		if err != nil {
+			log.Fatalf("Failed to listen: %v", err)
+		}
+		publishDebugVars(queryService)
+		debugServer = serveDebug(debugListener, newDebugHandler())
+	}
+
+	var agentConn net.PacketConn
+	if *agentPort != 0 {
+		agentConn, err = net.ListenPacket("udp", fmt.Sprintf(":%d", *agentPort))
+		if err != nil {
+			log.Fatalf("Failed to listen: %v", err)
+		}
+		go serveAgent(agentConn, queryService)
+	}
+
+	var handoffListener net.Listener
+	var handoffc <-chan net.Conn
+	if *handoffSocket != "" {
+		handoffListener, err = net.Listen("unix", *handoffSocket)
+		if err != nil {
+			log.Fatalf("Failed to listen: %v", err)
+		}
+		handoffc = acceptHandoff(handoffListener)
+	}
 
 	// Register the Query Service (api_v3)
 	api_v3.RegisterQueryServiceServer(grpcServer, queryService)
 
-	// Register gRPC reflection service
-	reflection.Register(grpcServer)
+	// Register the legacy Query Service (api_v2)
+	api_v2.RegisterQueryServiceServer(grpcServer, &queryServiceV2{q: queryService})
+	api_v2.RegisterCollectorServiceServer(grpcServer, &collectorServiceV2{q: queryService})
+
+	// Register the remote storage API (storage v2 readers, the OTLP trace
+	// writer and the storage v2 stream writer)
+	storagev2.RegisterTraceReaderServer(grpcServer, &storageTraceReader{q: queryService})
+	storagev2.RegisterDependencyReaderServer(grpcServer, &storageDependencyReader{q: queryService})
+	collectortrace.RegisterTraceServiceServer(grpcServer, &storageTraceWriter{q: queryService})
+	storagev2.RegisterTraceWriterServer(grpcServer, streamWriter)
+
+	// Register the channelz service, for the live inspection of the
+	// connections and streams
+	channelz.RegisterChannelzServiceToServer(grpcServer)
 
-	log.Printf("Jaeger Query Service (api_v3) listening on port %d\n", port)
+	// Register gRPC reflection service, if enabled
+	if *enableReflection {
+		reflection.Register(grpcServer)
+	}
+
+	for _, lis := range grpcListeners {
+		log.Printf("Jaeger Query Service (api_v3 and api_v2) listening on %s\n", lis.Addr())
+	}
+	grpcAddr := displayAddr(grpcListeners[0])
 	log.Println("Using OpenTelemetry Protocol (OTLP) format for traces")
 	log.Println()
-	log.Println("✓ gRPC Reflection enabled")
+	// Without reflection, grpcurl reads the services from the compiled
+	// descriptors of the repository.
+	grpcurl := "grpcurl -plaintext -protoset descriptors/jaeger.binpb"
+	if *enableReflection {
+		log.Println("✓ gRPC Reflection enabled")
+		grpcurl = "grpcurl -plaintext"
+	} else {
+		log.Println("gRPC Reflection disabled, enable it with --enable-reflection")
+	}
 	log.Println()
 	log.Println("To list available services:")
-	log.Println("  grpcurl -plaintext localhost:17271 list")
+	log.Printf("  %s %s list\n", grpcurl, grpcAddr)
 	log.Println()
 	log.Println("To list methods:")
-	log.Println("  grpcurl -plaintext localhost:17271 list jaeger.api_v3.QueryService")
+	log.Printf("  %s %s list jaeger.api_v3.QueryService\n", grpcurl, grpcAddr)
 	log.Println()
 	log.Println("To call GetServices:")
-	log.Println("  grpcurl -plaintext localhost:17271 jaeger.api_v3.QueryService/GetServices")
+	log.Printf("  %s %s jaeger.api_v3.QueryService/GetServices\n", grpcurl, grpcAddr)
 	log.Println()
 	log.Println("To call GetOperations:")
-	log.Println("  grpcurl -plaintext -d '{\"service\": \"frontend\"}' localhost:17271 jaeger.api_v3.QueryService/GetOperations")
+	log.Printf("  %s -d '{\"service\": \"frontend\"}' %s jaeger.api_v3.QueryService/GetOperations\n", grpcurl, grpcAddr)
+	log.Println()
+	log.Println("To call GetDependencies:")
+	log.Printf("  %s -d '{\"start_time\": \"2026-01-01T00:00:00Z\", \"end_time\": \"2030-01-01T00:00:00Z\"}' %s jaeger.api_v3.QueryService/GetDependencies\n", grpcurl, grpcAddr)
+	log.Println()
+	log.Println("To get several traces at once with the api_v2 GetTrace:")
+	log.Printf("  %s -H '%s: fedcba0987654321fedcba0987654321' -d '{\"trace_id\": \"EjRWeJCrze8SNFZ4kKvN7w==\"}' %s jaeger.api_v2.QueryService/GetTrace\n", grpcurl, batchTraceIDsHeader, grpcAddr)
+	log.Println()
+	if *enableReflection {
+		log.Println("To inspect the connections and streams with channelz:")
+		log.Printf("  %s %s grpc.channelz.v1.Channelz/GetServers\n", grpcurl, grpcAddr)
+		log.Println()
+	}
+	log.Println("To load synthetic traces over OTLP (or --protocol api_v2 for PostSpans, stream for WriteTraces):")
+	log.Printf("  go run ./cmd/tracegen --target %s --traces 1000\n", grpcAddr)
+	log.Println()
+	log.Println("To use this server as the backend of a Jaeger v2 query service or collector,")
+	log.Println("configure a grpc storage with the endpoint:")
+	log.Printf("  %s\n", grpcAddr)
 	log.Println()
+	if len(adminListeners) > 0 {
+		adminAddr := displayAddr(adminListeners[0])
+		log.Println("To get the retention report:")
+		log.Printf("  curl '%s/api/admin/retention?ttl=24h&maxSpans=100000'\n", adminAddr)
+		log.Println("To search for traces with numeric attribute predicates:")
+		log.Printf("  curl '%s/api/traces?service=frontend&tag=http.status_code:>=500'\n", adminAddr)
+		log.Println("To find the traces where a frontend span leads to a failing database call (experimental):")
+		log.Printf("  curl -X POST -d '{\"ancestor\": {\"service\": \"frontend\"}, \"descendant\": {\"service\": \"database\", \"attributes\": {\"error\": \"true\"}}}' %s/api/experimental/query\n", adminAddr)
+		log.Println("To get the statistics of a trace:")
+		log.Printf("  curl %s/api/traces/1234567890abcdef1234567890abcdef/stats\n", adminAddr)
+		log.Println("To see where the time of a trace went, by service:")
+		log.Printf("  curl %s/api/traces/1234567890abcdef1234567890abcdef/breakdown\n", adminAddr)
+		log.Println("To compare two traces:")
+		log.Printf("  curl %s/api/traces/1234567890abcdef1234567890abcdef/diff/fedcba0987654321fedcba0987654321\n", adminAddr)
+		log.Println("To compare the latency and error rate of the operations before and after a deployment:")
+		log.Printf("  curl '%s/api/operations/compare?deploy=2026-01-01T12:00:00Z&window=1h'\n", adminAddr)
+		log.Println("To debug the stored representation of a trace (format=hex or protojson):")
+		log.Printf("  curl '%s/api/admin/traces/1234567890abcdef1234567890abcdef/raw?format=protojson'\n", adminAddr)
+		log.Println("To get the number and size of the stored traces:")
+		log.Printf("  curl %s/api/admin/store\n", adminAddr)
+		log.Println("To delete a trace, or all of them to reset the demo between tests:")
+		log.Printf("  curl -X DELETE %s/api/admin/traces/1234567890abcdef1234567890abcdef\n", adminAddr)
+		log.Printf("  curl -X DELETE %s/api/admin/traces\n", adminAddr)
+		log.Println("To export the stored traces, e.g. to seed another instance with --seed-url:")
+		log.Printf("  curl -o traces.tar.gz '%s/api/admin/export?start=2026-01-01T00:00:00Z&end=2026-01-02T00:00:00Z'\n", adminAddr)
+		log.Println("To get the API usage report, with the latency of each RPC:")
+		log.Printf("  curl %s/api/admin/usage\n", adminAddr)
+		log.Println("To send Zipkin JSON v2 spans, point the Zipkin reporter at:")
+		log.Printf("  http://%s/api/v2/spans\n", adminAddr)
+		log.Println("To hide a service from GetServices:")
+		log.Printf("  curl -X PUT -d '{\"state\": \"hidden\"}' %s/api/admin/services/database/visibility\n", adminAddr)
+		if queryService.purger != nil {
+			log.Println("To check the purges of the retention policy:")
+			log.Printf("  curl %s/api/admin/retention/purges\n", adminAddr)
+		}
+		if queryService.forwarder != nil {
+			log.Println("To check the forwarding of spans:")
+			log.Printf("  curl %s/api/admin/forwarding\n", adminAddr)
+		}
+		log.Println("To drop 90% of the spans of a service on ingest:")
+		log.Printf("  curl -X PUT -d '{\"dropRates\": {\"frontend\": 0.9}}' %s/api/admin/ingest/filter\n", adminAddr)
+		if queryService.dependencies != nil {
+			log.Println("To check the aggregation of the dependency links:")
+			log.Printf("  curl %s/api/admin/dependencies/aggregation\n", adminAddr)
+		}
+		if queryService.tailSampler != nil {
+			log.Println("To check the decisions of the tail sampling:")
+			log.Printf("  curl %s/api/admin/sampling/tail\n", adminAddr)
+		}
+		if queryService.throttler != nil {
+			log.Println("To get the throttling credits of a client library:")
+			log.Printf("  curl '%s/credits?service=frontend&uuid=client-1&operations=GET%%20/users'\n", adminAddr)
+		}
+		if queryService.baggage != nil {
+			log.Println("To get the baggage restrictions of a service:")
+			log.Printf("  curl '%s/baggageRestrictions?service=frontend'\n", adminAddr)
+		}
+		log.Println("To rename an operation (add \"dryRun\": true to preview):")
+		log.Printf("  curl -X POST -d '{\"rules\": [{\"service\": \"frontend\", \"from\": \"HTTP GET /api/users\", \"to\": \"GET /users\"}]}' %s/api/admin/operations/rename\n", adminAddr)
+		log.Println()
+	}
+	if debugListener != nil {
+		debugAddr := displayAddr(debugListener)
+		log.Println("To get the store size, goroutines and ingest rate:")
+		log.Printf("  curl %s/debug/vars\n", debugAddr)
+		log.Println("To profile the CPU for 30 seconds:")
+		log.Printf("  go tool pprof 'http://%s/debug/pprof/profile?seconds=30'\n", debugAddr)
+		log.Println()
+	}
+	if agentConn != nil {
+		log.Printf("Jaeger agent emulator (jaeger.thrift compact) listening on udp %s\n", agentConn.LocalAddr())
+		log.Println("To report from a Jaeger client library, set:")
+		log.Printf("  JAEGER_AGENT_HOST=localhost JAEGER_AGENT_PORT=%d\n", *agentPort)
+		log.Println()
+	}
+	if handoffListener != nil {
+		log.Printf("Handoff socket listening on %s\n", *handoffSocket)
+		log.Println("To restart without losing data, start the new binary with the same flags")
+		log.Println()
+	}
 	log.Println("Sample data:")
 	log.Println("  - Services: frontend, auth-service, database")
 	log.Println("  - 2 sample traces with OTLP format spans")
 	log.Println()
 
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+	errc := make(chan error, len(grpcListeners))
+	for _, lis := range grpcListeners {
+		go func() {
+			errc <- grpcServer.Serve(lis)
+		}()
+	}
+
+	// shutdown finishes the in-flight requests before returning, so that a
+	// process manager can hand inherited sockets over to a new instance
+	// without dropping requests. When handing off, the pending traces of the
+	// tail sampler are left undecided, for the new process.
+	shutdown := func(handingOff bool) {
+		if stopFixtures != nil {
+			stopFixtures()
+		}
+		if stopPurger != nil {
+			stopPurger()
+		}
+		if stopExpiry != nil {
+			stopExpiry()
+		}
+		if handoffListener != nil {
+			handoffListener.Close()
+		}
+		if agentConn != nil {
+			agentConn.Close()
+		}
+		if adminServer != nil {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			adminServer.Shutdown(shutdownCtx)
+		}
+		if debugServer != nil {
+			debugServer.Close()
+		}
+		grpcServer.GracefulStop()
+		// the pending traces are decided once no more spans are received,
+		// before the forwarder is flushed
+		if stopTailSampler != nil {
+			stopTailSampler(!handingOff)
+		}
+		// the last window includes the spans received until the server stopped
+		if stopDependencies != nil {
+			stopDependencies()
+		}
+		if queryService.forwarder != nil {
+			forwardCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			queryService.forwarder.Close(forwardCtx)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+	select {
+	case err := <-errc:
+		if err != nil {
+			log.Fatalf("Failed to serve: %v", err)
+		}
+	case <-ctx.Done():
+		log.Println("Shutting down...")
+		shutdown(false)
+	case conn := <-handoffc:
+		log.Println("Handing off to a new process...")
+		if err := queryService.handOff(conn, func() { shutdown(true) }); err != nil {
+			log.Fatalf("Failed to hand off: %v", err)
+		}
 	}
 }
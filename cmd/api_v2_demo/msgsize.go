@@ -0,0 +1,110 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"strconv"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// defaultMaxMessageSize is the default max receive message size of the gRPC
+// clients, and so of the messages sent by the server.
+const defaultMaxMessageSize = 4 << 20
+
+// maxMessageSizeHeader lets a client lower the maximum size of the messages
+// of a response to its own max receive message size, when it is below the
+// one of the server, for example
+//
+//	grpcurl -H 'x-jaeger-max-message-size: 1048576' ...
+//
+// The traces that do not fit in a message are split into consecutive
+// messages, as the storage API allows, instead of failing the stream with
+// ResourceExhausted.
+const maxMessageSizeHeader = "x-jaeger-max-message-size"
+
+// messageSizeLimit returns the maximum size of the response messages of the
+// call: the one of the server, or the one of the request header if lower.
+func (q *QueryService) messageSizeLimit(ctx context.Context) (int, error) {
+	limit := q.maxMessageSize
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(maxMessageSizeHeader); len(values) > 0 {
+		n, err := strconv.Atoi(values[0])
+		if err != nil || n < 1 {
+			return 0, fmt.Errorf("invalid %s %q, expected a positive number of bytes", maxMessageSizeHeader, values[0])
+		}
+		limit = min(limit, n)
+	}
+	return limit, nil
+}
+
+// splitTraces splits td into consecutive messages of at most maxSize bytes,
+// keeping the order of the spans and repeating their resource and scope in
+// each message. The sizes of the resource and scope spans are estimated from
+// above, with the longest length prefixes. A span that does not fit in a
+// message by itself is sent alone.
+func splitTraces(td *trace.TracesData, maxSize int) []*trace.TracesData {
+	total := proto.Size(td)
+	if total <= maxSize {
+		return []*trace.TracesData{td}
+	}
+	var chunks []*trace.TracesData
+	chunk, size := &trace.TracesData{}, 0
+	for _, rs := range td.ResourceSpans {
+		rsSize := embeddedSizeBound(&trace.ResourceSpans{Resource: rs.Resource, SchemaUrl: rs.SchemaUrl})
+		var targetResource *trace.ResourceSpans
+		for _, ss := range rs.ScopeSpans {
+			ssSize := embeddedSizeBound(&trace.ScopeSpans{Scope: ss.Scope, SchemaUrl: ss.SchemaUrl})
+			var targetScope *trace.ScopeSpans
+			for _, span := range ss.Spans {
+				n := proto.Size(span)
+				n += 1 + uvarintSize(uint64(n))
+				if targetScope == nil {
+					n += ssSize
+				}
+				if targetResource == nil {
+					n += rsSize
+				}
+				if size > 0 && size+n > maxSize {
+					chunks = append(chunks, chunk)
+					if targetScope != nil {
+						n += ssSize
+					}
+					if targetResource != nil {
+						n += rsSize
+					}
+					chunk, size, targetResource, targetScope = &trace.TracesData{}, 0, nil, nil
+				}
+				if targetResource == nil {
+					targetResource = &trace.ResourceSpans{Resource: rs.Resource, SchemaUrl: rs.SchemaUrl}
+					chunk.ResourceSpans = append(chunk.ResourceSpans, targetResource)
+				}
+				if targetScope == nil {
+					targetScope = &trace.ScopeSpans{Scope: ss.Scope, SchemaUrl: ss.SchemaUrl}
+					targetResource.ScopeSpans = append(targetResource.ScopeSpans, targetScope)
+				}
+				targetScope.Spans = append(targetScope.Spans, span)
+				size += n
+			}
+		}
+	}
+	if size > 0 {
+		chunks = append(chunks, chunk)
+	}
+	log.Printf("Split a trace of %d bytes into %d messages of at most %d bytes\n", total, len(chunks), maxSize)
+	return chunks
+}
+
+// embeddedSizeBound returns the largest size of m as a field of its parent
+// message, with the tag and the longest length prefix.
+func embeddedSizeBound(m proto.Message) int {
+	return 1 + binary.MaxVarintLen32 + proto.Size(m)
+}
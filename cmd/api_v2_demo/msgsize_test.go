@@ -0,0 +1,126 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	common "go.opentelemetry.io/proto/otlp/common/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+)
+
+// bulkyTrace returns the spans of trace 0 in batches of 2 services, each
+// span with an attribute of 1000 bytes.
+func bulkyTrace() *trace.TracesData {
+	td := &trace.TracesData{}
+	for service := range 2 {
+		for span := range 3 {
+			batch := testBatch(service, 0, 3*service+span)
+			rs := batch.ResourceSpans[0]
+			spans := rs.ScopeSpans[0].Spans[:1]
+			spans[0].Attributes = append(spans[0].Attributes, &common.KeyValue{Key: "payload", Value: stringValue(strings.Repeat("x", 1000))})
+			rs.ScopeSpans[0].Spans = spans
+			td.ResourceSpans = append(td.ResourceSpans, rs)
+		}
+	}
+	return td
+}
+
+func spanIDs(chunks ...*trace.TracesData) []string {
+	var ids []string
+	for _, td := range chunks {
+		forEachSpan(td, func(_ string, span *trace.Span) {
+			ids = append(ids, hex.EncodeToString(span.SpanId))
+		})
+	}
+	return ids
+}
+
+func TestSplitTraces(t *testing.T) {
+	td := bulkyTrace()
+	assert.Equal(t, []*trace.TracesData{td}, splitTraces(td, proto.Size(td)))
+
+	chunks := splitTraces(td, 2500)
+	require.Len(t, chunks, 3)
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, proto.Size(chunk), 2500)
+		assert.Equal(t, 2, countSpans(chunk))
+	}
+	assert.Equal(t, spanIDs(td), spanIDs(chunks...), "the spans keep their order")
+	assert.Equal(t, "service-0", getServiceName(chunks[1].ResourceSpans[0].Resource))
+	assert.Equal(t, "service-1", getServiceName(chunks[1].ResourceSpans[1].Resource))
+
+	chunks = splitTraces(td, 100)
+	assert.Len(t, chunks, 6, "the spans larger than the max size are sent alone")
+}
+
+func TestMessageSizeLimit(t *testing.T) {
+	q := NewQueryService()
+	td := bulkyTrace()
+	require.Empty(t, q.importTraces(td))
+	conn := newTestConn(t, q)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), maxMessageSizeHeader, "2500")
+
+	recvAll := func(recv func() (proto.Message, error)) ([]proto.Message, error) {
+		var msgs []proto.Message
+		for {
+			msg, err := recv()
+			if errors.Is(err, io.EOF) {
+				return msgs, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			assert.LessOrEqual(t, proto.Size(msg), 2500)
+			msgs = append(msgs, msg)
+		}
+	}
+
+	v3, err := api_v3.NewQueryServiceClient(conn).GetTrace(ctx, &api_v3.GetTraceRequest{TraceId: hex.EncodeToString(testTraceID(0)), RawTraces: true})
+	require.NoError(t, err)
+	msgs, err := recvAll(func() (proto.Message, error) { return v3.Recv() })
+	require.NoError(t, err)
+	assert.Len(t, msgs, 3)
+
+	storage, err := storagev2.NewTraceReaderClient(conn).GetTraces(ctx, &storagev2.GetTracesRequest{
+		Query: []*storagev2.GetTraceParams{{TraceId: testTraceID(0)}},
+	})
+	require.NoError(t, err)
+	msgs, err = recvAll(func() (proto.Message, error) { return storage.Recv() })
+	require.NoError(t, err)
+	assert.Len(t, msgs, 3)
+
+	v2, err := api_v2.NewQueryServiceClient(conn).GetTrace(ctx, &api_v2.GetTraceRequest{TraceId: testTraceID(0)})
+	require.NoError(t, err)
+	msgs, err = recvAll(func() (proto.Message, error) { return v2.Recv() })
+	require.NoError(t, err)
+	spans := 0
+	for _, msg := range msgs {
+		spans += len(msg.(*api_v2.SpansResponseChunk).Spans)
+	}
+	assert.Equal(t, 6, spans)
+	assert.Greater(t, len(msgs), 1)
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), maxMessageSizeHeader, "0")
+	v3, err = api_v3.NewQueryServiceClient(conn).GetTrace(ctx, &api_v3.GetTraceRequest{TraceId: hex.EncodeToString(testTraceID(0))})
+	require.NoError(t, err)
+	_, err = v3.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
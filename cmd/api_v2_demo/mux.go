@@ -0,0 +1,167 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"log"
+	"net"
+	"sync"
+	"time"
+)
+
+// http2Preface starts every HTTP/2 connection, and so every gRPC connection.
+const http2Preface = "PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n"
+
+// sharedNextProtos are the ALPN protocols of the shared TLS listeners. The
+// server prefers http/1.1, so that HTTP clients offering both protocols, such
+// as curl and browsers, reach the HTTP endpoints, while gRPC clients only
+// offer h2.
+var sharedNextProtos = []string{"http/1.1", "h2"}
+
+// prefaceTimeout bounds the wait for the first bytes of a connection, as the
+// ReadHeaderTimeout of the HTTP servers.
+const prefaceTimeout = 10 * time.Second
+
+// connMux serves gRPC and HTTP/1.1 on the same listener, like cmux: the
+// connections starting with the HTTP/2 preface are accepted by the gRPC
+// listener, the other ones by the HTTP listener. With TLS, the connections
+// are told apart after the handshake, so both servers share the certificate
+// and the client CA of the listener.
+type connMux struct {
+	root net.Listener
+	grpc *muxListener
+	http *muxListener
+
+	mu sync.Mutex
+	// open is the number of child listeners not closed yet; the root
+	// listener is closed with the last one.
+	open int
+}
+
+func newConnMux(root net.Listener) *connMux {
+	m := &connMux{root: root, open: 2}
+	m.grpc = m.newListener()
+	m.http = m.newListener()
+	return m
+}
+
+func (m *connMux) newListener() *muxListener {
+	return &muxListener{mux: m, conns: make(chan net.Conn), done: make(chan struct{})}
+}
+
+// serve accepts the connections of the root listener until it is closed.
+func (m *connMux) serve() {
+	for {
+		conn, err := m.root.Accept()
+		if err != nil {
+			m.grpc.fail(err)
+			m.http.fail(err)
+			return
+		}
+		go m.dispatch(conn)
+	}
+}
+
+// dispatch hands the connection to the listener of its protocol, once its
+// first bytes have told it apart.
+func (m *connMux) dispatch(conn net.Conn) {
+	pc := &peekedConn{Conn: conn, r: bufio.NewReaderSize(conn, len(http2Preface))}
+	conn.SetReadDeadline(time.Now().Add(prefaceTimeout))
+	isGRPC, err := pc.hasPrefix(http2Preface)
+	conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		log.Printf("Closing connection from %s: %v\n", conn.RemoteAddr(), err)
+		conn.Close()
+		return
+	}
+	target := m.http
+	if isGRPC {
+		target = m.grpc
+	}
+	select {
+	case target.conns <- pc:
+	case <-target.done:
+		conn.Close()
+	}
+}
+
+func (m *connMux) closeChild() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.open--
+	if m.open == 0 {
+		return m.root.Close()
+	}
+	return nil
+}
+
+// muxListener is the listener of one protocol of a connMux.
+type muxListener struct {
+	mux   *connMux
+	conns chan net.Conn
+	done  chan struct{}
+
+	once sync.Once
+	// err is the error of the root listener, set before done is closed.
+	err error
+}
+
+func (l *muxListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		if l.err != nil {
+			return nil, l.err
+		}
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *muxListener) Close() error {
+	var err error
+	l.once.Do(func() {
+		close(l.done)
+		err = l.mux.closeChild()
+	})
+	return err
+}
+
+func (l *muxListener) Addr() net.Addr {
+	return l.mux.root.Addr()
+}
+
+// fail stops the listener after the root listener failed.
+func (l *muxListener) fail(err error) {
+	l.once.Do(func() {
+		l.err = err
+		close(l.done)
+	})
+}
+
+// peekedConn is a connection whose first bytes were read ahead.
+type peekedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *peekedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// hasPrefix reads the first bytes of the connection until they differ from
+// prefix, without consuming them.
+func (c *peekedConn) hasPrefix(prefix string) (bool, error) {
+	for n := 1; n <= len(prefix); n++ {
+		b, err := c.r.Peek(n)
+		if err != nil {
+			return false, err
+		}
+		if b[n-1] != prefix[n-1] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+// serveShared serves the query service and an HTTP handler on a shared
+// listener of spec, and returns its address.
+func serveShared(t *testing.T, spec listenSpec) string {
+	listeners, err := listen([]listenSpec{spec}, sharedNextProtos)
+	require.NoError(t, err)
+	mux := newConnMux(listeners[0])
+	go mux.serve()
+
+	q := NewQueryService()
+	require.Empty(t, q.importTraces(testBatch(0, 0, 0)))
+	grpcServer := grpc.NewServer()
+	api_v3.RegisterQueryServiceServer(grpcServer, q)
+	go grpcServer.Serve(mux.grpc)
+	httpServer := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, r.Proto)
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+	go httpServer.Serve(mux.http)
+	t.Cleanup(func() {
+		httpServer.Close()
+		grpcServer.Stop()
+	})
+	return listeners[0].Addr().String()
+}
+
+func assertSharedListener(t *testing.T, addr string, creds credentials.TransportCredentials, client *http.Client, url string) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	require.NoError(t, err)
+	defer conn.Close()
+	services, err := api_v3.NewQueryServiceClient(conn).GetServices(context.Background(), &api_v3.GetServicesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"service-0"}, services.Services)
+
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "HTTP/1.1", string(body))
+}
+
+func TestConnMux(t *testing.T) {
+	addr := serveShared(t, listenSpec{Addr: "127.0.0.1:0"})
+	assertSharedListener(t, addr, insecure.NewCredentials(), http.DefaultClient, "http://"+addr)
+}
+
+func TestConnMuxTLS(t *testing.T) {
+	spec, pool := testCertificate(t)
+	addr := serveShared(t, spec)
+	// the HTTP client offers both h2 and http/1.1
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{RootCAs: pool},
+		ForceAttemptHTTP2: true,
+	}}
+	defer client.CloseIdleConnections()
+	assertSharedListener(t, addr, credentials.NewClientTLSFromCert(pool, ""), client, "https://"+addr)
+}
+
+func TestConnMuxClose(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	mux := newConnMux(lis)
+	go mux.serve()
+
+	require.NoError(t, mux.grpc.Close())
+	_, err = mux.grpc.Accept()
+	require.ErrorIs(t, err, net.ErrClosed)
+	conn, err := net.Dial("tcp", lis.Addr().String())
+	require.NoError(t, err, "the HTTP listener is still open")
+	conn.Close()
+
+	require.NoError(t, mux.http.Close())
+	assert.Eventually(t, func() bool {
+		_, err := mux.http.Accept()
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+	_, err = net.Dial("tcp", lis.Addr().String())
+	assert.Error(t, err, "the root listener is closed with the last child")
+}
+
+// testCertificate returns the spec of a TLS listener on localhost with a
+// self-signed certificate, and the pool trusting it.
+func testCertificate(t *testing.T) (listenSpec, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	spec := listenSpec{
+		Addr:     "127.0.0.1:0",
+		CertFile: filepath.Join(dir, "cert.pem"),
+		KeyFile:  filepath.Join(dir, "key.pem"),
+	}
+	require.NoError(t, os.WriteFile(spec.CertFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(spec.KeyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return spec, pool
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// maxOTLPLineSize bounds the lines of the OTLP files, each holding one
+// exported batch.
+const maxOTLPLineSize = 64 << 20
+
+// maxLoggedInvalidLines bounds the invalid lines logged per file.
+const maxLoggedInvalidLines = 10
+
+// otlpFileStats counts the lines of the OTLP files and their spans.
+type otlpFileStats struct {
+	Files    int
+	Lines    int
+	Spans    int
+	Rejected int
+	// Skipped counts the lines without spans, e.g. the metrics and logs
+	// written to the same file.
+	Skipped int
+	// Invalid counts the lines that are not OTLP JSON, e.g. the last line
+	// of a file captured while it was being written.
+	Invalid int
+}
+
+// importOTLPFiles imports the traces of the files matching the glob
+// pattern, written by the file exporter of the OpenTelemetry Collector:
+// one OTLP ExportTraceServiceRequest in OTLP/JSON per line, the same
+// fields as TracesData, possibly gzipped. The files are streamed, a line
+// at a time. It fails if no spans are imported.
+func (q *QueryService) importOTLPFiles(pattern string) (otlpFileStats, error) {
+	var stats otlpFileStats
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return stats, err
+	}
+	if len(paths) == 0 {
+		return stats, fmt.Errorf("no files match %s", pattern)
+	}
+	for _, path := range paths {
+		if err := q.importOTLPFile(path, &stats); err != nil {
+			return stats, err
+		}
+	}
+	if stats.Spans == 0 {
+		return stats, fmt.Errorf("no spans found in %s", pattern)
+	}
+	log.Printf("Imported %d spans from %d lines of %d OTLP files, rejected %d invalid spans, skipped %d lines without spans and %d invalid lines\n",
+		stats.Spans, stats.Lines, stats.Files, stats.Rejected, stats.Skipped, stats.Invalid)
+	return stats, nil
+}
+
+func (q *QueryService) importOTLPFile(path string, stats *otlpFileStats) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r, err := otlpFileReader(f)
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %w", path, err)
+	}
+	stats.Files++
+
+	unmarshal := protojson.UnmarshalOptions{DiscardUnknown: true}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxOTLPLineSize)
+	invalid := 0
+	for n := 1; scanner.Scan(); n++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		stats.Lines++
+		td := &trace.TracesData{}
+		err := unmarshal.Unmarshal(line, td)
+		if err == nil {
+			err = decodeOTLPJSONIDs(td)
+		}
+		if err != nil {
+			if invalid++; invalid <= maxLoggedInvalidLines {
+				log.Printf("Skipped invalid line %d of %s: %v\n", n, path, err)
+			}
+			stats.Invalid++
+			continue
+		}
+		spans := countSpans(td)
+		if spans == 0 {
+			stats.Skipped++
+			continue
+		}
+		rejected := q.importTraces(td)
+		for _, err := range rejected {
+			log.Printf("Rejected span from line %d of %s: %v\n", n, path, err)
+		}
+		stats.Spans += spans - len(rejected)
+		stats.Rejected += len(rejected)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("cannot read %s: %w", path, err)
+	}
+	return nil
+}
+
+// otlpFileReader returns the content of f, decompressed if it is gzipped.
+// The zstd compression of the file exporter is not supported.
+func otlpFileReader(f io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(f)
+	magic, err := br.Peek(4)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		return gzip.NewReader(br)
+	case bytes.Equal(magic, []byte{0x28, 0xb5, 0x2f, 0xfd}):
+		return nil, errors.New("zstd compressed files are not supported, decompress the file first, e.g. with zstd -d")
+	}
+	return br, nil
+}
+
+// decodeOTLPJSONIDs decodes the trace and span IDs of td, which OTLP/JSON
+// writes in hex while protojson reads bytes in base64. The hex digits are
+// base64 digits, so the IDs read as base64 are encoded back to their text.
+func decodeOTLPJSONIDs(td *trace.TracesData) error {
+	decode := func(id *[]byte) error {
+		if len(*id) == 0 {
+			return nil
+		}
+		b, err := hex.DecodeString(base64.StdEncoding.EncodeToString(*id))
+		if err != nil {
+			return fmt.Errorf("invalid hex ID: %w", err)
+		}
+		*id = b
+		return nil
+	}
+	for _, rs := range td.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				ids := []*[]byte{&span.TraceId, &span.SpanId, &span.ParentSpanId}
+				for _, link := range span.Links {
+					ids = append(ids, &link.TraceId, &link.SpanId)
+				}
+				for _, id := range ids {
+					if err := decode(id); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
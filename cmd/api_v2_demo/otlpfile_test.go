@@ -0,0 +1,86 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// otlpJSON returns td in OTLP/JSON, with hex trace and span IDs.
+func otlpJSON(t *testing.T, td *trace.TracesData) []byte {
+	td = proto.CloneOf(td)
+	for _, rs := range td.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				for _, id := range []*[]byte{&span.TraceId, &span.SpanId, &span.ParentSpanId} {
+					b, err := base64.StdEncoding.DecodeString(hex.EncodeToString(*id))
+					require.NoError(t, err)
+					*id = b
+				}
+			}
+		}
+	}
+	b, err := protojson.Marshal(td)
+	require.NoError(t, err)
+	return b
+}
+
+func TestImportOTLPFiles(t *testing.T) {
+	dir := t.TempDir()
+	first := otlpJSON(t, testBatch(0, 0, 0))
+	second := otlpJSON(t, testBatch(1, 0, 1))
+	require.Contains(t, string(first), hex.EncodeToString(testTraceID(0)))
+
+	var lines bytes.Buffer
+	lines.Write(first)
+	lines.WriteString("\n\n")
+	lines.WriteString(`{"resourceMetrics": [{"scopeMetrics": []}]}` + "\n")
+	lines.Write(second[:len(second)/2])
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "traces.jsonl"), lines.Bytes(), 0o644))
+	var rotated bytes.Buffer
+	zw := gzip.NewWriter(&rotated)
+	zw.Write(second)
+	require.NoError(t, zw.Close())
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "traces-1.jsonl.gz"), rotated.Bytes(), 0o644))
+
+	q := NewQueryService()
+	stats, err := q.importOTLPFiles(filepath.Join(dir, "traces*"))
+	require.NoError(t, err)
+	assert.Equal(t, otlpFileStats{Files: 2, Lines: 4, Spans: 2 * testTraces, Skipped: 1, Invalid: 1}, stats)
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	require.Len(t, q.traces, testTraces)
+	require.Contains(t, q.traces, hex.EncodeToString(testTraceID(0)))
+	for _, td := range q.traces {
+		assert.Equal(t, 2, countSpans(td))
+	}
+}
+
+func TestImportOTLPFilesErrors(t *testing.T) {
+	dir := t.TempDir()
+	q := NewQueryService()
+	_, err := q.importOTLPFiles(filepath.Join(dir, "*.jsonl"))
+	assert.ErrorContains(t, err, "no files match")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "metrics.jsonl"), []byte(`{"resourceMetrics": []}`+"\n"), 0o644))
+	_, err = q.importOTLPFiles(filepath.Join(dir, "metrics.jsonl"))
+	assert.ErrorContains(t, err, "no spans found")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "traces.jsonl.zst"), []byte{0x28, 0xb5, 0x2f, 0xfd, 0}, 0o644))
+	_, err = q.importOTLPFiles(filepath.Join(dir, "traces.jsonl.zst"))
+	assert.ErrorContains(t, err, "zstd")
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"sync"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+)
+
+// tenantHeader is the HTTP header, or the gRPC metadata key, identifying the
+// tenant, as used by Jaeger multi-tenancy.
+const tenantHeader = "x-tenant"
+
+// privacyConfig describes the differential privacy settings of aggregate endpoints.
+//
+// Example:
+//
+//	{
+//	  "default": {"epsilon": 1.0},
+//	  "tenants": {"acme": {"epsilon": 0.1, "sensitivity": 5}, "internal": {"disabled": true}}
+//	}
+type privacyConfig struct {
+	Default *noiseConfig           `json:"default,omitempty"`
+	Tenants map[string]noiseConfig `json:"tenants,omitempty"`
+
+	// uniform, if set, replaces the global random source of the noise.
+	uniform func() float64
+}
+
+// noiseConfig calibrates the Laplace noise added to aggregate values.
+type noiseConfig struct {
+	// Epsilon is the privacy budget of a single query; smaller means more noise.
+	Epsilon float64 `json:"epsilon"`
+	// Sensitivity is the largest change a single trace can cause in an aggregate
+	// value. Defaults to 1.
+	Sensitivity float64 `json:"sensitivity,omitempty"`
+	// Disabled turns off the noise for a tenant when a default is configured.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+func loadPrivacyConfig(path string) (*privacyConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read privacy config: %w", err)
+	}
+	var cfg privacyConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse privacy config: %w", err)
+	}
+	if cfg.Default != nil {
+		if err := cfg.Default.validate(); err != nil {
+			return nil, fmt.Errorf("invalid default privacy config: %w", err)
+		}
+	}
+	for tenant, nc := range cfg.Tenants {
+		if err := nc.validate(); err != nil {
+			return nil, fmt.Errorf("invalid privacy config for tenant %q: %w", tenant, err)
+		}
+	}
+	return &cfg, nil
+}
+
+func (nc noiseConfig) validate() error {
+	if nc.Disabled {
+		return nil
+	}
+	if nc.Epsilon <= 0 {
+		return errors.New("epsilon must be positive")
+	}
+	if nc.Sensitivity < 0 {
+		return errors.New("sensitivity cannot be negative")
+	}
+	return nil
+}
+
+// noiseFor returns the noise source for the tenant of the HTTP request,
+// or nil if the aggregates for this tenant must be returned as is.
+func (c *privacyConfig) noiseFor(r *http.Request) *laplaceNoise {
+	return c.tenantNoise(r.Header.Get(tenantHeader))
+}
+
+// noiseForContext returns the noise source for the tenant of the gRPC call.
+func (c *privacyConfig) noiseForContext(ctx context.Context) *laplaceNoise {
+	var tenant string
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(tenantHeader); len(values) > 0 {
+		tenant = values[0]
+	}
+	return c.tenantNoise(tenant)
+}
+
+func (c *privacyConfig) tenantNoise(tenant string) *laplaceNoise {
+	if c == nil {
+		return nil
+	}
+	nc, ok := c.Tenants[tenant]
+	if !ok {
+		if c.Default == nil {
+			return nil
+		}
+		nc = *c.Default
+	}
+	if nc.Disabled {
+		return nil
+	}
+	sensitivity := nc.Sensitivity
+	if sensitivity == 0 {
+		sensitivity = 1
+	}
+	uniform := rand.Float64
+	if c.uniform != nil {
+		uniform = c.uniform
+	}
+	return &laplaceNoise{scale: sensitivity / nc.Epsilon, uniform: uniform}
+}
+
+// seed makes the noise reproducible: the same requests in the same order
+// get the same responses.
+func (c *privacyConfig) seed(seed uint64) {
+	var mu sync.Mutex
+	rng := rand.New(rand.NewPCG(seed, 0))
+	c.uniform = func() float64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return rng.Float64()
+	}
+}
+
+// laplaceNoise implements the Laplace mechanism with scale = sensitivity / epsilon.
+type laplaceNoise struct {
+	scale   float64
+	uniform func() float64 // returns values in [0, 1)
+}
+
+func (n *laplaceNoise) sample() float64 {
+	u := n.uniform() - 0.5
+	if u == -0.5 {
+		u = 0 // avoid log(0)
+	}
+	return -n.scale * math.Copysign(1, u) * math.Log(1-2*math.Abs(u))
+}
+
+// count returns a noisy version of a non-negative count.
+func (n *laplaceNoise) count(v int) int {
+	if n == nil {
+		return v
+	}
+	return max(0, int(math.Round(float64(v)+n.sample())))
+}
+
+// rate returns a noisy version of a non-negative rate.
+func (n *laplaceNoise) rate(v float64) float64 {
+	if n == nil {
+		return v
+	}
+	return math.Max(0, v+n.sample())
+}
+
+// applyNoise perturbs the aggregate values of the retention report.
+func (r *retentionReport) applyNoise(n *laplaceNoise) {
+	if n == nil {
+		return
+	}
+	r.Traces = n.count(r.Traces)
+	r.IngestRate = n.rate(r.IngestRate)
+	var total int
+	for i := range r.Services {
+		r.Services[i].Spans = n.count(r.Services[i].Spans)
+		total += r.Services[i].Spans
+	}
+	r.Spans = total
+	for i := range r.Services {
+		if total > 0 {
+			r.Services[i].Share = float64(r.Services[i].Spans) / float64(total)
+		} else {
+			r.Services[i].Share = 0
+		}
+	}
+}
+
+// noisyDependencies returns copies of the dependencies with noisy call and
+// error counts. The links left without calls are not reported.
+func noisyDependencies(n *laplaceNoise, deps []*storagev2.Dependency) []*storagev2.Dependency {
+	if n == nil {
+		return deps
+	}
+	noisy := make([]*storagev2.Dependency, 0, len(deps))
+	for _, dep := range deps {
+		calls := n.count(int(dep.CallCount))
+		if calls == 0 {
+			continue
+		}
+		dep = proto.Clone(dep).(*storagev2.Dependency)
+		dep.CallCount = uint64(calls)
+		dep.ErrorCount = uint64(min(calls, n.count(int(dep.ErrorCount))))
+		noisy = append(noisy, dep)
+	}
+	return noisy
+}
+
+// applyNoise perturbs the call and error counts of the operation
+// comparison, and the error rates derived from them.
+func (c *operationComparison) applyNoise(n *laplaceNoise) {
+	if n == nil {
+		return
+	}
+	for i := range c.Operations {
+		d := &c.Operations[i]
+		d.Before.applyNoise(n)
+		d.After.applyNoise(n)
+		d.ErrorRateDelta = d.After.ErrorRate - d.Before.ErrorRate
+	}
+}
+
+func (s *operationStats) applyNoise(n *laplaceNoise) {
+	s.Count = n.count(s.Count)
+	s.Errors = min(s.Count, n.count(s.Errors))
+	s.ErrorRate = 0
+	if s.Count > 0 {
+		s.ErrorRate = float64(s.Errors) / float64(s.Count)
+	}
+}
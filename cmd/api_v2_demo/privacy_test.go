@@ -0,0 +1,112 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+)
+
+// testPrivacy returns a privacy config with noise for every tenant except
+// "internal", with reproducible noise.
+func testPrivacy() *privacyConfig {
+	cfg := &privacyConfig{
+		Default: &noiseConfig{Epsilon: 0.2},
+		Tenants: map[string]noiseConfig{"internal": {Disabled: true}},
+	}
+	cfg.seed(1)
+	return cfg
+}
+
+func tenantContext(tenant string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), tenantHeader, tenant)
+}
+
+func TestDependenciesNoise(t *testing.T) {
+	q := NewQueryService()
+	require.Empty(t, q.importTraces(callBatch(0, testStart, 10*time.Millisecond)))
+	exact := q.dependencyLinks(nil, nil)
+	require.Len(t, exact, 1)
+	q.privacy = testPrivacy()
+	conn := newTestConn(t, q)
+
+	expected := noisyDependencies(testPrivacy().tenantNoise("acme"), exact)
+	require.Len(t, expected, 1)
+	assert.NotEqual(t, exact[0].CallCount, expected[0].CallCount, "the seed adds noise")
+	v3, err := api_v3.NewQueryServiceClient(conn).GetDependencies(tenantContext("acme"), &api_v3.GetDependenciesRequest{
+		StartTime: timestamppb.New(testStart.Add(-time.Hour)),
+		EndTime:   timestamppb.New(testStart.Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	require.Len(t, v3.Dependencies, 1)
+	assert.Equal(t, expected[0].CallCount, v3.Dependencies[0].CallCount)
+	assert.Equal(t, expected[0].ErrorCount, v3.Dependencies[0].ErrorCount)
+	assert.Equal(t, uint64(testTraces), exact[0].CallCount, "the stored links are not changed")
+
+	storage, err := storagev2.NewDependencyReaderClient(conn).GetDependencies(tenantContext("internal"), &storagev2.GetDependenciesRequest{})
+	require.NoError(t, err)
+	require.Len(t, storage.Dependencies, 1)
+	assert.Equal(t, uint64(testTraces), storage.Dependencies[0].CallCount, "the noise is disabled for the tenant")
+}
+
+func TestNoisyDependencies(t *testing.T) {
+	deps := []*storagev2.Dependency{{Parent: "a", Child: "b", CallCount: 1, ErrorCount: 1}}
+	assert.Same(t, deps[0], noisyDependencies(nil, deps)[0])
+
+	// uniform values giving a noise of 0, -1 and +1
+	zero, minusOne, plusOne := 0.5, 0.5-(1-1/math.E)/2, 0.5+(1-1/math.E)/2
+	noise := func(samples ...float64) *laplaceNoise {
+		return &laplaceNoise{scale: 1, uniform: func() float64 {
+			u := samples[0]
+			samples = samples[1:]
+			return u
+		}}
+	}
+	assert.Empty(t, noisyDependencies(noise(minusOne), deps), "the links without calls are dropped")
+	noisy := noisyDependencies(noise(zero, plusOne), deps)
+	require.Len(t, noisy, 1)
+	assert.Equal(t, uint64(1), noisy[0].CallCount)
+	assert.Equal(t, uint64(1), noisy[0].ErrorCount, "the errors are capped by the calls")
+}
+
+func TestCompareOperationsNoise(t *testing.T) {
+	q := NewQueryService()
+	require.Empty(t, q.importTraces(testBatch(0, 0, 0)))
+	params := url.Values{
+		"before": {testStart.Add(-time.Hour).Format(time.RFC3339Nano) + "," + testStart.Format(time.RFC3339Nano)},
+		"after":  {testStart.Format(time.RFC3339Nano) + "," + testStart.Add(time.Hour).Format(time.RFC3339Nano)},
+	}
+	compare := func(privacy *privacyConfig, tenant string) operationComparison {
+		req := httptest.NewRequest(http.MethodGet, "/api/operations/compare?"+params.Encode(), http.NoBody)
+		req.Header.Set(tenantHeader, tenant)
+		rec := httptest.NewRecorder()
+		newAdminHandler(q, newUsageTracker(), privacy).ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp operationComparison
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return resp
+	}
+
+	expected := compare(nil, "acme")
+	require.Len(t, expected.Operations, 1)
+	assert.Equal(t, testTraces, expected.Operations[0].After.Count)
+	expected.applyNoise(testPrivacy().tenantNoise("acme"))
+	assert.NotEqual(t, testTraces, expected.Operations[0].After.Count, "the seed adds noise")
+	assert.Equal(t, expected, compare(testPrivacy(), "acme"))
+	assert.Equal(t, testTraces, compare(testPrivacy(), "internal").Operations[0].After.Count)
+}
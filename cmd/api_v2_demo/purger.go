@@ -0,0 +1,126 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// retentionPolicy bounds how long the traces are kept after they started,
+// whether they still receive spans or not, and whichever API or file they
+// were received from.
+type retentionPolicy struct {
+	// MaxAge is the age of the traces above which they are purged.
+	MaxAge time.Duration
+	// Interval is how often the old traces are purged, by default a tenth
+	// of MaxAge between a second and a minute.
+	Interval time.Duration
+}
+
+// retentionPurger purges the traces older than the retention policy. It
+// finds them in the time buckets of the index, without visiting the
+// recent traces.
+type retentionPurger struct {
+	policy retentionPolicy
+
+	mu    sync.Mutex
+	stats purgeStats
+}
+
+// purgeStats is the report of the admin endpoint.
+type purgeStats struct {
+	MaxAge       string `json:"maxAge"`
+	Interval     string `json:"interval"`
+	Runs         int64  `json:"runs"`
+	PurgedTraces int64  `json:"purgedTraces"`
+	PurgedSpans  int64  `json:"purgedSpans"`
+	// LastRun is when the last purge started, and LastRunDuration how long
+	// it held the lock of the traces.
+	LastRun          *time.Time `json:"lastRun,omitempty"`
+	LastRunDuration  string     `json:"lastRunDuration,omitempty"`
+	LastPurgedTraces int        `json:"lastPurgedTraces"`
+}
+
+func newRetentionPurger(policy retentionPolicy) (*retentionPurger, error) {
+	if policy.MaxAge <= 0 || policy.Interval < 0 {
+		return nil, errors.New("the max age must be positive and the purge interval must not be negative")
+	}
+	if policy.Interval == 0 {
+		policy.Interval = ttlCheckInterval(policy.MaxAge)
+	}
+	return &retentionPurger{
+		policy: policy,
+		stats: purgeStats{
+			MaxAge:   policy.MaxAge.String(),
+			Interval: policy.Interval.String(),
+		},
+	}, nil
+}
+
+// purge removes the traces that started before the max age and returns the
+// number of traces and spans removed.
+func (p *retentionPurger) purge(q *QueryService, now time.Time) (traces, spans int) {
+	start := time.Now()
+	q.mu.Lock()
+	traceIDs := q.index.startedBefore(now.Add(-p.policy.MaxAge))
+	for _, traceID := range traceIDs {
+		forEachSpan(q.traces[traceID], func(string, *trace.Span) { spans++ })
+		q.deleteTrace(traceID)
+		q.memory.forget(traceID)
+	}
+	q.mu.Unlock()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stats.Runs++
+	p.stats.PurgedTraces += int64(len(traceIDs))
+	p.stats.PurgedSpans += int64(spans)
+	p.stats.LastRun = &now
+	p.stats.LastRunDuration = time.Since(start).String()
+	p.stats.LastPurgedTraces = len(traceIDs)
+	return len(traceIDs), spans
+}
+
+// run purges the old traces every interval until stop is called.
+func (p *retentionPurger) run(q *QueryService) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(p.policy.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if traces, spans := p.purge(q, now); traces > 0 {
+					log.Printf("[RETENTION] Purged %d traces (%d spans) older than %v\n", traces, spans, p.policy.MaxAge)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// handlePurgeStats serves the amount of data purged by the retention policy.
+func (q *QueryService) handlePurgeStats(w http.ResponseWriter, _ *http.Request) {
+	if q.purger == nil {
+		writeAdminError(w, http.StatusNotFound, errors.New("no retention policy is configured"))
+		return
+	}
+	q.purger.mu.Lock()
+	stats := q.purger.stats
+	q.purger.mu.Unlock()
+	writeAdminJSON(w, stats)
+}
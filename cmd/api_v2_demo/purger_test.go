@@ -0,0 +1,56 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+func TestRetentionPurge(t *testing.T) {
+	q := NewQueryService()
+	var err error
+	q.memory, err = newMemoryLimits(memoryOptions{MaxTraces: 1000})
+	require.NoError(t, err)
+	q.purger, err = newRetentionPurger(retentionPolicy{MaxAge: time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, 6*time.Second, q.purger.policy.Interval)
+	require.Empty(t, q.importTraces(indexedBatch(0, 0, 180)))
+
+	traces, spans := q.purger.purge(q, testStart.Add(2*time.Minute))
+	assert.Equal(t, 60, traces)
+	assert.Equal(t, 60, spans)
+	found := foundTraceIDs(t, q, &api_v3.TraceQueryParameters{ServiceName: "service-0"}, nil)
+	require.Len(t, found, 120)
+	assert.Equal(t, hex.EncodeToString(testTraceID(60)), found[0])
+	assert.Len(t, q.memory.entries, 120)
+
+	traces, _ = q.purger.purge(q, testStart.Add(2*time.Minute))
+	assert.Zero(t, traces)
+
+	var stats purgeStats
+	require.Equal(t, http.StatusOK, adminCall(t, newAdminHandler(q, nil, nil), http.MethodGet, "/api/admin/retention/purges", &stats))
+	assert.Equal(t, int64(2), stats.Runs)
+	assert.Equal(t, int64(60), stats.PurgedTraces)
+	assert.Equal(t, int64(60), stats.PurgedSpans)
+	assert.Zero(t, stats.LastPurgedTraces)
+	assert.Equal(t, "1m0s", stats.MaxAge)
+}
+
+func TestRetentionPolicyErrors(t *testing.T) {
+	_, err := newRetentionPurger(retentionPolicy{Interval: time.Second})
+	require.Error(t, err)
+	_, err = newRetentionPurger(retentionPolicy{MaxAge: time.Hour, Interval: -time.Second})
+	require.Error(t, err)
+
+	q := NewQueryService()
+	assert.Equal(t, http.StatusNotFound, adminCall(t, newAdminHandler(q, nil, nil), http.MethodGet, "/api/admin/retention/purges", nil))
+}
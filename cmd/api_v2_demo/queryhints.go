@@ -0,0 +1,196 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+// Query attributes with this prefix are not matched against the spans,
+// they are hints for the query planner.
+const queryHintPrefix = "jaeger.hint."
+
+// Query plan hints. For example
+//
+//	{"query": {"service_name": "frontend", "attributes": {"jaeger.hint.parallelism": "4"}}}
+const (
+	// hintIndex names the index whose candidates are scanned, when the
+	// query has a term of it, instead of the one with the fewest.
+	hintIndex = queryHintPrefix + "index"
+	// hintParallelism is the number of workers that scan the traces.
+	hintParallelism = queryHintPrefix + "parallelism"
+)
+
+// The indexes of the index hint: the traces of the service, of the
+// operation, of an attribute value and of the time range of the query.
+const (
+	indexService   = "service"
+	indexOperation = "operation"
+	indexAttribute = "attribute"
+	indexTime      = "time"
+)
+
+var knownIndexes = []string{indexService, indexOperation, indexAttribute, indexTime}
+
+// queryPlanHeader is the response header reporting the plan used for a query with hints.
+const queryPlanHeader = "x-jaeger-query-plan"
+
+// queryHintsConfig bounds the hints that clients may pass. Without it,
+// queries with hints are rejected.
+//
+// Example:
+//
+//	{"indexes": ["service", "operation"], "maxParallelism": 8}
+type queryHintsConfig struct {
+	// Indexes lists the index names accepted by the index hint, among
+	// service, operation, attribute and time.
+	Indexes []string `json:"indexes,omitempty"`
+	// MaxParallelism caps the parallelism hint, higher values are lowered to it.
+	MaxParallelism int `json:"maxParallelism,omitempty"`
+}
+
+// queryHints are the validated hints of a query. The zero value is the default plan.
+type queryHints struct {
+	Index       string
+	Parallelism int
+}
+
+func loadQueryHintsConfig(path string) (*queryHintsConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read query hints config: %w", err)
+	}
+	var cfg queryHintsConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse query hints config: %w", err)
+	}
+	if cfg.MaxParallelism < 0 {
+		return nil, fmt.Errorf("invalid maxParallelism %d", cfg.MaxParallelism)
+	}
+	for _, index := range cfg.Indexes {
+		if !slices.Contains(knownIndexes, index) {
+			return nil, fmt.Errorf("unknown index %q, expected one of %v", index, knownIndexes)
+		}
+	}
+	return &cfg, nil
+}
+
+// parseQueryHints extracts the hints from the query attributes and checks
+// them against cfg.
+func parseQueryHints(attributes map[string]string, cfg *queryHintsConfig) (queryHints, error) {
+	var hints queryHints
+	for key, value := range attributes {
+		if !strings.HasPrefix(key, queryHintPrefix) {
+			continue
+		}
+		if cfg == nil {
+			return hints, fmt.Errorf("query hints are not enabled on this server, remove %s", key)
+		}
+		switch key {
+		case hintIndex:
+			if !slices.Contains(cfg.Indexes, value) {
+				return hints, fmt.Errorf("unknown index %q in %s, expected one of %v", value, key, cfg.Indexes)
+			}
+			hints.Index = value
+		case hintParallelism:
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return hints, fmt.Errorf("invalid %s value %q, expected a positive integer", key, value)
+			}
+			hints.Parallelism = min(n, max(cfg.MaxParallelism, 1))
+		default:
+			return hints, fmt.Errorf("unknown query hint %s", key)
+		}
+	}
+	return hints, nil
+}
+
+// String describes the plan for the query plan header.
+func (h queryHints) String() string {
+	index := h.Index
+	if index == "" {
+		index = "none"
+	}
+	return fmt.Sprintf("index=%s parallelism=%d", index, max(h.Parallelism, 1))
+}
+
+// findTraces returns the traces matching the query and the attribute
+// filters, scanning the candidates of the index given by the hints with
+// their number of workers. The scan stops with an error
+// when ctx is done. Only looking up the candidates holds q.mu: as the stored
+// traces are not modified in place, they are scanned without blocking the writers.
+// The scanned traces and spans are counted in the scanStats of ctx, if any.
+func (q *QueryService) findTraces(ctx context.Context, query *api_v3.TraceQueryParameters, filters []attributeFilter, hints queryHints) ([]*trace.TracesData, error) {
+	stats := scanStatsFrom(ctx)
+	stats.searched()
+	q.mu.RLock()
+	traceIDs := q.index.candidates(query, filters, hints.Index)
+	all := make([]*trace.TracesData, 0, len(traceIDs))
+	for _, traceID := range traceIDs {
+		all = append(all, q.traces[traceID])
+	}
+	q.mu.RUnlock()
+	workers := min(max(hints.Parallelism, 1), max(len(all), 1))
+	chunk := (len(all) + workers - 1) / workers
+	results := make([][]*trace.TracesData, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		part := all[min(w*chunk, len(all)):min((w+1)*chunk, len(all))]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var scannedTraces, scannedSpans int
+			defer func() { stats.add(scannedTraces, scannedSpans) }()
+			for _, td := range part {
+				if ctx.Err() != nil {
+					return
+				}
+				if stats != nil {
+					scannedTraces++
+					scannedSpans += countSpans(td)
+				}
+				if traceMatches(td, query, filters) {
+					results[w] = append(results[w], td)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return slices.Concat(results...), nil
+}
+
+// traceMatches reports whether a span of the trace matches the service and
+// operation of the query, and all the attribute filters.
+func traceMatches(td *trace.TracesData, query *api_v3.TraceQueryParameters, filters []attributeFilter) bool {
+	for _, rs := range td.ResourceSpans {
+		if getServiceName(rs.Resource) != query.GetServiceName() {
+			continue
+		}
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				if query.GetOperationName() != "" && span.Name != query.GetOperationName() {
+					continue
+				}
+				if spanMatches(span, rs, filters) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryHints(t *testing.T) {
+	cfg := &queryHintsConfig{Indexes: []string{indexOperation, indexTime}, MaxParallelism: 4}
+	hints, err := parseQueryHints(map[string]string{
+		hintIndex:       indexTime,
+		hintParallelism: "16",
+		"http.method":   "GET",
+	}, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, queryHints{Index: indexTime, Parallelism: 4}, hints)
+	assert.Equal(t, "index=time parallelism=4", hints.String())
+	assert.Equal(t, "index=none parallelism=1", queryHints{}.String())
+
+	for msg, attributes := range map[string]map[string]string{
+		"unknown index":      {hintIndex: indexService},
+		"positive integer":   {hintParallelism: "0"},
+		"unknown query hint": {queryHintPrefix + "cache": "off"},
+	} {
+		_, err := parseQueryHints(attributes, cfg)
+		assert.ErrorContains(t, err, msg)
+	}
+	_, err = parseQueryHints(map[string]string{hintParallelism: "2"}, nil)
+	assert.ErrorContains(t, err, "hints are not enabled")
+}
+
+func TestLoadQueryHintsConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hints.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"indexes": ["service", "attribute"], "maxParallelism": 8}`), 0o600))
+	cfg, err := loadQueryHintsConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, &queryHintsConfig{Indexes: []string{indexService, indexAttribute}, MaxParallelism: 8}, cfg)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"indexes": ["btree"]}`), 0o600))
+	_, err = loadQueryHintsConfig(path)
+	assert.ErrorContains(t, err, `unknown index "btree"`)
+}
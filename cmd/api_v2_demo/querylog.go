@@ -0,0 +1,113 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// queryLogEntry is a line of the query log, which is in the JSON Lines
+// format and can be replayed with cmd/query-replay.
+type queryLogEntry struct {
+	// Time is when the call started.
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	// APIVersion is the query API version of the method, v2 or v3.
+	APIVersion string `json:"apiVersion"`
+	// Request is the request message in the protobuf JSON encoding.
+	Request    json.RawMessage `json:"request"`
+	DurationMs float64         `json:"durationMs"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// queryLogger appends the query API calls to a file, either all of them
+// as an audit log, or only those slower than minDuration.
+type queryLogger struct {
+	mu          sync.Mutex
+	file        *os.File
+	minDuration time.Duration
+}
+
+func newQueryLogger(path string, minDuration time.Duration) (*queryLogger, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open query log: %w", err)
+	}
+	return &queryLogger{file: f, minDuration: minDuration}, nil
+}
+
+// UnaryInterceptor logs unary RPCs.
+func (l *queryLogger) UnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	l.record(start, info.FullMethod, req, err)
+	return resp, err
+}
+
+// StreamInterceptor logs server-streaming RPCs.
+func (l *queryLogger) StreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	wrapped := &recordingStream{ServerStream: ss}
+	err := handler(srv, wrapped)
+	l.record(start, info.FullMethod, wrapped.req, err)
+	return err
+}
+
+func (l *queryLogger) record(start time.Time, method string, req any, err error) {
+	duration := time.Since(start)
+	if duration < l.minDuration {
+		return
+	}
+	entry := queryLogEntry{
+		Time:       start.UTC(),
+		Method:     method,
+		APIVersion: apiVersion(method),
+		Request:    json.RawMessage("{}"),
+		DurationMs: float64(duration) / float64(time.Millisecond),
+	}
+	if msg, ok := req.(proto.Message); ok {
+		if data, err := protojson.Marshal(msg); err == nil {
+			entry.Request = data
+		}
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("[QUERYLOG] Failed to encode entry: %v\n", err)
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		log.Printf("[QUERYLOG] Failed to write entry: %v\n", err)
+	}
+}
+
+func (l *queryLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// Formats of the raw trace endpoint.
+const (
+	rawFormatHex       = "hex"
+	rawFormatProtoJSON = "protojson"
+)
+
+// rawBackend names the storage backend of the demo in the raw trace responses.
+const rawBackend = "memory"
+
+// rawTrace is the stored representation of a trace.
+type rawTrace struct {
+	TraceID string `json:"traceId"`
+	Backend string `json:"backend"`
+	Format  string `json:"format"`
+	// SizeBytes is the size of the protobuf serialization of the trace.
+	SizeBytes int `json:"sizeBytes"`
+	SpanCount int `json:"spanCount"`
+	// SpanTraceIDs are the distinct trace IDs of the stored spans, which
+	// should all be TraceID.
+	SpanTraceIDs []string `json:"spanTraceIds"`
+	// Data is the protobuf serialization in hex, or the protojson of the
+	// stored TracesData.
+	Data json.RawMessage `json:"data"`
+	// Index lists the entries of the service and operation indexes that
+	// reference the trace.
+	Index []rawIndexEntry `json:"index"`
+}
+
+type rawIndexEntry struct {
+	Index     string `json:"index"`
+	Service   string `json:"service"`
+	Operation string `json:"operation,omitempty"`
+}
+
+// handleRawTrace serves the exact stored representation of a trace, in the
+// format given by the optional format query parameter, along with the index
+// entries referencing it, to debug converter and storage issues. It is not
+// available when the query results are anonymized, as the stored data is not.
+func (q *QueryService) handleRawTrace(w http.ResponseWriter, r *http.Request) {
+	if q.exportAnonymizer != nil {
+		writeAdminError(w, http.StatusForbidden, errors.New("raw traces are not available when the query results are anonymized"))
+		return
+	}
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "":
+		format = rawFormatHex
+	case rawFormatHex, rawFormatProtoJSON:
+	default:
+		writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid format %q, expected %s or %s",
+			format, rawFormatHex, rawFormatProtoJSON))
+		return
+	}
+	traceID := r.PathValue("traceID")
+	q.mu.RLock()
+	td, ok := q.traces[traceID]
+	var index []rawIndexEntry
+	if ok {
+		index = q.indexEntries(td)
+	}
+	q.mu.RUnlock()
+	if !ok {
+		writeAdminError(w, http.StatusNotFound, fmt.Errorf("trace not found: %s", traceID))
+		return
+	}
+
+	// the stored TracesData is never modified in place, it is safe to read without the lock
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(td)
+	if err != nil {
+		writeAdminError(w, http.StatusInternalServerError, fmt.Errorf("cannot serialize the trace: %w", err))
+		return
+	}
+	resp := rawTrace{
+		TraceID:      traceID,
+		Backend:      rawBackend,
+		Format:       format,
+		SizeBytes:    len(b),
+		SpanTraceIDs: []string{},
+		Index:        index,
+	}
+	seen := make(map[string]bool)
+	forEachSpan(td, func(_ string, span *trace.Span) {
+		resp.SpanCount++
+		if id := hex.EncodeToString(span.TraceId); !seen[id] {
+			seen[id] = true
+			resp.SpanTraceIDs = append(resp.SpanTraceIDs, id)
+		}
+	})
+	sort.Strings(resp.SpanTraceIDs)
+	if format == rawFormatHex {
+		resp.Data, err = json.Marshal(hex.EncodeToString(b))
+	} else {
+		resp.Data, err = protojson.Marshal(td)
+	}
+	if err != nil {
+		writeAdminError(w, http.StatusInternalServerError, fmt.Errorf("cannot serialize the trace: %w", err))
+		return
+	}
+	writeAdminJSON(w, resp)
+}
+
+// indexEntries returns the entries of the service and operation indexes for
+// the services and operations of the trace. It must be called with q.mu held.
+func (q *QueryService) indexEntries(td *trace.TracesData) []rawIndexEntry {
+	entries := []rawIndexEntry{}
+	seen := make(map[rawIndexEntry]bool)
+	add := func(e rawIndexEntry) {
+		if !seen[e] {
+			seen[e] = true
+			entries = append(entries, e)
+		}
+	}
+	catalog := q.catalogLocked()
+	forEachSpan(td, func(service string, span *trace.Span) {
+		ops, ok := catalog.operations[service]
+		if !ok {
+			return
+		}
+		add(rawIndexEntry{Index: "services", Service: service})
+		for _, op := range ops {
+			if op.name == span.Name {
+				add(rawIndexEntry{Index: "operations", Service: service, Operation: op.name})
+				break
+			}
+		}
+	})
+	return entries
+}
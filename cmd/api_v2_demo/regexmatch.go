@@ -0,0 +1,141 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"sync"
+	"time"
+)
+
+// opRegex prefixes the query attribute values and operation names that are
+// regular expressions, e.g. "~^GET /api/.*". The expressions use the RE2
+// syntax and are unanchored. Unless regular expressions are enabled, the
+// prefix is matched literally, like in the exact matches of the queries
+// written before they were supported.
+const opRegex = "~"
+
+// regexCacheSize is the number of compiled patterns kept by a regexMatcher.
+const regexCacheSize = 1024
+
+// maxRegexInstructions bounds the size of the compiled patterns, which grows
+// with counted repetitions, e.g. (a{100}){100}.
+const maxRegexInstructions = 10000
+
+// regexOptions configures the regular expression matching, which is off
+// unless enabled.
+type regexOptions struct {
+	Enabled bool
+	// MaxLength is the maximum length of a pattern.
+	MaxLength int
+	// Timeout bounds the scan of a query with patterns.
+	Timeout time.Duration
+}
+
+// regexMatcher compiles the patterns of the queries, caching them since
+// clients tend to repeat the same queries.
+type regexMatcher struct {
+	opts regexOptions
+
+	mu    sync.Mutex
+	cache map[string]*regexp.Regexp
+}
+
+func newRegexMatcher(opts regexOptions) (*regexMatcher, error) {
+	if opts.MaxLength < 1 || opts.Timeout <= 0 {
+		return nil, errors.New("regex max length and timeout must be positive")
+	}
+	return &regexMatcher{opts: opts, cache: make(map[string]*regexp.Regexp)}, nil
+}
+
+// compile returns the compiled pattern, rejecting the patterns that are too
+// long, too large once compiled, or that nest unbounded repetitions, like
+// (a+)+, which some storage backends cannot evaluate in linear time.
+func (m *regexMatcher) compile(pattern string) (*regexp.Regexp, error) {
+	m.mu.Lock()
+	re, ok := m.cache[pattern]
+	m.mu.Unlock()
+	if ok {
+		return re, nil
+	}
+
+	if len(pattern) > m.opts.MaxLength {
+		return nil, fmt.Errorf("regular expression is longer than %d characters", m.opts.MaxLength)
+	}
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression: %w", err)
+	}
+	if hasNestedRepeat(parsed, false) {
+		return nil, fmt.Errorf("regular expression %q nests unbounded repetitions", pattern)
+	}
+	prog, err := syntax.Compile(parsed.Simplify())
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression: %w", err)
+	}
+	if len(prog.Inst) > maxRegexInstructions {
+		return nil, fmt.Errorf("regular expression %q is too complex", pattern)
+	}
+	re, err = regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.cache) >= regexCacheSize {
+		clear(m.cache)
+	}
+	m.cache[pattern] = re
+	return re, nil
+}
+
+// hasNestedRepeat reports whether the expression has an unbounded repetition
+// within another one.
+func hasNestedRepeat(re *syntax.Regexp, inRepeat bool) bool {
+	unbounded := re.Op == syntax.OpStar || re.Op == syntax.OpPlus ||
+		(re.Op == syntax.OpRepeat && re.Max == -1)
+	if unbounded && inRepeat {
+		return true
+	}
+	for _, sub := range re.Sub {
+		if hasNestedRepeat(sub, inRepeat || unbounded) {
+			return true
+		}
+	}
+	return false
+}
+
+// withTimeout bounds ctx by the regex timeout if the filters have patterns.
+func (m *regexMatcher) withTimeout(ctx context.Context, filters []attributeFilter) (context.Context, context.CancelFunc) {
+	if m == nil {
+		return ctx, func() {}
+	}
+	for _, f := range filters {
+		if f.Op == opRegex {
+			return context.WithTimeout(ctx, m.opts.Timeout)
+		}
+	}
+	return ctx, func() {}
+}
+
+// operationFilter returns the filter of a query operation name, if it is a
+// pattern and regular expressions are enabled, and the operation name left
+// to match exactly.
+func (m *regexMatcher) operationFilter(operation string) (*attributeFilter, string, error) {
+	pattern, ok := strings.CutPrefix(operation, opRegex)
+	if !ok || m == nil {
+		return nil, operation, nil
+	}
+	re, err := m.compile(pattern)
+	if err != nil {
+		return nil, "", fmt.Errorf("operation name: %w", err)
+	}
+	return &attributeFilter{Name: true, Op: opRegex, Regexp: re}, "", nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp/syntax"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	common "go.opentelemetry.io/proto/otlp/common/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+func testRegexMatcher(t *testing.T) *regexMatcher {
+	m, err := newRegexMatcher(regexOptions{Enabled: true, MaxLength: 64, Timeout: time.Second})
+	require.NoError(t, err)
+	return m
+}
+
+// findTildeTraces stores a span named ~op with the attribute note=~note,
+// and returns the number of traces found by the query.
+func findTildeTraces(t *testing.T, q *QueryService, query *api_v3.TraceQueryParameters) (int, error) {
+	td := testBatch(0, 0, 0)
+	span := td.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	span.Name = "~op"
+	span.Attributes = []*common.KeyValue{{Key: "note", Value: stringValue("~note")}}
+	require.Empty(t, q.importTraces(td))
+
+	query.ServiceName = "service-0"
+	query.StartTimeMin = timestamppb.New(testStart.Add(-time.Hour))
+	query.StartTimeMax = timestamppb.New(testStart.Add(time.Hour))
+	stream, err := api_v3.NewQueryServiceClient(newTestConn(t, q)).FindTraces(context.Background(), &api_v3.FindTracesRequest{Query: query})
+	require.NoError(t, err)
+	found := 0
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return found, nil
+			}
+			return found, err
+		}
+		found++
+	}
+}
+
+func TestRegexDisabledMatchesLiterally(t *testing.T) {
+	f, err := parseAttributeFilter("note", "~note", nil)
+	require.NoError(t, err)
+	assert.Equal(t, attributeFilter{Key: "note", Op: opEqual, Value: "~note"}, f)
+	nameFilter, operation, err := (*regexMatcher)(nil).operationFilter("~op")
+	require.NoError(t, err)
+	assert.Nil(t, nameFilter)
+	assert.Equal(t, "~op", operation)
+
+	found, err := findTildeTraces(t, NewQueryService(), &api_v3.TraceQueryParameters{
+		OperationName: "~op",
+		Attributes:    map[string]string{"note": "~note"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, found)
+}
+
+func TestRegexEnabled(t *testing.T) {
+	q := NewQueryService()
+	q.regex = testRegexMatcher(t)
+	found, err := findTildeTraces(t, q, &api_v3.TraceQueryParameters{
+		OperationName: "~^~o",
+		Attributes:    map[string]string{"note": "~no+te$"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, found)
+}
+
+func TestHasNestedRepeat(t *testing.T) {
+	for pattern, nested := range map[string]bool{
+		"(a+)+":      true,
+		"(a*)*b":     true,
+		"(a{2,})+":   true,
+		"((ab)+c)*":  true,
+		"a+b*":       false,
+		"(ab)+":      false,
+		"(a{1,3})+":  false,
+		"^GET /api/": false,
+	} {
+		re, err := syntax.Parse(pattern, syntax.Perl)
+		require.NoError(t, err)
+		assert.Equal(t, nested, hasNestedRepeat(re, false), pattern)
+	}
+}
+
+func TestRegexCompileLimits(t *testing.T) {
+	_, err := newRegexMatcher(regexOptions{Enabled: true, MaxLength: 0, Timeout: time.Second})
+	require.Error(t, err)
+	_, err = newRegexMatcher(regexOptions{Enabled: true, MaxLength: 10})
+	require.Error(t, err)
+
+	m := testRegexMatcher(t)
+	for pattern, msg := range map[string]string{
+		"(a+)+$":                "nests unbounded repetitions",
+		"(abcdefghijk){1000}":   "too complex",
+		"[a-":                   "invalid regular expression",
+		fmt.Sprintf("%065d", 0): "longer than 64 characters",
+	} {
+		_, err := m.compile(pattern)
+		assert.ErrorContains(t, err, msg, pattern)
+	}
+	assert.Empty(t, m.cache, "the rejected patterns are not cached")
+}
+
+func TestRegexCache(t *testing.T) {
+	m := testRegexMatcher(t)
+	re, err := m.compile("^op-[0-9]$")
+	require.NoError(t, err)
+	again, err := m.compile("^op-[0-9]$")
+	require.NoError(t, err)
+	assert.Same(t, re, again)
+
+	for i := 1; i < regexCacheSize; i++ {
+		_, err := m.compile(fmt.Sprintf("^op-%d$", i))
+		require.NoError(t, err)
+	}
+	assert.Len(t, m.cache, regexCacheSize)
+	_, err = m.compile("^full$")
+	require.NoError(t, err)
+	assert.Len(t, m.cache, 1, "the cache is cleared once full")
+}
+
+func TestRegexTimeout(t *testing.T) {
+	m, err := newRegexMatcher(regexOptions{Enabled: true, MaxLength: 64, Timeout: time.Nanosecond})
+	require.NoError(t, err)
+
+	ctx, cancel := m.withTimeout(context.Background(), []attributeFilter{{Key: "note", Op: opEqual}})
+	defer cancel()
+	_, ok := ctx.Deadline()
+	assert.False(t, ok, "no timeout without patterns")
+	ctx, cancel = m.withTimeout(context.Background(), []attributeFilter{{Key: "note", Op: opRegex}})
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.True(t, ok)
+
+	q := NewQueryService()
+	q.regex = m
+	_, err = findTildeTraces(t, q, &api_v3.TraceQueryParameters{Attributes: map[string]string{"note": "~note"}})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
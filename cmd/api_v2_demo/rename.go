@@ -0,0 +1,184 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// renameRule maps operation names to new names. With Regex set, From is a
+// regular expression that must match the whole name and To may reference
+// its capture groups, e.g. "$1".
+type renameRule struct {
+	// Service restricts the rule to one service; empty matches all services.
+	Service string `json:"service,omitempty"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Regex   bool   `json:"regex,omitempty"`
+
+	re *regexp.Regexp
+}
+
+// renameRequest is the body of the rename-operations admin endpoint
+// and the format of the --rename-rules file.
+type renameRequest struct {
+	Rules []*renameRule `json:"rules"`
+	// DryRun reports what would be renamed without changing the data.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// renameResult summarizes the outcome of applying rename rules.
+type renameResult struct {
+	DryRun bool `json:"dryRun"`
+	// Spans is the number of stored spans whose operation was renamed.
+	Spans int `json:"spans"`
+	// Traces is the number of traces containing renamed spans.
+	Traces  int            `json:"traces"`
+	Renamed []renamedEntry `json:"renamed"`
+}
+
+type renamedEntry struct {
+	Service string `json:"service"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+}
+
+func (r *renameRequest) compile() error {
+	if len(r.Rules) == 0 {
+		return errors.New("no rename rules given")
+	}
+	for i, rule := range r.Rules {
+		if rule.From == "" || rule.To == "" {
+			return fmt.Errorf("rule %d: both from and to must be set", i)
+		}
+		if !rule.Regex {
+			continue
+		}
+		re, err := regexp.Compile("^(?:" + rule.From + ")$")
+		if err != nil {
+			return fmt.Errorf("rule %d: invalid regex: %w", i, err)
+		}
+		rule.re = re
+	}
+	return nil
+}
+
+// apply returns the new name of the operation, and whether any rule matched.
+func (r *renameRequest) apply(service, operation string) (string, bool) {
+	for _, rule := range r.Rules {
+		if rule.Service != "" && rule.Service != service {
+			continue
+		}
+		if rule.re == nil {
+			if rule.From == operation {
+				return rule.To, true
+			}
+			continue
+		}
+		if rule.re.MatchString(operation) {
+			return rule.re.ReplaceAllString(operation, rule.To), true
+		}
+	}
+	return operation, false
+}
+
+// renameOperations rewrites operation names across the stored spans, and
+// thereby the listed operations. Modified traces are replaced by renamed copies, so that
+// concurrent readers keep seeing consistent data.
+func (q *QueryService) renameOperations(req *renameRequest) renameResult {
+	result := renameResult{DryRun: req.DryRun}
+	renamed := make(map[renamedEntry]bool)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	updated := make(map[string]*trace.TracesData)
+	for traceID, td := range q.traces {
+		var clone *trace.TracesData
+		touched := false
+		for i, rs := range td.ResourceSpans {
+			service := getServiceName(rs.Resource)
+			for j, ss := range rs.ScopeSpans {
+				for k, span := range ss.Spans {
+					name, ok := req.apply(service, span.Name)
+					if !ok || name == span.Name {
+						continue
+					}
+					renamed[renamedEntry{Service: service, From: span.Name, To: name}] = true
+					result.Spans++
+					touched = true
+					if req.DryRun {
+						continue
+					}
+					if clone == nil {
+						clone = proto.Clone(td).(*trace.TracesData)
+					}
+					clone.ResourceSpans[i].ScopeSpans[j].Spans[k].Name = name
+				}
+			}
+		}
+		if touched {
+			result.Traces++
+		}
+		if clone != nil {
+			updated[traceID] = clone
+		}
+	}
+
+	for traceID, td := range updated {
+		q.storeTrace(traceID, td)
+	}
+	for entry := range renamed {
+		result.Renamed = append(result.Renamed, entry)
+	}
+	sort.Slice(result.Renamed, func(i, j int) bool {
+		a, b := result.Renamed[i], result.Renamed[j]
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		return a.From < b.From
+	})
+	return result
+}
+
+func loadRenameRules(path string) (*renameRequest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read rename rules: %w", err)
+	}
+	var req renameRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("cannot parse rename rules: %w", err)
+	}
+	if err := req.compile(); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// handleRenameOperations applies the rename rules from the request body.
+func (q *QueryService) handleRenameOperations(w http.ResponseWriter, r *http.Request) {
+	var req renameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if err := req.compile(); err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+	result := q.renameOperations(&req)
+	log.Printf("[ADMIN] Renamed %d spans in %d traces (dry run: %v)\n", result.Spans, result.Traces, result.DryRun)
+	writeAdminJSON(w, result)
+}
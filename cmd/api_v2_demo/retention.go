@@ -0,0 +1,125 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// retentionOptions are the hypothetical settings the report is projected against.
+type retentionOptions struct {
+	// Window is the look-back period, ending at the newest span,
+	// used to estimate the current ingest rate.
+	Window time.Duration
+	// TTL is the trace time-to-live the user is considering.
+	TTL time.Duration
+	// MaxSpans is the span quota the user is considering.
+	MaxSpans int
+	// Noise, if set, perturbs the aggregate values for differential privacy.
+	Noise *laplaceNoise
+}
+
+// retentionReport describes how much data is stored and how long it would be retained.
+type retentionReport struct {
+	Traces         int            `json:"traces"`
+	Spans          int            `json:"spans"`
+	OldestTrace    time.Time      `json:"oldestTrace"`
+	NewestTrace    time.Time      `json:"newestTrace"`
+	IngestRate     float64        `json:"ingestRateSpansPerSecond"`
+	IngestWindow   string         `json:"ingestWindow"`
+	Services       []serviceShare `json:"services"`
+	TTLEviction    *time.Time     `json:"ttlEvictionOfOldest,omitempty"`
+	QuotaHorizon   string         `json:"quotaRetentionHorizon,omitempty"`
+	QuotaExhausted *time.Time     `json:"quotaExhaustedAt,omitempty"`
+}
+
+// serviceShare is the portion of the stored spans produced by one service.
+type serviceShare struct {
+	Service string  `json:"service"`
+	Spans   int     `json:"spans"`
+	Share   float64 `json:"share"`
+}
+
+// retentionReport computes the retention status of the in-memory data.
+func (q *QueryService) retentionReport(opts retentionOptions) retentionReport {
+	var report retentionReport
+	perService := make(map[string]int)
+	var spanTimes []time.Time
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	for _, td := range q.traces {
+		report.Traces++
+		var traceStart time.Time
+		for _, rs := range td.ResourceSpans {
+			service := getServiceName(rs.Resource)
+			for _, ss := range rs.ScopeSpans {
+				for _, span := range ss.Spans {
+					start := time.Unix(0, int64(span.StartTimeUnixNano)).UTC()
+					if traceStart.IsZero() || start.Before(traceStart) {
+						traceStart = start
+					}
+					spanTimes = append(spanTimes, start)
+					perService[service]++
+					report.Spans++
+				}
+			}
+		}
+		if traceStart.IsZero() {
+			continue
+		}
+		if report.OldestTrace.IsZero() || traceStart.Before(report.OldestTrace) {
+			report.OldestTrace = traceStart
+		}
+		if traceStart.After(report.NewestTrace) {
+			report.NewestTrace = traceStart
+		}
+	}
+
+	for service, spans := range perService {
+		report.Services = append(report.Services, serviceShare{
+			Service: service,
+			Spans:   spans,
+			Share:   float64(spans) / float64(report.Spans),
+		})
+	}
+	if opts.Window > 0 && report.Spans > 0 {
+		var recent int
+		cutoff := report.NewestTrace.Add(-opts.Window)
+		for _, t := range spanTimes {
+			if !t.Before(cutoff) {
+				recent++
+			}
+		}
+		report.IngestRate = float64(recent) / opts.Window.Seconds()
+		report.IngestWindow = opts.Window.String()
+	}
+
+	report.applyNoise(opts.Noise)
+	sort.Slice(report.Services, func(i, j int) bool {
+		if report.Services[i].Spans != report.Services[j].Spans {
+			return report.Services[i].Spans > report.Services[j].Spans
+		}
+		return report.Services[i].Service < report.Services[j].Service
+	})
+
+	if opts.TTL > 0 && !report.OldestTrace.IsZero() {
+		eviction := report.OldestTrace.Add(opts.TTL)
+		report.TTLEviction = &eviction
+	}
+	if opts.MaxSpans > 0 && report.IngestRate > 0 {
+		// at steady state the quota holds this much history
+		horizon := time.Duration(float64(opts.MaxSpans) / report.IngestRate * float64(time.Second))
+		report.QuotaHorizon = horizon.Round(time.Second).String()
+		if remaining := opts.MaxSpans - report.Spans; remaining > 0 {
+			exhausted := time.Now().UTC().Add(time.Duration(float64(remaining) / report.IngestRate * float64(time.Second)))
+			report.QuotaExhausted = &exhausted
+		} else {
+			now := time.Now().UTC()
+			report.QuotaExhausted = &now
+		}
+	}
+	return report
+}
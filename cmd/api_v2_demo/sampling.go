@@ -0,0 +1,185 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"hash/fnv"
+	"slices"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// sampleOption is the query attribute selecting how the traces are picked
+// when more of them match than the search depth. Like the query hints, it is
+// not matched against the spans. For example
+//
+//	{"query": {"service_name": "frontend", "search_depth": 20, "attributes": {"jaeger.sample": "stratified"}}}
+const sampleOption = "jaeger.sample"
+
+// Values of sampleOption.
+const (
+	// sampleFirst returns the first traces by trace ID, the default.
+	sampleFirst = "first"
+	// sampleStratified returns a sample with the same proportions of root
+	// services and of failed traces as the matching traces.
+	sampleStratified = "stratified"
+)
+
+// resultSampleHeader is the response header reporting a sampled result.
+const resultSampleHeader = "x-jaeger-result-sample"
+
+// parseSampleOption returns the sampling of the query attributes.
+func parseSampleOption(attributes map[string]string) (string, error) {
+	switch value, ok := attributes[sampleOption]; {
+	case !ok || value == sampleFirst:
+		return sampleFirst, nil
+	case value == sampleStratified:
+		return sampleStratified, nil
+	default:
+		return "", fmt.Errorf("invalid %s value %q, expected %s or %s", sampleOption, value, sampleFirst, sampleStratified)
+	}
+}
+
+// resultSample is the outcome of limitTraces.
+type resultSample struct {
+	Matched int
+	Strata  int
+}
+
+// String describes the sample for the result sample header.
+func (s resultSample) String() string {
+	return fmt.Sprintf("stratified matched=%d strata=%d", s.Matched, s.Strata)
+}
+
+// limitTraces returns at most depth of the found traces, all of them if
+// depth is zero, in the order of their trace IDs. With sampleStratified, the
+// sample is reported if the found traces were more than depth.
+func limitTraces(found []*trace.TracesData, depth int, sampling string) ([]*trace.TracesData, *resultSample) {
+	var sample *resultSample
+	if depth > 0 && len(found) > depth {
+		if sampling == sampleStratified {
+			sample = &resultSample{Matched: len(found)}
+			found, sample.Strata = stratifiedSample(found, depth)
+		} else {
+			found = slices.Clone(found)
+			sortByTraceID(found)
+			found = found[:depth]
+		}
+	}
+	sortByTraceID(found)
+	return found, sample
+}
+
+func sortByTraceID(traces []*trace.TracesData) {
+	slices.SortFunc(traces, func(a, b *trace.TracesData) int {
+		return bytes.Compare(firstTraceID(a), firstTraceID(b))
+	})
+}
+
+// stratifiedSample picks n of the traces, grouped into strata by root
+// service and error status. Each stratum gets a share of n proportional to
+// its size, by largest remainder, and at least one trace if n allows it, so
+// that rare failures are not left out. Within a stratum, the traces with
+// the lowest hash of their trace ID are picked, which is random but stable
+// across identical queries, unlike the trace IDs themselves, which may be
+// ordered by time. It returns the sample and the number of strata.
+func stratifiedSample(traces []*trace.TracesData, n int) ([]*trace.TracesData, int) {
+	strata := make(map[stratum][]*trace.TracesData)
+	var keys []stratum
+	for _, td := range traces {
+		key := traceStratum(td)
+		if _, ok := strata[key]; !ok {
+			keys = append(keys, key)
+		}
+		strata[key] = append(strata[key], td)
+	}
+	slices.SortFunc(keys, func(a, b stratum) int {
+		if c := cmp.Compare(a.service, b.service); c != 0 {
+			return c
+		}
+		return cmp.Compare(boolToInt(a.isError), boolToInt(b.isError))
+	})
+
+	quotas := make([]int, len(keys))
+	remainders := make([]int, len(keys))
+	allocated := 0
+	for i, key := range keys {
+		size := len(strata[key])
+		quotas[i] = n * size / len(traces)
+		remainders[i] = n * size % len(traces)
+		allocated += quotas[i]
+	}
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(remainders[b], remainders[a]) })
+	for _, i := range order[:n-allocated] {
+		quotas[i]++
+	}
+	if n >= len(keys) {
+		// move a trace from the largest quotas to the empty ones
+		for i := range quotas {
+			if quotas[i] == 0 {
+				largest := 0
+				for j := range quotas {
+					if quotas[j] > quotas[largest] {
+						largest = j
+					}
+				}
+				quotas[largest]--
+				quotas[i]++
+			}
+		}
+	}
+
+	sample := make([]*trace.TracesData, 0, n)
+	for i, key := range keys {
+		stratum := strata[key]
+		slices.SortFunc(stratum, func(a, b *trace.TracesData) int {
+			return cmp.Compare(traceIDHash(a), traceIDHash(b))
+		})
+		sample = append(sample, stratum[:quotas[i]]...)
+	}
+	return sample, len(keys)
+}
+
+// stratum is the group of a trace in a stratified sample.
+type stratum struct {
+	service string
+	isError bool
+}
+
+// traceStratum returns the service of the root span of the trace, or of its
+// first span if it has no root, and whether a span of the trace failed.
+func traceStratum(td *trace.TracesData) stratum {
+	var s stratum
+	rootFound := false
+	forEachSpan(td, func(service string, span *trace.Span) {
+		if !rootFound && (s.service == "" || len(span.ParentSpanId) == 0) {
+			s.service = service
+			rootFound = len(span.ParentSpanId) == 0
+		}
+		if span.GetStatus().GetCode() == trace.Status_STATUS_CODE_ERROR {
+			s.isError = true
+		}
+	})
+	return s
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func traceIDHash(td *trace.TracesData) uint64 {
+	h := fnv.New64a()
+	h.Write(firstTraceID(td))
+	return h.Sum64()
+}
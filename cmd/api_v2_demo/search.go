@@ -0,0 +1,100 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+// defaultSearchLimit is the number of traces returned by a search without a limit.
+const defaultSearchLimit = 20
+
+// traceSearchResult summarizes a trace found by the HTTP search.
+type traceSearchResult struct {
+	TraceID   string   `json:"traceId"`
+	SpanCount int      `json:"spanCount"`
+	Services  []string `json:"services"`
+}
+
+// handleSearchTraces finds the traces matching the query parameters service
+// (required), operation, limit and tag. Like in the Jaeger HTTP API, tag is
+// repeated for each attribute as key:value, where the value may be a typed
+// predicate, e.g. tag=http.status_code:>=500.
+func (q *QueryService) handleSearchTraces(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	query := &api_v3.TraceQueryParameters{
+		ServiceName:   params.Get("service"),
+		OperationName: params.Get("operation"),
+		Attributes:    make(map[string]string),
+	}
+	if query.ServiceName == "" {
+		writeAdminError(w, http.StatusBadRequest, errors.New("missing service"))
+		return
+	}
+	for _, tag := range params["tag"] {
+		key, value, ok := strings.Cut(tag, ":")
+		if !ok || key == "" {
+			writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid tag %q, expected key:value", tag))
+			return
+		}
+		query.Attributes[key] = value
+	}
+	query, filters, err := q.queryFilters(query)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+	limit := defaultSearchLimit
+	if v := params.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 1 {
+			writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid limit %q", v))
+			return
+		}
+	}
+
+	ctx, cancel := q.regex.withTimeout(r.Context(), filters)
+	defer cancel()
+	results := []traceSearchResult{}
+	q.mu.RLock()
+	for traceID, td := range q.traces {
+		if ctx.Err() != nil {
+			break
+		}
+		if traceMatches(td, query, filters) {
+			results = append(results, summarizeTrace(traceID, td))
+		}
+	}
+	q.mu.RUnlock()
+	if ctx.Err() != nil {
+		writeAdminError(w, http.StatusServiceUnavailable, fmt.Errorf("search aborted: %w", ctx.Err()))
+		return
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].TraceID < results[j].TraceID
+	})
+	writeAdminJSON(w, results[:min(limit, len(results))])
+}
+
+func summarizeTrace(traceID string, td *trace.TracesData) traceSearchResult {
+	result := traceSearchResult{TraceID: traceID}
+	seen := make(map[string]bool)
+	forEachSpan(td, func(service string, _ *trace.Span) {
+		result.SpanCount++
+		if !seen[service] {
+			seen[service] = true
+			result.Services = append(result.Services, service)
+		}
+	})
+	sort.Strings(result.Services)
+	return result
+}
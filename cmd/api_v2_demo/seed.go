@@ -0,0 +1,311 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	common "go.opentelemetry.io/proto/otlp/common/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+	"github.com/jaegertracing/jaeger-idl/model/validation"
+)
+
+// maxSeedSize caps the size of a downloaded seed archive so that a bad URL
+// cannot exhaust the memory of the demo. It is lowered by the tests.
+var maxSeedSize = 256 << 20
+
+// seedOptions describes where to fetch the startup dataset from.
+type seedOptions struct {
+	URL     string
+	SHA256  string
+	Timeout time.Duration
+}
+
+// loadSeed downloads the dataset described by opts, verifies its checksum
+// and imports every trace it contains into the query service.
+//
+// The payload is either a single JSON document with OTLP TracesData,
+// or a (optionally gzipped) tarball of such documents.
+func (q *QueryService) loadSeed(ctx context.Context, opts seedOptions) error {
+	data, err := downloadSeed(ctx, opts)
+	if err != nil {
+		return err
+	}
+	docs, err := unpackSeed(data)
+	if err != nil {
+		return err
+	}
+	var rejected int
+	for name, doc := range docs {
+		td := &trace.TracesData{}
+		if err := protojson.Unmarshal(doc, td); err != nil {
+			return fmt.Errorf("cannot parse seed file %s: %w", name, err)
+		}
+		for _, err := range q.importTraces(td) {
+			log.Printf("Rejected span from seed file %s: %v\n", name, err)
+			rejected++
+		}
+	}
+	log.Printf("Imported %d seed documents from %s, rejected %d invalid spans\n", len(docs), opts.URL, rejected)
+	return nil
+}
+
+func downloadSeed(ctx context.Context, opts seedOptions) ([]byte, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, opts.URL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed URL: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot download seed data: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot download seed data: unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxSeedSize)+1))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read seed data: %w", err)
+	}
+	if len(data) > maxSeedSize {
+		return nil, fmt.Errorf("seed data exceeds the limit of %d bytes", maxSeedSize)
+	}
+	if err := verifyChecksum(data, opts.SHA256); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// verifyChecksum compares the SHA-256 digest of data with the expected hex digest.
+// An empty expected digest disables the verification.
+func verifyChecksum(data []byte, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	sum := sha256.Sum256(data)
+	actual := hex.EncodeToString(sum[:])
+	if !strings.EqualFold(actual, strings.TrimPrefix(expected, "sha256:")) {
+		return fmt.Errorf("seed checksum mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// unpackSeed returns the JSON documents contained in the payload, keyed by file name.
+func unpackSeed(data []byte) (map[string][]byte, error) {
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("cannot decompress seed data: %w", err)
+		}
+		defer zr.Close()
+		data, err = io.ReadAll(io.LimitReader(zr, int64(maxSeedSize)+1))
+		if err != nil {
+			return nil, fmt.Errorf("cannot decompress seed data: %w", err)
+		}
+		if len(data) > maxSeedSize {
+			return nil, fmt.Errorf("decompressed seed data exceeds the limit of %d bytes", maxSeedSize)
+		}
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		return map[string][]byte{"seed.json": trimmed}, nil
+	}
+
+	docs := make(map[string][]byte)
+	tr := tar.NewReader(bytes.NewReader(data))
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read seed archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || path.Ext(hdr.Name) != ".json" {
+			continue
+		}
+		doc, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read seed file %s: %w", hdr.Name, err)
+		}
+		docs[hdr.Name] = doc
+	}
+	if len(docs) == 0 {
+		return nil, errors.New("seed archive does not contain any .json files")
+	}
+	return docs, nil
+}
+
+// importTraces adds the spans from td to the in-memory data, grouping them
+// by trace ID and registering their services and operations. Spans that fail
+// validation are skipped and their errors returned. It is the single entry
+// point of the received spans, besides the tail sampler which accepts the
+// spans when they are received and stores them once their trace is kept.
+func (q *QueryService) importTraces(td *trace.TracesData) []error {
+	accepted, rejected := q.acceptSpans(td)
+	q.storeTraces(accepted)
+	return rejected
+}
+
+// acceptSpans truncates the spans of td and returns the valid ones, with the
+// errors of the others.
+func (q *QueryService) acceptSpans(td *trace.TracesData) (*trace.TracesData, []error) {
+	var rejected []error
+	accepted := &trace.TracesData{}
+	for _, rs := range td.ResourceSpans {
+		process := otlp.ResourceToProcess(rs.Resource)
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				if q.truncation != nil {
+					q.truncation.truncate(span)
+				}
+				if err := validateSpan(span, process, ss.Scope); err != nil {
+					rejected = append(rejected, err)
+					continue
+				}
+				appendAccepted(accepted, rs, ss, span)
+			}
+		}
+	}
+	return accepted, rejected
+}
+
+// storeTraces adds the accepted spans from td, which the ingest anonymizer
+// scrubs in place.
+//
+// Existing traces are copied before new spans are added to them, so that
+// readers holding on to the previous version are not affected. The write
+// lock is only held to store the spans. The spans are forwarded downstream
+// if forwarding is enabled.
+func (q *QueryService) storeTraces(td *trace.TracesData) {
+	if len(td.ResourceSpans) == 0 {
+		return
+	}
+	if q.ingestAnonymizer != nil {
+		q.ingestAnonymizer.anonymize(td)
+	}
+	copied := make(map[string]bool)
+	q.mu.Lock()
+	for _, rs := range td.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				traceID := hex.EncodeToString(span.TraceId)
+				if !copied[traceID] {
+					if existing, ok := q.traces[traceID]; ok {
+						q.traces[traceID] = proto.Clone(existing).(*trace.TracesData)
+					}
+					copied[traceID] = true
+				}
+				q.appendSpan(traceID, rs, ss, span)
+			}
+		}
+	}
+	for traceID := range copied {
+		q.index.update(traceID, q.traces[traceID])
+	}
+	q.catalog.Store(nil)
+	q.memory.evictOverflow(q)
+	q.mu.Unlock()
+
+	if q.forwarder != nil {
+		q.forwarder.enqueue(td)
+	}
+}
+
+// appendAccepted adds span to the batch of accepted spans, which mirrors
+// the resource and scope structure of the imported batch.
+func appendAccepted(td *trace.TracesData, rs *trace.ResourceSpans, ss *trace.ScopeSpans, span *trace.Span) {
+	var target *trace.ResourceSpans
+	if n := len(td.ResourceSpans); n > 0 && td.ResourceSpans[n-1].Resource == rs.Resource {
+		target = td.ResourceSpans[n-1]
+	} else {
+		target = &trace.ResourceSpans{Resource: rs.Resource, SchemaUrl: rs.SchemaUrl}
+		td.ResourceSpans = append(td.ResourceSpans, target)
+	}
+	if n := len(target.ScopeSpans); n > 0 && target.ScopeSpans[n-1].Scope == ss.Scope {
+		target.ScopeSpans[n-1].Spans = append(target.ScopeSpans[n-1].Spans, span)
+		return
+	}
+	target.ScopeSpans = append(target.ScopeSpans, &trace.ScopeSpans{
+		Scope:     ss.Scope,
+		SchemaUrl: ss.SchemaUrl,
+		Spans:     []*trace.Span{span},
+	})
+}
+
+// checkBatchSize rejects the batches of the ingest endpoints with more spans
+// than the limits, before they are converted and validated.
+func checkBatchSize(spans int) error {
+	return validation.Batch(spans, validation.DefaultLimits())
+}
+
+func countSpans(td *trace.TracesData) int {
+	n := 0
+	forEachSpan(td, func(string, *trace.Span) { n++ })
+	return n
+}
+
+// validateSpan checks an OTLP span before it is stored. Unlike the converter,
+// which clamps the duration at zero, it keeps spans that end before they
+// start, or have no end time, negative so that they are rejected.
+func validateSpan(span *trace.Span, process *model.Process, scope *common.InstrumentationScope) error {
+	domainSpan := otlp.SpanToDomain(span, process, scope)
+	domainSpan.Duration = time.Duration(int64(span.EndTimeUnixNano) - int64(span.StartTimeUnixNano))
+	return validation.Span(domainSpan, validation.DefaultLimits())
+}
+
+// appendSpan stores span under traceID, preserving its resource and scope.
+func (q *QueryService) appendSpan(traceID string, rs *trace.ResourceSpans, ss *trace.ScopeSpans, span *trace.Span) {
+	td, ok := q.traces[traceID]
+	if !ok {
+		td = &trace.TracesData{}
+		q.traces[traceID] = td
+	}
+	q.memory.written(traceID, time.Now())
+	var target *trace.ResourceSpans
+	for _, existing := range td.ResourceSpans {
+		if existing.Resource == rs.Resource {
+			target = existing
+			break
+		}
+	}
+	if target == nil {
+		target = &trace.ResourceSpans{Resource: rs.Resource, SchemaUrl: rs.SchemaUrl}
+		td.ResourceSpans = append(td.ResourceSpans, target)
+	}
+	for _, existing := range target.ScopeSpans {
+		if existing.Scope == ss.Scope {
+			existing.Spans = append(existing.Spans, span)
+			return
+		}
+	}
+	target.ScopeSpans = append(target.ScopeSpans, &trace.ScopeSpans{
+		Scope:     ss.Scope,
+		SchemaUrl: ss.SchemaUrl,
+		Spans:     []*trace.Span{span},
+	})
+}
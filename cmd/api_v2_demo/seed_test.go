@@ -0,0 +1,147 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// seedServer serves body as the seed data.
+func seedServer(t *testing.T, body []byte) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// seedDocument returns the traces of testBatch from first as an OTLP JSON document.
+func seedDocument(t *testing.T, first int) []byte {
+	doc, err := protojson.Marshal(testBatch(0, first, 0))
+	require.NoError(t, err)
+	return doc
+}
+
+// seedArchive returns a tarball of the files, gzipped if compress is set.
+func seedArchive(t *testing.T, files map[string][]byte, compress bool) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, data := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	if !compress {
+		return buf.Bytes()
+	}
+	return gzipped(t, buf.Bytes())
+}
+
+func gzipped(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestLoadSeed(t *testing.T) {
+	files := map[string][]byte{
+		"first.json":  seedDocument(t, 0),
+		"second.json": seedDocument(t, 100),
+		"README.md":   []byte("the documents that are not JSON are skipped"),
+	}
+	for _, tt := range []struct {
+		name   string
+		body   []byte
+		traces int
+	}{
+		{name: "json", body: seedDocument(t, 0), traces: testTraces},
+		{name: "gzipped json", body: gzipped(t, seedDocument(t, 0)), traces: testTraces},
+		{name: "tarball", body: seedArchive(t, files, false), traces: 2 * testTraces},
+		{name: "gzipped tarball", body: seedArchive(t, files, true), traces: 2 * testTraces},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewQueryService()
+			require.NoError(t, q.loadSeed(context.Background(), seedOptions{URL: seedServer(t, tt.body)}))
+			assert.Len(t, q.traces, tt.traces)
+		})
+	}
+}
+
+func TestSeedChecksum(t *testing.T) {
+	body := seedDocument(t, 0)
+	sum := sha256.Sum256(body)
+	url := seedServer(t, body)
+	for _, digest := range []string{
+		hex.EncodeToString(sum[:]),
+		"sha256:" + strings.ToUpper(hex.EncodeToString(sum[:])),
+	} {
+		q := NewQueryService()
+		require.NoError(t, q.loadSeed(context.Background(), seedOptions{URL: url, SHA256: digest}), digest)
+		assert.Len(t, q.traces, testTraces)
+	}
+
+	q := NewQueryService()
+	err := q.loadSeed(context.Background(), seedOptions{URL: url, SHA256: strings.Repeat("0", 64)})
+	require.ErrorContains(t, err, "seed checksum mismatch")
+	assert.Empty(t, q.traces, "nothing is imported from a corrupted download")
+}
+
+func TestSeedSizeLimit(t *testing.T) {
+	limit := maxSeedSize
+	maxSeedSize = 1024
+	t.Cleanup(func() { maxSeedSize = limit })
+
+	padding := bytes.Repeat([]byte(" "), maxSeedSize)
+	_, err := downloadSeed(context.Background(), seedOptions{URL: seedServer(t, append([]byte("{}"), padding...))})
+	require.ErrorContains(t, err, "seed data exceeds the limit of 1024 bytes")
+
+	// a small archive expanding beyond the limit
+	bomb := gzipped(t, append([]byte("{}"), padding...))
+	require.Less(t, len(bomb), maxSeedSize)
+	data, err := downloadSeed(context.Background(), seedOptions{URL: seedServer(t, bomb)})
+	require.NoError(t, err)
+	_, err = unpackSeed(data)
+	require.ErrorContains(t, err, "decompressed seed data exceeds the limit")
+}
+
+func TestSeedErrors(t *testing.T) {
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+	_, err := downloadSeed(context.Background(), seedOptions{URL: notFound.URL})
+	require.ErrorContains(t, err, "unexpected status 404")
+
+	slow := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer slow.Close()
+	_, err = downloadSeed(context.Background(), seedOptions{URL: slow.URL, Timeout: 50 * time.Millisecond})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = unpackSeed(seedArchive(t, map[string][]byte{"README.md": []byte("no traces")}, false))
+	require.ErrorContains(t, err, "does not contain any .json files")
+	_, err = unpackSeed([]byte("neither JSON nor a tarball"))
+	require.ErrorContains(t, err, "cannot read seed archive")
+
+	q := NewQueryService()
+	err = q.loadSeed(context.Background(), seedOptions{URL: seedServer(t, []byte(`{"resourceSpans": 1}`))})
+	require.ErrorContains(t, err, "cannot parse seed file seed.json")
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// scanStats counts the traces and spans scanned by the searches of a call.
+type scanStats struct {
+	searches atomic.Int64
+	traces   atomic.Int64
+	spans    atomic.Int64
+}
+
+type scanStatsKey struct{}
+
+// withScanStats returns a context in which findTraces counts the scanned
+// traces and spans.
+func withScanStats(ctx context.Context) (context.Context, *scanStats) {
+	stats := &scanStats{}
+	return context.WithValue(ctx, scanStatsKey{}, stats), stats
+}
+
+// scanStatsFrom returns the scanStats of ctx, or nil if it has none.
+func scanStatsFrom(ctx context.Context) *scanStats {
+	stats, _ := ctx.Value(scanStatsKey{}).(*scanStats)
+	return stats
+}
+
+// add counts the traces scanned by a search, it is a no-op on a nil
+// scanStats.
+func (s *scanStats) add(traces, spans int) {
+	if s == nil {
+		return
+	}
+	s.traces.Add(int64(traces))
+	s.spans.Add(int64(spans))
+}
+
+// searched counts a search, it is a no-op on a nil scanStats.
+func (s *scanStats) searched() {
+	if s != nil {
+		s.searches.Add(1)
+	}
+}
+
+// slowQueryLogger logs the searches slower than threshold, with their
+// query parameters and the number of spans they scanned.
+type slowQueryLogger struct {
+	threshold time.Duration
+}
+
+// UnaryInterceptor logs the slow unary searches, e.g. FindTraceIDs.
+func (l *slowQueryLogger) UnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, stats := withScanStats(ctx)
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	l.record(time.Since(start), info.FullMethod, req, stats, err)
+	return resp, err
+}
+
+// StreamInterceptor logs the slow server-streaming searches, e.g. FindTraces.
+func (l *slowQueryLogger) StreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, stats := withScanStats(ss.Context())
+	start := time.Now()
+	wrapped := &recordingStream{ServerStream: &contextStream{ServerStream: ss, ctx: ctx}}
+	err := handler(srv, wrapped)
+	l.record(time.Since(start), info.FullMethod, wrapped.req, stats, err)
+	return err
+}
+
+// contextStream overrides the context of a stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// record logs the call if it searched traces and took longer than the
+// threshold.
+func (l *slowQueryLogger) record(duration time.Duration, method string, req any, stats *scanStats, err error) {
+	if stats.searches.Load() == 0 || duration < l.threshold {
+		return
+	}
+	query := "{}"
+	if msg, ok := req.(proto.Message); ok {
+		if data, err := protojson.Marshal(msg); err == nil {
+			query = string(data)
+		}
+	}
+	if err != nil {
+		log.Printf("[SLOW QUERY] %s failed after %v, scanned %d spans in %d traces: %v, request: %s\n",
+			method, duration, stats.spans.Load(), stats.traces.Load(), err, query)
+		return
+	}
+	log.Printf("[SLOW QUERY] %s took %v, scanned %d spans in %d traces, request: %s\n",
+		method, duration, stats.spans.Load(), stats.traces.Load(), query)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+// fakeServerStream is a server stream receiving req.
+type fakeServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+	req *api_v3.FindTracesRequest
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) RecvMsg(m any) error {
+	proto.Merge(m.(proto.Message), s.req)
+	return nil
+}
+
+func TestSlowQueryLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	q := NewQueryService()
+	require.Empty(t, q.importTraces(testBatch(0, 0, 0)))
+	require.Empty(t, q.importTraces(testBatch(0, 0, 1)))
+	require.Empty(t, q.importTraces(testBatch(1, 0, 2)))
+	query := &api_v3.TraceQueryParameters{ServiceName: "service-0"}
+	search := func(srv any, stream grpc.ServerStream) error {
+		req := &api_v3.FindTracesRequest{}
+		require.NoError(t, stream.RecvMsg(req))
+		_, err := q.findTraces(stream.Context(), req.Query, nil, queryHints{})
+		return err
+	}
+	info := &grpc.StreamServerInfo{FullMethod: "/jaeger.api_v3.QueryService/FindTraces"}
+	stream := &fakeServerStream{ctx: t.Context(), req: &api_v3.FindTracesRequest{Query: query}}
+
+	l := &slowQueryLogger{}
+	require.NoError(t, l.StreamInterceptor(nil, stream, info, search))
+	assert.Contains(t, buf.String(), "[SLOW QUERY] /jaeger.api_v3.QueryService/FindTraces took")
+	assert.Contains(t, buf.String(), "scanned 24 spans in 8 traces")
+	assert.Contains(t, buf.String(), `"serviceName":"service-0"`)
+
+	// the calls that do not search and the fast searches are not logged
+	buf.Reset()
+	_, err := l.UnaryInterceptor(t.Context(), &api_v3.GetServicesRequest{}, &grpc.UnaryServerInfo{FullMethod: "/jaeger.api_v3.QueryService/GetServices"},
+		func(context.Context, any) (any, error) { return &api_v3.GetServicesResponse{}, nil })
+	require.NoError(t, err)
+	l.threshold = time.Hour
+	require.NoError(t, l.StreamInterceptor(nil, stream, info, search))
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/proto"
+	"google.golang.org/grpc/mem"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// spansChunkSpansTag is the tag of the spans field of an api_v2
+// SpansResponseChunk: field 1 with the length-delimited wire type.
+const spansChunkSpansTag = 1<<3 | 2
+
+// encodedSpansChunk is an api_v2 SpansResponseChunk already encoded. The
+// spans of the domain model have the wire format of the api_v2 spans, and
+// unrolled marshalers generated by gogo/protobuf, so they are encoded
+// directly instead of being converted to api_v2 spans and marshaled by
+// protobuf-go, which dominated the CPU time of streaming big traces.
+type encodedSpansChunk struct {
+	data []byte
+}
+
+// EncodedMessage returns the encoded chunk, for the stream checksums.
+func (c *encodedSpansChunk) EncodedMessage() []byte {
+	return c.data
+}
+
+// encodeSpansChunk encodes the spans as an api_v2 SpansResponseChunk into
+// a single buffer of the exact size.
+func encodeSpansChunk(spans []*model.Span) (*encodedSpansChunk, error) {
+	sizes := make([]int, len(spans))
+	total := 0
+	for i, span := range spans {
+		sizes[i] = span.Size()
+		total += 1 + uvarintSize(uint64(sizes[i])) + sizes[i]
+	}
+	data := make([]byte, total)
+	offset := 0
+	for i, span := range spans {
+		data[offset] = spansChunkSpansTag
+		offset++
+		offset += binary.PutUvarint(data[offset:], uint64(sizes[i]))
+		if _, err := span.MarshalToSizedBuffer(data[offset : offset+sizes[i]]); err != nil {
+			return nil, fmt.Errorf("cannot encode span %s: %w", span.SpanID, err)
+		}
+		offset += sizes[i]
+	}
+	return &encodedSpansChunk{data: data}, nil
+}
+
+func uvarintSize(x uint64) int {
+	n := 1
+	for x >= 0x80 {
+		x >>= 7
+		n++
+	}
+	return n
+}
+
+// spansChunkCodec is the protobuf codec of the gRPC server, which sends the
+// encoded chunks as they are.
+type spansChunkCodec struct {
+	encoding.CodecV2
+}
+
+func newSpansChunkCodec() spansChunkCodec {
+	return spansChunkCodec{CodecV2: encoding.GetCodecV2(proto.Name)}
+}
+
+func (c spansChunkCodec) Marshal(v any) (mem.BufferSlice, error) {
+	if chunk, ok := v.(*encodedSpansChunk); ok {
+		return mem.BufferSlice{mem.SliceBuffer(chunk.data)}, nil
+	}
+	return c.CodecV2.Marshal(v)
+}
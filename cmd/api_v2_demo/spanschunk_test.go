@@ -0,0 +1,129 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jaegertracing/jaeger-idl/adapter/streamcheck"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/apiv2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// richSpans are spans with every field of the api_v2 spans set.
+func richSpans() []*model.Span {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	tags := []model.KeyValue{
+		model.String("http.method", "GET"),
+		model.Bool("error", true),
+		model.Int64("http.status_code", -500),
+		model.Float64("ratio", 0.25),
+		model.Binary("payload", []byte{0, 1, 0x80, 0xff}),
+	}
+	return []*model.Span{
+		{
+			TraceID:       model.NewTraceID(1, 2),
+			SpanID:        model.NewSpanID(3),
+			OperationName: "get /users",
+			References: []model.SpanRef{
+				model.NewChildOfRef(model.NewTraceID(1, 2), model.NewSpanID(2)),
+				model.NewFollowsFromRef(model.NewTraceID(4, 5), model.NewSpanID(6)),
+			},
+			Flags:     model.Flags(1),
+			StartTime: start,
+			Duration:  1500 * time.Millisecond,
+			Tags:      tags,
+			Logs:      []model.Log{{Timestamp: start.Add(time.Millisecond), Fields: tags[:2]}},
+			Process:   model.NewProcess("frontend", tags[2:]),
+			ProcessID: "p1",
+			Warnings:  []string{"clock skew"},
+		},
+		{
+			TraceID: model.NewTraceID(0, 7),
+			SpanID:  model.NewSpanID(8),
+			Process: model.NewProcess("", nil),
+		},
+	}
+}
+
+func TestEncodedSpansChunkIsTheProtobufEncoding(t *testing.T) {
+	q := NewQueryService()
+	q.initDemoData(time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC))
+	spans := richSpans()
+	for _, td := range q.traces {
+		spans = append(spans, otlp.ToDomain(td)...)
+	}
+	spans = append(spans, otlp.ToDomain(indexedBatch(0, 0, 10))...)
+
+	for _, chunkSpans := range [][]*model.Span{nil, spans[:1], spans} {
+		protoSpans, err := apiv2.SpansToProto(chunkSpans)
+		require.NoError(t, err)
+		want, err := proto.MarshalOptions{Deterministic: true}.Marshal(&api_v2.SpansResponseChunk{Spans: protoSpans})
+		require.NoError(t, err)
+		chunk, err := encodeSpansChunk(chunkSpans)
+		require.NoError(t, err)
+		assert.Equal(t, want, chunk.data)
+	}
+}
+
+func TestGetTraceSendsEncodedChunks(t *testing.T) {
+	q := NewQueryService()
+	require.Empty(t, q.importTraces(testBatch(0, 0, 0)))
+	require.Empty(t, q.importTraces(testBatch(1, 0, 1)))
+	client := api_v2.NewQueryServiceClient(newTestConn(t, q, grpc.WithStreamInterceptor(
+		streamcheck.StreamClientInterceptor(streamcheck.ClientOptions{Required: true}))))
+
+	stream, err := client.GetTrace(context.Background(), &api_v2.GetTraceRequest{TraceId: testTraceID(3)})
+	require.NoError(t, err)
+	var spans []*api_v2.Span
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		spans = append(spans, chunk.Spans...)
+	}
+	require.Len(t, spans, 2)
+	assert.Equal(t, testTraceID(3), spans[0].TraceId)
+	assert.ElementsMatch(t, []string{"service-0", "service-1"}, []string{spans[0].Process.ServiceName, spans[1].Process.ServiceName})
+}
+
+// BenchmarkSendTrace compares the encoding of a chunk of 5000 spans with
+// the baseline, which converts the spans to api_v2 spans and marshals the
+// chunk with protobuf-go.
+func BenchmarkSendTrace(b *testing.B) {
+	spans := otlp.ToDomain(indexedBatch(0, 0, 5000))
+	b.Run("baseline", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			protoSpans, err := apiv2.SpansToProto(spans)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := proto.Marshal(&api_v2.SpansResponseChunk{Spans: protoSpans}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("encoded", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := encodeSpansChunk(spans); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	modeltrace "github.com/jaegertracing/jaeger-idl/model/trace"
+)
+
+// traceStats is the JSON representation of modeltrace.Stats.
+type traceStats struct {
+	TraceID    string           `json:"traceId"`
+	SpanCount  int              `json:"spanCount"`
+	ErrorCount int              `json:"errorCount"`
+	Services   []string         `json:"services"`
+	MaxDepth   int              `json:"maxDepth"`
+	DurationNs int64            `json:"durationNs"`
+	SelfTimeNs map[string]int64 `json:"selfTimeNs"`
+}
+
+// handleTraceStats serves the aggregate statistics of a single trace,
+// so that clients do not need to download the full trace to summarize it.
+func (q *QueryService) handleTraceStats(w http.ResponseWriter, r *http.Request) {
+	traceID := r.PathValue("traceID")
+	q.mu.RLock()
+	td, ok := q.traces[traceID]
+	q.mu.RUnlock()
+	if !ok {
+		writeAdminError(w, http.StatusNotFound, fmt.Errorf("trace not found: %s", traceID))
+		return
+	}
+	stats := modeltrace.NewTree(otlp.ToDomain(td)).Stats()
+	resp := traceStats{
+		TraceID:    traceID,
+		SpanCount:  stats.SpanCount,
+		ErrorCount: stats.ErrorCount,
+		Services:   stats.Services,
+		MaxDepth:   stats.MaxDepth,
+		DurationNs: stats.Duration.Nanoseconds(),
+		SelfTimeNs: make(map[string]int64, len(stats.SelfTime)),
+	}
+	for service, d := range stats.SelfTime {
+		resp.SelfTimeNs[service] = d.Nanoseconds()
+	}
+	writeAdminJSON(w, resp)
+}
+
+// traceBreakdown is the JSON representation of modeltrace.Breakdown.
+type traceBreakdown struct {
+	TraceID    string             `json:"traceId"`
+	DurationNs int64              `json:"durationNs"`
+	IdleNs     int64              `json:"idleNs"`
+	Services   []serviceBreakdown `json:"services"`
+}
+
+// serviceBreakdown is the part of a trace's duration attributed to a service.
+type serviceBreakdown struct {
+	Service    string `json:"service"`
+	DurationNs int64  `json:"durationNs"`
+	// Share is the fraction of the trace duration.
+	Share float64 `json:"share"`
+}
+
+// handleTraceBreakdown serves the share of the wall-clock duration of a
+// trace attributable to each service, in decreasing order.
+func (q *QueryService) handleTraceBreakdown(w http.ResponseWriter, r *http.Request) {
+	traceID := r.PathValue("traceID")
+	q.mu.RLock()
+	td, ok := q.traces[traceID]
+	q.mu.RUnlock()
+	if !ok {
+		writeAdminError(w, http.StatusNotFound, fmt.Errorf("trace not found: %s", traceID))
+		return
+	}
+	breakdown := modeltrace.NewTree(otlp.ToDomain(td)).ServiceBreakdown()
+	resp := traceBreakdown{
+		TraceID:    traceID,
+		DurationNs: breakdown.Duration.Nanoseconds(),
+		IdleNs:     breakdown.Idle.Nanoseconds(),
+		Services:   make([]serviceBreakdown, 0, len(breakdown.Services)),
+	}
+	for service, d := range breakdown.Services {
+		sb := serviceBreakdown{Service: service, DurationNs: d.Nanoseconds()}
+		if breakdown.Duration > 0 {
+			sb.Share = float64(d) / float64(breakdown.Duration)
+		}
+		resp.Services = append(resp.Services, sb)
+	}
+	sort.Slice(resp.Services, func(i, j int) bool {
+		a, b := resp.Services[i], resp.Services[j]
+		return a.DurationNs > b.DurationNs || (a.DurationNs == b.DurationNs && a.Service < b.Service)
+	})
+	writeAdminJSON(w, resp)
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+)
+
+// The remote storage API lets a Jaeger v2 query service or collector use the
+// in-memory data as its backend, by configuring the grpc storage with this
+// server as the endpoint. Reads use the storage v2 TraceReader and
+// DependencyReader services, writes use the OTLP TraceService, or the
+// storage v2 TraceWriter streams of high-volume producers, see
+// streamwrite.go.
+
+// storageTraceReader implements the storage v2 TraceReader.
+type storageTraceReader struct {
+	storagev2.UnimplementedTraceReaderServer
+
+	q *QueryService
+}
+
+// GetTraces returns the requested traces that exist, one message per trace.
+func (s *storageTraceReader) GetTraces(req *storagev2.GetTracesRequest, stream grpc.ServerStreamingServer[trace.TracesData]) error {
+	log.Printf("[STORAGE] GetTraces called for %d traces\n", len(req.Query))
+	maxSize, err := s.q.messageSizeLimit(stream.Context())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	for _, params := range req.Query {
+		traceID := hex.EncodeToString(params.TraceId)
+		s.q.mu.RLock()
+		td, ok := s.q.traces[traceID]
+		s.q.mu.RUnlock()
+		if !ok {
+			continue
+		}
+		s.q.memory.read(traceID)
+		if err := s.send(td, maxSize, stream); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *storageTraceReader) GetServices(context.Context, *storagev2.GetServicesRequest) (*storagev2.GetServicesResponse, error) {
+	return &storagev2.GetServicesResponse{Services: s.q.listedServices()}, nil
+}
+
+// GetOperations returns the operations of a service, only those of the
+// requested span kind if it is set.
+func (s *storageTraceReader) GetOperations(_ context.Context, req *storagev2.GetOperationsRequest) (*storagev2.GetOperationsResponse, error) {
+	resp := &storagev2.GetOperationsResponse{}
+	for _, op := range s.q.operations(req.Service, req.SpanKind) {
+		resp.Operations = append(resp.Operations, &storagev2.Operation{Name: op.name, SpanKind: op.spanKind})
+	}
+	return resp, nil
+}
+
+// FindTraces returns the traces matching the query, at most SearchDepth of them.
+func (s *storageTraceReader) FindTraces(req *storagev2.FindTracesRequest, stream grpc.ServerStreamingServer[trace.TracesData]) error {
+	maxSize, err := s.q.messageSizeLimit(stream.Context())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	found, sample, downsample, err := s.find(stream.Context(), req.GetQuery())
+	if err != nil {
+		return err
+	}
+	log.Printf("[STORAGE] FindTraces matched %d traces\n", len(found))
+	if sample != nil {
+		stream.SetHeader(metadata.Pairs(resultSampleHeader, sample.String()))
+	}
+	if header := downsample.String(); header != "" {
+		stream.SetHeader(metadata.Pairs(downsampleHeader, header))
+	}
+	for _, td := range found {
+		if err := s.send(downsample.result(td), maxSize, stream); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindTraceIDs returns the IDs and time spans of the traces matching the query.
+func (s *storageTraceReader) FindTraceIDs(ctx context.Context, req *storagev2.FindTracesRequest) (*storagev2.FindTraceIDsResponse, error) {
+	found, sample, downsample, err := s.find(ctx, req.GetQuery())
+	if err != nil {
+		return nil, err
+	}
+	if sample != nil {
+		grpc.SetHeader(ctx, metadata.Pairs(resultSampleHeader, sample.String()))
+	}
+	if header := downsample.String(); header != "" {
+		grpc.SetHeader(ctx, metadata.Pairs(downsampleHeader, header))
+	}
+	resp := &storagev2.FindTraceIDsResponse{}
+	for _, td := range found {
+		var traceID []byte
+		var start, end uint64
+		forEachSpan(td, func(_ string, span *trace.Span) {
+			traceID = span.TraceId
+			if start == 0 || span.StartTimeUnixNano < start {
+				start = span.StartTimeUnixNano
+			}
+			end = max(end, span.EndTimeUnixNano)
+		})
+		resp.TraceIds = append(resp.TraceIds, &storagev2.FoundTraceID{
+			TraceId: traceID,
+			Start:   timestamppb.New(time.Unix(0, int64(start))),
+			End:     timestamppb.New(time.Unix(0, int64(end))),
+		})
+	}
+	return resp, nil
+}
+
+// find matches the service, operation, attributes and start time range of
+// the query, like the query service does, and returns the traces in a stable order, with the
+// sample if it was limited by a stratified sample and the downsampling of the query, whose
+// stride is applied. String attribute values may be typed predicates, other values match exactly.
+func (s *storageTraceReader) find(ctx context.Context, query *storagev2.TraceQueryParameters) ([]*trace.TracesData, *resultSample, downsampling, error) {
+	attributes := make(map[string]string, len(query.GetAttributes()))
+	for _, kv := range query.GetAttributes() {
+		switch v := kv.GetValue().GetValue().(type) {
+		case *storagev2.AnyValue_StringValue:
+			attributes[kv.Key] = v.StringValue
+		case *storagev2.AnyValue_IntValue:
+			attributes[kv.Key] = opEqual + strconv.FormatInt(v.IntValue, 10)
+		case *storagev2.AnyValue_DoubleValue:
+			attributes[kv.Key] = opEqual + strconv.FormatFloat(v.DoubleValue, 'g', -1, 64)
+		case *storagev2.AnyValue_BoolValue:
+			attributes[kv.Key] = opEqual + strconv.FormatBool(v.BoolValue)
+		default:
+			return nil, nil, downsampling{}, status.Errorf(codes.InvalidArgument, "unsupported value type of attribute %s", kv.Key)
+		}
+	}
+	sampling, err := parseSampleOption(attributes)
+	if err != nil {
+		return nil, nil, downsampling{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	downsample, err := parseDownsampling(attributes)
+	if err != nil {
+		return nil, nil, downsampling{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	v3query, filters, err := s.q.queryFilters(&api_v3.TraceQueryParameters{
+		ServiceName:   query.GetServiceName(),
+		OperationName: query.GetOperationName(),
+		Attributes:    attributes,
+		StartTimeMin:  query.GetStartTimeMin(),
+		StartTimeMax:  query.GetStartTimeMax(),
+	})
+	if err != nil {
+		return nil, nil, downsampling{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx, cancel := s.q.regex.withTimeout(ctx, filters)
+	defer cancel()
+	found, err := s.q.findTraces(ctx, v3query, filters, queryHints{})
+	if err != nil {
+		return nil, nil, downsampling{}, status.FromContextError(err).Err()
+	}
+	found, sample := limitTraces(downsample.strided(found), int(query.GetSearchDepth()), sampling)
+	return found, sample, downsample, nil
+}
+
+// send sends the trace in consecutive messages of at most maxSize bytes.
+func (s *storageTraceReader) send(td *trace.TracesData, maxSize int, stream grpc.ServerStreamingServer[trace.TracesData]) error {
+	if s.q.exportAnonymizer != nil {
+		td = s.q.exportAnonymizer.anonymized(td)
+	}
+	for _, chunk := range splitTraces(&trace.TracesData{ResourceSpans: td.ResourceSpans}, maxSize) {
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storageDependencyReader implements the storage v2 DependencyReader.
+type storageDependencyReader struct {
+	storagev2.UnimplementedDependencyReaderServer
+
+	q *QueryService
+}
+
+// GetDependencies returns the calls between services among the spans that
+// started within the requested time range, see dependencyLinks, with the
+// differential privacy noise of the tenant.
+func (s *storageDependencyReader) GetDependencies(ctx context.Context, req *storagev2.GetDependenciesRequest) (*storagev2.GetDependenciesResponse, error) {
+	links := noisyDependencies(s.q.privacy.noiseForContext(ctx), s.q.dependencyLinks(req.StartTime, req.EndTime))
+	return &storagev2.GetDependenciesResponse{Dependencies: links}, nil
+}
+
+// storageTraceWriter implements the OTLP TraceService, which the remote
+// storage uses for writes.
+type storageTraceWriter struct {
+	collectortrace.UnimplementedTraceServiceServer
+
+	q *QueryService
+}
+
+// Export stores the spans. Invalid spans are rejected, as reported in the
+// partial success of the response, and requests with too many spans fail.
+func (s *storageTraceWriter) Export(_ context.Context, req *collectortrace.ExportTraceServiceRequest) (*collectortrace.ExportTraceServiceResponse, error) {
+	td := &trace.TracesData{ResourceSpans: req.ResourceSpans}
+	if err := checkBatchSize(countSpans(td)); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	rejected := s.q.receiveTraces(td)
+	log.Printf("[STORAGE] Export received %d resource spans, rejected %d spans\n", len(req.ResourceSpans), len(rejected))
+	resp := &collectortrace.ExportTraceServiceResponse{}
+	if len(rejected) > 0 {
+		resp.PartialSuccess = &collectortrace.ExportTracePartialSuccess{
+			RejectedSpans: int64(len(rejected)),
+			ErrorMessage:  errors.Join(rejected...).Error(),
+		}
+	}
+	return resp, nil
+}
+
+// forEachSpan calls fn with every span of the trace and the name of its service.
+func forEachSpan(td *trace.TracesData, fn func(service string, span *trace.Span)) {
+	for _, rs := range td.ResourceSpans {
+		service := getServiceName(rs.Resource)
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				fn(service, span)
+			}
+		}
+	}
+}
+
+func firstTraceID(td *trace.TracesData) []byte {
+	var traceID []byte
+	forEachSpan(td, func(_ string, span *trace.Span) {
+		if traceID == nil {
+			traceID = span.TraceId
+		}
+	})
+	return traceID
+}
+
+// spanKindName returns the span kind in the lower case form used by the
+// storage API, e.g. "server".
+func spanKindName(kind trace.Span_SpanKind) string {
+	switch kind {
+	case trace.Span_SPAN_KIND_INTERNAL:
+		return "internal"
+	case trace.Span_SPAN_KIND_SERVER:
+		return "server"
+	case trace.Span_SPAN_KIND_CLIENT:
+		return "client"
+	case trace.Span_SPAN_KIND_PRODUCER:
+		return "producer"
+	case trace.Span_SPAN_KIND_CONSUMER:
+		return "consumer"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// storeStats describes the traces held in memory.
+type storeStats struct {
+	Traces   int `json:"traces"`
+	Spans    int `json:"spans"`
+	Services int `json:"services"`
+	// EstimatedBytes is the size of the traces in the protobuf encoding,
+	// which is below their size in memory.
+	EstimatedBytes int `json:"estimatedBytes"`
+}
+
+// deleteResult is the response of the deletion endpoints.
+type deleteResult struct {
+	DeletedTraces int `json:"deletedTraces"`
+}
+
+func (q *QueryService) storeStats() storeStats {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	stats := storeStats{
+		Traces:   len(q.traces),
+		Services: len(q.catalogLocked().services),
+	}
+	for _, td := range q.traces {
+		forEachSpan(td, func(string, *trace.Span) { stats.Spans++ })
+		stats.EstimatedBytes += proto.Size(td)
+	}
+	return stats
+}
+
+// purgeTraces removes all the traces and returns their number. The
+// visibility of the services is kept.
+func (q *QueryService) purgeTraces() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := len(q.traces)
+	q.traces = make(map[string]*trace.TracesData)
+	q.index = newTraceIndex()
+	q.catalog.Store(nil)
+	q.memory.reset()
+	return n
+}
+
+// removeTrace removes the trace and reports whether it was stored.
+func (q *QueryService) removeTrace(traceID string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.traces[traceID]; !ok {
+		return false
+	}
+	q.deleteTrace(traceID)
+	q.memory.forget(traceID)
+	return true
+}
+
+// handleStoreStats serves the number and size of the stored traces.
+func (q *QueryService) handleStoreStats(w http.ResponseWriter, _ *http.Request) {
+	writeAdminJSON(w, q.storeStats())
+}
+
+// handlePurgeTraces removes all the traces, e.g. to reset the demo between
+// the test cases using it as a fixture.
+func (q *QueryService) handlePurgeTraces(w http.ResponseWriter, _ *http.Request) {
+	n := q.purgeTraces()
+	log.Printf("[ADMIN] Purged %d traces\n", n)
+	writeAdminJSON(w, deleteResult{DeletedTraces: n})
+}
+
+// handleDeleteTrace removes a single trace.
+func (q *QueryService) handleDeleteTrace(w http.ResponseWriter, r *http.Request) {
+	traceID := r.PathValue("traceID")
+	if !q.removeTrace(traceID) {
+		writeAdminError(w, http.StatusNotFound, fmt.Errorf("trace not found: %s", traceID))
+		return
+	}
+	log.Printf("[ADMIN] Deleted trace %s\n", traceID)
+	writeAdminJSON(w, deleteResult{DeletedTraces: 1})
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+// adminCall sends a request to the admin endpoints and decodes the JSON response.
+func adminCall(t *testing.T, handler http.Handler, method, path string, resp any) int {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	if resp != nil {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp), w.Body.String())
+	}
+	return w.Code
+}
+
+func TestStoreAdmin(t *testing.T) {
+	q := NewQueryService()
+	var err error
+	q.memory, err = newMemoryLimits(memoryOptions{MaxTraces: 100})
+	require.NoError(t, err)
+	require.Empty(t, q.importTraces(testBatch(0, 0, 0)))
+	require.Empty(t, q.importTraces(testBatch(1, 0, 1)))
+	handler := newAdminHandler(q, nil, nil)
+
+	var stats storeStats
+	require.Equal(t, http.StatusOK, adminCall(t, handler, http.MethodGet, "/api/admin/store", &stats))
+	assert.Equal(t, testTraces, stats.Traces)
+	assert.Equal(t, 2*testTraces, stats.Spans)
+	assert.Equal(t, 2, stats.Services)
+	assert.Positive(t, stats.EstimatedBytes)
+
+	traceID := hex.EncodeToString(testTraceID(3))
+	var deleted deleteResult
+	require.Equal(t, http.StatusOK, adminCall(t, handler, http.MethodDelete, "/api/admin/traces/"+traceID, &deleted))
+	assert.Equal(t, 1, deleted.DeletedTraces)
+	assert.Equal(t, http.StatusNotFound, adminCall(t, handler, http.MethodDelete, "/api/admin/traces/"+traceID, nil))
+	assert.NotContains(t, q.memory.entries, traceID)
+	assert.NotContains(t, foundTraceIDs(t, q, &api_v3.TraceQueryParameters{ServiceName: "service-0"}, nil), traceID)
+
+	require.Equal(t, http.StatusOK, adminCall(t, handler, http.MethodDelete, "/api/admin/traces", &deleted))
+	assert.Equal(t, testTraces-1, deleted.DeletedTraces)
+	require.Equal(t, http.StatusOK, adminCall(t, handler, http.MethodGet, "/api/admin/store", &stats))
+	assert.Equal(t, storeStats{}, stats)
+	assert.Empty(t, q.serviceCatalog().services)
+	assert.Empty(t, q.memory.entries)
+
+	// the store is usable after a purge
+	require.Empty(t, q.importTraces(testBatch(2, 0, 0)))
+	assert.Equal(t, []string{"service-2"}, q.serviceCatalog().services)
+}
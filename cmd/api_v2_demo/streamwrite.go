@@ -0,0 +1,134 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+)
+
+// streamWriteOptions configures the batching of the WriteTraces streams.
+type streamWriteOptions struct {
+	// MaxBatchSpans is the number of buffered spans above which they are
+	// written to the store and acknowledged.
+	MaxBatchSpans int
+	// FlushInterval is how often the buffered spans are written and
+	// acknowledged, when there are fewer than MaxBatchSpans.
+	FlushInterval time.Duration
+}
+
+// storageStreamWriter implements the storage v2 TraceWriter, buffering the
+// batches of each stream to write them to the store together.
+type storageStreamWriter struct {
+	storagev2.UnimplementedTraceWriterServer
+
+	q    *QueryService
+	opts streamWriteOptions
+}
+
+func newStorageStreamWriter(q *QueryService, opts streamWriteOptions) (*storageStreamWriter, error) {
+	if opts.MaxBatchSpans < 1 || opts.FlushInterval <= 0 {
+		return nil, errors.New("the max batch spans and the flush interval must be positive")
+	}
+	return &storageStreamWriter{q: q, opts: opts}, nil
+}
+
+// writeBuffer holds the batches of a stream that are not written yet.
+type writeBuffer struct {
+	traces  *trace.TracesData
+	spans   int
+	batches int
+	// last is the sequence number of the last batch received.
+	last uint64
+}
+
+// WriteTraces buffers the received batches and writes them to the store,
+// like Export, when MaxBatchSpans spans are buffered, every FlushInterval
+// and when the client closes the stream. Each write is acknowledged with
+// the sequence number of its last batch. The buffered batches are dropped
+// if the stream fails, as the client resends them.
+func (s *storageStreamWriter) WriteTraces(stream grpc.BidiStreamingServer[storagev2.WriteTracesRequest, storagev2.WriteTracesResponse]) error {
+	requests := make(chan *storagev2.WriteTracesRequest)
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				errc <- err
+				return
+			}
+			select {
+			case requests <- req:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(s.opts.FlushInterval)
+	defer ticker.Stop()
+	buf := &writeBuffer{traces: &trace.TracesData{}}
+	received := false
+	for {
+		select {
+		case req := <-requests:
+			if received && req.SequenceNumber <= buf.last {
+				return status.Errorf(codes.InvalidArgument, "sequence number %d is not greater than %d", req.SequenceNumber, buf.last)
+			}
+			spans := countSpans(req.Traces)
+			if err := checkBatchSize(spans); err != nil {
+				return status.Errorf(codes.InvalidArgument, "batch %d: %v", req.SequenceNumber, err)
+			}
+			received = true
+			buf.last = req.SequenceNumber
+			buf.traces.ResourceSpans = append(buf.traces.ResourceSpans, req.Traces.GetResourceSpans()...)
+			buf.spans += spans
+			buf.batches++
+			if buf.spans >= s.opts.MaxBatchSpans {
+				if err := s.flush(buf, stream); err != nil {
+					return err
+				}
+			}
+		case <-ticker.C:
+			if err := s.flush(buf, stream); err != nil {
+				return err
+			}
+		case err := <-errc:
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+			return s.flush(buf, stream)
+		}
+	}
+}
+
+// flush writes the buffered batches to the store and acknowledges them,
+// if there are any.
+func (s *storageStreamWriter) flush(buf *writeBuffer, stream grpc.BidiStreamingServer[storagev2.WriteTracesRequest, storagev2.WriteTracesResponse]) error {
+	if buf.batches == 0 {
+		return nil
+	}
+	rejected := s.q.receiveTraces(buf.traces)
+	log.Printf("[STORAGE] WriteTraces wrote %d batches up to %d, %d spans, rejected %d spans\n", buf.batches, buf.last, buf.spans, len(rejected))
+	resp := &storagev2.WriteTracesResponse{
+		SequenceNumber: buf.last,
+		AcceptedSpans:  int64(buf.spans - len(rejected)),
+		RejectedSpans:  int64(len(rejected)),
+	}
+	if len(rejected) > 0 {
+		resp.ErrorMessage = errors.Join(rejected...).Error()
+	}
+	buf.traces, buf.spans, buf.batches = &trace.TracesData{}, 0, 0
+	return stream.Send(resp)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+)
+
+// newStreamWriterClient serves a stream writer of q with the options in memory.
+func newStreamWriterClient(t *testing.T, q *QueryService, opts streamWriteOptions) storagev2.TraceWriterClient {
+	w, err := newStorageStreamWriter(q, opts)
+	require.NoError(t, err)
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	storagev2.RegisterTraceWriterServer(s, w)
+	go s.Serve(lis)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+	})
+	return storagev2.NewTraceWriterClient(conn)
+}
+
+func TestWriteTracesBatching(t *testing.T) {
+	q := NewQueryService()
+	client := newStreamWriterClient(t, q, streamWriteOptions{MaxBatchSpans: 2 * testTraces, FlushInterval: time.Hour})
+	stream, err := client.WriteTraces(context.Background())
+	require.NoError(t, err)
+
+	// the second batch fills the buffer
+	require.NoError(t, stream.Send(&storagev2.WriteTracesRequest{SequenceNumber: 1, Traces: testBatch(0, 0, 0)}))
+	require.NoError(t, stream.Send(&storagev2.WriteTracesRequest{SequenceNumber: 2, Traces: testBatch(1, 0, 1)}))
+	ack, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), ack.SequenceNumber)
+	assert.Equal(t, int64(2*testTraces), ack.AcceptedSpans)
+
+	// closing the stream flushes the rest, with the rejected spans
+	batch := testBatch(2, 0, 2)
+	batch.ResourceSpans[0].ScopeSpans[0].Spans[0].TraceId = nil
+	require.NoError(t, stream.Send(&storagev2.WriteTracesRequest{SequenceNumber: 5, Traces: batch}))
+	require.NoError(t, stream.CloseSend())
+	ack, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), ack.SequenceNumber)
+	assert.Equal(t, int64(testTraces-1), ack.AcceptedSpans)
+	assert.Equal(t, int64(1), ack.RejectedSpans)
+	assert.Contains(t, ack.ErrorMessage, "trace ID")
+	_, err = stream.Recv()
+	require.ErrorIs(t, err, io.EOF)
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	require.Len(t, q.traces, testTraces)
+	spans := 0
+	for _, td := range q.traces {
+		spans += countSpans(td)
+	}
+	assert.Equal(t, 3*testTraces-1, spans)
+}
+
+func TestWriteTracesFlushInterval(t *testing.T) {
+	q := NewQueryService()
+	client := newStreamWriterClient(t, q, streamWriteOptions{MaxBatchSpans: 1000, FlushInterval: 10 * time.Millisecond})
+	stream, err := client.WriteTraces(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(&storagev2.WriteTracesRequest{SequenceNumber: 7, Traces: testBatch(0, 0, 0)}))
+	ack, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), ack.SequenceNumber)
+	assert.Equal(t, int64(testTraces), ack.AcceptedSpans)
+	assert.Zero(t, ack.RejectedSpans)
+	require.NoError(t, stream.CloseSend())
+	_, err = stream.Recv()
+	require.ErrorIs(t, err, io.EOF, "the written batches are not acknowledged again")
+}
+
+func TestWriteTracesErrors(t *testing.T) {
+	client := newStreamWriterClient(t, NewQueryService(), streamWriteOptions{MaxBatchSpans: 1000, FlushInterval: time.Hour})
+	write := func(requests ...*storagev2.WriteTracesRequest) error {
+		stream, err := client.WriteTraces(context.Background())
+		require.NoError(t, err)
+		for _, req := range requests {
+			if err := stream.Send(req); err != nil {
+				break
+			}
+		}
+		stream.CloseSend()
+		for {
+			if _, err := stream.Recv(); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+
+	err := write(
+		&storagev2.WriteTracesRequest{SequenceNumber: 3, Traces: testBatch(0, 0, 0)},
+		&storagev2.WriteTracesRequest{SequenceNumber: 3, Traces: testBatch(0, 0, 1)},
+	)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.ErrorContains(t, err, "sequence number 3 is not greater than 3")
+
+	_, err = newStorageStreamWriter(NewQueryService(), streamWriteOptions{MaxBatchSpans: 1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	modeltrace "github.com/jaegertracing/jaeger-idl/model/trace"
+)
+
+// Relations of a structural query.
+const (
+	relationDescendant = "descendant"
+	relationChild      = "child"
+)
+
+// structuralQuery finds the traces where a span matching Ancestor has a
+// descendant, or a child, matching Descendant.
+//
+// Example, the frontend spans that lead to a failing database call:
+//
+//	{"ancestor": {"service": "frontend"},
+//	 "descendant": {"service": "database", "attributes": {"error": "true"}}}
+type structuralQuery struct {
+	Ancestor   spanPattern `json:"ancestor"`
+	Descendant spanPattern `json:"descendant"`
+	// Relation is descendant (the default) or child.
+	Relation string `json:"relation,omitempty"`
+	Limit    int    `json:"limit,omitempty"`
+}
+
+// spanPattern selects spans like the trace queries do, the attribute values
+// may be typed predicates.
+type spanPattern struct {
+	Service    string            `json:"service"`
+	Operation  string            `json:"operation,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// structuralResponse lists the matching traces along with the execution of the plan.
+type structuralResponse struct {
+	Plan   structuralPlan    `json:"plan"`
+	Traces []structuralTrace `json:"traces"`
+}
+
+// structuralPlan reports the two phases of a structural query: the scan for
+// candidate traces, which have spans matching both patterns, then the
+// evaluation of the relation on the tree of the candidates.
+type structuralPlan struct {
+	Scanned    int `json:"scanned"`
+	Candidates int `json:"candidates"`
+	Evaluated  int `json:"evaluated"`
+	Matched    int `json:"matched"`
+}
+
+// structuralTrace is a matching trace with the related span IDs.
+type structuralTrace struct {
+	TraceID string           `json:"traceId"`
+	Pairs   []structuralPair `json:"pairs"`
+}
+
+type structuralPair struct {
+	Ancestor   string `json:"ancestor"`
+	Descendant string `json:"descendant"`
+}
+
+// compiledPattern is a spanPattern with its attribute filters parsed.
+type compiledPattern struct {
+	query   *api_v3.TraceQueryParameters
+	filters []attributeFilter
+}
+
+func (q *QueryService) compilePattern(p spanPattern) (*compiledPattern, error) {
+	if p.Service == "" {
+		return nil, errors.New("service is required")
+	}
+	query, filters, err := q.queryFilters(&api_v3.TraceQueryParameters{
+		ServiceName:   p.Service,
+		OperationName: p.Operation,
+		Attributes:    p.Attributes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &compiledPattern{query: query, filters: filters}, nil
+}
+
+// matchingSpans returns the hex IDs of the spans of the trace matching the pattern.
+func (p *compiledPattern) matchingSpans(td *trace.TracesData) map[string]bool {
+	ids := make(map[string]bool)
+	for _, rs := range td.ResourceSpans {
+		if getServiceName(rs.Resource) != p.query.ServiceName {
+			continue
+		}
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				if p.query.OperationName != "" && span.Name != p.query.OperationName {
+					continue
+				}
+				if spanMatches(span, rs, p.filters) {
+					ids[hex.EncodeToString(span.SpanId)] = true
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// handleStructuralQuery serves the experimental structural queries.
+func (q *QueryService) handleStructuralQuery(w http.ResponseWriter, r *http.Request) {
+	var query structuralQuery
+	if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+		writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	rel := modeltrace.Descendant
+	switch query.Relation {
+	case "", relationDescendant:
+	case relationChild:
+		rel = modeltrace.Child
+	default:
+		writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid relation %q, expected %s or %s",
+			query.Relation, relationDescendant, relationChild))
+		return
+	}
+	ancestor, err := q.compilePattern(query.Ancestor)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, fmt.Errorf("ancestor: %w", err))
+		return
+	}
+	descendant, err := q.compilePattern(query.Descendant)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, fmt.Errorf("descendant: %w", err))
+		return
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	ctx, cancel := q.regex.withTimeout(r.Context(), slices.Concat(ancestor.filters, descendant.filters))
+	defer cancel()
+
+	type candidate struct {
+		traceID                string
+		td                     *trace.TracesData
+		ancestors, descendants map[string]bool
+	}
+	resp := structuralResponse{Traces: []structuralTrace{}}
+	q.mu.RLock()
+	var candidates []candidate
+	for traceID, td := range q.traces {
+		if ctx.Err() != nil {
+			break
+		}
+		resp.Plan.Scanned++
+		ancestors := ancestor.matchingSpans(td)
+		if len(ancestors) == 0 {
+			continue
+		}
+		if descendants := descendant.matchingSpans(td); len(descendants) > 0 {
+			candidates = append(candidates, candidate{traceID, td, ancestors, descendants})
+		}
+	}
+	resp.Plan.Candidates = len(candidates)
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].traceID < candidates[j].traceID
+	})
+	for _, c := range candidates {
+		if ctx.Err() != nil || len(resp.Traces) >= limit {
+			break
+		}
+		resp.Plan.Evaluated++
+		tree := modeltrace.NewTree(otlp.ToDomain(c.td))
+		pairs := tree.FindPairs(
+			func(node *modeltrace.Node) bool { return c.ancestors[node.Span.SpanID.String()] },
+			func(node *modeltrace.Node) bool { return c.descendants[node.Span.SpanID.String()] },
+			rel,
+		)
+		if len(pairs) == 0 {
+			continue
+		}
+		match := structuralTrace{TraceID: c.traceID}
+		for _, pair := range pairs {
+			match.Pairs = append(match.Pairs, structuralPair{
+				Ancestor:   pair.Ancestor.Span.SpanID.String(),
+				Descendant: pair.Descendant.Span.SpanID.String(),
+			})
+		}
+		resp.Traces = append(resp.Traces, match)
+	}
+	q.mu.RUnlock()
+	if ctx.Err() != nil {
+		writeAdminError(w, http.StatusServiceUnavailable, fmt.Errorf("query aborted: %w", ctx.Err()))
+		return
+	}
+	resp.Plan.Matched = len(resp.Traces)
+	log.Printf("[QUERY experimental] Structural query scanned %d traces, %d candidates, %d matched\n",
+		resp.Plan.Scanned, resp.Plan.Candidates, resp.Plan.Matched)
+	writeAdminJSON(w, resp)
+}
@@ -0,0 +1,433 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"container/list"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	common "go.opentelemetry.io/proto/otlp/common/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// Types of the tail sampling policies.
+const (
+	// policyError keeps the traces with a span with the error status.
+	policyError = "error"
+	// policyLatency keeps the traces lasting at least the threshold, from
+	// the start of their first span to the end of their last span.
+	policyLatency = "latency"
+	// policyAttribute keeps the traces with a span or resource attribute
+	// with one of the values.
+	policyAttribute = "attribute"
+	// policyProbabilistic keeps a proportion of the traces, picked by their
+	// trace IDs so that all the collectors make the same decisions.
+	policyProbabilistic = "probabilistic"
+)
+
+// tailSamplingConfig configures the tail sampler: the spans received by the
+// collector endpoints are held for the decision wait after the first span of
+// their trace, then the trace is kept if any of the policies matches it, and
+// dropped otherwise. The spans of a decided trace received during another
+// decision wait follow the decision. With maxPendingTraces, the trace
+// pending for the longest time is decided early, on the spans received so
+// far, when a new trace would exceed it.
+//
+// Example:
+//
+//	{
+//	  "decisionWait": "10s",
+//	  "maxPendingTraces": 100000,
+//	  "policies": [
+//	    {"name": "errors", "type": "error"},
+//	    {"name": "slow", "type": "latency", "threshold": "500ms"},
+//	    {"name": "vip", "type": "attribute", "key": "tenant", "values": ["acme"]},
+//	    {"name": "baseline", "type": "probabilistic", "rate": 0.1}
+//	  ]
+//	}
+type tailSamplingConfig struct {
+	DecisionWait duration `json:"decisionWait"`
+	// MaxPendingTraces caps the traces held at once, 0 for no cap.
+	MaxPendingTraces int              `json:"maxPendingTraces,omitempty"`
+	Policies         []samplingPolicy `json:"policies"`
+}
+
+// samplingPolicy is a policy of the tail sampler, with the fields of its type.
+type samplingPolicy struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Threshold is the duration of the latency policy.
+	Threshold duration `json:"threshold,omitempty"`
+	// Key and Values are the attribute of the attribute policy.
+	Key    string   `json:"key,omitempty"`
+	Values []string `json:"values,omitempty"`
+	// Rate is the proportion of the traces kept by the probabilistic policy.
+	Rate float64 `json:"rate,omitempty"`
+}
+
+// duration is a time.Duration in the format of time.ParseDuration in JSON.
+type duration time.Duration
+
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = duration(parsed)
+	return nil
+}
+
+func loadTailSamplingConfig(path string) (*tailSamplingConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read tail sampling config: %w", err)
+	}
+	var cfg tailSamplingConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse tail sampling config: %w", err)
+	}
+	if cfg.DecisionWait <= 0 {
+		return nil, errors.New("the decision wait must be positive")
+	}
+	if cfg.MaxPendingTraces < 0 {
+		return nil, errors.New("the max pending traces must not be negative")
+	}
+	if len(cfg.Policies) == 0 {
+		return nil, errors.New("no sampling policies")
+	}
+	for i, p := range cfg.Policies {
+		if err := p.validate(); err != nil {
+			return nil, fmt.Errorf("policy %d (%s): %w", i, p.Name, err)
+		}
+	}
+	return &cfg, nil
+}
+
+func (p samplingPolicy) validate() error {
+	if p.Name == "" {
+		return errors.New("the name must be set")
+	}
+	switch p.Type {
+	case policyError:
+	case policyLatency:
+		if p.Threshold <= 0 {
+			return errors.New("the threshold must be positive")
+		}
+	case policyAttribute:
+		if p.Key == "" || len(p.Values) == 0 {
+			return errors.New("the key and values must be set")
+		}
+	case policyProbabilistic:
+		if p.Rate < 0 || p.Rate > 1 {
+			return errors.New("the rate must be between 0 and 1")
+		}
+	default:
+		return fmt.Errorf("unknown type %q, expected %s, %s, %s or %s", p.Type, policyError, policyLatency, policyAttribute, policyProbabilistic)
+	}
+	return nil
+}
+
+// matches returns whether the policy keeps the trace.
+func (p samplingPolicy) matches(td *trace.TracesData) bool {
+	switch p.Type {
+	case policyError:
+		matched := false
+		forEachSpan(td, func(_ string, span *trace.Span) {
+			matched = matched || span.GetStatus().GetCode() == trace.Status_STATUS_CODE_ERROR
+		})
+		return matched
+	case policyLatency:
+		var start, end uint64 = math.MaxUint64, 0
+		forEachSpan(td, func(_ string, span *trace.Span) {
+			start = min(start, span.StartTimeUnixNano)
+			end = max(end, span.EndTimeUnixNano)
+		})
+		return end > start && time.Duration(end-start) >= time.Duration(p.Threshold)
+	case policyAttribute:
+		matches := func(attrs []*common.KeyValue) bool {
+			return slices.ContainsFunc(attrs, func(attr *common.KeyValue) bool {
+				return attr.Key == p.Key && slices.Contains(p.Values, attributeString(attr.Value))
+			})
+		}
+		for _, rs := range td.ResourceSpans {
+			if matches(rs.GetResource().GetAttributes()) {
+				return true
+			}
+		}
+		matched := false
+		forEachSpan(td, func(_ string, span *trace.Span) {
+			matched = matched || matches(span.Attributes)
+		})
+		return matched
+	case policyProbabilistic:
+		return traceIDSampled(firstTraceID(td), p.Rate)
+	}
+	return false
+}
+
+// traceIDSampled picks the proportion rate of the trace IDs, by the low half
+// of the trace ID, so that the decision of every span of a trace is the same.
+func traceIDSampled(traceID []byte, rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+	return len(traceID) == 16 && binary.BigEndian.Uint64(traceID[8:]) < uint64(rate*math.MaxUint64)
+}
+
+// tailSampler holds the received spans per trace until their trace is
+// decided, and keeps the decisions for late spans.
+type tailSampler struct {
+	config *tailSamplingConfig
+
+	mu      sync.Mutex
+	pending map[string]*pendingTrace
+	// order lists the IDs of the pending traces, first received first.
+	order     *list.List
+	decisions map[string]samplingDecision
+	stats     tailSamplingStats
+}
+
+type pendingTrace struct {
+	td       *trace.TracesData
+	received time.Time
+	elem     *list.Element
+}
+
+type samplingDecision struct {
+	keep    bool
+	decided time.Time
+}
+
+// tailSamplingStats is the report of the admin endpoint.
+type tailSamplingStats struct {
+	DecisionWait     string `json:"decisionWait"`
+	PendingTraces    int    `json:"pendingTraces"`
+	MaxPendingTraces int    `json:"maxPendingTraces,omitempty"`
+	KeptTraces       int64  `json:"keptTraces"`
+	DroppedTraces    int64  `json:"droppedTraces"`
+	DroppedSpans     int64  `json:"droppedSpans"`
+	// ForcedDecisions counts the traces decided before their decision
+	// wait, to stay within the max pending traces.
+	ForcedDecisions int64          `json:"forcedDecisions"`
+	KeptBy          map[string]int `json:"keptBy"`
+}
+
+func newTailSampler(config *tailSamplingConfig) *tailSampler {
+	return &tailSampler{
+		config:    config,
+		pending:   make(map[string]*pendingTrace),
+		order:     list.New(),
+		decisions: make(map[string]samplingDecision),
+		stats: tailSamplingStats{
+			DecisionWait:     time.Duration(config.DecisionWait).String(),
+			MaxPendingTraces: config.MaxPendingTraces,
+			KeptBy:           make(map[string]int),
+		},
+	}
+}
+
+// receiveTraces imports the spans received by the collector endpoints kept
+// by the ingest filter, through the tail sampler if one is configured.
+// Without it the spans are imported right away, otherwise the invalid spans
+// are rejected right away and the others are held until their traces are
+// decided.
+func (q *QueryService) receiveTraces(td *trace.TracesData) []error {
+	q.ingestRate.add(countSpans(td), time.Now())
+	td = q.ingestFilter.filter(td)
+	if q.tailSampler == nil {
+		return q.importTraces(td)
+	}
+	accepted, rejected := q.acceptSpans(td)
+	q.storeTraces(q.tailSampler.add(accepted, time.Now()))
+	return rejected
+}
+
+// add holds the spans of td until their traces are decided, and returns
+// the spans of the traces already kept, and of the traces kept by the
+// decisions forced by the max pending traces.
+func (s *tailSampler) add(td *trace.TracesData, now time.Time) *trace.TracesData {
+	kept := &trace.TracesData{}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rs := range td.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				traceID := hex.EncodeToString(span.TraceId)
+				if decision, ok := s.decisions[traceID]; ok {
+					if decision.keep {
+						appendAccepted(kept, rs, ss, span)
+					} else {
+						s.stats.DroppedSpans++
+					}
+					continue
+				}
+				p, ok := s.pending[traceID]
+				if !ok {
+					for s.config.MaxPendingTraces > 0 && len(s.pending) >= s.config.MaxPendingTraces {
+						s.decideOldest(kept, now)
+					}
+					p = &pendingTrace{td: &trace.TracesData{}, received: now}
+					s.track(traceID, p)
+				}
+				appendAccepted(p.td, rs, ss, span)
+			}
+		}
+	}
+	return kept
+}
+
+// decide decides the traces whose first span was received at least the
+// decision wait before now, or all of them if all is set, and forgets the
+// decisions older than the decision wait. It returns the kept traces.
+func (s *tailSampler) decide(now time.Time, all bool) []*trace.TracesData {
+	wait := time.Duration(s.config.DecisionWait)
+	var kept []*trace.TracesData
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for traceID, decision := range s.decisions {
+		if now.Sub(decision.decided) >= wait {
+			delete(s.decisions, traceID)
+		}
+	}
+	for e := s.order.Front(); e != nil; {
+		traceID := e.Value.(string)
+		p := s.pending[traceID]
+		if !all && now.Sub(p.received) < wait {
+			break
+		}
+		e = e.Next()
+		if s.decideTrace(traceID, p, now) {
+			kept = append(kept, p.td)
+		}
+	}
+	return kept
+}
+
+// track holds the pending trace, after the ones received before.
+func (s *tailSampler) track(traceID string, p *pendingTrace) {
+	p.elem = s.order.PushBack(traceID)
+	s.pending[traceID] = p
+}
+
+// decideTrace decides the pending trace, and returns whether it is kept.
+func (s *tailSampler) decideTrace(traceID string, p *pendingTrace, now time.Time) bool {
+	s.order.Remove(p.elem)
+	delete(s.pending, traceID)
+	policy := s.policyKeeping(p.td)
+	s.decisions[traceID] = samplingDecision{keep: policy != "", decided: now}
+	if policy == "" {
+		s.stats.DroppedTraces++
+		s.stats.DroppedSpans += int64(countSpans(p.td))
+		return false
+	}
+	s.stats.KeptTraces++
+	s.stats.KeptBy[policy]++
+	return true
+}
+
+// decideOldest decides the trace pending for the longest time before its
+// decision wait, and adds its spans to kept if it is kept.
+func (s *tailSampler) decideOldest(kept *trace.TracesData, now time.Time) {
+	traceID := s.order.Front().Value.(string)
+	p := s.pending[traceID]
+	s.stats.ForcedDecisions++
+	if !s.decideTrace(traceID, p, now) {
+		return
+	}
+	for _, rs := range p.td.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				appendAccepted(kept, rs, ss, span)
+			}
+		}
+	}
+}
+
+// policyKeeping returns the name of the first policy keeping the trace, or
+// "" if none does.
+func (s *tailSampler) policyKeeping(td *trace.TracesData) string {
+	for _, p := range s.config.Policies {
+		if p.matches(td) {
+			return p.Name
+		}
+	}
+	return ""
+}
+
+// run decides the traces until stop is called, which decides the traces
+// still pending if decidePending is set, or leaves them to be handed off.
+func (s *tailSampler) run(q *QueryService) (stop func(decidePending bool)) {
+	done := make(chan bool)
+	stopped := make(chan struct{})
+	flush := func(now time.Time, all bool) {
+		for _, td := range s.decide(now, all) {
+			q.storeTraces(td)
+		}
+	}
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(max(time.Duration(s.config.DecisionWait)/10, 10*time.Millisecond))
+		defer ticker.Stop()
+		for {
+			select {
+			case decidePending := <-done:
+				if decidePending {
+					flush(time.Now(), true)
+				}
+				return
+			case now := <-ticker.C:
+				flush(now, false)
+			}
+		}
+	}()
+	return func(decidePending bool) {
+		done <- decidePending
+		<-stopped
+	}
+}
+
+// handleTailSamplingStats serves the decisions of the tail sampler.
+func (q *QueryService) handleTailSamplingStats(w http.ResponseWriter, _ *http.Request) {
+	if q.tailSampler == nil {
+		writeAdminError(w, http.StatusNotFound, errors.New("no tail sampling is configured"))
+		return
+	}
+	s := q.tailSampler
+	s.mu.Lock()
+	stats := s.stats
+	stats.PendingTraces = len(s.pending)
+	stats.KeptBy = make(map[string]int, len(s.stats.KeptBy))
+	for name, n := range s.stats.KeptBy {
+		stats.KeptBy[name] = n
+	}
+	s.mu.Unlock()
+	writeAdminJSON(w, stats)
+}
+
+// logTailSampling logs the policies of the tail sampler.
+func logTailSampling(cfg *tailSamplingConfig) {
+	names := make([]string, len(cfg.Policies))
+	for i, p := range cfg.Policies {
+		names[i] = fmt.Sprintf("%s (%s)", p.Name, p.Type)
+	}
+	log.Printf("Tail sampling the received traces after %v with the policies %v\n", time.Duration(cfg.DecisionWait), names)
+	if cfg.MaxPendingTraces > 0 {
+		log.Printf("Deciding the traces early above %d pending traces\n", cfg.MaxPendingTraces)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	common "go.opentelemetry.io/proto/otlp/common/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+const testTailSamplingConfig = `{
+  "decisionWait": "1s",
+  "policies": [
+    {"name": "errors", "type": "error"},
+    {"name": "slow", "type": "latency", "threshold": "500ms"},
+    {"name": "vip", "type": "attribute", "key": "tenant", "values": ["acme"]}
+  ]
+}`
+
+func loadTestTailSampling(t *testing.T, config string) (*tailSamplingConfig, error) {
+	path := filepath.Join(t.TempDir(), "sampling.json")
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+	return loadTailSamplingConfig(path)
+}
+
+// sampledSpan returns a batch with a span of the trace lasting d.
+func sampledSpan(traceID, span int, d time.Duration) *trace.TracesData {
+	td := testBatch(0, traceID, span)
+	spans := td.ResourceSpans[0].ScopeSpans[0].Spans[:1]
+	spans[0].EndTimeUnixNano = uint64(testStart.Add(d).UnixNano())
+	td.ResourceSpans[0].ScopeSpans[0].Spans = spans
+	return td
+}
+
+func TestLoadTailSamplingConfig(t *testing.T) {
+	cfg, err := loadTestTailSampling(t, testTailSamplingConfig)
+	require.NoError(t, err)
+	assert.Equal(t, duration(time.Second), cfg.DecisionWait)
+	require.Len(t, cfg.Policies, 3)
+	assert.Equal(t, duration(500*time.Millisecond), cfg.Policies[1].Threshold)
+
+	for _, invalid := range []string{
+		`{`,
+		`{"decisionWait": "soon", "policies": [{"name": "errors", "type": "error"}]}`,
+		`{"policies": [{"name": "errors", "type": "error"}]}`,
+		`{"decisionWait": "1s"}`,
+		`{"decisionWait": "1s", "maxPendingTraces": -1, "policies": [{"name": "errors", "type": "error"}]}`,
+		`{"decisionWait": "1s", "policies": [{"type": "error"}]}`,
+		`{"decisionWait": "1s", "policies": [{"name": "slow", "type": "latency"}]}`,
+		`{"decisionWait": "1s", "policies": [{"name": "vip", "type": "attribute", "key": "tenant"}]}`,
+		`{"decisionWait": "1s", "policies": [{"name": "sample", "type": "probabilistic", "rate": 1.5}]}`,
+		`{"decisionWait": "1s", "policies": [{"name": "all", "type": "always"}]}`,
+	} {
+		_, err := loadTestTailSampling(t, invalid)
+		require.Error(t, err, invalid)
+	}
+	_, err = loadTailSamplingConfig(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestSamplingPolicies(t *testing.T) {
+	errored := sampledSpan(0, 0, time.Millisecond)
+	errored.ResourceSpans[0].ScopeSpans[0].Spans[0].Status = &trace.Status{Code: trace.Status_STATUS_CODE_ERROR}
+	slow := sampledSpan(0, 0, time.Second)
+	tagged := sampledSpan(0, 0, time.Millisecond)
+	tagged.ResourceSpans[0].ScopeSpans[0].Spans[0].Attributes = []*common.KeyValue{{Key: "tenant", Value: stringValue("acme")}}
+	tenant := sampledSpan(0, 0, time.Millisecond)
+	tenant.ResourceSpans[0].Resource.Attributes = append(tenant.ResourceSpans[0].Resource.Attributes, &common.KeyValue{Key: "tenant", Value: stringValue("acme")})
+	plain := sampledSpan(0, 0, time.Millisecond)
+
+	errorPolicy := samplingPolicy{Type: policyError}
+	assert.True(t, errorPolicy.matches(errored))
+	assert.False(t, errorPolicy.matches(plain))
+
+	latency := samplingPolicy{Type: policyLatency, Threshold: duration(500 * time.Millisecond)}
+	assert.True(t, latency.matches(slow))
+	assert.False(t, latency.matches(plain))
+
+	attribute := samplingPolicy{Type: policyAttribute, Key: "tenant", Values: []string{"acme"}}
+	assert.True(t, attribute.matches(tagged))
+	assert.True(t, attribute.matches(tenant))
+	assert.False(t, attribute.matches(plain))
+
+	// the probabilistic policy compares the low half of the trace ID
+	withID := func(low uint64) *trace.TracesData {
+		td := sampledSpan(0, 0, time.Millisecond)
+		binary.BigEndian.PutUint64(td.ResourceSpans[0].ScopeSpans[0].Spans[0].TraceId[8:], low)
+		return td
+	}
+	half := samplingPolicy{Type: policyProbabilistic, Rate: 0.5}
+	assert.True(t, half.matches(withID(1)))
+	assert.False(t, half.matches(withID(math.MaxUint64)))
+	assert.True(t, samplingPolicy{Type: policyProbabilistic, Rate: 1}.matches(withID(math.MaxUint64)))
+	assert.False(t, samplingPolicy{Type: policyProbabilistic}.matches(withID(0)))
+}
+
+func TestTailSampler(t *testing.T) {
+	cfg, err := loadTestTailSampling(t, testTailSamplingConfig)
+	require.NoError(t, err)
+	s := newTailSampler(cfg)
+	now := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	// the slow trace is only known to be slow once its root span arrives
+	assert.Empty(t, s.add(sampledSpan(0, 1, time.Millisecond), now).ResourceSpans)
+	assert.Empty(t, s.add(sampledSpan(1, 0, time.Millisecond), now).ResourceSpans)
+	assert.Empty(t, s.add(sampledSpan(0, 0, time.Second), now.Add(500*time.Millisecond)).ResourceSpans)
+	assert.Empty(t, s.decide(now.Add(500*time.Millisecond), false))
+
+	kept := s.decide(now.Add(time.Second), false)
+	require.Len(t, kept, 1)
+	assert.Equal(t, 2, countSpans(kept[0]))
+	assert.Equal(t, testTraceID(0), firstTraceID(kept[0]))
+	assert.Empty(t, s.pending)
+
+	// the late spans follow the decisions
+	assert.Equal(t, 1, countSpans(s.add(sampledSpan(0, 2, time.Millisecond), now.Add(1500*time.Millisecond))))
+	assert.Empty(t, s.add(sampledSpan(1, 1, time.Millisecond), now.Add(1500*time.Millisecond)).ResourceSpans)
+
+	// until they are forgotten
+	assert.Empty(t, s.decide(now.Add(2*time.Second), false))
+	assert.Empty(t, s.decisions)
+	assert.Empty(t, s.add(sampledSpan(1, 2, time.Millisecond), now.Add(2*time.Second)).ResourceSpans)
+	assert.Empty(t, s.decide(now.Add(2*time.Second), true))
+
+	assert.Equal(t, tailSamplingStats{
+		DecisionWait:  "1s",
+		KeptTraces:    1,
+		DroppedTraces: 2,
+		DroppedSpans:  3,
+		KeptBy:        map[string]int{"slow": 1},
+	}, s.stats)
+}
+
+func TestTailSamplerMaxPending(t *testing.T) {
+	cfg, err := loadTestTailSampling(t, testTailSamplingConfig)
+	require.NoError(t, err)
+	cfg.MaxPendingTraces = 2
+	s := newTailSampler(cfg)
+	now := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	assert.Empty(t, s.add(sampledSpan(0, 0, time.Second), now).ResourceSpans)
+	assert.Empty(t, s.add(sampledSpan(1, 0, time.Millisecond), now.Add(time.Millisecond)).ResourceSpans)
+	// a new trace forces the decision of the oldest one, which is kept
+	kept := s.add(sampledSpan(2, 0, time.Millisecond), now.Add(2*time.Millisecond))
+	assert.Equal(t, 1, countSpans(kept))
+	assert.Equal(t, testTraceID(0), firstTraceID(kept))
+	// then dropped
+	assert.Empty(t, s.add(sampledSpan(3, 0, time.Millisecond), now.Add(3*time.Millisecond)).ResourceSpans)
+	// the spans of the pending traces do not force decisions
+	assert.Empty(t, s.add(sampledSpan(3, 1, time.Millisecond), now.Add(3*time.Millisecond)).ResourceSpans)
+	assert.Len(t, s.pending, 2)
+	assert.Equal(t, 2, s.order.Len())
+
+	// the traces are decided in the order they were received
+	assert.Empty(t, s.decide(now.Add(time.Second+2*time.Millisecond), false))
+	assert.Equal(t, []string{hex.EncodeToString(testTraceID(3))}, slices.Collect(maps.Keys(s.pending)))
+
+	assert.Equal(t, tailSamplingStats{
+		DecisionWait:     "1s",
+		MaxPendingTraces: 2,
+		KeptTraces:       1,
+		DroppedTraces:    2,
+		DroppedSpans:     2,
+		ForcedDecisions:  2,
+		KeptBy:           map[string]int{"slow": 1},
+	}, s.stats)
+}
+
+func TestReceiveTracesTailSampling(t *testing.T) {
+	q := NewQueryService()
+	handler := newAdminHandler(q, newUsageTracker(), nil)
+	getStats := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/sampling/tail", nil))
+		return w
+	}
+	assert.Equal(t, http.StatusNotFound, getStats().Code)
+
+	cfg, err := loadTestTailSampling(t, testTailSamplingConfig)
+	require.NoError(t, err)
+	cfg.DecisionWait = duration(time.Hour)
+	q.tailSampler = newTailSampler(cfg)
+	stop := q.tailSampler.run(q)
+
+	errored := sampledSpan(0, 0, time.Millisecond)
+	errored.ResourceSpans[0].ScopeSpans[0].Spans[0].Status = &trace.Status{Code: trace.Status_STATUS_CODE_ERROR}
+	require.Empty(t, q.receiveTraces(errored))
+	require.Empty(t, q.receiveTraces(sampledSpan(1, 0, time.Millisecond)))
+	// the invalid spans are rejected right away
+	invalid := sampledSpan(2, 0, time.Millisecond)
+	invalid.ResourceSpans[0].ScopeSpans[0].Spans[0].SpanId = nil
+	assert.Len(t, q.receiveTraces(invalid), 1)
+
+	w := getStats()
+	require.Equal(t, http.StatusOK, w.Code)
+	var stats tailSamplingStats
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Equal(t, 2, stats.PendingTraces)
+	q.mu.RLock()
+	assert.Empty(t, q.traces)
+	q.mu.RUnlock()
+
+	// stopping decides the pending traces
+	stop(true)
+	q.mu.RLock()
+	assert.Contains(t, q.traces, hex.EncodeToString(testTraceID(0)))
+	assert.NotContains(t, q.traces, hex.EncodeToString(testTraceID(1)))
+	q.mu.RUnlock()
+	require.NoError(t, json.Unmarshal(getStats().Body.Bytes(), &stats))
+	assert.Equal(t, tailSamplingStats{
+		DecisionWait:  "1h0m0s",
+		KeptTraces:    1,
+		DroppedTraces: 1,
+		DroppedSpans:  1,
+		KeptBy:        map[string]int{"errors": 1},
+	}, stats)
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	common "go.opentelemetry.io/proto/otlp/common/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/model/validation"
+)
+
+// truncatedAttribute is the span attribute marking the spans with truncated
+// attribute values.
+const truncatedAttribute = "truncated"
+
+// truncatePolicy truncates the long string and bytes attribute values of the
+// received spans, such as full SQL statements or request bodies, so that they
+// are stored instead of rejected and the memory use of the store stays
+// predictable. Like the limits of the Jaeger collector on the tags, it applies
+// to the attributes of the spans, of their events and of their links, not to
+// the resource attributes.
+type truncatePolicy struct {
+	// MaxValueLength is the length in bytes above which values are truncated.
+	MaxValueLength int
+}
+
+func newTruncatePolicy(maxValueLength int) (*truncatePolicy, error) {
+	limit := validation.DefaultLimits().MaxValueLength
+	if maxValueLength <= 0 || maxValueLength > limit {
+		return nil, fmt.Errorf("the max attribute value length must be between 1 and %d bytes", limit)
+	}
+	return &truncatePolicy{MaxValueLength: maxValueLength}, nil
+}
+
+// truncate truncates the attribute values of span in place, and marks it with
+// the truncated attribute if any was truncated.
+func (p *truncatePolicy) truncate(span *trace.Span) {
+	truncated := p.attributes(span.Attributes)
+	for _, event := range span.Events {
+		truncated = p.attributes(event.Attributes) || truncated
+	}
+	for _, link := range span.Links {
+		truncated = p.attributes(link.Attributes) || truncated
+	}
+	if !truncated {
+		return
+	}
+	marker := &common.AnyValue{Value: &common.AnyValue_BoolValue{BoolValue: true}}
+	for _, attr := range span.Attributes {
+		if attr.Key == truncatedAttribute {
+			attr.Value = marker
+			return
+		}
+	}
+	span.Attributes = append(span.Attributes, &common.KeyValue{Key: truncatedAttribute, Value: marker})
+}
+
+func (p *truncatePolicy) attributes(attrs []*common.KeyValue) bool {
+	truncated := false
+	for _, attr := range attrs {
+		truncated = p.value(attr.Value) || truncated
+	}
+	return truncated
+}
+
+func (p *truncatePolicy) value(value *common.AnyValue) bool {
+	switch v := value.GetValue().(type) {
+	case *common.AnyValue_StringValue:
+		if len(v.StringValue) > p.MaxValueLength {
+			v.StringValue = truncateString(v.StringValue, p.MaxValueLength)
+			return true
+		}
+	case *common.AnyValue_BytesValue:
+		if len(v.BytesValue) > p.MaxValueLength {
+			v.BytesValue = v.BytesValue[:p.MaxValueLength]
+			return true
+		}
+	case *common.AnyValue_ArrayValue:
+		truncated := false
+		for _, elem := range v.ArrayValue.GetValues() {
+			truncated = p.value(elem) || truncated
+		}
+		return truncated
+	case *common.AnyValue_KvlistValue:
+		return p.attributes(v.KvlistValue.GetValues())
+	}
+	return false
+}
+
+// truncateString returns the first n bytes of s at most, without splitting
+// a multi-byte character.
+func truncateString(s string, n int) string {
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
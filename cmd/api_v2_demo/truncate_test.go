@@ -0,0 +1,92 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	common "go.opentelemetry.io/proto/otlp/common/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+func TestTruncatePolicy(t *testing.T) {
+	p, err := newTruncatePolicy(4)
+	require.NoError(t, err)
+
+	span := &trace.Span{
+		Attributes: []*common.KeyValue{
+			{Key: "db.statement", Value: stringValue("SELECT 1")},
+			{Key: "short", Value: stringValue("abcd")},
+			// é is 2 bytes, the truncation does not split it
+			{Key: "accented", Value: stringValue("abcé")},
+			{Key: "body", Value: &common.AnyValue{Value: &common.AnyValue_BytesValue{BytesValue: []byte("payload")}}},
+			intAttr("http.status_code", 500),
+		},
+		Events: []*trace.Span_Event{{Attributes: []*common.KeyValue{{
+			Key: "args",
+			Value: &common.AnyValue{Value: &common.AnyValue_ArrayValue{ArrayValue: &common.ArrayValue{
+				Values: []*common.AnyValue{stringValue("--verbose"), stringValue("-q")},
+			}}},
+		}}}},
+	}
+	p.truncate(span)
+	attrs := span.Attributes
+	assert.Equal(t, "SELE", attrs[0].Value.GetStringValue())
+	assert.Equal(t, "abcd", attrs[1].Value.GetStringValue())
+	assert.Equal(t, "abc", attrs[2].Value.GetStringValue())
+	assert.Equal(t, []byte("payl"), attrs[3].Value.GetBytesValue())
+	assert.Equal(t, int64(500), attrs[4].Value.GetIntValue())
+	args := span.Events[0].Attributes[0].Value.GetArrayValue().Values
+	assert.Equal(t, "--ve", args[0].GetStringValue())
+	assert.Equal(t, "-q", args[1].GetStringValue())
+	require.Len(t, attrs, 6)
+	assert.Equal(t, truncatedAttribute, attrs[5].Key)
+	assert.True(t, attrs[5].Value.GetBoolValue())
+
+	// the marker is not duplicated
+	span.Attributes[0].Value = stringValue("SELECT 2")
+	p.truncate(span)
+	assert.Len(t, span.Attributes, 6)
+
+	untouched := &trace.Span{Attributes: []*common.KeyValue{{Key: "short", Value: stringValue("abc")}}}
+	p.truncate(untouched)
+	assert.Len(t, untouched.Attributes, 1)
+
+	for _, invalid := range []int{-1, 0, 32<<10 + 1} {
+		_, err := newTruncatePolicy(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestImportTruncatesAttributes(t *testing.T) {
+	statement := strings.Repeat("x", 64<<10)
+	batch := func() *trace.TracesData {
+		td := largeTrace(1)
+		span := td.ResourceSpans[0].ScopeSpans[0].Spans[0]
+		span.Attributes = append(span.Attributes, &common.KeyValue{Key: "db.statement", Value: stringValue(statement)})
+		return td
+	}
+
+	q := NewQueryService()
+	assert.Len(t, q.importTraces(batch()), 1)
+
+	var err error
+	q.truncation, err = newTruncatePolicy(1024)
+	require.NoError(t, err)
+	require.Empty(t, q.importTraces(batch()))
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	stored := q.traces[hex.EncodeToString(testTraceID(0))].ResourceSpans[0].ScopeSpans[0].Spans[0]
+	values := make(map[string]*common.AnyValue)
+	for _, attr := range stored.Attributes {
+		values[attr.Key] = attr.Value
+	}
+	assert.Equal(t, statement[:1024], values["db.statement"].GetStringValue())
+	assert.True(t, values[truncatedAttribute].GetBoolValue())
+}
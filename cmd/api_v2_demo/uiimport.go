@@ -0,0 +1,52 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	"github.com/jaegertracing/jaeger-idl/model/converter/uijson"
+)
+
+// importDir imports the traces of the .json files of dir, in the JSON format
+// of the Jaeger UI, e.g. the traces downloaded from a production Jaeger UI.
+func (q *QueryService) importDir(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no .json files in %s", dir)
+	}
+	var traces, rejected int
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		uiTraces, err := uijson.ParseJSON(data)
+		if err != nil {
+			return fmt.Errorf("cannot parse %s: %w", path, err)
+		}
+		spans, err := uijson.ToDomain(uiTraces)
+		if err != nil {
+			return fmt.Errorf("cannot convert %s: %w", path, err)
+		}
+		for _, err := range q.importTraces(otlp.FromDomain(spans)) {
+			log.Printf("Rejected span from %s: %v\n", path, err)
+			rejected++
+		}
+		traces += len(uiTraces)
+	}
+	if traces == 0 {
+		return errors.New("no traces found")
+	}
+	log.Printf("Imported %d traces from %d files in %s, rejected %d invalid spans\n", traces, len(paths), dir, rejected)
+	return nil
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxUsageKeys caps the number of distinct user agents and attribute keys
+// tracked, so that misbehaving clients cannot grow the report without bound.
+const maxUsageKeys = 100
+
+// otherUsageKey collects the values that did not fit under maxUsageKeys.
+const otherUsageKey = "<other>"
+
+// usageTracker aggregates how clients use the query API.
+type usageTracker struct {
+	mu      sync.Mutex
+	started time.Time
+	methods map[string]*methodUsage
+	agents  map[string]int
+	apis    map[string]*apiUsage
+}
+
+// methodUsage is the usage profile of a single RPC method.
+type methodUsage struct {
+	Calls  int `json:"calls"`
+	Errors int `json:"errors"`
+	// Parameters counts the calls that populated each request field,
+	// keyed by the field path, e.g. "query.attributes".
+	Parameters map[string]int `json:"parameters"`
+	// AttributeKeys counts the attribute keys used in query attributes.
+	AttributeKeys map[string]int `json:"attributeKeys,omitempty"`
+	// Latency is the latency of the handler, the whole stream for the
+	// server-streaming RPCs.
+	Latency *latencyHistogram `json:"latency"`
+	// ServiceLatency is the latency by the service name parameter of the
+	// requests, for the methods that have one.
+	ServiceLatency map[string]*latencyHistogram `json:"serviceLatency,omitempty"`
+}
+
+// apiUsage is the usage of a query API version, to measure the migration
+// of clients from api_v2 to api_v3.
+type apiUsage struct {
+	Calls  int `json:"calls"`
+	Errors int `json:"errors"`
+	// UserAgents counts the calls of each client.
+	UserAgents map[string]int `json:"userAgents"`
+}
+
+// usageReport is the aggregate usage of the query API.
+type usageReport struct {
+	Since      time.Time               `json:"since"`
+	Methods    map[string]*methodUsage `json:"methods"`
+	UserAgents map[string]int          `json:"userAgents"`
+	// APIVersions is the usage per query API version, v2 or v3.
+	APIVersions map[string]*apiUsage `json:"apiVersions"`
+}
+
+func newUsageTracker() *usageTracker {
+	return &usageTracker{
+		started: time.Now().UTC(),
+		methods: make(map[string]*methodUsage),
+		agents:  make(map[string]int),
+		apis:    make(map[string]*apiUsage),
+	}
+}
+
+// UnaryInterceptor records the usage of unary RPCs.
+func (u *usageTracker) UnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	u.record(ctx, info.FullMethod, req, err, time.Since(start))
+	return resp, err
+}
+
+// StreamInterceptor records the usage of server-streaming RPCs.
+func (u *usageTracker) StreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	wrapped := &recordingStream{ServerStream: ss}
+	err := handler(srv, wrapped)
+	u.record(ss.Context(), info.FullMethod, wrapped.req, err, time.Since(start))
+	return err
+}
+
+// recordingStream remembers the first message received from the client.
+type recordingStream struct {
+	grpc.ServerStream
+	req any
+}
+
+func (s *recordingStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.req == nil {
+		s.req = m
+	}
+	return err
+}
+
+func (u *usageTracker) record(ctx context.Context, method string, req any, err error, latency time.Duration) {
+	var agent string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-agent"); len(values) > 0 {
+			agent = values[0]
+		}
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	usage, ok := u.methods[method]
+	if !ok {
+		usage = &methodUsage{Parameters: make(map[string]int), Latency: newLatencyHistogram()}
+		u.methods[method] = usage
+	}
+	usage.Calls++
+	if err != nil {
+		usage.Errors++
+	}
+	if msg, ok := req.(proto.Message); ok {
+		usage.recordParameters("", msg.ProtoReflect())
+	}
+	usage.Latency.observe(latency)
+	if service := requestService(req); service != "" {
+		usage.recordServiceLatency(service, latency)
+	}
+	incrementCapped(u.agents, agent)
+
+	api, ok := u.apis[apiVersion(method)]
+	if !ok {
+		api = &apiUsage{UserAgents: make(map[string]int)}
+		u.apis[apiVersion(method)] = api
+	}
+	api.Calls++
+	if err != nil {
+		api.Errors++
+	}
+	incrementCapped(api.UserAgents, agent)
+}
+
+// recordParameters counts the populated fields of msg, descending into nested messages.
+func (m *methodUsage) recordParameters(prefix string, msg protoreflect.Message) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		path := prefix + string(fd.Name())
+		m.Parameters[path]++
+		switch {
+		case fd.IsMap() && fd.Name() == "attributes":
+			if m.AttributeKeys == nil {
+				m.AttributeKeys = make(map[string]int)
+			}
+			v.Map().Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+				incrementCapped(m.AttributeKeys, k.String())
+				return true
+			})
+		case fd.Message() != nil && !fd.IsList() && !fd.IsMap() && !isWellKnown(fd.Message()):
+			m.recordParameters(path+".", v.Message())
+		}
+		return true
+	})
+}
+
+// recordServiceLatency records the latency of a call for the service name
+// parameter, capping the number of services like incrementCapped.
+func (m *methodUsage) recordServiceLatency(service string, latency time.Duration) {
+	if m.ServiceLatency == nil {
+		m.ServiceLatency = make(map[string]*latencyHistogram)
+	}
+	h, ok := m.ServiceLatency[service]
+	if !ok && len(m.ServiceLatency) >= maxUsageKeys {
+		service = otherUsageKey
+		h, ok = m.ServiceLatency[service]
+	}
+	if !ok {
+		h = newLatencyHistogram()
+		m.ServiceLatency[service] = h
+	}
+	h.observe(latency)
+}
+
+func isWellKnown(md protoreflect.MessageDescriptor) bool {
+	return md.ParentFile().Package() == "google.protobuf"
+}
+
+func incrementCapped(counts map[string]int, key string) {
+	if _, ok := counts[key]; !ok && len(counts) >= maxUsageKeys {
+		key = otherUsageKey
+	}
+	counts[key]++
+}
+
+// report returns a snapshot of the usage collected so far.
+func (u *usageTracker) report() usageReport {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	report := usageReport{
+		Since:       u.started,
+		Methods:     make(map[string]*methodUsage, len(u.methods)),
+		UserAgents:  maps.Clone(u.agents),
+		APIVersions: make(map[string]*apiUsage, len(u.apis)),
+	}
+	for method, usage := range u.methods {
+		methodReport := &methodUsage{
+			Calls:         usage.Calls,
+			Errors:        usage.Errors,
+			Parameters:    maps.Clone(usage.Parameters),
+			AttributeKeys: maps.Clone(usage.AttributeKeys),
+			Latency:       usage.Latency.report(),
+		}
+		if len(usage.ServiceLatency) > 0 {
+			methodReport.ServiceLatency = make(map[string]*latencyHistogram, len(usage.ServiceLatency))
+			for service, h := range usage.ServiceLatency {
+				methodReport.ServiceLatency[service] = h.report()
+			}
+		}
+		report.Methods[method] = methodReport
+	}
+	for version, api := range u.apis {
+		report.APIVersions[version] = &apiUsage{
+			Calls:      api.Calls,
+			Errors:     api.Errors,
+			UserAgents: maps.Clone(api.UserAgents),
+		}
+	}
+	return report
+}
+
+func (u *usageTracker) handleUsage(w http.ResponseWriter, _ *http.Request) {
+	writeAdminJSON(w, u.report())
+}
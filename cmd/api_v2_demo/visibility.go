@@ -0,0 +1,128 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+)
+
+// Service visibility states. Hidden and deprecated services are left out of
+// GetServices, so they do not show up in service pickers, but their traces
+// and operations can still be queried by name.
+const (
+	serviceVisible    = "visible"
+	serviceHidden     = "hidden"
+	serviceDeprecated = "deprecated"
+)
+
+// visibilityConfig lists the services that are not visible.
+//
+// Example:
+//
+//	{"hidden": ["load-generator"], "deprecated": ["legacy-auth"]}
+type visibilityConfig struct {
+	Hidden     []string `json:"hidden,omitempty"`
+	Deprecated []string `json:"deprecated,omitempty"`
+}
+
+// serviceVisibility is the visibility state of a service as reported by the admin API.
+type serviceVisibility struct {
+	Service string `json:"service"`
+	State   string `json:"state"`
+}
+
+func loadVisibilityConfig(path string) (*visibilityConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read service visibility config: %w", err)
+	}
+	var cfg visibilityConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse service visibility config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// applyVisibilityConfig sets the visibility state of the services listed in cfg.
+func (q *QueryService) applyVisibilityConfig(cfg *visibilityConfig) {
+	for _, service := range cfg.Hidden {
+		q.setServiceVisibility(service, serviceHidden)
+	}
+	for _, service := range cfg.Deprecated {
+		q.setServiceVisibility(service, serviceDeprecated)
+	}
+}
+
+func (q *QueryService) setServiceVisibility(service, state string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if state == serviceVisible {
+		delete(q.visibility, service)
+		return
+	}
+	q.visibility[service] = state
+}
+
+// isListed reports whether the service is included in GetServices.
+// The caller must hold mu.
+func (q *QueryService) isListed(service string) bool {
+	_, ok := q.visibility[service]
+	return !ok
+}
+
+// warnIfDeprecated logs the direct use of a deprecated service.
+func (q *QueryService) warnIfDeprecated(api, service string) {
+	q.mu.RLock()
+	state := q.visibility[service]
+	q.mu.RUnlock()
+	if state == serviceDeprecated {
+		log.Printf("[QUERY %s] Service %s is deprecated\n", api, service)
+	}
+}
+
+// handleListServiceVisibility returns the visibility state of all known services.
+func (q *QueryService) handleListServiceVisibility(w http.ResponseWriter, _ *http.Request) {
+	q.mu.RLock()
+	catalog := q.catalogLocked()
+	services := make([]serviceVisibility, 0, len(catalog.services))
+	for _, service := range catalog.services {
+		state, ok := q.visibility[service]
+		if !ok {
+			state = serviceVisible
+		}
+		services = append(services, serviceVisibility{Service: service, State: state})
+	}
+	q.mu.RUnlock()
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Service < services[j].Service
+	})
+	writeAdminJSON(w, services)
+}
+
+// handleSetServiceVisibility changes the visibility state of a service.
+func (q *QueryService) handleSetServiceVisibility(w http.ResponseWriter, r *http.Request) {
+	service := r.PathValue("service")
+	var body struct {
+		State string `json:"state"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	switch body.State {
+	case serviceVisible, serviceHidden, serviceDeprecated:
+	default:
+		writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid state %q, expected one of %s, %s or %s",
+			body.State, serviceVisible, serviceHidden, serviceDeprecated))
+		return
+	}
+	q.setServiceVisibility(service, body.State)
+	log.Printf("[ADMIN] Service %s is now %s\n", service, body.State)
+	writeAdminJSON(w, serviceVisibility{Service: service, State: body.State})
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	"github.com/jaegertracing/jaeger-idl/model/converter/zipkin"
+)
+
+// maxZipkinBatchSize caps the size of a (decompressed) Zipkin request body.
+const maxZipkinBatchSize = 16 << 20
+
+// handleZipkinSpans implements the Zipkin v2 POST /api/v2/spans endpoint for
+// spans in the JSON v2 format, optionally gzip-compressed. Valid spans are
+// stored even if others in the same request are rejected.
+func (q *QueryService) handleZipkinSpans(w http.ResponseWriter, r *http.Request) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/x-protobuf" || mediaType == "application/x-thrift" {
+		writeAdminError(w, http.StatusUnsupportedMediaType,
+			fmt.Errorf("unsupported content type %q, only JSON v2 is accepted", mediaType))
+		return
+	}
+	body := io.Reader(r.Body)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			writeAdminError(w, http.StatusBadRequest, fmt.Errorf("cannot decompress request body: %w", err))
+			return
+		}
+		defer zr.Close()
+		body = zr
+	}
+	data, err := io.ReadAll(io.LimitReader(body, maxZipkinBatchSize+1))
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, fmt.Errorf("cannot read request body: %w", err))
+		return
+	}
+	if len(data) > maxZipkinBatchSize {
+		writeAdminError(w, http.StatusRequestEntityTooLarge,
+			fmt.Errorf("request body exceeds the limit of %d bytes", maxZipkinBatchSize))
+		return
+	}
+
+	zipkinSpans, err := zipkin.ParseJSON(data)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := checkBatchSize(len(zipkinSpans)); err != nil {
+		writeAdminError(w, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+	spans, err := zipkin.ToDomain(zipkinSpans)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+	rejected := q.receiveTraces(otlp.FromDomain(spans))
+	log.Printf("[ZIPKIN] Received %d spans, rejected %d\n", len(spans), len(rejected))
+	if len(rejected) > 0 {
+		writeAdminError(w, http.StatusBadRequest, errors.Join(rejected...))
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
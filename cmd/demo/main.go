@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"net"
@@ -12,7 +13,9 @@ import (
 
 	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
 	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
-	"github.com/jaegertracing/jaeger-idl/model/v1"
+	"github.com/jaegertracing/jaeger-idl/internal/fixtures"
+	"github.com/jaegertracing/jaeger-idl/model/converter/apiv2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
 )
 
 // QueryService implements the Jaeger Query API (read path)
@@ -170,66 +173,28 @@ func (q *QueryService) initDemoData() {
 		"UPDATE last_login",
 	}
 
-	// Create sample traces
-	q.createSampleTrace1()
-	q.createSampleTrace2()
+	// Load the sample traces, the second one five minutes earlier
+	now := time.Now()
+	q.loadSampleTrace("trace1.json", now)
+	q.loadSampleTrace("trace2.json", now.Add(-5*time.Minute))
 
 	log.Printf("Demo data initialized with %d traces\n", len(q.traces))
 }
 
-func (q *QueryService) createSampleTrace2() {
-	traceID, _ := model.TraceIDFromString("fedcba0987654321fedcba0987654321")
-
-	now := time.Now().Add(-5 * time.Minute)
-
-	// Span 1: Login request
-	span1 := &model.Span{
-		TraceID:       traceID,
-		SpanID:        model.NewSpanID(0x4444444444444444),
-		OperationName: "HTTP POST /api/login",
-		StartTime:     now,
-		Duration:      200 * time.Millisecond,
-		Process: &model.Process{
-			ServiceName: "frontend",
-			Tags: []model.KeyValue{
-				model.String("hostname", "frontend-01"),
-			},
-		},
-		Tags: []model.KeyValue{
-			model.String("http.method", "POST"),
-			model.String("http.url", "/api/login"),
-			model.Int64("http.status_code", 200),
-		},
+// loadSampleTrace adds a trace of the fixtures, shifted to start at start.
+func (q *QueryService) loadSampleTrace(name string, start time.Time) {
+	td, err := fixtures.ReadTraces(name)
+	if err != nil {
+		log.Fatalf("Failed to load sample trace %s: %v", name, err)
 	}
-
-	// Span 2: Validate token
-	span2 := &model.Span{
-		TraceID:       traceID,
-		SpanID:        model.NewSpanID(0x5555555555555555),
-		OperationName: "validate-token",
-		References: []model.SpanRef{
-			{
-				TraceID: traceID,
-				SpanID:  model.NewSpanID(0x4444444444444444),
-				RefType: model.ChildOf,
-			},
-		},
-		StartTime: now.Add(15 * time.Millisecond),
-		Duration:  80 * time.Millisecond,
-		Process: &model.Process{
-			ServiceName: "auth-service",
-			Tags: []model.KeyValue{
-				model.String("hostname", "auth-01"),
-			},
-		},
-		Tags: []model.KeyValue{
-			model.String("user.email", "user@example.com"),
-			model.String("token.type", "jwt"),
-		},
+	fixtures.Rebase(td, start)
+	spans, err := apiv2.SpansToProto(otlp.ToDomain(td))
+	if err != nil {
+		log.Fatalf("Failed to convert sample trace %s: %v", name, err)
 	}
-
-	q.traces[traceID.String()] = []*api_v2.Span{span1, span2}
-	log.Println("Created sample trace 2:", traceID.String())
+	traceID := spans[0].TraceId
+	q.traces[string(traceID)] = spans
+	log.Println("Created sample trace:", hex.EncodeToString(traceID))
 }
 
 func main() {
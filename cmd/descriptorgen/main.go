@@ -0,0 +1,123 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command descriptorgen writes the FileDescriptorSet of the api_v2 and
+// api_v3 protos, with all their imports, embedded by the idl package.
+//
+// The descriptors are taken from the generated Go packages instead of
+// running protoc, so the set always matches the Go types of the module.
+// The output is the same as `protoc --include_imports
+// --descriptor_set_out`, without the source info: the files are listed
+// with their imports first.
+//
+// Usage:
+//
+//	descriptorgen [-o file]
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	_ "github.com/gogo/protobuf/gogoproto" // registers gogo.proto
+	gogoproto "github.com/gogo/protobuf/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+// gogoProtoPath is the import path of gogo.proto in model.proto. The Go
+// types generated by protoc-gen-go only reference it, as the gogoproto
+// package registers it with gogo/protobuf, as gogo.proto.
+const gogoProtoPath = "gogoproto/gogo.proto"
+
+func main() {
+	out := flag.String("o", "descriptors/jaeger.binpb", "output file")
+	flag.Parse()
+
+	set, err := descriptorSet()
+	if err != nil {
+		log.Fatal(err)
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(set)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func descriptorSet() (*descriptorpb.FileDescriptorSet, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	var add func(fd protoreflect.FileDescriptor) error
+	add = func(fd protoreflect.FileDescriptor) error {
+		if seen[fd.Path()] {
+			return nil
+		}
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			if err := add(imports.Get(i).FileDescriptor); err != nil {
+				return err
+			}
+		}
+		if fd.IsPlaceholder() {
+			if fd.Path() != gogoProtoPath {
+				return fmt.Errorf("no descriptor of %s", fd.Path())
+			}
+			gogo, err := gogoDescriptor()
+			if err != nil {
+				return err
+			}
+			set.File = append(set.File, gogo)
+			return nil
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+		return nil
+	}
+	for _, fd := range []protoreflect.FileDescriptor{
+		api_v2.File_api_v2_collector_proto,
+		api_v2.File_api_v2_query_proto,
+		api_v2.File_api_v2_sampling_proto,
+		api_v3.File_api_v3_query_service_proto,
+	} {
+		if err := add(fd); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+// gogoDescriptor returns the descriptor of gogo.proto registered with
+// gogo/protobuf, renamed to its import path.
+func gogoDescriptor() (*descriptorpb.FileDescriptorProto, error) {
+	gz := gogoproto.FileDescriptor("gogo.proto")
+	if gz == nil {
+		return nil, fmt.Errorf("no descriptor of %s", gogoProtoPath)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, fmt.Errorf("cannot decompress the descriptor of %s: %w", gogoProtoPath, err)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decompress the descriptor of %s: %w", gogoProtoPath, err)
+	}
+	fd := &descriptorpb.FileDescriptorProto{}
+	if err := proto.Unmarshal(data, fd); err != nil {
+		return nil, fmt.Errorf("cannot decode the descriptor of %s: %w", gogoProtoPath, err)
+	}
+	fd.Name = proto.String(gogoProtoPath)
+	return fd, nil
+}
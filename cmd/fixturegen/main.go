@@ -0,0 +1,256 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command fixturegen writes sample api_v2 request payloads built from the
+// sample traces, so that the bindings of the IDL in other languages can be
+// tested with the same inputs as the Go code.
+//
+// Each payload is written in the proto3 JSON mapping, as <name>.json, and in
+// the binary encoding, as <name>.pb, with a stable output: the files only
+// change when the sample traces or the IDL change. The manifest.json file
+// lists the payloads with their message type and gRPC method, e.g.
+//
+//	{
+//	  "fixtures": [
+//	    {
+//	      "name": "get_trace_request",
+//	      "message": "jaeger.api_v2.GetTraceRequest",
+//	      "method": "/jaeger.api_v2.QueryService/GetTrace",
+//	      "json": "get_trace_request.json",
+//	      "binary": "get_trace_request.pb"
+//	    },
+//	    ...
+//	  ]
+//	}
+//
+// A test of another binding decodes both files of each payload into the
+// message type, checks that they are equal, and that re-encoding the message
+// gives back the same payload.
+//
+// Usage:
+//
+//	fixturegen [-o dir]
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/internal/fixtures"
+	"github.com/jaegertracing/jaeger-idl/model/converter/apiv2"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// sampleTrace is the sample trace the payloads refer to.
+const sampleTrace = "trace1.json"
+
+// payload is a request payload of a gRPC method.
+type payload struct {
+	name    string
+	method  string
+	message proto.Message
+}
+
+// manifest lists the written payloads.
+type manifest struct {
+	Fixtures []manifestEntry `json:"fixtures"`
+}
+
+type manifestEntry struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+	Method  string `json:"method"`
+	JSON    string `json:"json"`
+	Binary  string `json:"binary"`
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("fixturegen: ")
+	dir := flag.String("o", ".", "directory to write the payloads to, created if missing")
+	flag.Parse()
+	if flag.NArg() > 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	spans, err := fixtures.ReadSpans(sampleTrace)
+	if err != nil {
+		log.Fatal(err)
+	}
+	payloads, err := buildPayloads(spans)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		log.Fatal(err)
+	}
+	var m manifest
+	for _, p := range payloads {
+		entry, err := writePayload(*dir, p)
+		if err != nil {
+			log.Fatal(err)
+		}
+		m.Fixtures = append(m.Fixtures, entry)
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(*dir, "manifest.json"), append(data, '\n'), 0o644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("wrote %d payloads to %s", len(payloads), *dir)
+}
+
+// buildPayloads returns the payloads of the sample trace: a GetTrace and a
+// FindTraces request matching it, and the PostSpans requests reporting it,
+// one per process as sent by the Jaeger clients.
+func buildPayloads(spans []*model.Span) ([]payload, error) {
+	if len(spans) == 0 {
+		return nil, fmt.Errorf("no spans in %s", sampleTrace)
+	}
+	root := spans[0]
+	start, end := root.StartTime, root.StartTime.Add(root.Duration)
+	for _, span := range spans {
+		if span.StartTime.Before(start) {
+			start = span.StartTime
+		}
+		if e := span.StartTime.Add(span.Duration); e.After(end) {
+			end = e
+		}
+		if len(span.References) == 0 {
+			root = span
+		}
+	}
+	traceID := make([]byte, 16)
+	if _, err := root.TraceID.MarshalTo(traceID); err != nil {
+		return nil, fmt.Errorf("cannot encode trace ID: %w", err)
+	}
+
+	payloads := []payload{
+		{
+			name:   "get_trace_request",
+			method: api_v2.QueryService_GetTrace_FullMethodName,
+			message: &api_v2.GetTraceRequest{
+				TraceId:   traceID,
+				StartTime: timestamppb.New(start),
+				EndTime:   timestamppb.New(end),
+			},
+		},
+		{
+			name:   "find_traces_request",
+			method: api_v2.QueryService_FindTraces_FullMethodName,
+			message: &api_v2.FindTracesRequest{
+				Query: &api_v2.TraceQueryParameters{
+					ServiceName:   root.Process.ServiceName,
+					OperationName: root.OperationName,
+					Tags:          queryTags(root),
+					StartTimeMin:  timestamppb.New(start.Add(-time.Minute)),
+					StartTimeMax:  timestamppb.New(end.Add(time.Minute)),
+					DurationMin:   durationpb.New(root.Duration / 2),
+					DurationMax:   durationpb.New(root.Duration * 2),
+					SearchDepth:   20,
+				},
+			},
+		},
+	}
+	batches, err := batchesByProcess(spans)
+	if err != nil {
+		return nil, err
+	}
+	for _, batch := range batches {
+		payloads = append(payloads, payload{
+			name:    "post_spans_request_" + fileName(batch.Process.ServiceName),
+			method:  api_v2.CollectorService_PostSpans_FullMethodName,
+			message: &api_v2.PostSpansRequest{Batch: batch},
+		})
+	}
+	return payloads, nil
+}
+
+// queryTags returns the string tags of the span, which FindTraces matches exactly.
+func queryTags(span *model.Span) map[string]string {
+	tags := make(map[string]string)
+	for _, tag := range span.Tags {
+		if tag.VType == model.StringType {
+			tags[tag.Key] = tag.VStr
+		}
+	}
+	return tags
+}
+
+// batchesByProcess groups the spans by process, in the order of their first
+// span, leaving the process of the spans to their batch.
+func batchesByProcess(spans []*model.Span) ([]*api_v2.Batch, error) {
+	protoSpans, err := apiv2.SpansToProto(spans)
+	if err != nil {
+		return nil, err
+	}
+	var batches []*api_v2.Batch
+	for _, span := range protoSpans {
+		i := 0
+		for i < len(batches) && !proto.Equal(batches[i].Process, span.Process) {
+			i++
+		}
+		if i == len(batches) {
+			batches = append(batches, &api_v2.Batch{Process: span.Process})
+		}
+		span.Process = nil
+		batches[i].Spans = append(batches[i].Spans, span)
+	}
+	return batches, nil
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// fileName turns a service name into a part of a file name.
+func fileName(service string) string {
+	return unsafeFileChars.ReplaceAllString(strings.ToLower(service), "_")
+}
+
+// writePayload writes the JSON and binary encodings of the payload.
+func writePayload(dir string, p payload) (manifestEntry, error) {
+	entry := manifestEntry{
+		Name:    p.name,
+		Message: string(proto.MessageName(p.message)),
+		Method:  p.method,
+		JSON:    p.name + ".json",
+		Binary:  p.name + ".pb",
+	}
+	data, err := protojson.Marshal(p.message)
+	if err != nil {
+		return entry, fmt.Errorf("cannot encode %s as JSON: %w", p.name, err)
+	}
+	// protojson randomizes its whitespace, which is normalized for stable files
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return entry, fmt.Errorf("cannot format %s: %w", p.name, err)
+	}
+	buf.WriteByte('\n')
+	if err := os.WriteFile(filepath.Join(dir, entry.JSON), buf.Bytes(), 0o644); err != nil {
+		return entry, err
+	}
+	data, err = proto.MarshalOptions{Deterministic: true}.Marshal(p.message)
+	if err != nil {
+		return entry, fmt.Errorf("cannot encode %s: %w", p.name, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, entry.Binary), data, 0o644); err != nil {
+		return entry, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+// Encodings of the exported traces.
+const (
+	encodingJSON  = "json"
+	encodingProto = "proto"
+)
+
+// listFlag collects repeated or comma separated flag values.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(s string) error {
+	*l = append(*l, strings.Split(s, ",")...)
+	return nil
+}
+
+// tagsFlag collects the repeated --tag key=value flags.
+type tagsFlag map[string]string
+
+func (t tagsFlag) String() string {
+	return ""
+}
+
+func (t tagsFlag) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("expected key=value, got %q", s)
+	}
+	t[key] = value
+	return nil
+}
+
+// export writes the traces selected by ID or by a query, fetched with the
+// api_v3 query API, as a single OTLP TracesData.
+func export(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	target := fs.String("target", "localhost:17271", "address of the api_v3 query service")
+	encoding := fs.String("encoding", encodingJSON, "encoding of the OTLP TracesData: json or proto (binary protobuf)")
+	output := fs.String("o", "", "file to write to, instead of the standard output")
+	timeout := fs.Duration("timeout", time.Minute, "timeout of the export")
+	var traceIDs listFlag
+	fs.Var(&traceIDs, "trace-id", "hex ID of a trace to export, repeatable or comma separated")
+	query := &api_v3.TraceQueryParameters{Attributes: make(map[string]string)}
+	fs.StringVar(&query.ServiceName, "service", "", "service of the traces to export, instead of --trace-id")
+	fs.StringVar(&query.OperationName, "operation", "", "operation of the traces to export")
+	fs.Var(tagsFlag(query.Attributes), "tag", "tag of a span of the traces to export as key=value, repeatable")
+	lookback := fs.Duration("lookback", time.Hour, "time range of the query, ending now")
+	limit := fs.Int("limit", 20, "maximum number of traces of the query")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: jaegerctl export [flags] (--trace-id ID... | --service NAME)")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 0 || (len(traceIDs) == 0) == (query.ServiceName == "") {
+		fs.Usage()
+		return 2
+	}
+	if *encoding != encodingJSON && *encoding != encodingProto {
+		log.Fatalf("unknown encoding %q, expected %s or %s", *encoding, encodingJSON, encodingProto)
+	}
+
+	conn, err := grpc.NewClient(*target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+	client := api_v3.NewQueryServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	td := &tracev1.TracesData{}
+	if len(traceIDs) > 0 {
+		for _, id := range traceIDs {
+			stream, err := client.GetTrace(ctx, &api_v3.GetTraceRequest{TraceId: id})
+			if err == nil {
+				err = recvTracesData(stream, td)
+			}
+			if err != nil {
+				log.Fatalf("cannot get trace %s: %v", id, err)
+			}
+		}
+	} else {
+		now := time.Now()
+		query.StartTimeMin = timestamppb.New(now.Add(-*lookback))
+		query.StartTimeMax = timestamppb.New(now)
+		query.SearchDepth = int32(*limit)
+		stream, err := client.FindTraces(ctx, &api_v3.FindTracesRequest{Query: query})
+		if err == nil {
+			err = recvTracesData(stream, td)
+		}
+		if err != nil {
+			log.Fatalf("cannot find traces: %v", err)
+		}
+	}
+
+	var b []byte
+	if *encoding == encodingJSON {
+		b, err = protojson.Marshal(td)
+	} else {
+		b, err = proto.Marshal(td)
+	}
+	if err != nil {
+		log.Fatalf("cannot encode the traces: %v", err)
+	}
+	if *output == "" {
+		if _, err := os.Stdout.Write(b); err != nil {
+			log.Fatal(err)
+		}
+	} else if err := os.WriteFile(*output, b, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("exported %d spans", spanCount(td))
+	return 0
+}
+
+// recvTracesData appends the chunks of a stream to td.
+func recvTracesData(stream grpc.ServerStreamingClient[tracev1.TracesData], td *tracev1.TracesData) error {
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		td.ResourceSpans = append(td.ResourceSpans, chunk.ResourceSpans...)
+	}
+}
+
+func spanCount(td *tracev1.TracesData) int {
+	n := 0
+	for _, rs := range td.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			n += len(ss.Spans)
+		}
+	}
+	return n
+}
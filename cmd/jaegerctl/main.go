@@ -0,0 +1,160 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command jaegerctl provides tools for working with Jaeger trace data.
+//
+// The export command writes the traces selected by ID or by a query, fetched
+// with the api_v3 query API of any Jaeger query service, as a single OTLP
+// TracesData in JSON or binary protobuf. As TracesData has the same fields as
+// the OTLP ExportTraceServiceRequest, the files can be sent as is to OTLP/HTTP
+// receivers, e.g. to load them into another backend.
+//
+// The verify-roundtrip command runs the spans of a trace file through the
+// round trips between the Jaeger model, OTLP, api_v2 and their JSON
+// encodings, and reports the fields that are lost or altered, e.g. to file a
+// precise bug about a lossy conversion. The file is either in the JSON
+// format of the Jaeger UI or OTLP JSON. It exits with status 1 if any
+// difference is found.
+//
+// Usage:
+//
+//	jaegerctl export --target localhost:17271 [--encoding json|proto] [-o file] (--trace-id ID... | --service NAME [--tag key=value]...)
+//	jaegerctl verify-roundtrip [--format auto|jaeger-ui|otlp] <file>
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	"github.com/jaegertracing/jaeger-idl/model/converter/roundtrip"
+	"github.com/jaegertracing/jaeger-idl/model/converter/uijson"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// Formats of the trace files.
+const (
+	formatAuto     = "auto"
+	formatJaegerUI = "jaeger-ui"
+	formatOTLP     = "otlp"
+)
+
+const usage = `Usage: jaegerctl <command> [arguments]
+
+Commands:
+  export [flags]                    write traces from a query service as OTLP TracesData
+  verify-roundtrip [flags] <file>   report the fields of the spans lost or altered by the conversions
+
+Run jaegerctl <command> -h for the flags of a command.
+`
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("jaegerctl: ")
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "export":
+		os.Exit(export(args))
+	case "verify-roundtrip":
+		os.Exit(verifyRoundtrip(args))
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+	default:
+		fmt.Fprintf(os.Stderr, "jaegerctl: unknown command %q\n%s", cmd, usage)
+		os.Exit(2)
+	}
+}
+
+func verifyRoundtrip(args []string) int {
+	fs := flag.NewFlagSet("verify-roundtrip", flag.ExitOnError)
+	format := fs.String("format", formatAuto, "format of the file: jaeger-ui, otlp (JSON), or auto to detect it")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: jaegerctl verify-roundtrip [flags] <file>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	spans, err := parseSpans(data, *format)
+	if err != nil {
+		log.Fatalf("cannot read %s: %v", fs.Arg(0), err)
+	}
+	if len(spans) == 0 {
+		log.Fatalf("no spans in %s", fs.Arg(0))
+	}
+	if printResults(os.Stdout, len(spans), roundtrip.Verify(spans)) {
+		return 1
+	}
+	return 0
+}
+
+// parseSpans decodes the spans of a trace file, detecting OTLP JSON by its
+// resourceSpans field in the auto format.
+func parseSpans(data []byte, format string) ([]*model.Span, error) {
+	if format == formatAuto {
+		format = formatJaegerUI
+		if bytes.Contains(data, []byte(`"resourceSpans"`)) || bytes.Contains(data, []byte(`"resource_spans"`)) {
+			format = formatOTLP
+		}
+	}
+	switch format {
+	case formatJaegerUI:
+		traces, err := uijson.ParseJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		return uijson.ToDomain(traces)
+	case formatOTLP:
+		td := &tracev1.TracesData{}
+		if err := protojson.Unmarshal(data, td); err != nil {
+			return nil, fmt.Errorf("cannot parse OTLP JSON: %w", err)
+		}
+		return otlp.ToDomain(td), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected %s, %s or %s", format, formatAuto, formatJaegerUI, formatOTLP)
+	}
+}
+
+// printResults prints the differences of each round trip and reports whether there are any.
+func printResults(w io.Writer, spanCount int, results []roundtrip.Result) bool {
+	fmt.Fprintf(w, "Verified %d spans\n\n", spanCount)
+	failed := false
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			failed = true
+			fmt.Fprintf(w, "%s: FAILED: %v\n\n", r.Conversion, r.Err)
+		case len(r.Differences) == 0:
+			fmt.Fprintf(w, "%s: OK\n\n", r.Conversion)
+		default:
+			failed = true
+			fmt.Fprintf(w, "%s: %d differences\n", r.Conversion, len(r.Differences))
+			tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "  SPAN\tFIELD\tBEFORE\tAFTER")
+			for _, d := range r.Differences {
+				fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", d.Span, d.Field, d.Before, d.After)
+			}
+			tw.Flush()
+			fmt.Fprintln(w)
+		}
+	}
+	return failed
+}
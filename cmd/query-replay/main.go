@@ -0,0 +1,316 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command query-replay replays the api_v3 and api_v2 query calls recorded in a query
+// log, as written by api_v2_demo --query-log, against a query service, and
+// compares the latencies with the recorded ones. It is meant for regression
+// benchmarking of storage changes.
+//
+// Usage:
+//
+//	query-replay --log queries.jsonl --target localhost:17271 --speed 2
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	"github.com/jaegertracing/jaeger-idl/model/comparator"
+)
+
+// maxLineSize caps the size of a query log line.
+const maxLineSize = 1 << 20
+
+// entry is a line of the query log.
+type entry struct {
+	Time       time.Time       `json:"time"`
+	Method     string          `json:"method"`
+	Request    json.RawMessage `json:"request"`
+	DurationMs float64         `json:"durationMs"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// result is the outcome of replaying an entry.
+type result struct {
+	method   string
+	recorded time.Duration
+	replayed time.Duration
+	err      error
+}
+
+func main() {
+	logPath := flag.String("log", "", "query log to replay, in the JSON Lines format written by --query-log")
+	target := flag.String("target", "localhost:17271", "address of the query service")
+	speed := flag.Float64("speed", 1, "pacing relative to the recorded calls, e.g. 2 replays twice as fast; 0 replays without pauses")
+	concurrency := flag.Int("concurrency", 16, "maximum number of calls in flight")
+	timeout := flag.Duration("timeout", 30*time.Second, "timeout of each call")
+	flag.Parse()
+
+	if *logPath == "" {
+		log.Fatal("--log is required")
+	}
+	if *speed < 0 || *concurrency < 1 {
+		log.Fatal("--speed must not be negative and --concurrency must be positive")
+	}
+	entries, err := readLog(*logPath)
+	if err != nil {
+		log.Fatalf("Failed to read query log: %v", err)
+	}
+	if len(entries) == 0 {
+		log.Fatal("The query log is empty")
+	}
+
+	conn, err := grpc.NewClient(*target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+	client := api_v3.NewQueryServiceClient(conn)
+
+	log.Printf("Replaying %d calls against %s\n", len(entries), *target)
+	results := replay(client, api_v2.NewQueryServiceClient(conn), entries, *speed, *concurrency, *timeout)
+	printReport(os.Stdout, results)
+}
+
+func readLog(path string) ([]*entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var entries []*entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		e := &entry{}
+		if err := json.Unmarshal(scanner.Bytes(), e); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	return entries, nil
+}
+
+// replay issues the calls at the recorded offsets from the first call,
+// divided by speed. Calls that cannot start on time because concurrency
+// calls are in flight start as soon as one completes.
+func replay(client api_v3.QueryServiceClient, v2 api_v2.QueryServiceClient, entries []*entry, speed float64, concurrency int, timeout time.Duration) []result {
+	results := make([]result, len(entries))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i, e := range entries {
+		if speed > 0 {
+			offset := time.Duration(float64(e.Time.Sub(entries[0].Time)) / speed)
+			time.Sleep(time.Until(start.Add(offset)))
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			callStart := time.Now()
+			err := call(ctx, client, v2, e)
+			results[i] = result{
+				method:   e.Method,
+				recorded: time.Duration(e.DurationMs * float64(time.Millisecond)),
+				replayed: time.Since(callStart),
+				err:      err,
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// call issues the recorded call and reads the complete response.
+func call(ctx context.Context, client api_v3.QueryServiceClient, v2 api_v2.QueryServiceClient, e *entry) error {
+	switch e.Method {
+	case api_v3.QueryService_GetTrace_FullMethodName:
+		req := &api_v3.GetTraceRequest{}
+		if err := protojson.Unmarshal(e.Request, req); err != nil {
+			return err
+		}
+		stream, err := client.GetTrace(ctx, req)
+		if err != nil {
+			return err
+		}
+		return drain(stream)
+	case api_v3.QueryService_FindTraces_FullMethodName:
+		req := &api_v3.FindTracesRequest{}
+		if err := protojson.Unmarshal(e.Request, req); err != nil {
+			return err
+		}
+		stream, err := client.FindTraces(ctx, req)
+		if err != nil {
+			return err
+		}
+		return drain(stream)
+	case api_v3.QueryService_GetServices_FullMethodName:
+		req := &api_v3.GetServicesRequest{}
+		if err := protojson.Unmarshal(e.Request, req); err != nil {
+			return err
+		}
+		_, err := client.GetServices(ctx, req)
+		return err
+	case api_v3.QueryService_GetOperations_FullMethodName:
+		req := &api_v3.GetOperationsRequest{}
+		if err := protojson.Unmarshal(e.Request, req); err != nil {
+			return err
+		}
+		_, err := client.GetOperations(ctx, req)
+		return err
+	case api_v3.QueryService_GetIndexedAttributesNames_FullMethodName:
+		req := &api_v3.GetIndexedAttributesNamesRequest{}
+		if err := protojson.Unmarshal(e.Request, req); err != nil {
+			return err
+		}
+		_, err := client.GetIndexedAttributesNames(ctx, req)
+		return err
+	case api_v3.QueryService_GetTopKAttributeValues_FullMethodName:
+		req := &api_v3.GetTopKAttributeValuesRequest{}
+		if err := protojson.Unmarshal(e.Request, req); err != nil {
+			return err
+		}
+		_, err := client.GetTopKAttributeValues(ctx, req)
+		return err
+	case api_v3.QueryService_GetDependencies_FullMethodName:
+		req := &api_v3.GetDependenciesRequest{}
+		if err := protojson.Unmarshal(e.Request, req); err != nil {
+			return err
+		}
+		_, err := client.GetDependencies(ctx, req)
+		return err
+	case api_v2.QueryService_GetTrace_FullMethodName:
+		req := &api_v2.GetTraceRequest{}
+		if err := protojson.Unmarshal(e.Request, req); err != nil {
+			return err
+		}
+		stream, err := v2.GetTrace(ctx, req)
+		if err != nil {
+			return err
+		}
+		return drain(stream)
+	case api_v2.QueryService_FindTraces_FullMethodName:
+		req := &api_v2.FindTracesRequest{}
+		if err := protojson.Unmarshal(e.Request, req); err != nil {
+			return err
+		}
+		stream, err := v2.FindTraces(ctx, req)
+		if err != nil {
+			return err
+		}
+		return drain(stream)
+	case api_v2.QueryService_GetServices_FullMethodName:
+		req := &api_v2.GetServicesRequest{}
+		if err := protojson.Unmarshal(e.Request, req); err != nil {
+			return err
+		}
+		_, err := v2.GetServices(ctx, req)
+		return err
+	case api_v2.QueryService_GetOperations_FullMethodName:
+		req := &api_v2.GetOperationsRequest{}
+		if err := protojson.Unmarshal(e.Request, req); err != nil {
+			return err
+		}
+		_, err := v2.GetOperations(ctx, req)
+		return err
+	default:
+		return fmt.Errorf("unsupported method %s", e.Method)
+	}
+}
+
+func drain[T any](stream grpc.ServerStreamingClient[T]) error {
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// printReport writes the latency percentiles of the recorded and replayed
+// calls per method, followed by the distinct errors.
+func printReport(w io.Writer, results []result) {
+	byMethod := make(map[string][]result)
+	for _, r := range results {
+		byMethod[r.method] = append(byMethod[r.method], r)
+	}
+	methods := make([]string, 0, len(byMethod))
+	for method := range byMethod {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tCALLS\tERRORS\tRECORDED P50\tREPLAYED P50\tRECORDED P95\tREPLAYED P95\tREPLAYED P99\tREPLAYED MAX")
+	errs := make(map[string]int)
+	for _, method := range methods {
+		var recorded, replayed []time.Duration
+		failed := 0
+		for _, r := range byMethod[method] {
+			recorded = append(recorded, r.recorded)
+			replayed = append(replayed, r.replayed)
+			if r.err != nil {
+				failed++
+				errs[method+": "+r.err.Error()]++
+			}
+		}
+		slices.Sort(recorded)
+		slices.Sort(replayed)
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%v\t%v\t%v\t%v\t%v\t%v\n",
+			method, len(replayed), failed,
+			comparator.Percentile(recorded, 50).Round(time.Microsecond),
+			comparator.Percentile(replayed, 50).Round(time.Microsecond),
+			comparator.Percentile(recorded, 95).Round(time.Microsecond),
+			comparator.Percentile(replayed, 95).Round(time.Microsecond),
+			comparator.Percentile(replayed, 99).Round(time.Microsecond),
+			replayed[len(replayed)-1].Round(time.Microsecond),
+		)
+	}
+	tw.Flush()
+
+	if len(errs) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Errors:")
+		messages := make([]string, 0, len(errs))
+		for msg := range errs {
+			messages = append(messages, msg)
+		}
+		sort.Strings(messages)
+		for _, msg := range messages {
+			fmt.Fprintf(w, "  %dx %s\n", errs[msg], msg)
+		}
+	}
+}
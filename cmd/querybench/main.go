@@ -0,0 +1,453 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command querybench benchmarks a Jaeger query service by issuing a mix of
+// GetTrace, FindTraces and GetServices calls, with the api_v3 or api_v2
+// query API, at a given concurrency and rate, then reports the latency
+// percentiles and the error rate of each method.
+//
+// The services and trace IDs to query are discovered on startup with
+// GetServices and FindTraces, unless given with --services.
+//
+// Usage:
+//
+//	querybench --target localhost:17271 --duration 1m --concurrency 32 --qps 500 --mix GetTrace=5,FindTraces=1
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	"github.com/jaegertracing/jaeger-idl/model/comparator"
+)
+
+// Benchmarked methods.
+const (
+	methodGetTrace    = "GetTrace"
+	methodFindTraces  = "FindTraces"
+	methodGetServices = "GetServices"
+)
+
+// maxDiscoveredTraces caps the number of trace IDs collected on startup.
+const maxDiscoveredTraces = 1000
+
+// queryClient issues the benchmarked calls with one of the query APIs.
+type queryClient interface {
+	getServices(ctx context.Context) ([]string, error)
+	// findTraces returns the hex IDs of the traces found.
+	findTraces(ctx context.Context, service string, lookback time.Duration, limit int) ([]string, error)
+	getTrace(ctx context.Context, traceID string) error
+}
+
+// weightedMethod is a method of the mix with its relative frequency.
+type weightedMethod struct {
+	method string
+	weight int
+}
+
+// target is what the calls query.
+type target struct {
+	services []string
+	traceIDs []string
+	lookback time.Duration
+	limit    int
+}
+
+// result is the outcome of a call.
+type result struct {
+	method  string
+	latency time.Duration
+	err     error
+}
+
+func main() {
+	addr := flag.String("target", "localhost:17271", "address of the query service")
+	api := flag.String("api", "v3", "query API to use, v3 or v2")
+	duration := flag.Duration("duration", 30*time.Second, "duration of the benchmark")
+	concurrency := flag.Int("concurrency", 8, "number of concurrent callers")
+	qps := flag.Float64("qps", 0, "total rate of calls per second, 0 for as fast as possible")
+	mixFlag := flag.String("mix", "GetTrace=1,FindTraces=1,GetServices=1", "relative frequency of the methods")
+	servicesFlag := flag.String("services", "", "comma separated services to query, discovered with GetServices by default")
+	lookback := flag.Duration("lookback", time.Hour, "time range of the FindTraces calls, ending now")
+	limit := flag.Int("limit", 20, "maximum number of traces of the FindTraces calls")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of each call")
+	flag.Parse()
+
+	if *concurrency < 1 || *qps < 0 || *duration <= 0 || *limit < 1 {
+		log.Fatal("--concurrency, --duration and --limit must be positive and --qps must not be negative")
+	}
+	mix, err := parseMix(*mixFlag)
+	if err != nil {
+		log.Fatalf("Invalid --mix: %v", err)
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+	var client queryClient
+	switch *api {
+	case "v3":
+		client = &v3Client{client: api_v3.NewQueryServiceClient(conn)}
+	case "v2":
+		client = &v2Client{client: api_v2.NewQueryServiceClient(conn)}
+	default:
+		log.Fatalf("Unknown API %q, expected v3 or v2", *api)
+	}
+
+	tgt := &target{lookback: *lookback, limit: *limit}
+	if *servicesFlag != "" {
+		tgt.services = strings.Split(*servicesFlag, ",")
+	}
+	if err := discover(client, tgt, *timeout); err != nil {
+		log.Fatalf("Failed to discover the data to query: %v", err)
+	}
+	log.Printf("Querying %d services and %d traces\n", len(tgt.services), len(tgt.traceIDs))
+	if len(tgt.traceIDs) == 0 {
+		mix = slices.DeleteFunc(mix, func(m weightedMethod) bool { return m.method == methodGetTrace })
+		if len(mix) == 0 {
+			log.Fatal("No traces found for GetTrace")
+		}
+		log.Println("No traces found, skipping GetTrace")
+	}
+
+	log.Printf("Running for %v with %d callers against %s\n", *duration, *concurrency, *addr)
+	start := time.Now()
+	results := run(client, tgt, mix, *duration, *concurrency, *qps, *timeout)
+	printReport(os.Stdout, results, time.Since(start))
+}
+
+// parseMix parses the method weights, e.g. GetTrace=5,FindTraces=1.
+func parseMix(s string) ([]weightedMethod, error) {
+	var mix []weightedMethod
+	for _, part := range strings.Split(s, ",") {
+		method, w, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("expected method=weight, got %q", part)
+		}
+		switch method {
+		case methodGetTrace, methodFindTraces, methodGetServices:
+		default:
+			return nil, fmt.Errorf("unknown method %q, expected %s, %s or %s",
+				method, methodGetTrace, methodFindTraces, methodGetServices)
+		}
+		weight, err := strconv.Atoi(w)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight %q of %s", w, method)
+		}
+		if weight > 0 {
+			mix = append(mix, weightedMethod{method: method, weight: weight})
+		}
+	}
+	if len(mix) == 0 {
+		return nil, errors.New("all weights are zero")
+	}
+	return mix, nil
+}
+
+// discover fills in the services, unless given, and the trace IDs to query.
+func discover(client queryClient, tgt *target, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if len(tgt.services) == 0 {
+		services, err := client.getServices(ctx)
+		if err != nil {
+			return err
+		}
+		if len(services) == 0 {
+			return errors.New("no services")
+		}
+		tgt.services = services
+	}
+	for _, service := range tgt.services {
+		ids, err := client.findTraces(ctx, service, tgt.lookback, tgt.limit)
+		if err != nil {
+			return fmt.Errorf("cannot find the traces of %s: %w", service, err)
+		}
+		tgt.traceIDs = append(tgt.traceIDs, ids...)
+		if len(tgt.traceIDs) >= maxDiscoveredTraces {
+			tgt.traceIDs = tgt.traceIDs[:maxDiscoveredTraces]
+			break
+		}
+	}
+	return nil
+}
+
+// run issues calls until duration has elapsed. With a rate, the callers
+// share the calls allowed at qps; if they cannot keep up, the achieved
+// rate is lower, as shown by the report.
+func run(client queryClient, tgt *target, mix []weightedMethod, duration time.Duration, concurrency int, qps float64, timeout time.Duration) []result {
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+
+	var tokens chan struct{}
+	if qps > 0 {
+		tokens = make(chan struct{})
+		go func() {
+			ticker := time.NewTicker(time.Duration(float64(time.Second) / qps))
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					select {
+					case tokens <- struct{}{}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	total := 0
+	for _, m := range mix {
+		total += m.weight
+	}
+	perCaller := make([][]result, concurrency)
+	var wg sync.WaitGroup
+	for i := range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := rand.New(rand.NewPCG(uint64(i), uint64(time.Now().UnixNano())))
+			for {
+				if tokens != nil {
+					select {
+					case <-tokens:
+					case <-ctx.Done():
+						return
+					}
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				method := pick(mix, total, rng)
+				callCtx, callCancel := context.WithTimeout(context.Background(), timeout)
+				callStart := time.Now()
+				err := call(callCtx, client, tgt, method, rng)
+				callCancel()
+				perCaller[i] = append(perCaller[i], result{method: method, latency: time.Since(callStart), err: err})
+			}
+		}()
+	}
+	wg.Wait()
+	return slices.Concat(perCaller...)
+}
+
+func pick(mix []weightedMethod, total int, rng *rand.Rand) string {
+	n := rng.IntN(total)
+	for _, m := range mix {
+		if n -= m.weight; n < 0 {
+			return m.method
+		}
+	}
+	return mix[len(mix)-1].method
+}
+
+func call(ctx context.Context, client queryClient, tgt *target, method string, rng *rand.Rand) error {
+	switch method {
+	case methodGetTrace:
+		return client.getTrace(ctx, tgt.traceIDs[rng.IntN(len(tgt.traceIDs))])
+	case methodFindTraces:
+		_, err := client.findTraces(ctx, tgt.services[rng.IntN(len(tgt.services))], tgt.lookback, tgt.limit)
+		return err
+	default:
+		_, err := client.getServices(ctx)
+		return err
+	}
+}
+
+// printReport writes the latency percentiles and the error rate of each
+// method, then the distinct errors.
+func printReport(w io.Writer, results []result, elapsed time.Duration) {
+	byMethod := make(map[string][]result)
+	for _, r := range results {
+		byMethod[r.method] = append(byMethod[r.method], r)
+	}
+	methods := make([]string, 0, len(byMethod))
+	for method := range byMethod {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tCALLS\tQPS\tERRORS\tERROR RATE\tP50\tP90\tP99\tMAX")
+	errs := make(map[string]int)
+	for _, method := range methods {
+		var latencies []time.Duration
+		failed := 0
+		for _, r := range byMethod[method] {
+			latencies = append(latencies, r.latency)
+			if r.err != nil {
+				failed++
+				errs[method+": "+r.err.Error()]++
+			}
+		}
+		slices.Sort(latencies)
+		fmt.Fprintf(tw, "%s\t%d\t%.1f\t%d\t%.2f%%\t%v\t%v\t%v\t%v\n",
+			method, len(latencies), float64(len(latencies))/elapsed.Seconds(),
+			failed, 100*float64(failed)/float64(len(latencies)),
+			comparator.Percentile(latencies, 50).Round(time.Microsecond),
+			comparator.Percentile(latencies, 90).Round(time.Microsecond),
+			comparator.Percentile(latencies, 99).Round(time.Microsecond),
+			latencies[len(latencies)-1].Round(time.Microsecond),
+		)
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\n%d calls in %v (%.1f calls/s)\n",
+		len(results), elapsed.Round(time.Millisecond), float64(len(results))/elapsed.Seconds())
+
+	if len(errs) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Errors:")
+		messages := make([]string, 0, len(errs))
+		for msg := range errs {
+			messages = append(messages, msg)
+		}
+		sort.Strings(messages)
+		for _, msg := range messages {
+			fmt.Fprintf(w, "  %dx %s\n", errs[msg], msg)
+		}
+	}
+}
+
+// v3Client uses the api_v3 query API.
+type v3Client struct {
+	client api_v3.QueryServiceClient
+}
+
+func (c *v3Client) getServices(ctx context.Context) ([]string, error) {
+	resp, err := c.client.GetServices(ctx, &api_v3.GetServicesRequest{})
+	return resp.GetServices(), err
+}
+
+func (c *v3Client) findTraces(ctx context.Context, service string, lookback time.Duration, limit int) ([]string, error) {
+	now := time.Now()
+	stream, err := c.client.FindTraces(ctx, &api_v3.FindTracesRequest{Query: &api_v3.TraceQueryParameters{
+		ServiceName:  service,
+		StartTimeMin: timestamppb.New(now.Add(-lookback)),
+		StartTimeMax: timestamppb.New(now),
+		SearchDepth:  int32(limit),
+	}})
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var ids []string
+	for {
+		td, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return ids, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, rs := range td.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, span := range ss.Spans {
+					if id := hex.EncodeToString(span.TraceId); !seen[id] {
+						seen[id] = true
+						ids = append(ids, id)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (c *v3Client) getTrace(ctx context.Context, traceID string) error {
+	stream, err := c.client.GetTrace(ctx, &api_v3.GetTraceRequest{TraceId: traceID})
+	if err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// v2Client uses the api_v2 query API.
+type v2Client struct {
+	client api_v2.QueryServiceClient
+}
+
+func (c *v2Client) getServices(ctx context.Context) ([]string, error) {
+	resp, err := c.client.GetServices(ctx, &api_v2.GetServicesRequest{})
+	return resp.GetServices(), err
+}
+
+func (c *v2Client) findTraces(ctx context.Context, service string, lookback time.Duration, limit int) ([]string, error) {
+	now := time.Now()
+	stream, err := c.client.FindTraces(ctx, &api_v2.FindTracesRequest{Query: &api_v2.TraceQueryParameters{
+		ServiceName:  service,
+		StartTimeMin: timestamppb.New(now.Add(-lookback)),
+		StartTimeMax: timestamppb.New(now),
+		SearchDepth:  int32(limit),
+	}})
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var ids []string
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return ids, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, span := range chunk.Spans {
+			if id := hex.EncodeToString(span.TraceId); !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+}
+
+func (c *v2Client) getTrace(ctx context.Context, traceID string) error {
+	id, err := hex.DecodeString(traceID)
+	if err != nil {
+		return err
+	}
+	stream, err := c.client.GetTrace(ctx, &api_v2.GetTraceRequest{TraceId: id})
+	if err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
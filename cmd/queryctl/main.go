@@ -0,0 +1,579 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command queryctl queries a Jaeger query service with the api_v3 or api_v2
+// query API from the command line, printing the responses as tables or as
+// the JSON of the API, so that neither grpcurl nor hand-written request
+// payloads are needed.
+//
+// Usage:
+//
+//	queryctl [flags] services
+//	queryctl [flags] operations [--span-kind server] <service>
+//	queryctl [flags] trace [--view table|tree|waterfall] <trace-id>
+//	queryctl [flags] find --service frontend [--operation name] [--tag key=value]... [--lookback 1h] [--limit 20]
+//	queryctl [flags] dependencies [--lookback 24h]
+//
+// For example:
+//
+//	queryctl --target localhost:17271 --api v2 --output json find --service frontend --tag error=true
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/adapter/streamcheck"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+	"github.com/jaegertracing/jaeger-idl/model/converter/apiv2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	modeltrace "github.com/jaegertracing/jaeger-idl/model/trace"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// Output formats.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// Views of the trace command.
+const (
+	viewTable     = "table"
+	viewTree      = "tree"
+	viewWaterfall = "waterfall"
+)
+
+const usage = `Usage: queryctl [flags] <command> [arguments]
+
+Commands:
+  services                       list the services
+  operations [flags] <service>   list the operations of a service
+  trace [flags] <trace-id>       print a trace
+  find [flags]                   find the traces matching a query
+  dependencies [flags]           list the calls between services
+
+Run queryctl <command> -h for the flags of a command.
+
+Flags:
+`
+
+// operation is an operation of a service, whichever the API.
+type operation struct {
+	name     string
+	spanKind string
+}
+
+// dependency is the calls of a service to another, whichever the API. The
+// api_v2 links have no error count nor latencies.
+type dependency struct {
+	parent, child string
+	calls, errors uint64
+	p50, p95, p99 time.Duration
+}
+
+// findQuery holds the parameters of the find command, whichever the API.
+type findQuery struct {
+	service     string
+	operation   string
+	tags        map[string]string
+	start, end  time.Time
+	minDuration time.Duration
+	maxDuration time.Duration
+	limit       int
+}
+
+// queryClient issues the calls with one of the query APIs. Each call returns
+// the response of the API, merged into a single message for the streaming
+// calls, along with its content for the tables.
+type queryClient interface {
+	services(ctx context.Context) (proto.Message, []string, error)
+	operations(ctx context.Context, service, spanKind string) (proto.Message, []operation, error)
+	trace(ctx context.Context, traceID string) (proto.Message, []*model.Span, error)
+	find(ctx context.Context, query *findQuery) (proto.Message, []*model.Span, error)
+	dependencies(ctx context.Context, start, end time.Time) (proto.Message, []dependency, error)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("queryctl: ")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	target := flag.String("target", "localhost:17271", "address of the query service")
+	api := flag.String("api", "v3", "query API to use, v3 or v2")
+	output := flag.String("output", outputTable, "output format, table or json")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of the call")
+	requireChecksum := flag.Bool("require-checksum", false, "fail the streamed responses without a checksum trailer, instead of only verifying the checksums sent by the server")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *output != outputTable && *output != outputJSON {
+		log.Fatalf("unknown output %q, expected %s or %s", *output, outputTable, outputJSON)
+	}
+
+	conn, err := grpc.NewClient(*target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStreamInterceptor(streamcheck.StreamClientInterceptor(streamcheck.ClientOptions{Required: *requireChecksum})),
+	)
+	if err != nil {
+		log.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+	var client queryClient
+	switch *api {
+	case "v3":
+		client = &v3Client{client: api_v3.NewQueryServiceClient(conn)}
+	case "v2":
+		client = &v2Client{client: api_v2.NewQueryServiceClient(conn)}
+	default:
+		log.Fatalf("unknown API %q, expected v3 or v2", *api)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	cmd, args := flag.Arg(0), flag.Args()[1:]
+	var resp proto.Message
+	var print func(w io.Writer)
+	switch cmd {
+	case "services":
+		fs := newFlagSet(cmd, "")
+		fs.Parse(args)
+		var services []string
+		resp, services, err = client.services(ctx)
+		print = func(w io.Writer) { printServices(w, services) }
+	case "operations":
+		fs := newFlagSet(cmd, "<service>")
+		spanKind := fs.String("span-kind", "", "span kind of the operations, e.g. server")
+		fs.Parse(args)
+		if fs.NArg() != 1 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		var operations []operation
+		resp, operations, err = client.operations(ctx, fs.Arg(0), *spanKind)
+		print = func(w io.Writer) { printOperations(w, operations) }
+	case "trace":
+		fs := newFlagSet(cmd, "<trace-id>")
+		view := fs.String("view", viewTable, "layout of the trace in the table output: table, tree or waterfall")
+		width := fs.Int("width", modeltrace.DefaultRenderWidth, "width of the bars of the waterfall view")
+		fs.Parse(args)
+		if fs.NArg() != 1 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		if *view != viewTable && *view != viewTree && *view != viewWaterfall {
+			log.Fatalf("unknown view %q, expected %s, %s or %s", *view, viewTable, viewTree, viewWaterfall)
+		}
+		var spans []*model.Span
+		resp, spans, err = client.trace(ctx, fs.Arg(0))
+		print = func(w io.Writer) { printTrace(w, spans, *view, *width) }
+	case "find":
+		query, perr := parseFindQuery(args)
+		if perr != nil {
+			log.Fatal(perr)
+		}
+		var spans []*model.Span
+		resp, spans, err = client.find(ctx, query)
+		print = func(w io.Writer) { printTraces(w, spans) }
+	case "dependencies":
+		fs := newFlagSet(cmd, "")
+		timeRange := addTimeRangeFlags(fs, "dependencies", 24*time.Hour)
+		fs.Parse(args)
+		if fs.NArg() != 0 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		start, end, perr := timeRange.parse()
+		if perr != nil {
+			log.Fatal(perr)
+		}
+		var dependencies []dependency
+		resp, dependencies, err = client.dependencies(ctx, start, end)
+		print = func(w io.Writer) { printDependencies(w, dependencies) }
+	default:
+		log.Printf("unknown command %q", cmd)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *output == outputJSON {
+		b, err := protojson.MarshalOptions{Multiline: true}.Marshal(resp)
+		if err != nil {
+			log.Fatalf("failed to marshal the response: %v", err)
+		}
+		fmt.Println(string(b))
+		return
+	}
+	print(os.Stdout)
+}
+
+func newFlagSet(cmd, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: queryctl [flags] %s [flags] %s\n", cmd, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// tagsFlag collects the repeated --tag key=value flags.
+type tagsFlag map[string]string
+
+func (t tagsFlag) String() string {
+	return ""
+}
+
+func (t tagsFlag) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("expected key=value, got %q", s)
+	}
+	t[key] = value
+	return nil
+}
+
+func parseFindQuery(args []string) (*findQuery, error) {
+	fs := newFlagSet("find", "")
+	query := &findQuery{tags: make(map[string]string)}
+	fs.StringVar(&query.service, "service", "", "service of the traces (required)")
+	fs.StringVar(&query.operation, "operation", "", "operation of the traces")
+	fs.Var(tagsFlag(query.tags), "tag", "tag of a span of the traces as key=value, repeatable")
+	timeRange := addTimeRangeFlags(fs, "query", time.Hour)
+	fs.DurationVar(&query.minDuration, "min-duration", 0, "minimum duration of a span of the traces")
+	fs.DurationVar(&query.maxDuration, "max-duration", 0, "maximum duration of a span of the traces")
+	fs.IntVar(&query.limit, "limit", 20, "maximum number of traces")
+	fs.Parse(args)
+	if fs.NArg() != 0 {
+		return nil, fmt.Errorf("unexpected arguments %q", fs.Args())
+	}
+	if query.service == "" {
+		return nil, errors.New("missing --service")
+	}
+	var err error
+	query.start, query.end, err = timeRange.parse()
+	if err != nil {
+		return nil, err
+	}
+	return query, nil
+}
+
+// timeRangeFlags holds the --lookback, --start and --end flags of a command.
+type timeRangeFlags struct {
+	lookback   time.Duration
+	start, end string
+}
+
+func addTimeRangeFlags(fs *flag.FlagSet, what string, lookback time.Duration) *timeRangeFlags {
+	r := &timeRangeFlags{}
+	fs.DurationVar(&r.lookback, "lookback", lookback, "time range of the "+what+", ending at --end")
+	fs.StringVar(&r.start, "start", "", "start of the time range in RFC 3339 format, instead of --lookback")
+	fs.StringVar(&r.end, "end", "", "end of the time range in RFC 3339 format (default now)")
+	return r
+}
+
+// parse returns the time range of the parsed flags.
+func (r *timeRangeFlags) parse() (start, end time.Time, err error) {
+	end = time.Now()
+	if r.end != "" {
+		if end, err = time.Parse(time.RFC3339Nano, r.end); err != nil {
+			return start, end, fmt.Errorf("invalid --end: %w", err)
+		}
+	}
+	start = end.Add(-r.lookback)
+	if r.start != "" {
+		if start, err = time.Parse(time.RFC3339Nano, r.start); err != nil {
+			return start, end, fmt.Errorf("invalid --start: %w", err)
+		}
+	}
+	return start, end, nil
+}
+
+func printServices(w io.Writer, services []string) {
+	for _, service := range services {
+		fmt.Fprintln(w, service)
+	}
+}
+
+func printOperations(w io.Writer, operations []operation) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "OPERATION\tSPAN KIND")
+	for _, op := range operations {
+		fmt.Fprintf(tw, "%s\t%s\n", op.name, op.spanKind)
+	}
+	tw.Flush()
+}
+
+// printTrace prints the spans of a trace as a table indented like a tree,
+// with their start time relative to the start of the trace, or rendered as
+// a tree or a waterfall.
+func printTrace(w io.Writer, spans []*model.Span, view string, width int) {
+	tree := modeltrace.NewTree(spans)
+	if tree.Len() == 0 {
+		fmt.Fprintln(w, "No spans")
+		return
+	}
+	switch view {
+	case viewTree:
+		tree.Render(w, modeltrace.RenderOptions{Style: modeltrace.RenderTree})
+		return
+	case viewWaterfall:
+		tree.Render(w, modeltrace.RenderOptions{Style: modeltrace.RenderWaterfall, Width: width})
+		return
+	}
+	start := tree.Roots[0].Span.StartTime
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SPAN ID\tSERVICE\tOPERATION\tSTART\tDURATION\tERROR")
+	tree.Walk(func(node *modeltrace.Node) bool {
+		span := node.Span
+		isError := ""
+		if modeltrace.IsError(span) {
+			isError = "yes"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s%s\t+%v\t%v\t%s\n",
+			span.SpanID, span.Process.GetServiceName(), strings.Repeat("  ", node.Depth), span.OperationName,
+			span.StartTime.Sub(start), span.Duration, isError)
+		return true
+	})
+	tw.Flush()
+}
+
+// printTraces prints a line per trace of the spans, in the order of the response.
+func printTraces(w io.Writer, spans []*model.Span) {
+	var order []model.TraceID
+	byTrace := make(map[model.TraceID][]*model.Span)
+	for _, span := range spans {
+		if _, ok := byTrace[span.TraceID]; !ok {
+			order = append(order, span.TraceID)
+		}
+		byTrace[span.TraceID] = append(byTrace[span.TraceID], span)
+	}
+	if len(order) == 0 {
+		fmt.Fprintln(w, "No traces")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TRACE ID\tROOT\tSPANS\tERRORS\tDURATION\tSTART")
+	for _, traceID := range order {
+		tree := modeltrace.NewTree(byTrace[traceID])
+		stats := tree.Stats()
+		root := tree.Roots[0]
+		if r := tree.Root(); r != nil {
+			root = r
+		}
+		fmt.Fprintf(tw, "%s\t%s: %s\t%d\t%d\t%v\t%s\n",
+			traceID, root.Span.Process.GetServiceName(), root.Span.OperationName,
+			stats.SpanCount, stats.ErrorCount, stats.Duration,
+			root.Span.StartTime.Local().Format(time.RFC3339))
+	}
+	tw.Flush()
+}
+
+// printDependencies prints a line per dependency, in the order of the response.
+func printDependencies(w io.Writer, dependencies []dependency) {
+	if len(dependencies) == 0 {
+		fmt.Fprintln(w, "No dependencies")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PARENT\tCHILD\tCALLS\tERRORS\tP50\tP95\tP99")
+	for _, dep := range dependencies {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%v\t%v\t%v\n",
+			dep.parent, dep.child, dep.calls, dep.errors, dep.p50, dep.p95, dep.p99)
+	}
+	tw.Flush()
+}
+
+// v3Client uses the api_v3 query API.
+type v3Client struct {
+	client api_v3.QueryServiceClient
+}
+
+func (c *v3Client) services(ctx context.Context) (proto.Message, []string, error) {
+	resp, err := c.client.GetServices(ctx, &api_v3.GetServicesRequest{})
+	return resp, resp.GetServices(), err
+}
+
+func (c *v3Client) operations(ctx context.Context, service, spanKind string) (proto.Message, []operation, error) {
+	resp, err := c.client.GetOperations(ctx, &api_v3.GetOperationsRequest{Service: service, SpanKind: spanKind})
+	var operations []operation
+	for _, op := range resp.GetOperations() {
+		operations = append(operations, operation{name: op.Name, spanKind: op.SpanKind})
+	}
+	return resp, operations, err
+}
+
+func (c *v3Client) trace(ctx context.Context, traceID string) (proto.Message, []*model.Span, error) {
+	stream, err := c.client.GetTrace(ctx, &api_v3.GetTraceRequest{TraceId: traceID})
+	if err != nil {
+		return nil, nil, err
+	}
+	return recvTracesData(stream)
+}
+
+func (c *v3Client) find(ctx context.Context, query *findQuery) (proto.Message, []*model.Span, error) {
+	params := &api_v3.TraceQueryParameters{
+		ServiceName:   query.service,
+		OperationName: query.operation,
+		Attributes:    query.tags,
+		StartTimeMin:  timestamppb.New(query.start),
+		StartTimeMax:  timestamppb.New(query.end),
+		SearchDepth:   int32(query.limit),
+	}
+	if query.minDuration > 0 {
+		params.DurationMin = durationpb.New(query.minDuration)
+	}
+	if query.maxDuration > 0 {
+		params.DurationMax = durationpb.New(query.maxDuration)
+	}
+	stream, err := c.client.FindTraces(ctx, &api_v3.FindTracesRequest{Query: params})
+	if err != nil {
+		return nil, nil, err
+	}
+	return recvTracesData(stream)
+}
+
+func (c *v3Client) dependencies(ctx context.Context, start, end time.Time) (proto.Message, []dependency, error) {
+	resp, err := c.client.GetDependencies(ctx, &api_v3.GetDependenciesRequest{
+		StartTime: timestamppb.New(start),
+		EndTime:   timestamppb.New(end),
+	})
+	var dependencies []dependency
+	for _, dep := range resp.GetDependencies() {
+		dependencies = append(dependencies, dependency{
+			parent: dep.Parent,
+			child:  dep.Child,
+			calls:  dep.CallCount,
+			errors: dep.ErrorCount,
+			p50:    dep.LatencyP50.AsDuration(),
+			p95:    dep.LatencyP95.AsDuration(),
+			p99:    dep.LatencyP99.AsDuration(),
+		})
+	}
+	return resp, dependencies, err
+}
+
+// recvTracesData merges the chunks of an api_v3 stream.
+func recvTracesData(stream grpc.ServerStreamingClient[tracev1.TracesData]) (proto.Message, []*model.Span, error) {
+	merged := &tracev1.TracesData{}
+	for {
+		td, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return merged, otlp.ToDomain(merged), nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		merged.ResourceSpans = append(merged.ResourceSpans, td.ResourceSpans...)
+	}
+}
+
+// v2Client uses the api_v2 query API.
+type v2Client struct {
+	client api_v2.QueryServiceClient
+}
+
+func (c *v2Client) services(ctx context.Context) (proto.Message, []string, error) {
+	resp, err := c.client.GetServices(ctx, &api_v2.GetServicesRequest{})
+	return resp, resp.GetServices(), err
+}
+
+func (c *v2Client) operations(ctx context.Context, service, spanKind string) (proto.Message, []operation, error) {
+	resp, err := c.client.GetOperations(ctx, &api_v2.GetOperationsRequest{Service: service, SpanKind: spanKind})
+	var operations []operation
+	for _, op := range resp.GetOperations() {
+		operations = append(operations, operation{name: op.Name, spanKind: op.SpanKind})
+	}
+	return resp, operations, err
+}
+
+func (c *v2Client) trace(ctx context.Context, traceID string) (proto.Message, []*model.Span, error) {
+	id, err := hex.DecodeString(traceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid trace ID %q: %w", traceID, err)
+	}
+	stream, err := c.client.GetTrace(ctx, &api_v2.GetTraceRequest{TraceId: id})
+	if err != nil {
+		return nil, nil, err
+	}
+	return recvSpansChunks(stream)
+}
+
+func (c *v2Client) find(ctx context.Context, query *findQuery) (proto.Message, []*model.Span, error) {
+	params := &api_v2.TraceQueryParameters{
+		ServiceName:   query.service,
+		OperationName: query.operation,
+		Tags:          query.tags,
+		StartTimeMin:  timestamppb.New(query.start),
+		StartTimeMax:  timestamppb.New(query.end),
+		SearchDepth:   int32(query.limit),
+	}
+	if query.minDuration > 0 {
+		params.DurationMin = durationpb.New(query.minDuration)
+	}
+	if query.maxDuration > 0 {
+		params.DurationMax = durationpb.New(query.maxDuration)
+	}
+	stream, err := c.client.FindTraces(ctx, &api_v2.FindTracesRequest{Query: params})
+	if err != nil {
+		return nil, nil, err
+	}
+	return recvSpansChunks(stream)
+}
+
+func (c *v2Client) dependencies(ctx context.Context, start, end time.Time) (proto.Message, []dependency, error) {
+	resp, err := c.client.GetDependencies(ctx, &api_v2.GetDependenciesRequest{
+		StartTime: timestamppb.New(start),
+		EndTime:   timestamppb.New(end),
+	})
+	var dependencies []dependency
+	for _, link := range resp.GetDependencies() {
+		dependencies = append(dependencies, dependency{parent: link.Parent, child: link.Child, calls: link.CallCount})
+	}
+	return resp, dependencies, err
+}
+
+// recvSpansChunks merges the chunks of an api_v2 stream.
+func recvSpansChunks(stream grpc.ServerStreamingClient[api_v2.SpansResponseChunk]) (proto.Message, []*model.Span, error) {
+	merged := &api_v2.SpansResponseChunk{}
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		merged.Spans = append(merged.Spans, chunk.Spans...)
+	}
+	spans, err := apiv2.SpansFromProto(merged.Spans)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid spans in the response: %w", err)
+	}
+	return merged, spans, nil
+}
@@ -0,0 +1,653 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command storagebench benchmarks a storage backend implementing the remote
+// storage API, i.e. the storage v2 TraceReader for reads and the OTLP
+// TraceService for writes, with a standardized workload, so that the
+// backends can be compared on the same report.
+//
+// The write phase exports generated traces, whose number, size and tag
+// cardinality are set by the flags, and reports the write throughput and
+// the latency of the Export calls. The query phase then issues a mix of
+// GetTraces, FindTraces, FindTraceIDs, GetServices and GetOperations calls
+// for the written data and reports the latency percentiles and the error
+// rate of each method. GetTraces calls for traces that are not found count
+// as errors.
+//
+// Memory is not visible through the API: with --metrics-url the memory of
+// the backend is read from its Prometheus metrics before and after each phase.
+//
+// Usage:
+//
+//	storagebench --target localhost:17271 --traces 1000 --spans-per-trace 10 --duration 30s [--metrics-url http://localhost:8888/metrics] [--format table|json]
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+	"github.com/jaegertracing/jaeger-idl/model/comparator"
+)
+
+// Benchmarked query methods.
+const (
+	methodGetTraces     = "GetTraces"
+	methodFindTraces    = "FindTraces"
+	methodFindTraceIDs  = "FindTraceIDs"
+	methodGetServices   = "GetServices"
+	methodGetOperations = "GetOperations"
+)
+
+// Report formats.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// workload describes the generated data, which is the same for every
+// backend given the same flags and seed.
+type workload struct {
+	Traces         int   `json:"traces"`
+	SpansPerTrace  int   `json:"spansPerTrace"`
+	Services       int   `json:"services"`
+	Operations     int   `json:"operations"`
+	Attributes     int   `json:"attributes"`
+	AttributeSize  int   `json:"attributeSize"`
+	TagCardinality int   `json:"tagCardinality"`
+	BatchSize      int   `json:"batchSize"`
+	Seed           int64 `json:"seed"`
+}
+
+// writtenTrace is a generated trace, kept to query it in the query phase.
+type writtenTrace struct {
+	id         []byte
+	start, end time.Time
+	service    string
+	operation  string
+	// attribute is a tag of the root span, used for the FindTraces queries.
+	attribute *commonv1.KeyValue
+}
+
+// weightedMethod is a method of the mix with its relative frequency.
+type weightedMethod struct {
+	method string
+	weight int
+}
+
+// result is the outcome of a call.
+type result struct {
+	method  string
+	latency time.Duration
+	err     error
+}
+
+// report is the outcome of a benchmark.
+type report struct {
+	Target   string          `json:"target"`
+	Workload workload        `json:"workload"`
+	Write    writeReport     `json:"write"`
+	Queries  []latencyReport `json:"queries"`
+	Memory   []memorySample  `json:"memory,omitempty"`
+	Errors   map[string]int  `json:"errors,omitempty"`
+}
+
+type writeReport struct {
+	Spans       int           `json:"spans"`
+	Bytes       int           `json:"bytes"`
+	Elapsed     time.Duration `json:"elapsedNanos"`
+	SpansPerSec float64       `json:"spansPerSec"`
+	BytesPerSec float64       `json:"bytesPerSec"`
+	Export      latencyReport `json:"export"`
+}
+
+// latencyReport summarizes the calls of a method.
+type latencyReport struct {
+	Method    string        `json:"method"`
+	Calls     int           `json:"calls"`
+	PerSecond float64       `json:"perSec"`
+	Errors    int           `json:"errors"`
+	P50       time.Duration `json:"p50Nanos"`
+	P90       time.Duration `json:"p90Nanos"`
+	P99       time.Duration `json:"p99Nanos"`
+	Max       time.Duration `json:"maxNanos"`
+}
+
+// memorySample is the memory of the backend at the start or the end of a phase.
+type memorySample struct {
+	Phase string  `json:"phase"`
+	Bytes float64 `json:"bytes"`
+}
+
+func main() {
+	addr := flag.String("target", "localhost:17271", "address of the remote storage")
+	var w workload
+	flag.IntVar(&w.Traces, "traces", 1000, "number of traces to write")
+	flag.IntVar(&w.SpansPerTrace, "spans-per-trace", 10, "number of spans of each trace")
+	flag.IntVar(&w.Services, "services", 5, "number of services")
+	flag.IntVar(&w.Operations, "operations", 10, "number of operations of each service")
+	flag.IntVar(&w.Attributes, "attributes", 10, "number of attributes of each span")
+	flag.IntVar(&w.AttributeSize, "attribute-size", 32, "size of the attribute values in bytes")
+	flag.IntVar(&w.TagCardinality, "tag-cardinality", 100, "number of distinct values of each attribute")
+	flag.IntVar(&w.BatchSize, "batch-size", 10, "number of traces of each Export call")
+	flag.Int64Var(&w.Seed, "seed", 1, "seed of the generated data")
+	duration := flag.Duration("duration", 30*time.Second, "duration of the query phase")
+	concurrency := flag.Int("concurrency", 8, "number of concurrent callers of both phases")
+	qps := flag.Float64("qps", 0, "total rate of queries per second, 0 for as fast as possible")
+	mixFlag := flag.String("mix", "GetTraces=4,FindTraces=2,FindTraceIDs=2,GetServices=1,GetOperations=1", "relative frequency of the query methods")
+	lookback := flag.Duration("lookback", time.Hour, "time range of the find queries, ending now")
+	limit := flag.Int("limit", 20, "maximum number of traces of the find queries")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of each call")
+	metricsURL := flag.String("metrics-url", "", "Prometheus metrics endpoint of the backend, to report its memory")
+	memoryMetric := flag.String("memory-metric", "process_resident_memory_bytes", "metric of the memory of the backend")
+	format := flag.String("format", formatTable, "format of the report, table or json")
+	flag.Parse()
+
+	if w.Traces < 1 || w.SpansPerTrace < 1 || w.Services < 1 || w.Operations < 1 || w.AttributeSize < 1 ||
+		w.TagCardinality < 1 || w.BatchSize < 1 || w.Attributes < 0 {
+		log.Fatal("the workload flags must be positive, --attributes must not be negative")
+	}
+	if *concurrency < 1 || *qps < 0 || *duration < 0 || *limit < 1 {
+		log.Fatal("--concurrency and --limit must be positive, --duration and --qps must not be negative")
+	}
+	if *format != formatTable && *format != formatJSON {
+		log.Fatalf("Unknown format %q, expected %s or %s", *format, formatTable, formatJSON)
+	}
+	mix, err := parseMix(*mixFlag)
+	if err != nil {
+		log.Fatalf("Invalid --mix: %v", err)
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	rep := &report{Target: *addr, Workload: w, Errors: make(map[string]int)}
+	sampleMemory := func(phase string) {
+		if *metricsURL == "" {
+			return
+		}
+		bytes, err := readMetric(*metricsURL, *memoryMetric, *timeout)
+		if err != nil {
+			log.Printf("Cannot read the memory of the backend: %v\n", err)
+			return
+		}
+		rep.Memory = append(rep.Memory, memorySample{Phase: phase, Bytes: bytes})
+	}
+
+	log.Printf("Generating %d traces of %d spans\n", w.Traces, w.SpansPerTrace)
+	requests, traces := generate(w, time.Now())
+	sampleMemory("before writes")
+	log.Printf("Writing %d traces with %d callers to %s\n", w.Traces, *concurrency, *addr)
+	writeResults, elapsed := write(collectortrace.NewTraceServiceClient(conn), requests, *concurrency, *timeout)
+	rep.Write = writeReport{Spans: w.Traces * w.SpansPerTrace, Elapsed: elapsed}
+	for _, req := range requests {
+		rep.Write.Bytes += proto.Size(req)
+	}
+	rep.Write.SpansPerSec = float64(rep.Write.Spans) / elapsed.Seconds()
+	rep.Write.BytesPerSec = float64(rep.Write.Bytes) / elapsed.Seconds()
+	rep.Write.Export = summarize(methodExport, writeResults, elapsed, rep.Errors)
+	sampleMemory("after writes")
+
+	if *duration > 0 {
+		log.Printf("Querying for %v with %d callers\n", *duration, *concurrency)
+		q := &querier{
+			client:   storagev2.NewTraceReaderClient(conn),
+			traces:   traces,
+			lookback: *lookback,
+			limit:    *limit,
+		}
+		start := time.Now()
+		results := run(q, mix, *duration, *concurrency, *qps, *timeout)
+		elapsed := time.Since(start)
+		byMethod := make(map[string][]result)
+		for _, r := range results {
+			byMethod[r.method] = append(byMethod[r.method], r)
+		}
+		for _, m := range mix {
+			if len(byMethod[m.method]) > 0 {
+				rep.Queries = append(rep.Queries, summarize(m.method, byMethod[m.method], elapsed, rep.Errors))
+			}
+		}
+		sampleMemory("after queries")
+	}
+
+	if *format == formatJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rep); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	printReport(os.Stdout, rep, *metricsURL != "")
+}
+
+// methodExport is the write call in the reports.
+const methodExport = "Export"
+
+// parseMix parses the method weights, e.g. GetTraces=4,FindTraces=1.
+func parseMix(s string) ([]weightedMethod, error) {
+	var mix []weightedMethod
+	for _, part := range strings.Split(s, ",") {
+		method, w, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("expected method=weight, got %q", part)
+		}
+		switch method {
+		case methodGetTraces, methodFindTraces, methodFindTraceIDs, methodGetServices, methodGetOperations:
+		default:
+			return nil, fmt.Errorf("unknown method %q, expected %s, %s, %s, %s or %s", method,
+				methodGetTraces, methodFindTraces, methodFindTraceIDs, methodGetServices, methodGetOperations)
+		}
+		weight, err := strconv.Atoi(w)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight %q of %s", w, method)
+		}
+		if weight > 0 {
+			mix = append(mix, weightedMethod{method: method, weight: weight})
+		}
+	}
+	if len(mix) == 0 {
+		return nil, errors.New("all weights are zero")
+	}
+	return mix, nil
+}
+
+// generate builds the Export requests of the workload, with the traces
+// starting at now. The trace IDs start with the time of the run, so that
+// successive runs against the same backend do not overwrite each other.
+func generate(w workload, now time.Time) ([]*collectortrace.ExportTraceServiceRequest, []writtenTrace) {
+	rng := rand.New(rand.NewPCG(uint64(w.Seed), 0))
+	values := make([]string, w.TagCardinality)
+	for i := range values {
+		v := fmt.Sprintf("value-%d-", i)
+		for len(v) < w.AttributeSize {
+			v += strconv.Itoa(i)
+		}
+		values[i] = v[:w.AttributeSize]
+	}
+
+	var requests []*collectortrace.ExportTraceServiceRequest
+	var traces []writtenTrace
+	for t := 0; t < w.Traces; t += w.BatchSize {
+		byService := make(map[string]*tracev1.ScopeSpans)
+		req := &collectortrace.ExportTraceServiceRequest{}
+		for i := t; i < min(t+w.BatchSize, w.Traces); i++ {
+			traceID := make([]byte, 16)
+			binary.BigEndian.PutUint64(traceID, uint64(now.UnixNano()))
+			binary.BigEndian.PutUint64(traceID[8:], uint64(i)+1)
+			start := now.Add(-time.Duration(rng.Int64N(int64(time.Minute))))
+			wt := writtenTrace{id: traceID, start: start}
+			spanIDs := make([][]byte, w.SpansPerTrace)
+			for s := range w.SpansPerTrace {
+				spanIDs[s] = binary.BigEndian.AppendUint64(nil, uint64(s)+1)
+				service := fmt.Sprintf("storagebench-service-%d", rng.IntN(w.Services))
+				span := &tracev1.Span{
+					TraceId: traceID,
+					SpanId:  spanIDs[s],
+					Name:    fmt.Sprintf("operation-%d", rng.IntN(w.Operations)),
+					Kind:    tracev1.Span_SPAN_KIND_SERVER,
+				}
+				offset := time.Duration(0)
+				duration := time.Duration(1+rng.IntN(1000)) * time.Millisecond
+				if s > 0 {
+					span.ParentSpanId = spanIDs[rng.IntN(s)]
+					offset = time.Duration(rng.IntN(100)) * time.Millisecond
+				}
+				span.StartTimeUnixNano = uint64(start.Add(offset).UnixNano())
+				span.EndTimeUnixNano = uint64(start.Add(offset + duration).UnixNano())
+				if end := start.Add(offset + duration); end.After(wt.end) {
+					wt.end = end
+				}
+				for a := range w.Attributes {
+					span.Attributes = append(span.Attributes, &commonv1.KeyValue{
+						Key:   fmt.Sprintf("bench.attribute.%d", a),
+						Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: values[rng.IntN(len(values))]}},
+					})
+				}
+				if s == 0 {
+					wt.service, wt.operation = service, span.Name
+					if len(span.Attributes) > 0 {
+						wt.attribute = span.Attributes[0]
+					}
+				}
+				ss, ok := byService[service]
+				if !ok {
+					ss = &tracev1.ScopeSpans{Scope: &commonv1.InstrumentationScope{Name: "storagebench"}}
+					byService[service] = ss
+					req.ResourceSpans = append(req.ResourceSpans, &tracev1.ResourceSpans{
+						Resource: &resourcev1.Resource{Attributes: []*commonv1.KeyValue{{
+							Key:   "service.name",
+							Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: service}},
+						}}},
+						ScopeSpans: []*tracev1.ScopeSpans{ss},
+					})
+				}
+				ss.Spans = append(ss.Spans, span)
+			}
+			traces = append(traces, wt)
+		}
+		requests = append(requests, req)
+	}
+	return requests, traces
+}
+
+// write exports the requests with concurrent callers and returns the
+// results of the calls and the time taken.
+func write(client collectortrace.TraceServiceClient, requests []*collectortrace.ExportTraceServiceRequest, concurrency int, timeout time.Duration) ([]result, time.Duration) {
+	queue := make(chan *collectortrace.ExportTraceServiceRequest)
+	results := make([][]result, concurrency)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for req := range queue {
+				ctx, cancel := context.WithTimeout(context.Background(), timeout)
+				callStart := time.Now()
+				resp, err := client.Export(ctx, req)
+				cancel()
+				if err == nil && resp.GetPartialSuccess().GetRejectedSpans() > 0 {
+					err = fmt.Errorf("%d spans rejected: %s", resp.PartialSuccess.RejectedSpans, resp.PartialSuccess.ErrorMessage)
+				}
+				results[i] = append(results[i], result{method: methodExport, latency: time.Since(callStart), err: err})
+			}
+		}()
+	}
+	for _, req := range requests {
+		queue <- req
+	}
+	close(queue)
+	wg.Wait()
+	return slices.Concat(results...), time.Since(start)
+}
+
+// querier issues the query calls for the written traces.
+type querier struct {
+	client   storagev2.TraceReaderClient
+	traces   []writtenTrace
+	lookback time.Duration
+	limit    int
+}
+
+func (q *querier) call(ctx context.Context, method string, rng *rand.Rand) error {
+	wt := q.traces[rng.IntN(len(q.traces))]
+	switch method {
+	case methodGetTraces:
+		stream, err := q.client.GetTraces(ctx, &storagev2.GetTracesRequest{Query: []*storagev2.GetTraceParams{{
+			TraceId:   wt.id,
+			StartTime: timestamppb.New(wt.start),
+			EndTime:   timestamppb.New(wt.end),
+		}}})
+		if err != nil {
+			return err
+		}
+		spans, err := recvSpanCount(stream)
+		if err == nil && spans == 0 {
+			err = errors.New("trace not found")
+		}
+		return err
+	case methodFindTraces:
+		stream, err := q.client.FindTraces(ctx, &storagev2.FindTracesRequest{Query: q.query(wt)})
+		if err != nil {
+			return err
+		}
+		_, err = recvSpanCount(stream)
+		return err
+	case methodFindTraceIDs:
+		_, err := q.client.FindTraceIDs(ctx, &storagev2.FindTracesRequest{Query: q.query(wt)})
+		return err
+	case methodGetOperations:
+		_, err := q.client.GetOperations(ctx, &storagev2.GetOperationsRequest{Service: wt.service})
+		return err
+	default:
+		_, err := q.client.GetServices(ctx, &storagev2.GetServicesRequest{})
+		return err
+	}
+}
+
+// query finds the traces with the service, operation and first attribute of the root span of wt.
+func (q *querier) query(wt writtenTrace) *storagev2.TraceQueryParameters {
+	now := time.Now()
+	query := &storagev2.TraceQueryParameters{
+		ServiceName:   wt.service,
+		OperationName: wt.operation,
+		StartTimeMin:  timestamppb.New(now.Add(-q.lookback)),
+		StartTimeMax:  timestamppb.New(now),
+		SearchDepth:   int32(q.limit),
+	}
+	if wt.attribute != nil {
+		query.Attributes = []*storagev2.KeyValue{{
+			Key:   wt.attribute.Key,
+			Value: &storagev2.AnyValue{Value: &storagev2.AnyValue_StringValue{StringValue: wt.attribute.Value.GetStringValue()}},
+		}}
+	}
+	return query
+}
+
+func recvSpanCount(stream grpc.ServerStreamingClient[tracev1.TracesData]) (int, error) {
+	n := 0
+	for {
+		td, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		for _, rs := range td.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				n += len(ss.Spans)
+			}
+		}
+	}
+}
+
+// run issues queries until duration has elapsed. With a rate, the callers
+// share the calls allowed at qps; if they cannot keep up, the achieved
+// rate is lower, as shown by the report.
+func run(q *querier, mix []weightedMethod, duration time.Duration, concurrency int, qps float64, timeout time.Duration) []result {
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+
+	var tokens chan struct{}
+	if qps > 0 {
+		tokens = make(chan struct{})
+		go func() {
+			ticker := time.NewTicker(time.Duration(float64(time.Second) / qps))
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					select {
+					case tokens <- struct{}{}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	total := 0
+	for _, m := range mix {
+		total += m.weight
+	}
+	perCaller := make([][]result, concurrency)
+	var wg sync.WaitGroup
+	for i := range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := rand.New(rand.NewPCG(uint64(i), uint64(time.Now().UnixNano())))
+			for {
+				if tokens != nil {
+					select {
+					case <-tokens:
+					case <-ctx.Done():
+						return
+					}
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				method := pick(mix, total, rng)
+				callCtx, callCancel := context.WithTimeout(context.Background(), timeout)
+				callStart := time.Now()
+				err := q.call(callCtx, method, rng)
+				callCancel()
+				perCaller[i] = append(perCaller[i], result{method: method, latency: time.Since(callStart), err: err})
+			}
+		}()
+	}
+	wg.Wait()
+	return slices.Concat(perCaller...)
+}
+
+func pick(mix []weightedMethod, total int, rng *rand.Rand) string {
+	n := rng.IntN(total)
+	for _, m := range mix {
+		if n -= m.weight; n < 0 {
+			return m.method
+		}
+	}
+	return mix[len(mix)-1].method
+}
+
+// summarize computes the latency report of the calls of a method, counting
+// their distinct errors in errs.
+func summarize(method string, results []result, elapsed time.Duration, errs map[string]int) latencyReport {
+	latencies := make([]time.Duration, 0, len(results))
+	r := latencyReport{Method: method, Calls: len(results), PerSecond: float64(len(results)) / elapsed.Seconds()}
+	for _, res := range results {
+		latencies = append(latencies, res.latency)
+		if res.err != nil {
+			r.Errors++
+			errs[method+": "+res.err.Error()]++
+		}
+	}
+	if len(latencies) == 0 {
+		return r
+	}
+	slices.Sort(latencies)
+	r.P50 = comparator.Percentile(latencies, 50).Round(time.Microsecond)
+	r.P90 = comparator.Percentile(latencies, 90).Round(time.Microsecond)
+	r.P99 = comparator.Percentile(latencies, 99).Round(time.Microsecond)
+	r.Max = latencies[len(latencies)-1].Round(time.Microsecond)
+	return r
+}
+
+// readMetric sums the values of the samples of a metric in the Prometheus
+// text format served at url.
+func readMetric(url, metric string, timeout time.Duration) (float64, error) {
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	total, found := 0.0, false
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		rest, ok := strings.CutPrefix(line, metric)
+		if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '{') {
+			continue
+		}
+		if i := strings.LastIndexByte(rest, '}'); i >= 0 {
+			rest = rest[i+1:]
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid sample %q: %w", line, err)
+		}
+		total += value
+		found = true
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fmt.Errorf("metric %s not found at %s", metric, url)
+	}
+	return total, nil
+}
+
+func printReport(w io.Writer, rep *report, memory bool) {
+	wl := rep.Workload
+	fmt.Fprintf(w, "Workload: %d traces of %d spans, %d services, %d operations per service, %d attributes of %d bytes with %d values each, %d traces per Export, seed %d\n\n",
+		wl.Traces, wl.SpansPerTrace, wl.Services, wl.Operations, wl.Attributes, wl.AttributeSize, wl.TagCardinality, wl.BatchSize, wl.Seed)
+	fmt.Fprintf(w, "Write: %d spans (%.1f MB) in %v, %.1f spans/s, %.2f MB/s\n\n",
+		rep.Write.Spans, float64(rep.Write.Bytes)/1e6, rep.Write.Elapsed.Round(time.Millisecond),
+		rep.Write.SpansPerSec, rep.Write.BytesPerSec/1e6)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tCALLS\tCALLS/S\tERRORS\tERROR RATE\tP50\tP90\tP99\tMAX")
+	for _, r := range append([]latencyReport{rep.Write.Export}, rep.Queries...) {
+		fmt.Fprintf(tw, "%s\t%d\t%.1f\t%d\t%.2f%%\t%v\t%v\t%v\t%v\n",
+			r.Method, r.Calls, r.PerSecond, r.Errors, 100*float64(r.Errors)/float64(max(r.Calls, 1)),
+			r.P50, r.P90, r.P99, r.Max)
+	}
+	tw.Flush()
+
+	if memory {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Memory:")
+		for _, s := range rep.Memory {
+			fmt.Fprintf(w, "  %s: %.1f MB\n", s.Phase, s.Bytes/1e6)
+		}
+	}
+
+	if len(rep.Errors) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Errors:")
+		messages := make([]string, 0, len(rep.Errors))
+		for msg := range rep.Errors {
+			messages = append(messages, msg)
+		}
+		sort.Strings(messages)
+		for _, msg := range messages {
+			fmt.Fprintf(w, "  %dx %s\n", rep.Errors[msg], msg)
+		}
+	}
+}
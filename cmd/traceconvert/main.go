@@ -0,0 +1,216 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command traceconvert converts trace files between the JSON encodings of
+// api_v2 (a Batch or a SpansResponseChunk), of the Jaeger UI and of OTLP
+// (TracesData, which has the same fields as ExportTraceServiceRequest).
+//
+// The input is a sequence of JSON documents, either a single document or
+// one per line as in JSON Lines, read from a file or the standard input.
+// The documents are converted one at a time, so files larger than the
+// memory can be converted as long as each document fits. Each input
+// document is written as one output document on its own line.
+//
+// Usage:
+//
+//	traceconvert [--from auto|api_v2|jaeger-ui|otlp] --to api_v2|jaeger-ui|otlp [-o file] [file]
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/apiv2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	"github.com/jaegertracing/jaeger-idl/model/converter/uijson"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// Formats of the trace files.
+const (
+	formatAuto     = "auto"
+	formatAPIv2    = "api_v2"
+	formatJaegerUI = "jaeger-ui"
+	formatOTLP     = "otlp"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("traceconvert: ")
+	from := flag.String("from", formatAuto, "format of the input: api_v2, jaeger-ui, otlp, or auto to detect it for each document")
+	to := flag.String("to", "", "format of the output: api_v2, jaeger-ui or otlp")
+	output := flag.String("o", "", "file to write to, instead of the standard output")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: traceconvert [flags] [file]\n\nReads the standard input if the file is omitted or -.")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	switch *from {
+	case formatAuto, formatAPIv2, formatJaegerUI, formatOTLP:
+	default:
+		log.Fatalf("unknown input format %q", *from)
+	}
+	switch *to {
+	case formatAPIv2, formatJaegerUI, formatOTLP:
+	case "":
+		flag.Usage()
+		os.Exit(2)
+	default:
+		log.Fatalf("unknown output format %q", *to)
+	}
+
+	in := io.Reader(os.Stdin)
+	if name := flag.Arg(0); name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		in = f
+	}
+	out := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		out = f
+	}
+
+	w := bufio.NewWriter(out)
+	spans, docs, err := convert(bufio.NewReader(in), w, *from, *to)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil && out != os.Stdout {
+		err = out.Close()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("converted %d spans in %d documents", spans, docs)
+}
+
+// convert converts the documents of r one at a time and writes each to w,
+// returning the number of spans and documents converted.
+func convert(r io.Reader, w io.Writer, from, to string) (int, int, error) {
+	dec := json.NewDecoder(r)
+	spanCount := 0
+	for docs := 0; ; docs++ {
+		var doc json.RawMessage
+		if err := dec.Decode(&doc); errors.Is(err, io.EOF) {
+			return spanCount, docs, nil
+		} else if err != nil {
+			return spanCount, docs, fmt.Errorf("cannot read document %d: %w", docs, err)
+		}
+		format := from
+		if format == formatAuto {
+			format = detectFormat(doc)
+		}
+		spans, err := decode(doc, format)
+		if err != nil {
+			return spanCount, docs, fmt.Errorf("document %d: %w", docs, err)
+		}
+		b, err := encode(spans, to)
+		if err != nil {
+			return spanCount, docs, fmt.Errorf("document %d: %w", docs, err)
+		}
+		if _, err := w.Write(append(b, '\n')); err != nil {
+			return spanCount, docs, err
+		}
+		spanCount += len(spans)
+	}
+}
+
+// detectFormat detects the format of a document by its top level fields.
+// The Jaeger UI format is a list of traces, a trace, or a response with the
+// traces in data, while api_v2 only has the spans and process of a Batch.
+func detectFormat(doc []byte) string {
+	if bytes.HasPrefix(doc, []byte("[")) {
+		return formatJaegerUI
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &fields); err != nil {
+		// let the api_v2 decoder report the error
+		return formatAPIv2
+	}
+	for _, field := range []string{"resourceSpans", "resource_spans"} {
+		if _, ok := fields[field]; ok {
+			return formatOTLP
+		}
+	}
+	for _, field := range []string{"data", "traceID", "processes"} {
+		if _, ok := fields[field]; ok {
+			return formatJaegerUI
+		}
+	}
+	return formatAPIv2
+}
+
+func decode(doc []byte, format string) ([]*model.Span, error) {
+	switch format {
+	case formatAPIv2:
+		// a SpansResponseChunk has the same spans field as a Batch
+		batch := &api_v2.Batch{}
+		if err := protojson.Unmarshal(doc, batch); err != nil {
+			return nil, fmt.Errorf("cannot parse api_v2 JSON: %w", err)
+		}
+		domainBatch, err := apiv2.BatchFromProto(batch)
+		if err != nil {
+			return nil, err
+		}
+		for _, span := range domainBatch.Spans {
+			if span.Process == nil {
+				span.Process = domainBatch.Process
+			}
+		}
+		return domainBatch.Spans, nil
+	case formatJaegerUI:
+		traces, err := uijson.ParseJSON(doc)
+		if err != nil {
+			return nil, err
+		}
+		return uijson.ToDomain(traces)
+	case formatOTLP:
+		td := &tracev1.TracesData{}
+		if err := protojson.Unmarshal(doc, td); err != nil {
+			return nil, fmt.Errorf("cannot parse OTLP JSON: %w", err)
+		}
+		return otlp.ToDomain(td), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func encode(spans []*model.Span, format string) ([]byte, error) {
+	switch format {
+	case formatAPIv2:
+		protoSpans, err := apiv2.SpansToProto(spans)
+		if err != nil {
+			return nil, err
+		}
+		return protojson.Marshal(&api_v2.SpansResponseChunk{Spans: protoSpans})
+	case formatJaegerUI:
+		return json.Marshal(uijson.Response{Data: uijson.FromDomain(spans)})
+	case formatOTLP:
+		return protojson.Marshal(otlp.FromDomain(spans))
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AIMD parameters of the adaptive rate.
+const (
+	// aimdIncrease is the part of the target rate added after each batch
+	// submitted without congestion.
+	aimdIncrease = 0.05
+	// aimdDecrease multiplies the rate after a congested batch.
+	aimdDecrease = 0.5
+	// aimdSlowFraction is the part of the request timeout above which a
+	// successful request is considered slow, i.e. congested.
+	aimdSlowFraction = 0.5
+)
+
+// rateController adapts the rate of the traces to the collector with
+// additive increase, multiplicative decrease (AIMD), like TCP congestion
+// control: the rate is halved when a request fails because the collector is
+// overloaded or takes more than half of its timeout, and grows linearly back
+// towards the target otherwise.
+type rateController struct {
+	target  float64
+	minRate float64
+	timeout time.Duration
+
+	rate      float64
+	lowest    float64
+	decreases int
+}
+
+func newRateController(target, minRate float64, timeout time.Duration) *rateController {
+	return &rateController{
+		target:  target,
+		minRate: min(minRate, target),
+		timeout: timeout,
+		rate:    target,
+		lowest:  target,
+	}
+}
+
+// observe adapts the rate to the outcome of a request, returning why it was
+// decreased, or an empty string. The rate only grows after the successful
+// requests.
+func (c *rateController) observe(latency time.Duration, err error) string {
+	reason := c.congestion(latency, err)
+	if reason == "" {
+		if err != nil {
+			return ""
+		}
+		c.rate = min(c.target, c.rate+aimdIncrease*c.target)
+		return ""
+	}
+	c.rate = max(c.minRate, c.rate*aimdDecrease)
+	c.lowest = min(c.lowest, c.rate)
+	c.decreases++
+	return reason
+}
+
+// congestion returns why the outcome of a request shows that the collector
+// is overloaded, or an empty string. Errors that do not depend on the load,
+// e.g. rejected spans, do not change the rate.
+func (c *rateController) congestion(latency time.Duration, err error) string {
+	if err == nil {
+		if latency > time.Duration(aimdSlowFraction*float64(c.timeout)) {
+			return fmt.Sprintf("request took %v of its %v timeout", latency.Round(time.Millisecond), c.timeout)
+		}
+		return ""
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "request timed out"
+	}
+	switch code := status.Code(err); code {
+	case codes.DeadlineExceeded, codes.ResourceExhausted, codes.Unavailable, codes.Aborted:
+		return "collector returned " + code.String()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateController(t *testing.T) {
+	c := newRateController(100, 20, time.Second)
+
+	// the congested requests halve the rate, down to the min rate
+	assert.Equal(t, "collector returned Unavailable", c.observe(time.Millisecond, status.Error(codes.Unavailable, "overloaded")))
+	assert.InDelta(t, 50, c.rate, 1e-9)
+	assert.Equal(t, "request took 600ms of its 1s timeout", c.observe(600*time.Millisecond, nil))
+	assert.InDelta(t, 25, c.rate, 1e-9)
+	assert.Equal(t, "request timed out", c.observe(time.Second, fmt.Errorf("export: %w", context.DeadlineExceeded)))
+	assert.InDelta(t, 20, c.rate, 1e-9)
+
+	// the errors independent of the load do not change the rate
+	assert.Empty(t, c.observe(time.Millisecond, status.Error(codes.InvalidArgument, "rejected spans")))
+	assert.Empty(t, c.observe(time.Millisecond, errors.New("connection refused")))
+	assert.InDelta(t, 20, c.rate, 1e-9)
+
+	// the successful requests grow the rate back linearly, up to the target
+	assert.Empty(t, c.observe(time.Millisecond, nil))
+	assert.InDelta(t, 25, c.rate, 1e-9)
+	for range 20 {
+		c.observe(time.Millisecond, nil)
+	}
+	assert.InDelta(t, 100, c.rate, 1e-9)
+	assert.InDelta(t, 20, c.lowest, 1e-9)
+	assert.Equal(t, 3, c.decreases)
+}
+
+func TestRateControllerMinRate(t *testing.T) {
+	// the min rate is capped by the target
+	c := newRateController(10, 50, time.Second)
+	c.observe(time.Millisecond, status.Error(codes.ResourceExhausted, "quota"))
+	assert.InDelta(t, 10, c.rate, 1e-9)
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	model "github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// faultTag marks the spans altered by a fault with the kind of the fault, so
+// that the faulty traces can be found and told apart.
+const faultTag = "tracegen.fault"
+
+// Kinds of the injected faults.
+const (
+	faultError         = "error"
+	faultLatencySpike  = "latency-spike"
+	faultMissingParent = "missing-parent"
+	faultClockSkew     = "clock-skew"
+)
+
+// faultOptions configures the faults injected into the generated traces, to
+// exercise the handling of bad data by the query side: the adjusters, the
+// error rates of the service performance monitoring and the span warnings.
+// Each rate is the probability of a trace having the fault.
+type faultOptions struct {
+	// ErrorRate is the probability of a span failing, with an exception log,
+	// and of the failure propagating to all its ancestors.
+	ErrorRate float64
+	// LatencySpikeRate is the probability of a span being slower by
+	// LatencySpikeFactor, delaying its ancestors and the spans after it.
+	LatencySpikeRate   float64
+	LatencySpikeFactor float64
+	// MissingParentRate is the probability of a parent span being lost, as
+	// if its service had not reported it.
+	MissingParentRate float64
+	// ClockSkewRate is the probability of the clock of a service other than
+	// the one of the root span being off by up to ClockSkew.
+	ClockSkewRate float64
+	ClockSkew     time.Duration
+}
+
+func (o faultOptions) validate() error {
+	for _, rate := range []float64{o.ErrorRate, o.LatencySpikeRate, o.MissingParentRate, o.ClockSkewRate} {
+		if rate < 0 || rate > 1 {
+			return errors.New("the fault rates must be between 0 and 1")
+		}
+	}
+	if o.LatencySpikeFactor < 1 {
+		return errors.New("the latency spike factor must be at least 1")
+	}
+	if o.ClockSkew < 0 {
+		return errors.New("the clock skew must not be negative")
+	}
+	return nil
+}
+
+// enabled reports whether any fault is injected.
+func (o faultOptions) enabled() bool {
+	return o.ErrorRate > 0 || o.LatencySpikeRate > 0 || o.MissingParentRate > 0 || o.ClockSkewRate > 0
+}
+
+// faultInjector injects faults into the traces of another generator. It has
+// its own random generator, so that the same seed generates the same traces
+// with and without faults.
+type faultInjector struct {
+	traceGenerator
+
+	opts     faultOptions
+	rng      *rand.Rand
+	injected map[string]int // fault kind -> number of traces
+}
+
+func newFaultInjector(g traceGenerator, opts faultOptions, seed uint64) *faultInjector {
+	return &faultInjector{
+		traceGenerator: g,
+		opts:           opts,
+		rng:            rand.New(rand.NewPCG(seed, ^seed)),
+		injected:       make(map[string]int),
+	}
+}
+
+func (f *faultInjector) trace(start time.Time) []*model.Span {
+	spans := f.traceGenerator.trace(start)
+	if f.rng.Float64() < f.opts.ErrorRate {
+		f.injectError(spans)
+	}
+	if f.rng.Float64() < f.opts.LatencySpikeRate {
+		f.injectLatencySpike(spans)
+	}
+	if f.rng.Float64() < f.opts.ClockSkewRate && f.opts.ClockSkew > 0 {
+		f.injectClockSkew(spans)
+	}
+	if f.rng.Float64() < f.opts.MissingParentRate {
+		spans = f.injectMissingParent(spans)
+	}
+	return spans
+}
+
+// summary describes the injected faults.
+func (f *faultInjector) summary() string {
+	return fmt.Sprintf("%d errors, %d latency spikes, %d missing parents and %d clock skews",
+		f.injected[faultError], f.injected[faultLatencySpike], f.injected[faultMissingParent], f.injected[faultClockSkew])
+}
+
+// injectError fails a random span and its ancestors.
+func (f *faultInjector) injectError(spans []*model.Span) {
+	failed := spans[f.rng.IntN(len(spans))]
+	failed.Logs = append(failed.Logs, model.Log{
+		Timestamp: failed.StartTime.Add(failed.Duration),
+		Fields: []model.KeyValue{
+			model.String("event", "exception"),
+			model.String("exception.type", "InjectedFault"),
+			model.String("exception.message", "fault injected by tracegen"),
+		},
+	})
+	failed.Tags = append(failed.Tags, model.String(faultTag, faultError))
+	for _, span := range append([]*model.Span{failed}, ancestors(spans, failed)...) {
+		setError(span)
+	}
+	f.injected[faultError]++
+}
+
+// injectLatencySpike slows a random span down, which delays the end of its
+// ancestors and the spans starting after it.
+func (f *faultInjector) injectLatencySpike(spans []*model.Span) {
+	slow := spans[f.rng.IntN(len(spans))]
+	end := slow.StartTime.Add(slow.Duration)
+	delay := time.Duration(float64(slow.Duration) * (f.opts.LatencySpikeFactor - 1))
+	for _, span := range spans {
+		if !span.StartTime.Before(end) {
+			span.StartTime = span.StartTime.Add(delay)
+		}
+	}
+	for _, span := range append([]*model.Span{slow}, ancestors(spans, slow)...) {
+		span.Duration += delay
+	}
+	slow.Tags = append(slow.Tags, model.String(faultTag, faultLatencySpike))
+	f.injected[faultLatencySpike]++
+}
+
+// injectClockSkew shifts the spans of a random service other than the one of
+// the root span, as if its clock was off.
+func (f *faultInjector) injectClockSkew(spans []*model.Span) {
+	root := spans[0].Process.ServiceName
+	var services []string
+	seen := map[string]bool{root: true}
+	for _, span := range spans {
+		if service := span.Process.ServiceName; !seen[service] {
+			seen[service] = true
+			services = append(services, service)
+		}
+	}
+	if len(services) == 0 {
+		return
+	}
+	skewed := services[f.rng.IntN(len(services))]
+	skew := time.Duration(f.rng.Int64N(int64(2*f.opts.ClockSkew)+1)) - f.opts.ClockSkew
+	for _, span := range spans {
+		if span.Process.ServiceName == skewed {
+			span.StartTime = span.StartTime.Add(skew)
+			span.Tags = append(span.Tags, model.String(faultTag, faultClockSkew))
+		}
+	}
+	f.injected[faultClockSkew]++
+}
+
+// injectMissingParent drops a random span with children, other than the
+// root span, and returns the remaining spans.
+func (f *faultInjector) injectMissingParent(spans []*model.Span) []*model.Span {
+	parents := make(map[model.SpanID]bool)
+	for _, span := range spans[1:] {
+		parents[span.ParentSpanID()] = true
+	}
+	var candidates []int
+	for i, span := range spans[1:] {
+		if parents[span.SpanID] {
+			candidates = append(candidates, i+1)
+		}
+	}
+	if len(candidates) == 0 {
+		return spans
+	}
+	dropped := spans[candidates[f.rng.IntN(len(candidates))]]
+	remaining := make([]*model.Span, 0, len(spans)-1)
+	for _, span := range spans {
+		if span == dropped {
+			continue
+		}
+		if span.ParentSpanID() == dropped.SpanID {
+			span.Tags = append(span.Tags, model.String(faultTag, faultMissingParent))
+		}
+		remaining = append(remaining, span)
+	}
+	f.injected[faultMissingParent]++
+	return remaining
+}
+
+// ancestors returns the ancestors of span, from its parent up.
+func ancestors(spans []*model.Span, span *model.Span) []*model.Span {
+	byID := make(map[model.SpanID]*model.Span, len(spans))
+	for _, s := range spans {
+		byID[s.SpanID] = s
+	}
+	var result []*model.Span
+	for parent, ok := byID[span.ParentSpanID()]; ok && len(result) < len(spans); parent, ok = byID[parent.ParentSpanID()] {
+		result = append(result, parent)
+	}
+	return result
+}
+
+// setError marks span as an error, with a 500 status code if it has one.
+func setError(span *model.Span) {
+	hasError := false
+	for i, tag := range span.Tags {
+		switch tag.Key {
+		case "error":
+			span.Tags[i] = model.Bool("error", true)
+			hasError = true
+		case "http.status_code":
+			span.Tags[i] = model.Int64("http.status_code", 500)
+		}
+	}
+	if !hasError {
+		span.Tags = append(span.Tags, model.Bool("error", true))
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	model "github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// Schemes of the generated trace IDs.
+const (
+	// idSchemeRandom generates 128 random bits, as recommended by W3C Trace Context.
+	idSchemeRandom = "random"
+	// idSchemeSortable prefixes the trace ID with the start of the trace in
+	// milliseconds since the epoch on 48 bits, so that the IDs sort by time,
+	// like ULIDs or UUIDv7.
+	idSchemeSortable = "sortable"
+	// idSchemeXRay prefixes the trace ID with the start of the trace in
+	// seconds since the epoch on 32 bits, like AWS X-Ray trace IDs
+	// (1-{seconds}-{96 random bits}).
+	idSchemeXRay = "xray"
+)
+
+// traceIDFunc returns the ID of a new trace starting at start.
+type traceIDFunc func(rng *rand.Rand, start time.Time) model.TraceID
+
+func traceIDScheme(scheme string) (traceIDFunc, error) {
+	switch scheme {
+	case idSchemeRandom:
+		return func(rng *rand.Rand, _ time.Time) model.TraceID {
+			return model.NewTraceID(rng.Uint64(), rng.Uint64()|1)
+		}, nil
+	case idSchemeSortable:
+		return func(rng *rand.Rand, start time.Time) model.TraceID {
+			return model.NewTraceID(uint64(start.UnixMilli())<<16|rng.Uint64()>>48, rng.Uint64())
+		}, nil
+	case idSchemeXRay:
+		return func(rng *rand.Rand, start time.Time) model.TraceID {
+			return model.NewTraceID(uint64(uint32(start.Unix()))<<32|uint64(rng.Uint32()), rng.Uint64())
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown trace ID scheme %q, expected %s, %s or %s",
+			scheme, idSchemeRandom, idSchemeSortable, idSchemeXRay)
+	}
+}
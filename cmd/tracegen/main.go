@@ -0,0 +1,390 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command tracegen generates synthetic traces and submits them to a
+// collector, either with the OTLP TraceService, the api_v2 CollectorService
+// PostSpans, or on a single storage v2 TraceWriter stream (--protocol
+// stream), whose batches are acknowledged by the collector asynchronously.
+// The shape of the traces (services, spans per trace, attributes and their
+// cardinality) is configurable, for load and query testing beyond the sample
+// traces of api_v2_demo. Alternatively the traces follow a topology of
+// services and calls described in a file, see topology.json for an example.
+// The trace IDs are random, or prefixed with the start time of the trace to
+// test the ordering and interoperability assumptions of storage backends,
+// see --trace-id-scheme.
+//
+// With --adaptive, the rate adapts to the collector so that real collectors
+// can be load tested safely: it is halved whenever a request fails because
+// the collector is overloaded (Unavailable, ResourceExhausted, or a timeout)
+// or takes more than half of its timeout, and grows back towards --rate
+// otherwise. The achieved rate is reported along with the target.
+//
+// The --fault-* flags inject faults into a proportion of the traces: failures
+// propagating to the ancestor spans, latency spikes, lost parent spans and
+// skewed service clocks, marked with a tracegen.fault tag, to give the
+// adjusters, error rates and span warnings of the query side bad data to
+// handle.
+//
+// Usage:
+//
+//	tracegen --target localhost:17271 --traces 10000 --services 20 --spans 50 --rate 500
+//	tracegen --target localhost:17271 --traces 100000 --protocol stream
+//	tracegen --target localhost:17271 --traces 10000 --topology topology.json
+//	tracegen --target localhost:17271 --traces 10000 --trace-id-scheme sortable
+//	tracegen --target collector:4317 --traces 100000 --rate 2000 --adaptive
+//	tracegen --target localhost:17271 --topology topology.json --fault-missing-parent-rate 0.1 --fault-clock-skew-rate 0.1
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/apiv2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	model "github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// Protocols used to submit the spans.
+const (
+	protocolOTLP   = "otlp"
+	protocolAPIv2  = "api_v2"
+	protocolStream = "stream"
+)
+
+// Bounds of the root span durations, which are distributed log-uniformly.
+const (
+	minRootDuration = time.Millisecond
+	maxRootDuration = 2 * time.Second
+)
+
+// options configures the generated traces.
+type options struct {
+	Traces     int
+	Services   int
+	Spans      int
+	Operations int
+	// Attributes is the number of generic attributes of each span, named
+	// attr.0, attr.1, ..., each with Cardinality distinct values.
+	Attributes  int
+	Cardinality int
+	// ErrorRate is the probability of a span being an error.
+	ErrorRate float64
+	Seed      uint64
+	// TraceIDScheme is how the trace IDs are generated, see traceIDScheme.
+	TraceIDScheme string
+}
+
+// traceGenerator builds the spans of new traces.
+type traceGenerator interface {
+	trace(start time.Time) []*model.Span
+}
+
+// generator builds random traces.
+type generator struct {
+	opts       options
+	rng        *rand.Rand
+	traceID    traceIDFunc
+	processes  []*model.Process
+	operations [][]string
+}
+
+// sender submits a batch of spans.
+type sender interface {
+	send(ctx context.Context, spans []*model.Span) error
+}
+
+func main() {
+	var opts options
+	target := flag.String("target", "localhost:17271", "address of the collector")
+	protocol := flag.String("protocol", protocolOTLP, "protocol used to submit the spans, otlp, api_v2 or stream")
+	flag.IntVar(&opts.Traces, "traces", 100, "number of traces to generate")
+	flag.IntVar(&opts.Services, "services", 5, "number of services")
+	flag.IntVar(&opts.Spans, "spans", 10, "number of spans per trace")
+	flag.IntVar(&opts.Operations, "operations", 5, "number of operations per service")
+	flag.IntVar(&opts.Attributes, "attributes", 3, "number of generic attributes per span")
+	flag.IntVar(&opts.Cardinality, "cardinality", 100, "number of distinct values of each generic attribute")
+	flag.Float64Var(&opts.ErrorRate, "error-rate", 0.05, "fraction of the spans that are errors")
+	flag.Uint64Var(&opts.Seed, "seed", 0, "seed of the random generator, 0 for a random seed")
+	flag.StringVar(&opts.TraceIDScheme, "trace-id-scheme", idSchemeRandom, "how trace IDs are generated: random, sortable (prefixed with the start time in milliseconds) or xray (prefixed with the start time in seconds, like AWS X-Ray)")
+	topologyPath := flag.String("topology", "", "JSON file describing the services and their calls, overrides --services, --spans and --operations")
+	batchSize := flag.Int("batch", 10, "number of traces per request")
+	rate := flag.Float64("rate", 0, "traces per second, 0 submits as fast as possible")
+	adaptive := flag.Bool("adaptive", false, "adapt the rate to the collector, halving it when the collector is overloaded or slow, and growing it back towards --rate")
+	minRate := flag.Float64("min-rate", 1, "lowest traces per second of the adaptive rate")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of each request")
+	var faults faultOptions
+	flag.Float64Var(&faults.ErrorRate, "fault-error-rate", 0, "fraction of the traces with a failed span, with an exception log, whose failure propagates to its ancestors")
+	flag.Float64Var(&faults.LatencySpikeRate, "fault-latency-spike-rate", 0, "fraction of the traces with a span slowed down by --fault-latency-spike-factor, delaying its ancestors and the spans after it")
+	flag.Float64Var(&faults.LatencySpikeFactor, "fault-latency-spike-factor", 10, "factor of the duration of the spans slowed down by --fault-latency-spike-rate")
+	flag.Float64Var(&faults.MissingParentRate, "fault-missing-parent-rate", 0, "fraction of the traces missing a parent span")
+	flag.Float64Var(&faults.ClockSkewRate, "fault-clock-skew-rate", 0, "fraction of the traces with the spans of a service other than the root one shifted by up to --fault-clock-skew")
+	flag.DurationVar(&faults.ClockSkew, "fault-clock-skew", 5*time.Second, "maximum clock skew of the services of --fault-clock-skew-rate")
+	flag.Parse()
+
+	if opts.Traces < 1 || opts.Services < 1 || opts.Spans < 1 || opts.Operations < 1 || opts.Cardinality < 1 || *batchSize < 1 {
+		log.Fatal("--traces, --services, --spans, --operations, --cardinality and --batch must be positive")
+	}
+	if opts.Attributes < 0 || opts.ErrorRate < 0 || opts.ErrorRate > 1 || *rate < 0 {
+		log.Fatal("--attributes and --rate must not be negative and --error-rate must be between 0 and 1")
+	}
+	if *adaptive && (*rate == 0 || *minRate <= 0) {
+		log.Fatal("--adaptive requires a positive --rate and --min-rate")
+	}
+	if opts.Seed == 0 {
+		opts.Seed = rand.Uint64()
+	}
+	if _, err := traceIDScheme(opts.TraceIDScheme); err != nil {
+		log.Fatal(err)
+	}
+	if err := faults.validate(); err != nil {
+		log.Fatalf("Invalid faults: %v", err)
+	}
+
+	conn, err := grpc.NewClient(*target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+	var s sender
+	var stream *streamSender
+	switch *protocol {
+	case protocolOTLP:
+		s = &otlpSender{client: collectortrace.NewTraceServiceClient(conn)}
+	case protocolAPIv2:
+		s = &apiv2Sender{client: api_v2.NewCollectorServiceClient(conn)}
+	case protocolStream:
+		stream, err = newStreamSender(context.Background(), storagev2.NewTraceWriterClient(conn))
+		if err != nil {
+			log.Fatalf("Failed to open the stream: %v", err)
+		}
+		s = stream
+	default:
+		log.Fatalf("Unknown protocol %q, expected %s, %s or %s", *protocol, protocolOTLP, protocolAPIv2, protocolStream)
+	}
+
+	var g traceGenerator = newGenerator(opts)
+	if *topologyPath != "" {
+		t, err := loadTopology(*topologyPath)
+		if err != nil {
+			log.Fatalf("Failed to load topology: %v", err)
+		}
+		tg := newTopologyGenerator(newGenerator(opts), t)
+		log.Printf("Generating %d traces over the %d services of %s with seed %d\n",
+			opts.Traces, len(tg.services), *topologyPath, opts.Seed)
+		g = tg
+	} else {
+		log.Printf("Generating %d traces of %d spans over %d services with seed %d\n",
+			opts.Traces, opts.Spans, opts.Services, opts.Seed)
+	}
+	var injector *faultInjector
+	if faults.enabled() {
+		injector = newFaultInjector(g, faults, opts.Seed)
+		g = injector
+	}
+	var interval time.Duration
+	if *rate > 0 {
+		interval = time.Duration(float64(*batchSize) / *rate * float64(time.Second))
+	}
+	var controller *rateController
+	if *adaptive {
+		controller = newRateController(*rate, *minRate, *timeout)
+	}
+
+	start := time.Now()
+	next := start
+	var sent, failed int
+	for i := 0; i < opts.Traces; i += *batchSize {
+		if controller != nil {
+			// the batches missed while the collector was slow are not caught up
+			next = maxTime(next, time.Now())
+			time.Sleep(time.Until(next))
+			next = next.Add(time.Duration(float64(*batchSize) / controller.rate * float64(time.Second)))
+		} else if interval > 0 {
+			time.Sleep(time.Until(start.Add(time.Duration(i / *batchSize) * interval)))
+		}
+		var spans []*model.Span
+		for range min(*batchSize, opts.Traces-i) {
+			spans = append(spans, g.trace(time.Now())...)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		sendStart := time.Now()
+		err := s.send(ctx, spans)
+		latency := time.Since(sendStart)
+		cancel()
+		if controller != nil {
+			if reason := controller.observe(latency, err); reason != "" {
+				log.Printf("Collector overloaded (%s), decreasing the rate to %.1f traces/s\n", reason, controller.rate)
+			}
+		}
+		if err != nil {
+			failed += len(spans)
+			log.Printf("Failed to submit %d spans: %v\n", len(spans), err)
+			continue
+		}
+		sent += len(spans)
+	}
+	var streamErr error
+	if stream != nil {
+		// the spans are only written once they are acknowledged
+		streamErr = stream.close()
+	}
+	elapsed := time.Since(start)
+	log.Printf("Submitted %d spans in %s (%.0f spans/s), %d failed\n",
+		sent, elapsed.Round(time.Millisecond), float64(sent)/elapsed.Seconds(), failed)
+	if *rate > 0 {
+		achieved := float64(opts.Traces) / elapsed.Seconds()
+		log.Printf("Achieved %.1f traces/s of the target %.1f traces/s (%.0f%%)\n", achieved, *rate, 100*achieved / *rate)
+	}
+	if controller != nil {
+		log.Printf("Adaptive rate decreased %d times, down to %.1f traces/s, ending at %.1f traces/s\n",
+			controller.decreases, controller.lowest, controller.rate)
+	}
+	if injector != nil {
+		log.Printf("Injected %s\n", injector.summary())
+	}
+	if streamErr != nil {
+		log.Fatalf("Some spans could not be written: %v", streamErr)
+	}
+	if failed > 0 {
+		log.Fatal("Some spans could not be submitted")
+	}
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func newGenerator(opts options) *generator {
+	traceID, _ := traceIDScheme(opts.TraceIDScheme)
+	g := &generator{
+		opts:    opts,
+		rng:     rand.New(rand.NewPCG(opts.Seed, opts.Seed)),
+		traceID: traceID,
+	}
+	for i := range opts.Services {
+		service := fmt.Sprintf("service-%d", i)
+		g.processes = append(g.processes, model.NewProcess(service, []model.KeyValue{
+			model.String("host.name", fmt.Sprintf("host-%d", i)),
+			model.String("telemetry.sdk.name", "tracegen"),
+		}))
+		ops := make([]string, opts.Operations)
+		for j := range ops {
+			ops[j] = fmt.Sprintf("%s-op-%d", service, j)
+		}
+		g.operations = append(g.operations, ops)
+	}
+	return g
+}
+
+// trace returns the spans of a new trace with a root span starting at start.
+// Each span is a child of a random earlier span and runs within it; it stays
+// in the service of its parent half of the time, otherwise it is the server
+// span of a call to another service.
+func (g *generator) trace(start time.Time) []*model.Span {
+	traceID := g.traceID(g.rng, start)
+	services := make([]int, g.opts.Spans)
+	spans := make([]*model.Span, g.opts.Spans)
+
+	services[0] = g.rng.IntN(g.opts.Services)
+	logRange := math.Log(float64(maxRootDuration) / float64(minRootDuration))
+	rootDuration := time.Duration(float64(minRootDuration) * math.Exp(g.rng.Float64()*logRange))
+	spans[0] = g.span(traceID, services[0], start, rootDuration, model.SpanKindServer)
+
+	for i := 1; i < len(spans); i++ {
+		p := g.rng.IntN(i)
+		parent := spans[p]
+		kind := model.SpanKindInternal
+		services[i] = services[p]
+		if g.opts.Services > 1 && g.rng.IntN(2) == 0 {
+			kind = model.SpanKindServer
+			for services[i] == services[p] {
+				services[i] = g.rng.IntN(g.opts.Services)
+			}
+		}
+		offset := time.Duration(g.rng.Float64() * float64(parent.Duration) / 2)
+		duration := time.Duration(g.rng.Float64() * float64(parent.Duration-offset))
+		spans[i] = g.span(traceID, services[i], parent.StartTime.Add(offset), duration, kind)
+		spans[i].References = []model.SpanRef{model.NewChildOfRef(traceID, parent.SpanID)}
+	}
+	return spans
+}
+
+func (g *generator) span(traceID model.TraceID, service int, start time.Time, duration time.Duration, kind model.SpanKind) *model.Span {
+	ops := g.operations[service]
+	return &model.Span{
+		TraceID:       traceID,
+		SpanID:        model.NewSpanID(g.rng.Uint64() | 1),
+		OperationName: ops[g.rng.IntN(len(ops))],
+		StartTime:     start,
+		Duration:      duration,
+		Tags:          g.tags(kind, g.rng.Float64() < g.opts.ErrorRate),
+		Process:       g.processes[service],
+	}
+}
+
+// tags returns the tags of a span: its kind, the HTTP status code of server
+// spans, the error flag and the generic attributes.
+func (g *generator) tags(kind model.SpanKind, isError bool) []model.KeyValue {
+	tags := []model.KeyValue{model.SpanKindTag(kind)}
+	if kind == model.SpanKindServer {
+		code := int64(200)
+		if isError {
+			code = 500
+		}
+		tags = append(tags, model.Int64("http.status_code", code))
+	}
+	if isError {
+		tags = append(tags, model.Bool("error", true))
+	}
+	for i := range g.opts.Attributes {
+		tags = append(tags, model.String(fmt.Sprintf("attr.%d", i), fmt.Sprintf("value-%d", g.rng.IntN(g.opts.Cardinality))))
+	}
+	return tags
+}
+
+// otlpSender submits the spans with the OTLP TraceService.
+type otlpSender struct {
+	client collectortrace.TraceServiceClient
+}
+
+func (s *otlpSender) send(ctx context.Context, spans []*model.Span) error {
+	td := otlp.FromDomain(spans)
+	resp, err := s.client.Export(ctx, &collectortrace.ExportTraceServiceRequest{ResourceSpans: td.ResourceSpans})
+	if err != nil {
+		return err
+	}
+	if rejected := resp.GetPartialSuccess().GetRejectedSpans(); rejected > 0 {
+		return fmt.Errorf("%d spans rejected: %s", rejected, resp.GetPartialSuccess().GetErrorMessage())
+	}
+	return nil
+}
+
+// apiv2Sender submits the spans with the api_v2 CollectorService, each span
+// carrying its process.
+type apiv2Sender struct {
+	client api_v2.CollectorServiceClient
+}
+
+func (s *apiv2Sender) send(ctx context.Context, spans []*model.Span) error {
+	protoSpans, err := apiv2.SpansToProto(spans)
+	if err != nil {
+		return fmt.Errorf("cannot convert spans: %w", err)
+	}
+	_, err = s.client.PostSpans(ctx, &api_v2.PostSpansRequest{Batch: &api_v2.Batch{Spans: protoSpans}})
+	return err
+}
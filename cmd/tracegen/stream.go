@@ -0,0 +1,93 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	model "github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// streamSender submits the spans on a single storage v2 WriteTraces stream,
+// one batch per message, without waiting for the batches to be written:
+// the collector acknowledges them periodically, and all of them when the
+// stream is closed.
+type streamSender struct {
+	stream grpc.BidiStreamingClient[storagev2.WriteTracesRequest, storagev2.WriteTracesResponse]
+	// sequence is the sequence number of the last batch sent.
+	sequence uint64
+
+	// The acknowledgements, only read once done is closed.
+	done     chan struct{}
+	acked    uint64
+	rejected int64
+	message  string
+	err      error
+}
+
+func newStreamSender(ctx context.Context, client storagev2.TraceWriterClient) (*streamSender, error) {
+	stream, err := client.WriteTraces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s := &streamSender{stream: stream, done: make(chan struct{})}
+	go s.receive()
+	return s, nil
+}
+
+func (s *streamSender) receive() {
+	defer close(s.done)
+	for {
+		ack, err := s.stream.Recv()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				s.err = err
+			}
+			return
+		}
+		s.acked = ack.SequenceNumber
+		s.rejected += ack.RejectedSpans
+		if ack.ErrorMessage != "" {
+			s.message = ack.ErrorMessage
+		}
+	}
+}
+
+// send sends the batch on the stream. The context is ignored, as it would
+// cancel the whole stream.
+func (s *streamSender) send(_ context.Context, spans []*model.Span) error {
+	s.sequence++
+	err := s.stream.Send(&storagev2.WriteTracesRequest{SequenceNumber: s.sequence, Traces: otlp.FromDomain(spans)})
+	if errors.Is(err, io.EOF) {
+		// the stream failed, with the error returned by Recv
+		<-s.done
+		return fmt.Errorf("stream failed: %w", s.err)
+	}
+	return err
+}
+
+// close closes the stream and waits until the collector has acknowledged
+// all the batches.
+func (s *streamSender) close() error {
+	if err := s.stream.CloseSend(); err != nil {
+		return err
+	}
+	<-s.done
+	switch {
+	case s.err != nil:
+		return s.err
+	case s.acked != s.sequence:
+		return fmt.Errorf("%d batches not acknowledged", s.sequence-s.acked)
+	case s.rejected > 0:
+		return fmt.Errorf("%d spans rejected: %s", s.rejected, s.message)
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"time"
+
+	model "github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// maxTopologyDepth bounds the depth of the traces of a topology with cycles.
+const maxTopologyDepth = 10
+
+// networkDelay separates a client span from the server span of the call.
+const networkDelay = 100 * time.Microsecond
+
+// Latency distribution types.
+const (
+	distConstant  = "constant"
+	distUniform   = "uniform"
+	distNormal    = "normal"
+	distLognormal = "lognormal"
+)
+
+// topology describes a system of services calling each other. Traces start
+// at one of the roots, picked by weight, and follow the edges: every call
+// is a client span in the caller and a server span in the callee, whose
+// own processing time is drawn from the latency distribution of the edge.
+//
+// Example:
+//
+//	{
+//	  "roots": [{"service": "frontend", "operation": "GET /", "latency": {"type": "constant", "ms": 2}}],
+//	  "edges": [
+//	    {"from": "frontend", "to": "auth", "operation": "Authenticate",
+//	     "latency": {"type": "lognormal", "medianMs": 5, "sigma": 0.5}},
+//	    {"from": "frontend", "to": "db", "operation": "SELECT", "fanOut": 3, "parallel": true,
+//	     "latency": {"type": "uniform", "minMs": 1, "maxMs": 10}, "errorRate": 0.01}
+//	  ]
+//	}
+type topology struct {
+	Roots []topologyRoot `json:"roots"`
+	Edges []topologyEdge `json:"edges"`
+}
+
+// topologyRoot is an entry point of the traces.
+type topologyRoot struct {
+	Service   string `json:"service"`
+	Operation string `json:"operation"`
+	// Weight is the relative frequency of the root, 1 by default.
+	Weight  float64      `json:"weight,omitempty"`
+	Latency distribution `json:"latency"`
+}
+
+// topologyEdge is a call from a service to an operation of another one.
+type topologyEdge struct {
+	From string `json:"from"`
+	// FromOperation restricts the calls to the spans of an operation of the caller.
+	FromOperation string `json:"fromOperation,omitempty"`
+	To            string `json:"to"`
+	Operation     string `json:"operation"`
+	// FanOut is the number of calls per span of the caller, 1 by default.
+	FanOut int `json:"fanOut,omitempty"`
+	// Parallel issues the calls of the fan out concurrently rather than in sequence.
+	Parallel bool `json:"parallel,omitempty"`
+	// Probability is the probability of the calls being made, 1 by default.
+	Probability *float64 `json:"probability,omitempty"`
+	// Latency is the processing time of the callee, excluding its own calls.
+	Latency   distribution `json:"latency"`
+	ErrorRate float64      `json:"errorRate,omitempty"`
+}
+
+// distribution is a latency distribution, in milliseconds.
+type distribution struct {
+	Type     string  `json:"type"`
+	Ms       float64 `json:"ms,omitempty"`
+	MinMs    float64 `json:"minMs,omitempty"`
+	MaxMs    float64 `json:"maxMs,omitempty"`
+	MeanMs   float64 `json:"meanMs,omitempty"`
+	StddevMs float64 `json:"stddevMs,omitempty"`
+	MedianMs float64 `json:"medianMs,omitempty"`
+	Sigma    float64 `json:"sigma,omitempty"`
+}
+
+// topologyGenerator builds the traces of a topology.
+type topologyGenerator struct {
+	*generator
+
+	topology *topology
+	edges    map[string][]*topologyEdge // caller -> edges
+	services map[string]*model.Process  // service -> process
+	weights  float64
+}
+
+func loadTopology(path string) (*topology, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read topology: %w", err)
+	}
+	var t topology
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("cannot parse topology: %w", err)
+	}
+	if err := t.validate(); err != nil {
+		return nil, fmt.Errorf("invalid topology: %w", err)
+	}
+	return &t, nil
+}
+
+func (t *topology) validate() error {
+	if len(t.Roots) == 0 {
+		return errors.New("no roots")
+	}
+	for i, root := range t.Roots {
+		if root.Service == "" || root.Operation == "" {
+			return fmt.Errorf("root %d: service and operation are required", i)
+		}
+		if root.Weight < 0 {
+			return fmt.Errorf("root %d: negative weight", i)
+		}
+		if err := root.Latency.validate(); err != nil {
+			return fmt.Errorf("root %d: %w", i, err)
+		}
+	}
+	for i, edge := range t.Edges {
+		if edge.From == "" || edge.To == "" || edge.Operation == "" {
+			return fmt.Errorf("edge %d: from, to and operation are required", i)
+		}
+		if edge.FanOut < 0 || edge.ErrorRate < 0 || edge.ErrorRate > 1 {
+			return fmt.Errorf("edge %d: fanOut must not be negative and errorRate must be between 0 and 1", i)
+		}
+		if p := edge.Probability; p != nil && (*p < 0 || *p > 1) {
+			return fmt.Errorf("edge %d: probability must be between 0 and 1", i)
+		}
+		if err := edge.Latency.validate(); err != nil {
+			return fmt.Errorf("edge %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (d distribution) validate() error {
+	switch d.Type {
+	case distConstant:
+		if d.Ms < 0 {
+			return errors.New("negative constant latency")
+		}
+	case distUniform:
+		if d.MinMs < 0 || d.MaxMs < d.MinMs {
+			return errors.New("uniform latency requires 0 <= minMs <= maxMs")
+		}
+	case distNormal:
+		if d.MeanMs < 0 || d.StddevMs < 0 {
+			return errors.New("normal latency requires non-negative meanMs and stddevMs")
+		}
+	case distLognormal:
+		if d.MedianMs <= 0 || d.Sigma < 0 {
+			return errors.New("lognormal latency requires a positive medianMs and a non-negative sigma")
+		}
+	default:
+		return fmt.Errorf("unknown latency type %q, expected %s, %s, %s or %s",
+			d.Type, distConstant, distUniform, distNormal, distLognormal)
+	}
+	return nil
+}
+
+// sample draws a latency, negative samples of the normal distribution are zero.
+func (d distribution) sample(rng *rand.Rand) time.Duration {
+	var ms float64
+	switch d.Type {
+	case distConstant:
+		ms = d.Ms
+	case distUniform:
+		ms = d.MinMs + rng.Float64()*(d.MaxMs-d.MinMs)
+	case distNormal:
+		ms = d.MeanMs + rng.NormFloat64()*d.StddevMs
+	case distLognormal:
+		ms = d.MedianMs * math.Exp(rng.NormFloat64()*d.Sigma)
+	}
+	return time.Duration(max(ms, 0) * float64(time.Millisecond))
+}
+
+func newTopologyGenerator(g *generator, t *topology) *topologyGenerator {
+	tg := &topologyGenerator{
+		generator: g,
+		topology:  t,
+		edges:     make(map[string][]*topologyEdge),
+		services:  make(map[string]*model.Process),
+	}
+	addService := func(service string) {
+		if _, ok := tg.services[service]; !ok {
+			tg.services[service] = model.NewProcess(service, []model.KeyValue{
+				model.String("host.name", fmt.Sprintf("host-%d", len(tg.services))),
+				model.String("telemetry.sdk.name", "tracegen"),
+			})
+		}
+	}
+	for _, root := range t.Roots {
+		addService(root.Service)
+		tg.weights += rootWeight(root)
+	}
+	for i := range t.Edges {
+		edge := &t.Edges[i]
+		addService(edge.From)
+		addService(edge.To)
+		tg.edges[edge.From] = append(tg.edges[edge.From], edge)
+	}
+	return tg
+}
+
+func rootWeight(root topologyRoot) float64 {
+	if root.Weight == 0 {
+		return 1
+	}
+	return root.Weight
+}
+
+// trace returns the spans of a new trace starting at a random root.
+func (tg *topologyGenerator) trace(start time.Time) []*model.Span {
+	root := tg.topology.Roots[len(tg.topology.Roots)-1]
+	pick := tg.rng.Float64() * tg.weights
+	for _, r := range tg.topology.Roots {
+		if pick -= rootWeight(r); pick < 0 {
+			root = r
+			break
+		}
+	}
+	traceID := tg.traceID(tg.rng, start)
+	isError := tg.rng.Float64() < tg.opts.ErrorRate
+	spans, _ := tg.serve(traceID, nil, root.Service, root.Operation, root.Latency, isError, start, 0)
+	return spans
+}
+
+// serve returns the server span of an operation, preceded by the spans of
+// the calls it makes, and its end time. Half of the processing time of the
+// service is spent before the calls and half after them.
+func (tg *topologyGenerator) serve(
+	traceID model.TraceID,
+	parent *model.Span,
+	service, operation string,
+	latency distribution,
+	isError bool,
+	start time.Time,
+	depth int,
+) ([]*model.Span, time.Time) {
+	server := tg.topologySpan(traceID, parent, service, operation, model.SpanKindServer, isError, start)
+	self := latency.sample(tg.rng)
+	cursor := start.Add(self / 2)
+	var spans []*model.Span
+	if depth < maxTopologyDepth {
+		for _, edge := range tg.edges[service] {
+			if edge.FromOperation != "" && edge.FromOperation != operation {
+				continue
+			}
+			if edge.Probability != nil && tg.rng.Float64() >= *edge.Probability {
+				continue
+			}
+			callStart, callsEnd := cursor, cursor
+			for range max(edge.FanOut, 1) {
+				callSpans, end := tg.call(traceID, server, edge, callStart, depth)
+				spans = append(spans, callSpans...)
+				callsEnd = later(callsEnd, end)
+				if !edge.Parallel {
+					callStart = end
+				}
+			}
+			cursor = callsEnd
+		}
+	}
+	end := cursor.Add(self - self/2)
+	server.Duration = end.Sub(start)
+	return append([]*model.Span{server}, spans...), end
+}
+
+// call returns the client span of a call along the edge, followed by the
+// spans of the callee, and its end time.
+func (tg *topologyGenerator) call(traceID model.TraceID, caller *model.Span, edge *topologyEdge, start time.Time, depth int) ([]*model.Span, time.Time) {
+	isError := tg.rng.Float64() < edge.ErrorRate
+	client := tg.topologySpan(traceID, caller, edge.From, edge.Operation, model.SpanKindClient, isError, start)
+	spans, serverEnd := tg.serve(traceID, client, edge.To, edge.Operation, edge.Latency, isError, start.Add(networkDelay), depth+1)
+	end := serverEnd.Add(networkDelay)
+	client.Duration = end.Sub(start)
+	return append([]*model.Span{client}, spans...), end
+}
+
+func (tg *topologyGenerator) topologySpan(
+	traceID model.TraceID,
+	parent *model.Span,
+	service, operation string,
+	kind model.SpanKind,
+	isError bool,
+	start time.Time,
+) *model.Span {
+	span := &model.Span{
+		TraceID:       traceID,
+		SpanID:        model.NewSpanID(tg.rng.Uint64() | 1),
+		OperationName: operation,
+		StartTime:     start,
+		Tags:          tg.tags(kind, isError),
+		Process:       tg.services[service],
+	}
+	if parent != nil {
+		span.References = []model.SpanRef{model.NewChildOfRef(traceID, parent.SpanID)}
+	}
+	return span
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
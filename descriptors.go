@@ -0,0 +1,53 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package idl
+
+import (
+	_ "embed"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+//go:generate go run ./cmd/descriptorgen -o descriptors/jaeger.binpb
+
+// descriptorSet is the FileDescriptorSet of the api_v2 and api_v3 protos
+// with all their imports, written by cmd/descriptorgen.
+//
+//go:embed descriptors/jaeger.binpb
+var descriptorSet []byte
+
+// DescriptorSet returns the encoded FileDescriptorSet of the api_v2 and
+// api_v3 protos with all their imports, as written by `protoc
+// --include_imports --descriptor_set_out`, e.g. for `grpcurl -protoset`.
+func DescriptorSet() []byte {
+	return append([]byte(nil), descriptorSet...)
+}
+
+// Descriptors returns the FileDescriptorSet of the api_v2 and api_v3 protos
+// with all their imports, listed with their imports first.
+func Descriptors() (*descriptorpb.FileDescriptorSet, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(descriptorSet, set); err != nil {
+		return nil, fmt.Errorf("cannot decode the descriptor set: %w", err)
+	}
+	return set, nil
+}
+
+// Files returns a registry of the files of Descriptors, to look up the
+// messages and services of the protos by name.
+func Files() (*protoregistry.Files, error) {
+	set, err := Descriptors()
+	if err != nil {
+		return nil, err
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build the descriptors: %w", err)
+	}
+	return files, nil
+}
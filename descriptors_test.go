@@ -0,0 +1,75 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package idl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/gen/api_v3"
+)
+
+func TestDescriptorsMatchGeneratedTypes(t *testing.T) {
+	set, err := Descriptors()
+	require.NoError(t, err)
+	byName := make(map[string]*descriptorpb.FileDescriptorProto)
+	for _, fd := range set.File {
+		byName[fd.GetName()] = fd
+	}
+	for _, fd := range []protoreflect.FileDescriptor{
+		api_v2.File_api_v2_model_proto,
+		api_v2.File_api_v2_collector_proto,
+		api_v2.File_api_v2_query_proto,
+		api_v2.File_api_v2_sampling_proto,
+		api_v3.File_api_v3_query_service_proto,
+	} {
+		embedded, ok := byName[fd.Path()]
+		require.True(t, ok, "%s is missing", fd.Path())
+		assert.True(t, proto.Equal(protodesc.ToFileDescriptorProto(fd), embedded),
+			"%s is out of date, run go generate", fd.Path())
+	}
+}
+
+func TestFiles(t *testing.T) {
+	files, err := Files()
+	require.NoError(t, err)
+	for _, name := range []protoreflect.FullName{
+		"jaeger.api_v2.QueryService",
+		"jaeger.api_v2.CollectorService",
+		"jaeger.api_v2.SamplingManager",
+		"jaeger.api_v3.QueryService",
+	} {
+		desc, err := files.FindDescriptorByName(name)
+		require.NoError(t, err)
+		assert.Implements(t, (*protoreflect.ServiceDescriptor)(nil), desc)
+	}
+
+	desc, err := files.FindDescriptorByName("jaeger.api_v2.Span")
+	require.NoError(t, err)
+	data, err := proto.Marshal(&api_v2.Span{OperationName: "get /users", Process: &api_v2.Process{ServiceName: "frontend"}})
+	require.NoError(t, err)
+	span := dynamicpb.NewMessage(desc.(protoreflect.MessageDescriptor))
+	require.NoError(t, proto.Unmarshal(data, span))
+	assert.Equal(t, "get /users", span.Get(span.Descriptor().Fields().ByName("operation_name")).String())
+}
+
+func TestDescriptorSet(t *testing.T) {
+	data := DescriptorSet()
+	set := &descriptorpb.FileDescriptorSet{}
+	require.NoError(t, proto.Unmarshal(data, set))
+	want, err := Descriptors()
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(want, set))
+
+	data[0] ^= 0xff
+	assert.NotEqual(t, data, DescriptorSet())
+}
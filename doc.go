@@ -0,0 +1,16 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package idl gives access to the compiled api_v2 and api_v3 protos at
+// runtime, for the tools handling the Jaeger messages dynamically, e.g. to
+// decode or validate payloads or to reflect on the services, without
+// running protoc.
+//
+// For example:
+//
+//	files, err := idl.Files()
+//	if err != nil {
+//		return err
+//	}
+//	desc, err := files.FindDescriptorByName("jaeger.api_v2.Span")
+package idl
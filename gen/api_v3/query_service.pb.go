@@ -841,6 +841,217 @@ func (x *GetTopKAttributeValuesResponse) GetValues() []string {
 	return nil
 }
 
+// Request object to get the dependencies between services.
+type GetDependenciesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The start of the time interval to search for the dependencies.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Required. The end of the time interval to search for the dependencies.
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Optional. The workspace ID to filter dependencies.
+	WorkspaceId   string `protobuf:"bytes,3,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDependenciesRequest) Reset() {
+	*x = GetDependenciesRequest{}
+	mi := &file_api_v3_query_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDependenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDependenciesRequest) ProtoMessage() {}
+
+func (x *GetDependenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDependenciesRequest.ProtoReflect.Descriptor instead.
+func (*GetDependenciesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetDependenciesRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetDependenciesRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetDependenciesRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+// Dependency represents the calls of a parent service to a child service.
+type Dependency struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// parent is the name of the caller service.
+	Parent string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// child is the name of the service being called.
+	Child string `protobuf:"bytes,2,opt,name=child,proto3" json:"child,omitempty"`
+	// call_count is the number of times the parent service called the child service.
+	CallCount uint64 `protobuf:"varint,3,opt,name=call_count,json=callCount,proto3" json:"call_count,omitempty"`
+	// error_count is the number of the calls that failed, i.e. whose child span
+	// has an error status.
+	ErrorCount uint64 `protobuf:"varint,4,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	// latency_p50 is the median latency of the calls, i.e. of the durations of
+	// the child spans.
+	LatencyP50 *durationpb.Duration `protobuf:"bytes,5,opt,name=latency_p50,json=latencyP50,proto3" json:"latency_p50,omitempty"`
+	// latency_p95 is the 95th percentile of the latency of the calls.
+	LatencyP95 *durationpb.Duration `protobuf:"bytes,6,opt,name=latency_p95,json=latencyP95,proto3" json:"latency_p95,omitempty"`
+	// latency_p99 is the 99th percentile of the latency of the calls.
+	LatencyP99    *durationpb.Duration `protobuf:"bytes,7,opt,name=latency_p99,json=latencyP99,proto3" json:"latency_p99,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dependency) Reset() {
+	*x = Dependency{}
+	mi := &file_api_v3_query_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dependency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dependency) ProtoMessage() {}
+
+func (x *Dependency) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dependency.ProtoReflect.Descriptor instead.
+func (*Dependency) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Dependency) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *Dependency) GetChild() string {
+	if x != nil {
+		return x.Child
+	}
+	return ""
+}
+
+func (x *Dependency) GetCallCount() uint64 {
+	if x != nil {
+		return x.CallCount
+	}
+	return 0
+}
+
+func (x *Dependency) GetErrorCount() uint64 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
+}
+
+func (x *Dependency) GetLatencyP50() *durationpb.Duration {
+	if x != nil {
+		return x.LatencyP50
+	}
+	return nil
+}
+
+func (x *Dependency) GetLatencyP95() *durationpb.Duration {
+	if x != nil {
+		return x.LatencyP95
+	}
+	return nil
+}
+
+func (x *Dependency) GetLatencyP99() *durationpb.Duration {
+	if x != nil {
+		return x.LatencyP99
+	}
+	return nil
+}
+
+// Response object to get the dependencies between services.
+type GetDependenciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dependencies  []*Dependency          `protobuf:"bytes,1,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDependenciesResponse) Reset() {
+	*x = GetDependenciesResponse{}
+	mi := &file_api_v3_query_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDependenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDependenciesResponse) ProtoMessage() {}
+
+func (x *GetDependenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDependenciesResponse.ProtoReflect.Descriptor instead.
+func (*GetDependenciesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetDependenciesResponse) GetDependencies() []*Dependency {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
 // GRPCGatewayError is the type returned when GRPC server returns an error.
 // Example: {"error":{"grpcCode":2,"httpCode":500,"message":"...","httpStatus":"text..."}}.
 type GRPCGatewayError struct {
@@ -852,7 +1063,7 @@ type GRPCGatewayError struct {
 
 func (x *GRPCGatewayError) Reset() {
 	*x = GRPCGatewayError{}
-	mi := &file_api_v3_query_service_proto_msgTypes[12]
+	mi := &file_api_v3_query_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -864,7 +1075,7 @@ func (x *GRPCGatewayError) String() string {
 func (*GRPCGatewayError) ProtoMessage() {}
 
 func (x *GRPCGatewayError) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_query_service_proto_msgTypes[12]
+	mi := &file_api_v3_query_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -877,7 +1088,7 @@ func (x *GRPCGatewayError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GRPCGatewayError.ProtoReflect.Descriptor instead.
 func (*GRPCGatewayError) Descriptor() ([]byte, []int) {
-	return file_api_v3_query_service_proto_rawDescGZIP(), []int{12}
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GRPCGatewayError) GetError() *GRPCGatewayError_GRPCGatewayErrorDetails {
@@ -907,7 +1118,7 @@ type GRPCGatewayWrapper struct {
 
 func (x *GRPCGatewayWrapper) Reset() {
 	*x = GRPCGatewayWrapper{}
-	mi := &file_api_v3_query_service_proto_msgTypes[13]
+	mi := &file_api_v3_query_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -919,7 +1130,7 @@ func (x *GRPCGatewayWrapper) String() string {
 func (*GRPCGatewayWrapper) ProtoMessage() {}
 
 func (x *GRPCGatewayWrapper) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_query_service_proto_msgTypes[13]
+	mi := &file_api_v3_query_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -932,7 +1143,7 @@ func (x *GRPCGatewayWrapper) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GRPCGatewayWrapper.ProtoReflect.Descriptor instead.
 func (*GRPCGatewayWrapper) Descriptor() ([]byte, []int) {
-	return file_api_v3_query_service_proto_rawDescGZIP(), []int{13}
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GRPCGatewayWrapper) GetResult() *v1.TracesData {
@@ -954,7 +1165,7 @@ type GRPCGatewayError_GRPCGatewayErrorDetails struct {
 
 func (x *GRPCGatewayError_GRPCGatewayErrorDetails) Reset() {
 	*x = GRPCGatewayError_GRPCGatewayErrorDetails{}
-	mi := &file_api_v3_query_service_proto_msgTypes[15]
+	mi := &file_api_v3_query_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -966,7 +1177,7 @@ func (x *GRPCGatewayError_GRPCGatewayErrorDetails) String() string {
 func (*GRPCGatewayError_GRPCGatewayErrorDetails) ProtoMessage() {}
 
 func (x *GRPCGatewayError_GRPCGatewayErrorDetails) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_query_service_proto_msgTypes[15]
+	mi := &file_api_v3_query_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -979,7 +1190,7 @@ func (x *GRPCGatewayError_GRPCGatewayErrorDetails) ProtoReflect() protoreflect.M
 
 // Deprecated: Use GRPCGatewayError_GRPCGatewayErrorDetails.ProtoReflect.Descriptor instead.
 func (*GRPCGatewayError_GRPCGatewayErrorDetails) Descriptor() ([]byte, []int) {
-	return file_api_v3_query_service_proto_rawDescGZIP(), []int{12, 0}
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{15, 0}
 }
 
 func (x *GRPCGatewayError_GRPCGatewayErrorDetails) GetGrpcCode() int32 {
@@ -1074,7 +1285,28 @@ const file_api_v3_query_service_proto_rawDesc = "" +
 	"\x05query\x18\x05 \x01(\v2#.jaeger.api_v3.TraceQueryParametersR\x05query\x12\f\n" +
 	"\x01k\x18\x06 \x01(\x05R\x01k\"8\n" +
 	"\x1eGetTopKAttributeValuesResponse\x12\x16\n" +
-	"\x06values\x18\x01 \x03(\tR\x06values\"\xef\x01\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\xad\x01\n" +
+	"\x16GetDependenciesRequest\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12!\n" +
+	"\fworkspace_id\x18\x03 \x01(\tR\vworkspaceId\"\xae\x02\n" +
+	"\n" +
+	"Dependency\x12\x16\n" +
+	"\x06parent\x18\x01 \x01(\tR\x06parent\x12\x14\n" +
+	"\x05child\x18\x02 \x01(\tR\x05child\x12\x1d\n" +
+	"\n" +
+	"call_count\x18\x03 \x01(\x04R\tcallCount\x12\x1f\n" +
+	"\verror_count\x18\x04 \x01(\x04R\n" +
+	"errorCount\x12:\n" +
+	"\vlatency_p50\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"latencyP50\x12:\n" +
+	"\vlatency_p95\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"latencyP95\x12:\n" +
+	"\vlatency_p99\x18\a \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"latencyP99\"X\n" +
+	"\x17GetDependenciesResponse\x12=\n" +
+	"\fdependencies\x18\x01 \x03(\v2\x19.jaeger.api_v3.DependencyR\fdependencies\"\xef\x01\n" +
 	"\x10GRPCGatewayError\x12M\n" +
 	"\x05error\x18\x01 \x01(\v27.jaeger.api_v3.GRPCGatewayError.GRPCGatewayErrorDetailsR\x05error\x1a\x8b\x01\n" +
 	"\x17GRPCGatewayErrorDetails\x12\x1a\n" +
@@ -1085,7 +1317,7 @@ const file_api_v3_query_service_proto_rawDesc = "" +
 	"httpStatus\x18\x04 \x01(\tR\n" +
 	"httpStatus\"V\n" +
 	"\x12GRPCGatewayWrapper\x12@\n" +
-	"\x06result\x18\x01 \x01(\v2(.opentelemetry.proto.trace.v1.TracesDataR\x06result2\xbf\x06\n" +
+	"\x06result\x18\x01 \x01(\v2(.opentelemetry.proto.trace.v1.TracesDataR\x06result2\xbf\a\n" +
 	"\fQueryService\x12y\n" +
 	"\bGetTrace\x12\x1e.jaeger.api_v3.GetTraceRequest\x1a(.opentelemetry.proto.trace.v1.TracesData\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v3/traces/{trace_id}0\x01\x12\x87\x01\n" +
 	"\n" +
@@ -1093,7 +1325,8 @@ const file_api_v3_query_service_proto_rawDesc = "" +
 	"\vGetServices\x12!.jaeger.api_v3.GetServicesRequest\x1a\".jaeger.api_v3.GetServicesResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v3/services\x12v\n" +
 	"\rGetOperations\x12#.jaeger.api_v3.GetOperationsRequest\x1a$.jaeger.api_v3.GetOperationsResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v3/operations\x12\xa1\x01\n" +
 	"\x19GetIndexedAttributesNames\x12/.jaeger.api_v3.GetIndexedAttributesNamesRequest\x1a).jaeger.api_v3.GetAttributesNamesResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /api/v3/attributes/indexed/names\x12\x9d\x01\n" +
-	"\x16GetTopKAttributeValues\x12,.jaeger.api_v3.GetTopKAttributeValuesRequest\x1a-.jaeger.api_v3.GetTopKAttributeValuesResponse\"&\x82\xd3\xe4\x93\x02 \x12\x1e/api/v3/attributes/values/topkB\xa7\x01\n" +
+	"\x16GetTopKAttributeValues\x12,.jaeger.api_v3.GetTopKAttributeValuesRequest\x1a-.jaeger.api_v3.GetTopKAttributeValuesResponse\"&\x82\xd3\xe4\x93\x02 \x12\x1e/api/v3/attributes/values/topk\x12~\n" +
+	"\x0fGetDependencies\x12%.jaeger.api_v3.GetDependenciesRequest\x1a&.jaeger.api_v3.GetDependenciesResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v3/dependenciesB\xa7\x01\n" +
 	"\x11com.jaeger.api_v3B\x11QueryServiceProtoP\x01Z.github.com/jaegertracing/jaeger-idl/gen/api_v3\xa2\x02\x03JAX\xaa\x02\fJaeger.ApiV3\xca\x02\fJaeger\\ApiV3\xe2\x02\x18Jaeger\\ApiV3\\GPBMetadata\xea\x02\rJaeger::ApiV3b\x06proto3"
 
 var (
@@ -1108,7 +1341,7 @@ func file_api_v3_query_service_proto_rawDescGZIP() []byte {
 	return file_api_v3_query_service_proto_rawDescData
 }
 
-var file_api_v3_query_service_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_api_v3_query_service_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_api_v3_query_service_proto_goTypes = []any{
 	(*GetTraceRequest)(nil),                          // 0: jaeger.api_v3.GetTraceRequest
 	(*TraceQueryParameters)(nil),                     // 1: jaeger.api_v3.TraceQueryParameters
@@ -1122,45 +1355,56 @@ var file_api_v3_query_service_proto_goTypes = []any{
 	(*GetAttributesNamesResponse)(nil),               // 9: jaeger.api_v3.GetAttributesNamesResponse
 	(*GetTopKAttributeValuesRequest)(nil),            // 10: jaeger.api_v3.GetTopKAttributeValuesRequest
 	(*GetTopKAttributeValuesResponse)(nil),           // 11: jaeger.api_v3.GetTopKAttributeValuesResponse
-	(*GRPCGatewayError)(nil),                         // 12: jaeger.api_v3.GRPCGatewayError
-	(*GRPCGatewayWrapper)(nil),                       // 13: jaeger.api_v3.GRPCGatewayWrapper
-	nil,                                              // 14: jaeger.api_v3.TraceQueryParameters.AttributesEntry
-	(*GRPCGatewayError_GRPCGatewayErrorDetails)(nil), // 15: jaeger.api_v3.GRPCGatewayError.GRPCGatewayErrorDetails
-	(*timestamppb.Timestamp)(nil),                    // 16: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),                      // 17: google.protobuf.Duration
-	(*v1.TracesData)(nil),                            // 18: opentelemetry.proto.trace.v1.TracesData
+	(*GetDependenciesRequest)(nil),                   // 12: jaeger.api_v3.GetDependenciesRequest
+	(*Dependency)(nil),                               // 13: jaeger.api_v3.Dependency
+	(*GetDependenciesResponse)(nil),                  // 14: jaeger.api_v3.GetDependenciesResponse
+	(*GRPCGatewayError)(nil),                         // 15: jaeger.api_v3.GRPCGatewayError
+	(*GRPCGatewayWrapper)(nil),                       // 16: jaeger.api_v3.GRPCGatewayWrapper
+	nil,                                              // 17: jaeger.api_v3.TraceQueryParameters.AttributesEntry
+	(*GRPCGatewayError_GRPCGatewayErrorDetails)(nil), // 18: jaeger.api_v3.GRPCGatewayError.GRPCGatewayErrorDetails
+	(*timestamppb.Timestamp)(nil),                    // 19: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),                      // 20: google.protobuf.Duration
+	(*v1.TracesData)(nil),                            // 21: opentelemetry.proto.trace.v1.TracesData
 }
 var file_api_v3_query_service_proto_depIdxs = []int32{
-	16, // 0: jaeger.api_v3.GetTraceRequest.start_time:type_name -> google.protobuf.Timestamp
-	16, // 1: jaeger.api_v3.GetTraceRequest.end_time:type_name -> google.protobuf.Timestamp
-	14, // 2: jaeger.api_v3.TraceQueryParameters.attributes:type_name -> jaeger.api_v3.TraceQueryParameters.AttributesEntry
-	16, // 3: jaeger.api_v3.TraceQueryParameters.start_time_min:type_name -> google.protobuf.Timestamp
-	16, // 4: jaeger.api_v3.TraceQueryParameters.start_time_max:type_name -> google.protobuf.Timestamp
-	17, // 5: jaeger.api_v3.TraceQueryParameters.duration_min:type_name -> google.protobuf.Duration
-	17, // 6: jaeger.api_v3.TraceQueryParameters.duration_max:type_name -> google.protobuf.Duration
+	19, // 0: jaeger.api_v3.GetTraceRequest.start_time:type_name -> google.protobuf.Timestamp
+	19, // 1: jaeger.api_v3.GetTraceRequest.end_time:type_name -> google.protobuf.Timestamp
+	17, // 2: jaeger.api_v3.TraceQueryParameters.attributes:type_name -> jaeger.api_v3.TraceQueryParameters.AttributesEntry
+	19, // 3: jaeger.api_v3.TraceQueryParameters.start_time_min:type_name -> google.protobuf.Timestamp
+	19, // 4: jaeger.api_v3.TraceQueryParameters.start_time_max:type_name -> google.protobuf.Timestamp
+	20, // 5: jaeger.api_v3.TraceQueryParameters.duration_min:type_name -> google.protobuf.Duration
+	20, // 6: jaeger.api_v3.TraceQueryParameters.duration_max:type_name -> google.protobuf.Duration
 	1,  // 7: jaeger.api_v3.FindTracesRequest.query:type_name -> jaeger.api_v3.TraceQueryParameters
 	6,  // 8: jaeger.api_v3.GetOperationsResponse.operations:type_name -> jaeger.api_v3.Operation
 	1,  // 9: jaeger.api_v3.GetIndexedAttributesNamesRequest.query:type_name -> jaeger.api_v3.TraceQueryParameters
 	1,  // 10: jaeger.api_v3.GetTopKAttributeValuesRequest.query:type_name -> jaeger.api_v3.TraceQueryParameters
-	15, // 11: jaeger.api_v3.GRPCGatewayError.error:type_name -> jaeger.api_v3.GRPCGatewayError.GRPCGatewayErrorDetails
-	18, // 12: jaeger.api_v3.GRPCGatewayWrapper.result:type_name -> opentelemetry.proto.trace.v1.TracesData
-	0,  // 13: jaeger.api_v3.QueryService.GetTrace:input_type -> jaeger.api_v3.GetTraceRequest
-	2,  // 14: jaeger.api_v3.QueryService.FindTraces:input_type -> jaeger.api_v3.FindTracesRequest
-	3,  // 15: jaeger.api_v3.QueryService.GetServices:input_type -> jaeger.api_v3.GetServicesRequest
-	5,  // 16: jaeger.api_v3.QueryService.GetOperations:input_type -> jaeger.api_v3.GetOperationsRequest
-	8,  // 17: jaeger.api_v3.QueryService.GetIndexedAttributesNames:input_type -> jaeger.api_v3.GetIndexedAttributesNamesRequest
-	10, // 18: jaeger.api_v3.QueryService.GetTopKAttributeValues:input_type -> jaeger.api_v3.GetTopKAttributeValuesRequest
-	18, // 19: jaeger.api_v3.QueryService.GetTrace:output_type -> opentelemetry.proto.trace.v1.TracesData
-	18, // 20: jaeger.api_v3.QueryService.FindTraces:output_type -> opentelemetry.proto.trace.v1.TracesData
-	4,  // 21: jaeger.api_v3.QueryService.GetServices:output_type -> jaeger.api_v3.GetServicesResponse
-	7,  // 22: jaeger.api_v3.QueryService.GetOperations:output_type -> jaeger.api_v3.GetOperationsResponse
-	9,  // 23: jaeger.api_v3.QueryService.GetIndexedAttributesNames:output_type -> jaeger.api_v3.GetAttributesNamesResponse
-	11, // 24: jaeger.api_v3.QueryService.GetTopKAttributeValues:output_type -> jaeger.api_v3.GetTopKAttributeValuesResponse
-	19, // [19:25] is the sub-list for method output_type
-	13, // [13:19] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	19, // 11: jaeger.api_v3.GetDependenciesRequest.start_time:type_name -> google.protobuf.Timestamp
+	19, // 12: jaeger.api_v3.GetDependenciesRequest.end_time:type_name -> google.protobuf.Timestamp
+	20, // 13: jaeger.api_v3.Dependency.latency_p50:type_name -> google.protobuf.Duration
+	20, // 14: jaeger.api_v3.Dependency.latency_p95:type_name -> google.protobuf.Duration
+	20, // 15: jaeger.api_v3.Dependency.latency_p99:type_name -> google.protobuf.Duration
+	13, // 16: jaeger.api_v3.GetDependenciesResponse.dependencies:type_name -> jaeger.api_v3.Dependency
+	18, // 17: jaeger.api_v3.GRPCGatewayError.error:type_name -> jaeger.api_v3.GRPCGatewayError.GRPCGatewayErrorDetails
+	21, // 18: jaeger.api_v3.GRPCGatewayWrapper.result:type_name -> opentelemetry.proto.trace.v1.TracesData
+	0,  // 19: jaeger.api_v3.QueryService.GetTrace:input_type -> jaeger.api_v3.GetTraceRequest
+	2,  // 20: jaeger.api_v3.QueryService.FindTraces:input_type -> jaeger.api_v3.FindTracesRequest
+	3,  // 21: jaeger.api_v3.QueryService.GetServices:input_type -> jaeger.api_v3.GetServicesRequest
+	5,  // 22: jaeger.api_v3.QueryService.GetOperations:input_type -> jaeger.api_v3.GetOperationsRequest
+	8,  // 23: jaeger.api_v3.QueryService.GetIndexedAttributesNames:input_type -> jaeger.api_v3.GetIndexedAttributesNamesRequest
+	10, // 24: jaeger.api_v3.QueryService.GetTopKAttributeValues:input_type -> jaeger.api_v3.GetTopKAttributeValuesRequest
+	12, // 25: jaeger.api_v3.QueryService.GetDependencies:input_type -> jaeger.api_v3.GetDependenciesRequest
+	21, // 26: jaeger.api_v3.QueryService.GetTrace:output_type -> opentelemetry.proto.trace.v1.TracesData
+	21, // 27: jaeger.api_v3.QueryService.FindTraces:output_type -> opentelemetry.proto.trace.v1.TracesData
+	4,  // 28: jaeger.api_v3.QueryService.GetServices:output_type -> jaeger.api_v3.GetServicesResponse
+	7,  // 29: jaeger.api_v3.QueryService.GetOperations:output_type -> jaeger.api_v3.GetOperationsResponse
+	9,  // 30: jaeger.api_v3.QueryService.GetIndexedAttributesNames:output_type -> jaeger.api_v3.GetAttributesNamesResponse
+	11, // 31: jaeger.api_v3.QueryService.GetTopKAttributeValues:output_type -> jaeger.api_v3.GetTopKAttributeValuesResponse
+	14, // 32: jaeger.api_v3.QueryService.GetDependencies:output_type -> jaeger.api_v3.GetDependenciesResponse
+	26, // [26:33] is the sub-list for method output_type
+	19, // [19:26] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_api_v3_query_service_proto_init() }
@@ -1174,7 +1418,7 @@ func file_api_v3_query_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v3_query_service_proto_rawDesc), len(file_api_v3_query_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -40,6 +40,7 @@ const (
 	QueryService_GetOperations_FullMethodName             = "/jaeger.api_v3.QueryService/GetOperations"
 	QueryService_GetIndexedAttributesNames_FullMethodName = "/jaeger.api_v3.QueryService/GetIndexedAttributesNames"
 	QueryService_GetTopKAttributeValues_FullMethodName    = "/jaeger.api_v3.QueryService/GetTopKAttributeValues"
+	QueryService_GetDependencies_FullMethodName           = "/jaeger.api_v3.QueryService/GetDependencies"
 )
 
 // QueryServiceClient is the client API for QueryService service.
@@ -63,6 +64,9 @@ type QueryServiceClient interface {
 	GetIndexedAttributesNames(ctx context.Context, in *GetIndexedAttributesNamesRequest, opts ...grpc.CallOption) (*GetAttributesNamesResponse, error)
 	// GetTopKAttributeValues returns the most frequently observed values for an attribute.
 	GetTopKAttributeValues(ctx context.Context, in *GetTopKAttributeValuesRequest, opts ...grpc.CallOption) (*GetTopKAttributeValuesResponse, error)
+	// GetDependencies returns the dependencies between services, aggregated
+	// from the spans in the time interval.
+	GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error)
 }
 
 type queryServiceClient struct {
@@ -151,6 +155,16 @@ func (c *queryServiceClient) GetTopKAttributeValues(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *queryServiceClient) GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDependenciesResponse)
+	err := c.cc.Invoke(ctx, QueryService_GetDependencies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QueryServiceServer is the server API for QueryService service.
 // All implementations should embed UnimplementedQueryServiceServer
 // for forward compatibility.
@@ -172,6 +186,9 @@ type QueryServiceServer interface {
 	GetIndexedAttributesNames(context.Context, *GetIndexedAttributesNamesRequest) (*GetAttributesNamesResponse, error)
 	// GetTopKAttributeValues returns the most frequently observed values for an attribute.
 	GetTopKAttributeValues(context.Context, *GetTopKAttributeValuesRequest) (*GetTopKAttributeValuesResponse, error)
+	// GetDependencies returns the dependencies between services, aggregated
+	// from the spans in the time interval.
+	GetDependencies(context.Context, *GetDependenciesRequest) (*GetDependenciesResponse, error)
 }
 
 // UnimplementedQueryServiceServer should be embedded to have
@@ -199,6 +216,9 @@ func (UnimplementedQueryServiceServer) GetIndexedAttributesNames(context.Context
 func (UnimplementedQueryServiceServer) GetTopKAttributeValues(context.Context, *GetTopKAttributeValuesRequest) (*GetTopKAttributeValuesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTopKAttributeValues not implemented")
 }
+func (UnimplementedQueryServiceServer) GetDependencies(context.Context, *GetDependenciesRequest) (*GetDependenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDependencies not implemented")
+}
 func (UnimplementedQueryServiceServer) testEmbeddedByValue() {}
 
 // UnsafeQueryServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -313,6 +333,24 @@ func _QueryService_GetTopKAttributeValues_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QueryService_GetDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).GetDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueryService_GetDependencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).GetDependencies(ctx, req.(*GetDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // QueryService_ServiceDesc is the grpc.ServiceDesc for QueryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -336,6 +374,10 @@ var QueryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTopKAttributeValues",
 			Handler:    _QueryService_GetTopKAttributeValues_Handler,
 		},
+		{
+			MethodName: "GetDependencies",
+			Handler:    _QueryService_GetDependencies_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -163,6 +163,49 @@ func local_request_TraceReader_FindTraceIDs_0(ctx context.Context, marshaler run
 	return msg, metadata, err
 }
 
+func request_TraceWriter_WriteTraces_0(ctx context.Context, marshaler runtime.Marshaler, client extStoragev2.TraceWriterClient, req *http.Request, pathParams map[string]string) (extStoragev2.TraceWriter_WriteTracesClient, runtime.ServerMetadata, error) {
+	var metadata runtime.ServerMetadata
+	stream, err := client.WriteTraces(ctx)
+	if err != nil {
+		grpclog.Errorf("Failed to start streaming: %v", err)
+		return nil, metadata, err
+	}
+	dec := marshaler.NewDecoder(req.Body)
+	handleSend := func() error {
+		var protoReq extStoragev2.WriteTracesRequest
+		err := dec.Decode(&protoReq)
+		if errors.Is(err, io.EOF) {
+			return err
+		}
+		if err != nil {
+			grpclog.Errorf("Failed to decode request: %v", err)
+			return status.Errorf(codes.InvalidArgument, "Failed to decode request: %v", err)
+		}
+		if err := stream.Send(&protoReq); err != nil {
+			grpclog.Errorf("Failed to send request: %v", err)
+			return err
+		}
+		return nil
+	}
+	go func() {
+		for {
+			if err := handleSend(); err != nil {
+				break
+			}
+		}
+		if err := stream.CloseSend(); err != nil {
+			grpclog.Errorf("Failed to terminate client stream: %v", err)
+		}
+	}()
+	header, err := stream.Header()
+	if err != nil {
+		grpclog.Errorf("Failed to get header from client: %v", err)
+		return nil, metadata, err
+	}
+	metadata.HeaderMD = header
+	return stream, metadata, nil
+}
+
 // RegisterTraceReaderHandlerServer registers the http handlers for service TraceReader to "mux".
 // UnaryRPC     :call TraceReaderServer directly.
 // StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
@@ -246,6 +289,22 @@ func RegisterTraceReaderHandlerServer(ctx context.Context, mux *runtime.ServeMux
 	return nil
 }
 
+// RegisterTraceWriterHandlerServer registers the http handlers for service TraceWriter to "mux".
+// UnaryRPC     :call TraceWriterServer directly.
+// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterTraceWriterHandlerFromEndpoint instead.
+// GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
+func RegisterTraceWriterHandlerServer(ctx context.Context, mux *runtime.ServeMux, server extStoragev2.TraceWriterServer) error {
+	mux.Handle(http.MethodPost, pattern_TraceWriter_WriteTraces_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+		return
+	})
+
+	return nil
+}
+
 // RegisterTraceReaderHandlerFromEndpoint is same as RegisterTraceReaderHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
 func RegisterTraceReaderHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
@@ -385,3 +444,67 @@ var (
 	forward_TraceReader_FindTraces_0    = runtime.ForwardResponseStream
 	forward_TraceReader_FindTraceIDs_0  = runtime.ForwardResponseMessage
 )
+
+// RegisterTraceWriterHandlerFromEndpoint is same as RegisterTraceWriterHandler but
+// automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+func RegisterTraceWriterHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	conn, err := grpc.NewClient(endpoint, opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %s: %v", endpoint, cerr)
+			}
+			return
+		}
+		go func() {
+			<-ctx.Done()
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %s: %v", endpoint, cerr)
+			}
+		}()
+	}()
+	return RegisterTraceWriterHandler(ctx, mux, conn)
+}
+
+// RegisterTraceWriterHandler registers the http handlers for service TraceWriter to "mux".
+// The handlers forward requests to the grpc endpoint over "conn".
+func RegisterTraceWriterHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	return RegisterTraceWriterHandlerClient(ctx, mux, extStoragev2.NewTraceWriterClient(conn))
+}
+
+// RegisterTraceWriterHandlerClient registers the http handlers for service TraceWriter
+// to "mux". The handlers forward requests to the grpc endpoint over the given implementation of "extStoragev2.TraceWriterClient".
+// Note: the gRPC framework executes interceptors within the gRPC handler. If the passed in "extStoragev2.TraceWriterClient"
+// doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
+// "extStoragev2.TraceWriterClient" to call the correct interceptors. This client ignores the HTTP middlewares.
+func RegisterTraceWriterHandlerClient(ctx context.Context, mux *runtime.ServeMux, client extStoragev2.TraceWriterClient) error {
+	mux.Handle(http.MethodPost, pattern_TraceWriter_WriteTraces_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, "/jaeger.storage.v2.TraceWriter/WriteTraces", runtime.WithHTTPPathPattern("/jaeger.storage.v2.TraceWriter/WriteTraces"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := request_TraceWriter_WriteTraces_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		forward_TraceWriter_WriteTraces_0(annotatedContext, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
+	})
+	return nil
+}
+
+var (
+	pattern_TraceWriter_WriteTraces_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"jaeger.storage.v2.TraceWriter", "WriteTraces"}, ""))
+)
+
+var (
+	forward_TraceWriter_WriteTraces_0 = runtime.ForwardResponseStream
+)
@@ -9,6 +9,7 @@ package storagev2
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -86,7 +87,17 @@ type Dependency struct {
 	// call_count is the number of times the parent service called the child service.
 	CallCount uint64 `protobuf:"varint,3,opt,name=call_count,json=callCount,proto3" json:"call_count,omitempty"`
 	// source contains the origin from where the dependency was extracted.
-	Source        string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	Source string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	// error_count is the number of the calls of the parent service to the child
+	// service that failed, i.e. whose child span has an error status.
+	ErrorCount uint64 `protobuf:"varint,5,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	// latency_p50 is the median latency of the calls, i.e. of the durations of
+	// the child spans.
+	LatencyP50 *durationpb.Duration `protobuf:"bytes,6,opt,name=latency_p50,json=latencyP50,proto3" json:"latency_p50,omitempty"`
+	// latency_p95 is the 95th percentile of the latency of the calls.
+	LatencyP95 *durationpb.Duration `protobuf:"bytes,7,opt,name=latency_p95,json=latencyP95,proto3" json:"latency_p95,omitempty"`
+	// latency_p99 is the 99th percentile of the latency of the calls.
+	LatencyP99    *durationpb.Duration `protobuf:"bytes,8,opt,name=latency_p99,json=latencyP99,proto3" json:"latency_p99,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -149,6 +160,34 @@ func (x *Dependency) GetSource() string {
 	return ""
 }
 
+func (x *Dependency) GetErrorCount() uint64 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
+}
+
+func (x *Dependency) GetLatencyP50() *durationpb.Duration {
+	if x != nil {
+		return x.LatencyP50
+	}
+	return nil
+}
+
+func (x *Dependency) GetLatencyP95() *durationpb.Duration {
+	if x != nil {
+		return x.LatencyP95
+	}
+	return nil
+}
+
+func (x *Dependency) GetLatencyP99() *durationpb.Duration {
+	if x != nil {
+		return x.LatencyP99
+	}
+	return nil
+}
+
 type GetDependenciesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Dependencies  []*Dependency          `protobuf:"bytes,1,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
@@ -197,18 +236,26 @@ var File_storage_v2_dependency_storage_proto protoreflect.FileDescriptor
 
 const file_storage_v2_dependency_storage_proto_rawDesc = "" +
 	"\n" +
-	"#storage/v2/dependency_storage.proto\x12\x11jaeger.storage.v2\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8a\x01\n" +
+	"#storage/v2/dependency_storage.proto\x12\x11jaeger.storage.v2\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\"\x8a\x01\n" +
 	"\x16GetDependenciesRequest\x129\n" +
 	"\n" +
 	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
-	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"q\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"\xc6\x02\n" +
 	"\n" +
 	"Dependency\x12\x16\n" +
 	"\x06parent\x18\x01 \x01(\tR\x06parent\x12\x14\n" +
 	"\x05child\x18\x02 \x01(\tR\x05child\x12\x1d\n" +
 	"\n" +
 	"call_count\x18\x03 \x01(\x04R\tcallCount\x12\x16\n" +
-	"\x06source\x18\x04 \x01(\tR\x06source\"\\\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12\x1f\n" +
+	"\verror_count\x18\x05 \x01(\x04R\n" +
+	"errorCount\x12:\n" +
+	"\vlatency_p50\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"latencyP50\x12:\n" +
+	"\vlatency_p95\x18\a \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"latencyP95\x12:\n" +
+	"\vlatency_p99\x18\b \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"latencyP99\"\\\n" +
 	"\x17GetDependenciesResponse\x12A\n" +
 	"\fdependencies\x18\x01 \x03(\v2\x1d.jaeger.storage.v2.DependencyR\fdependencies2|\n" +
 	"\x10DependencyReader\x12h\n" +
@@ -233,18 +280,22 @@ var file_storage_v2_dependency_storage_proto_goTypes = []any{
 	(*Dependency)(nil),              // 1: jaeger.storage.v2.Dependency
 	(*GetDependenciesResponse)(nil), // 2: jaeger.storage.v2.GetDependenciesResponse
 	(*timestamppb.Timestamp)(nil),   // 3: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),     // 4: google.protobuf.Duration
 }
 var file_storage_v2_dependency_storage_proto_depIdxs = []int32{
 	3, // 0: jaeger.storage.v2.GetDependenciesRequest.start_time:type_name -> google.protobuf.Timestamp
 	3, // 1: jaeger.storage.v2.GetDependenciesRequest.end_time:type_name -> google.protobuf.Timestamp
-	1, // 2: jaeger.storage.v2.GetDependenciesResponse.dependencies:type_name -> jaeger.storage.v2.Dependency
-	0, // 3: jaeger.storage.v2.DependencyReader.GetDependencies:input_type -> jaeger.storage.v2.GetDependenciesRequest
-	2, // 4: jaeger.storage.v2.DependencyReader.GetDependencies:output_type -> jaeger.storage.v2.GetDependenciesResponse
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	4, // 2: jaeger.storage.v2.Dependency.latency_p50:type_name -> google.protobuf.Duration
+	4, // 3: jaeger.storage.v2.Dependency.latency_p95:type_name -> google.protobuf.Duration
+	4, // 4: jaeger.storage.v2.Dependency.latency_p99:type_name -> google.protobuf.Duration
+	1, // 5: jaeger.storage.v2.GetDependenciesResponse.dependencies:type_name -> jaeger.storage.v2.Dependency
+	0, // 6: jaeger.storage.v2.DependencyReader.GetDependencies:input_type -> jaeger.storage.v2.GetDependenciesRequest
+	2, // 7: jaeger.storage.v2.DependencyReader.GetDependencies:output_type -> jaeger.storage.v2.GetDependenciesResponse
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_storage_v2_dependency_storage_proto_init() }
@@ -980,6 +980,140 @@ func (x *FindTraceIDsResponse) GetTraceIds() []*FoundTraceID {
 	return nil
 }
 
+// WriteTracesRequest is a batch of spans sent on a WriteTraces stream.
+type WriteTracesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sequence_number identifies the batch within the stream. It is set by the
+	// client and MUST increase with every batch of the stream.
+	SequenceNumber uint64 `protobuf:"varint,1,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
+	// traces contains the spans of the batch.
+	Traces        *v1.TracesData `protobuf:"bytes,2,opt,name=traces,proto3" json:"traces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteTracesRequest) Reset() {
+	*x = WriteTracesRequest{}
+	mi := &file_storage_v2_trace_storage_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteTracesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteTracesRequest) ProtoMessage() {}
+
+func (x *WriteTracesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_v2_trace_storage_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteTracesRequest.ProtoReflect.Descriptor instead.
+func (*WriteTracesRequest) Descriptor() ([]byte, []int) {
+	return file_storage_v2_trace_storage_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WriteTracesRequest) GetSequenceNumber() uint64 {
+	if x != nil {
+		return x.SequenceNumber
+	}
+	return 0
+}
+
+func (x *WriteTracesRequest) GetTraces() *v1.TracesData {
+	if x != nil {
+		return x.Traces
+	}
+	return nil
+}
+
+// WriteTracesResponse acknowledges the batches written to the storage.
+type WriteTracesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sequence_number is the sequence number of the last batch written. It
+	// acknowledges this batch and all the batches sent before it.
+	SequenceNumber uint64 `protobuf:"varint,1,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
+	// accepted_spans is the number of spans written since the previous
+	// acknowledgement.
+	AcceptedSpans int64 `protobuf:"varint,2,opt,name=accepted_spans,json=acceptedSpans,proto3" json:"accepted_spans,omitempty"`
+	// rejected_spans is the number of invalid spans that were dropped since the
+	// previous acknowledgement. Rejected spans MUST NOT be retried.
+	RejectedSpans int64 `protobuf:"varint,3,opt,name=rejected_spans,json=rejectedSpans,proto3" json:"rejected_spans,omitempty"`
+	// error_message describes why spans were rejected.
+	//
+	// This field is optional.
+	ErrorMessage  string `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteTracesResponse) Reset() {
+	*x = WriteTracesResponse{}
+	mi := &file_storage_v2_trace_storage_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteTracesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteTracesResponse) ProtoMessage() {}
+
+func (x *WriteTracesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_v2_trace_storage_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteTracesResponse.ProtoReflect.Descriptor instead.
+func (*WriteTracesResponse) Descriptor() ([]byte, []int) {
+	return file_storage_v2_trace_storage_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WriteTracesResponse) GetSequenceNumber() uint64 {
+	if x != nil {
+		return x.SequenceNumber
+	}
+	return 0
+}
+
+func (x *WriteTracesResponse) GetAcceptedSpans() int64 {
+	if x != nil {
+		return x.AcceptedSpans
+	}
+	return 0
+}
+
+func (x *WriteTracesResponse) GetRejectedSpans() int64 {
+	if x != nil {
+		return x.RejectedSpans
+	}
+	return 0
+}
+
+func (x *WriteTracesResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
 var File_storage_v2_trace_storage_proto protoreflect.FileDescriptor
 
 const file_storage_v2_trace_storage_proto_rawDesc = "" +
@@ -1049,14 +1183,24 @@ const file_storage_v2_trace_storage_proto_rawDesc = "" +
 	"\x05start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
 	"\x03end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"T\n" +
 	"\x14FindTraceIDsResponse\x12<\n" +
-	"\ttrace_ids\x18\x01 \x03(\v2\x1f.jaeger.storage.v2.FoundTraceIDR\btraceIds2\xf6\x03\n" +
+	"\ttrace_ids\x18\x01 \x03(\v2\x1f.jaeger.storage.v2.FoundTraceIDR\btraceIds\"\x7f\n" +
+	"\x12WriteTracesRequest\x12'\n" +
+	"\x0fsequence_number\x18\x01 \x01(\x04R\x0esequenceNumber\x12@\n" +
+	"\x06traces\x18\x02 \x01(\v2(.opentelemetry.proto.trace.v1.TracesDataR\x06traces\"\xb1\x01\n" +
+	"\x13WriteTracesResponse\x12'\n" +
+	"\x0fsequence_number\x18\x01 \x01(\x04R\x0esequenceNumber\x12%\n" +
+	"\x0eaccepted_spans\x18\x02 \x01(\x03R\racceptedSpans\x12%\n" +
+	"\x0erejected_spans\x18\x03 \x01(\x03R\rrejectedSpans\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage2\xf6\x03\n" +
 	"\vTraceReader\x12^\n" +
 	"\tGetTraces\x12#.jaeger.storage.v2.GetTracesRequest\x1a(.opentelemetry.proto.trace.v1.TracesData\"\x000\x01\x12^\n" +
 	"\vGetServices\x12%.jaeger.storage.v2.GetServicesRequest\x1a&.jaeger.storage.v2.GetServicesResponse\"\x00\x12d\n" +
 	"\rGetOperations\x12'.jaeger.storage.v2.GetOperationsRequest\x1a(.jaeger.storage.v2.GetOperationsResponse\"\x00\x12`\n" +
 	"\n" +
 	"FindTraces\x12$.jaeger.storage.v2.FindTracesRequest\x1a(.opentelemetry.proto.trace.v1.TracesData\"\x000\x01\x12_\n" +
-	"\fFindTraceIDs\x12$.jaeger.storage.v2.FindTracesRequest\x1a'.jaeger.storage.v2.FindTraceIDsResponse\"\x00B\xce\x01\n" +
+	"\fFindTraceIDs\x12$.jaeger.storage.v2.FindTracesRequest\x1a'.jaeger.storage.v2.FindTraceIDsResponse\"\x002q\n" +
+	"\vTraceWriter\x12b\n" +
+	"\vWriteTraces\x12%.jaeger.storage.v2.WriteTracesRequest\x1a&.jaeger.storage.v2.WriteTracesResponse\"\x00(\x010\x01B\xce\x01\n" +
 	"\x15com.jaeger.storage.v2B\x11TraceStorageProtoP\x01Z<github.com/jaegertracing/jaeger-idl/gen/storage/v2;storagev2\xa2\x02\x03JSX\xaa\x02\x11Jaeger.Storage.V2\xca\x02\x11Jaeger\\Storage\\V2\xe2\x02\x1dJaeger\\Storage\\V2\\GPBMetadata\xea\x02\x13Jaeger::Storage::V2b\x06proto3"
 
 var (
@@ -1071,7 +1215,7 @@ func file_storage_v2_trace_storage_proto_rawDescGZIP() []byte {
 	return file_storage_v2_trace_storage_proto_rawDescData
 }
 
-var file_storage_v2_trace_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_storage_v2_trace_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_storage_v2_trace_storage_proto_goTypes = []any{
 	(*GetTraceParams)(nil),        // 0: jaeger.storage.v2.GetTraceParams
 	(*GetTracesRequest)(nil),      // 1: jaeger.storage.v2.GetTracesRequest
@@ -1088,13 +1232,15 @@ var file_storage_v2_trace_storage_proto_goTypes = []any{
 	(*FindTracesRequest)(nil),     // 12: jaeger.storage.v2.FindTracesRequest
 	(*FoundTraceID)(nil),          // 13: jaeger.storage.v2.FoundTraceID
 	(*FindTraceIDsResponse)(nil),  // 14: jaeger.storage.v2.FindTraceIDsResponse
-	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),   // 16: google.protobuf.Duration
-	(*v1.TracesData)(nil),         // 17: opentelemetry.proto.trace.v1.TracesData
+	(*WriteTracesRequest)(nil),    // 15: jaeger.storage.v2.WriteTracesRequest
+	(*WriteTracesResponse)(nil),   // 16: jaeger.storage.v2.WriteTracesResponse
+	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 18: google.protobuf.Duration
+	(*v1.TracesData)(nil),         // 19: opentelemetry.proto.trace.v1.TracesData
 }
 var file_storage_v2_trace_storage_proto_depIdxs = []int32{
-	15, // 0: jaeger.storage.v2.GetTraceParams.start_time:type_name -> google.protobuf.Timestamp
-	15, // 1: jaeger.storage.v2.GetTraceParams.end_time:type_name -> google.protobuf.Timestamp
+	17, // 0: jaeger.storage.v2.GetTraceParams.start_time:type_name -> google.protobuf.Timestamp
+	17, // 1: jaeger.storage.v2.GetTraceParams.end_time:type_name -> google.protobuf.Timestamp
 	0,  // 2: jaeger.storage.v2.GetTracesRequest.query:type_name -> jaeger.storage.v2.GetTraceParams
 	5,  // 3: jaeger.storage.v2.GetOperationsResponse.operations:type_name -> jaeger.storage.v2.Operation
 	8,  // 4: jaeger.storage.v2.KeyValue.value:type_name -> jaeger.storage.v2.AnyValue
@@ -1103,29 +1249,32 @@ var file_storage_v2_trace_storage_proto_depIdxs = []int32{
 	7,  // 7: jaeger.storage.v2.KeyValueList.values:type_name -> jaeger.storage.v2.KeyValue
 	8,  // 8: jaeger.storage.v2.ArrayValue.values:type_name -> jaeger.storage.v2.AnyValue
 	7,  // 9: jaeger.storage.v2.TraceQueryParameters.attributes:type_name -> jaeger.storage.v2.KeyValue
-	15, // 10: jaeger.storage.v2.TraceQueryParameters.start_time_min:type_name -> google.protobuf.Timestamp
-	15, // 11: jaeger.storage.v2.TraceQueryParameters.start_time_max:type_name -> google.protobuf.Timestamp
-	16, // 12: jaeger.storage.v2.TraceQueryParameters.duration_min:type_name -> google.protobuf.Duration
-	16, // 13: jaeger.storage.v2.TraceQueryParameters.duration_max:type_name -> google.protobuf.Duration
+	17, // 10: jaeger.storage.v2.TraceQueryParameters.start_time_min:type_name -> google.protobuf.Timestamp
+	17, // 11: jaeger.storage.v2.TraceQueryParameters.start_time_max:type_name -> google.protobuf.Timestamp
+	18, // 12: jaeger.storage.v2.TraceQueryParameters.duration_min:type_name -> google.protobuf.Duration
+	18, // 13: jaeger.storage.v2.TraceQueryParameters.duration_max:type_name -> google.protobuf.Duration
 	11, // 14: jaeger.storage.v2.FindTracesRequest.query:type_name -> jaeger.storage.v2.TraceQueryParameters
-	15, // 15: jaeger.storage.v2.FoundTraceID.start:type_name -> google.protobuf.Timestamp
-	15, // 16: jaeger.storage.v2.FoundTraceID.end:type_name -> google.protobuf.Timestamp
+	17, // 15: jaeger.storage.v2.FoundTraceID.start:type_name -> google.protobuf.Timestamp
+	17, // 16: jaeger.storage.v2.FoundTraceID.end:type_name -> google.protobuf.Timestamp
 	13, // 17: jaeger.storage.v2.FindTraceIDsResponse.trace_ids:type_name -> jaeger.storage.v2.FoundTraceID
-	1,  // 18: jaeger.storage.v2.TraceReader.GetTraces:input_type -> jaeger.storage.v2.GetTracesRequest
-	2,  // 19: jaeger.storage.v2.TraceReader.GetServices:input_type -> jaeger.storage.v2.GetServicesRequest
-	4,  // 20: jaeger.storage.v2.TraceReader.GetOperations:input_type -> jaeger.storage.v2.GetOperationsRequest
-	12, // 21: jaeger.storage.v2.TraceReader.FindTraces:input_type -> jaeger.storage.v2.FindTracesRequest
-	12, // 22: jaeger.storage.v2.TraceReader.FindTraceIDs:input_type -> jaeger.storage.v2.FindTracesRequest
-	17, // 23: jaeger.storage.v2.TraceReader.GetTraces:output_type -> opentelemetry.proto.trace.v1.TracesData
-	3,  // 24: jaeger.storage.v2.TraceReader.GetServices:output_type -> jaeger.storage.v2.GetServicesResponse
-	6,  // 25: jaeger.storage.v2.TraceReader.GetOperations:output_type -> jaeger.storage.v2.GetOperationsResponse
-	17, // 26: jaeger.storage.v2.TraceReader.FindTraces:output_type -> opentelemetry.proto.trace.v1.TracesData
-	14, // 27: jaeger.storage.v2.TraceReader.FindTraceIDs:output_type -> jaeger.storage.v2.FindTraceIDsResponse
-	23, // [23:28] is the sub-list for method output_type
-	18, // [18:23] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	19, // 18: jaeger.storage.v2.WriteTracesRequest.traces:type_name -> opentelemetry.proto.trace.v1.TracesData
+	1,  // 19: jaeger.storage.v2.TraceReader.GetTraces:input_type -> jaeger.storage.v2.GetTracesRequest
+	2,  // 20: jaeger.storage.v2.TraceReader.GetServices:input_type -> jaeger.storage.v2.GetServicesRequest
+	4,  // 21: jaeger.storage.v2.TraceReader.GetOperations:input_type -> jaeger.storage.v2.GetOperationsRequest
+	12, // 22: jaeger.storage.v2.TraceReader.FindTraces:input_type -> jaeger.storage.v2.FindTracesRequest
+	12, // 23: jaeger.storage.v2.TraceReader.FindTraceIDs:input_type -> jaeger.storage.v2.FindTracesRequest
+	15, // 24: jaeger.storage.v2.TraceWriter.WriteTraces:input_type -> jaeger.storage.v2.WriteTracesRequest
+	19, // 25: jaeger.storage.v2.TraceReader.GetTraces:output_type -> opentelemetry.proto.trace.v1.TracesData
+	3,  // 26: jaeger.storage.v2.TraceReader.GetServices:output_type -> jaeger.storage.v2.GetServicesResponse
+	6,  // 27: jaeger.storage.v2.TraceReader.GetOperations:output_type -> jaeger.storage.v2.GetOperationsResponse
+	19, // 28: jaeger.storage.v2.TraceReader.FindTraces:output_type -> opentelemetry.proto.trace.v1.TracesData
+	14, // 29: jaeger.storage.v2.TraceReader.FindTraceIDs:output_type -> jaeger.storage.v2.FindTraceIDsResponse
+	16, // 30: jaeger.storage.v2.TraceWriter.WriteTraces:output_type -> jaeger.storage.v2.WriteTracesResponse
+	25, // [25:31] is the sub-list for method output_type
+	19, // [19:25] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_storage_v2_trace_storage_proto_init() }
@@ -1148,9 +1297,9 @@ func file_storage_v2_trace_storage_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_storage_v2_trace_storage_proto_rawDesc), len(file_storage_v2_trace_storage_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   17,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_storage_v2_trace_storage_proto_goTypes,
 		DependencyIndexes: file_storage_v2_trace_storage_proto_depIdxs,
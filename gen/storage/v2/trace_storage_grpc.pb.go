@@ -343,3 +343,127 @@ var TraceReader_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "storage/v2/trace_storage.proto",
 }
+
+const (
+	TraceWriter_WriteTraces_FullMethodName = "/jaeger.storage.v2.TraceWriter/WriteTraces"
+)
+
+// TraceWriterClient is the client API for TraceWriter service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TraceWriter is a service that allows writing traces to storage over a
+// long-lived stream. It is an alternative to OTEL's TraceService for
+// high-volume producers, which avoids the overhead of a unary call per batch.
+type TraceWriterClient interface {
+	// WriteTraces writes the batches of spans sent on the stream.
+	//
+	// The server MAY buffer several batches before writing them to storage and
+	// acknowledges them periodically, at least once after the client closes its
+	// side of the stream. A client SHOULD keep the batches that are not
+	// acknowledged and resend them on a new stream if the stream fails.
+	//
+	// Edge cases:
+	// - A batch with a sequence number not greater than the previous one fails
+	//   the stream with INVALID_ARGUMENT.
+	// - A batch with too many spans fails the stream with INVALID_ARGUMENT.
+	WriteTraces(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[WriteTracesRequest, WriteTracesResponse], error)
+}
+
+type traceWriterClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTraceWriterClient(cc grpc.ClientConnInterface) TraceWriterClient {
+	return &traceWriterClient{cc}
+}
+
+func (c *traceWriterClient) WriteTraces(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[WriteTracesRequest, WriteTracesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TraceWriter_ServiceDesc.Streams[0], TraceWriter_WriteTraces_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WriteTracesRequest, WriteTracesResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TraceWriter_WriteTracesClient = grpc.BidiStreamingClient[WriteTracesRequest, WriteTracesResponse]
+
+// TraceWriterServer is the server API for TraceWriter service.
+// All implementations should embed UnimplementedTraceWriterServer
+// for forward compatibility.
+//
+// TraceWriter is a service that allows writing traces to storage over a
+// long-lived stream. It is an alternative to OTEL's TraceService for
+// high-volume producers, which avoids the overhead of a unary call per batch.
+type TraceWriterServer interface {
+	// WriteTraces writes the batches of spans sent on the stream.
+	//
+	// The server MAY buffer several batches before writing them to storage and
+	// acknowledges them periodically, at least once after the client closes its
+	// side of the stream. A client SHOULD keep the batches that are not
+	// acknowledged and resend them on a new stream if the stream fails.
+	//
+	// Edge cases:
+	// - A batch with a sequence number not greater than the previous one fails
+	//   the stream with INVALID_ARGUMENT.
+	// - A batch with too many spans fails the stream with INVALID_ARGUMENT.
+	WriteTraces(grpc.BidiStreamingServer[WriteTracesRequest, WriteTracesResponse]) error
+}
+
+// UnimplementedTraceWriterServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTraceWriterServer struct{}
+
+func (UnimplementedTraceWriterServer) WriteTraces(grpc.BidiStreamingServer[WriteTracesRequest, WriteTracesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WriteTraces not implemented")
+}
+func (UnimplementedTraceWriterServer) testEmbeddedByValue() {}
+
+// UnsafeTraceWriterServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TraceWriterServer will
+// result in compilation errors.
+type UnsafeTraceWriterServer interface {
+	mustEmbedUnimplementedTraceWriterServer()
+}
+
+func RegisterTraceWriterServer(s grpc.ServiceRegistrar, srv TraceWriterServer) {
+	// If the following call pancis, it indicates UnimplementedTraceWriterServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TraceWriter_ServiceDesc, srv)
+}
+
+func _TraceWriter_WriteTraces_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TraceWriterServer).WriteTraces(&grpc.GenericServerStream[WriteTracesRequest, WriteTracesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TraceWriter_WriteTracesServer = grpc.BidiStreamingServer[WriteTracesRequest, WriteTracesResponse]
+
+// TraceWriter_ServiceDesc is the grpc.ServiceDesc for TraceWriter service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TraceWriter_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "jaeger.storage.v2.TraceWriter",
+	HandlerType: (*TraceWriterServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WriteTraces",
+			Handler:       _TraceWriter_WriteTraces_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "storage/v2/trace_storage.proto",
+}
@@ -0,0 +1,7 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fixtures loads the sample traces shared by the demo servers and
+// the tests, stored as files in the traces directory of the package, into
+// OTLP or Jaeger domain model structures.
+package fixtures
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package fixtures
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	"github.com/jaegertracing/jaeger-idl/model/converter/uijson"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+//go:embed traces
+var files embed.FS
+
+// Names returns the names of the fixture files, in lexical order.
+func Names() []string {
+	entries, _ := fs.ReadDir(files, "traces")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+// ReadTraces reads a fixture file as OTLP traces, see ParseTraces.
+func ReadTraces(name string) (*tracev1.TracesData, error) {
+	data, err := files.ReadFile(path.Join("traces", name))
+	if err != nil {
+		return nil, err
+	}
+	td, err := ParseTraces(name, data)
+	if err != nil {
+		return nil, fmt.Errorf("fixture %s: %w", name, err)
+	}
+	return td, nil
+}
+
+// ParseTraces decodes the content of a trace file as OTLP traces, by the
+// extension of its name. A .json file holds OTLP JSON or traces in the JSON
+// format of the Jaeger UI, a .pb file holds a binary OTLP TracesData.
+func ParseTraces(name string, data []byte) (*tracev1.TracesData, error) {
+	td := &tracev1.TracesData{}
+	var err error
+	switch {
+	case strings.HasSuffix(name, ".pb"):
+		err = proto.Unmarshal(data, td)
+	case strings.HasSuffix(name, ".json") && (bytes.Contains(data, []byte(`"resourceSpans"`)) || bytes.Contains(data, []byte(`"resource_spans"`))):
+		err = protojson.Unmarshal(data, td)
+	case strings.HasSuffix(name, ".json"):
+		var spans []*model.Span
+		spans, err = readUIJSON(data)
+		td = otlp.FromDomain(spans)
+	default:
+		return nil, errors.New("unknown file type, expected .json or .pb")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return td, nil
+}
+
+// ReadSpans reads a fixture file as Jaeger domain model spans.
+func ReadSpans(name string) ([]*model.Span, error) {
+	td, err := ReadTraces(name)
+	if err != nil {
+		return nil, err
+	}
+	return otlp.ToDomain(td), nil
+}
+
+// LoadTrace reads a fixture file as OTLP traces, failing the test on error.
+func LoadTrace(t testing.TB, name string) *tracev1.TracesData {
+	t.Helper()
+	td, err := ReadTraces(name)
+	require.NoError(t, err)
+	return td
+}
+
+// LoadSpans reads a fixture file as Jaeger domain model spans, failing the test on error.
+func LoadSpans(t testing.TB, name string) []*model.Span {
+	t.Helper()
+	spans, err := ReadSpans(name)
+	require.NoError(t, err)
+	return spans
+}
+
+// Rebase shifts the timestamps of the spans and their events so that the
+// earliest span starts at start, e.g. to make the fixtures recent enough
+// for the default lookback of searches.
+func Rebase(td *tracev1.TracesData, start time.Time) {
+	var earliest uint64
+	forEachSpan(td, func(span *tracev1.Span) {
+		if earliest == 0 || span.StartTimeUnixNano < earliest {
+			earliest = span.StartTimeUnixNano
+		}
+	})
+	if earliest == 0 {
+		return
+	}
+	shift := uint64(start.UnixNano()) - earliest
+	forEachSpan(td, func(span *tracev1.Span) {
+		span.StartTimeUnixNano += shift
+		span.EndTimeUnixNano += shift
+		for _, event := range span.Events {
+			event.TimeUnixNano += shift
+		}
+	})
+}
+
+func forEachSpan(td *tracev1.TracesData, fn func(span *tracev1.Span)) {
+	for _, rs := range td.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				fn(span)
+			}
+		}
+	}
+}
+
+func readUIJSON(data []byte) ([]*model.Span, error) {
+	traces, err := uijson.ParseJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return uijson.ToDomain(traces)
+}
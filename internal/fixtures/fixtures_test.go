@@ -0,0 +1,105 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package fixtures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, []string{"trace1.json", "trace2.json", "ui-error-trace.json"}, Names())
+}
+
+func TestLoadAll(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			assert.NotEmpty(t, LoadSpans(t, name))
+		})
+	}
+}
+
+func TestLoadOTLP(t *testing.T) {
+	spans := LoadSpans(t, "trace1.json")
+	require.Len(t, spans, 3)
+	traceID, err := model.TraceIDFromString("1234567890abcdef1234567890abcdef")
+	require.NoError(t, err)
+	assert.Equal(t, traceID, spans[0].TraceID)
+	assert.Equal(t, "frontend", spans[0].Process.ServiceName)
+	assert.Equal(t, "HTTP GET /api/users", spans[0].OperationName)
+	assert.Equal(t, 120*time.Millisecond, spans[0].Duration)
+	assert.Equal(t, spans[1].SpanID, spans[2].ParentSpanID())
+}
+
+func TestSampleTracesDataModel(t *testing.T) {
+	first := LoadSpans(t, "trace1.json")
+	second := LoadSpans(t, "trace2.json")
+	require.Len(t, first, 3)
+	require.Len(t, second, 2)
+
+	// the first trace links to the root span of the second one
+	require.Len(t, first[0].References, 1)
+	assert.Equal(t, model.NewFollowsFromRef(second[0].TraceID, second[0].SpanID), first[0].References[0])
+	assert.Len(t, first[1].Logs, 1)
+	assert.Len(t, first[2].Logs, 1)
+	assert.Equal(t, []string{"clock skew adjusted by 2ms"}, first[2].Warnings)
+
+	errorTag, ok := model.KeyValues(second[1].Tags).FindByKey("error")
+	require.True(t, ok)
+	assert.True(t, errorTag.Bool())
+	require.Len(t, second[1].Logs, 1)
+	assert.Equal(t, "exception", second[1].Logs[0].Fields[0].AsString())
+}
+
+func TestLoadUIJSON(t *testing.T) {
+	td := LoadTrace(t, "ui-error-trace.json")
+	require.Len(t, td.ResourceSpans, 2)
+	spans := LoadSpans(t, "ui-error-trace.json")
+	require.Len(t, spans, 2)
+	assert.Equal(t, "checkout", spans[0].Process.ServiceName)
+	assert.Len(t, spans[0].Logs, 1)
+	assert.Equal(t, spans[0].SpanID, spans[1].ParentSpanID())
+}
+
+func TestReadTracesErrors(t *testing.T) {
+	_, err := ReadTraces("missing.json")
+	require.ErrorContains(t, err, "missing.json")
+	_, err = ReadTraces("trace1.txt")
+	require.Error(t, err)
+	_, err = ReadSpans("missing.pb")
+	require.Error(t, err)
+}
+
+func TestRebase(t *testing.T) {
+	td := LoadTrace(t, "trace1.json")
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	Rebase(td, start)
+	spans := LoadSpans(t, "trace1.json")
+	root := td.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	assert.Equal(t, uint64(start.UnixNano()), root.StartTimeUnixNano)
+	assert.Equal(t, uint64(start.Add(spans[0].Duration).UnixNano()), root.EndTimeUnixNano)
+	child := td.ResourceSpans[2].ScopeSpans[0].Spans[0]
+	assert.Equal(t, uint64(start.Add(spans[2].StartTime.Sub(spans[0].StartTime)).UnixNano()), child.StartTimeUnixNano)
+}
+
+func TestParseTraces(t *testing.T) {
+	b, err := proto.Marshal(LoadTrace(t, "trace2.json"))
+	require.NoError(t, err)
+	td, err := ParseTraces("trace2.pb", b)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(LoadTrace(t, "trace2.json"), td))
+
+	_, err = ParseTraces("trace.json", []byte(`{"resourceSpans": 1}`))
+	require.Error(t, err)
+	_, err = ParseTraces("trace.json", []byte(`{"data": 1}`))
+	require.Error(t, err)
+	_, err = ParseTraces("trace.txt", nil)
+	require.ErrorContains(t, err, "unknown file type")
+}
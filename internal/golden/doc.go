@@ -0,0 +1,17 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package golden compares the output of the tests with golden files, to lock
+// down output formats such as those of the converters. The api_v2, api_v3
+// and OTLP messages are compared in their canonical JSON: the protojson
+// encoding, indented with two spaces.
+//
+// The golden files are in the testdata/golden directory of the package under
+// test, and are committed along with the code, so that the changes of the
+// output formats show up in the reviews. Running the tests with the -update
+// flag writes the actual output to the golden files instead of comparing it.
+// The flag is only defined in the packages that use golden, so that they
+// have to be listed:
+//
+//	go test ./model/converter/otlp ./model/converter/zipkin -update
+package golden
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jaegertracing/jaeger-idl/model/converter/apiv2"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// Dir is the directory of the golden files, relative to the directory of
+// the package under test.
+const Dir = "testdata/golden"
+
+var update = flag.Bool("update", false, "write the actual output to the golden files instead of comparing it")
+
+// Marshal returns the canonical JSON of msg.
+func Marshal(msg proto.Message) ([]byte, error) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return indent(data)
+}
+
+// indent returns data indented with two spaces, with a final newline. It
+// also normalizes the spacing of protojson, which varies on purpose.
+func indent(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// Assert compares the canonical JSON of msg with the golden file name.
+func Assert(t testing.TB, name string, msg proto.Message) {
+	t.Helper()
+	data, err := Marshal(msg)
+	require.NoError(t, err)
+	compare(t, name, data)
+}
+
+// AssertJSON compares the JSON encoding of v, indented, with the golden file
+// name, for the outputs that are not proto messages.
+func AssertJSON(t testing.TB, name string, v any) {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	data, err = indent(data)
+	require.NoError(t, err)
+	compare(t, name, data)
+}
+
+// AssertSpans compares the domain model spans with the golden file name, as
+// an api_v2 trace.
+func AssertSpans(t testing.TB, name string, spans []*model.Span) {
+	t.Helper()
+	trace, err := apiv2.TraceToProto(&model.Trace{Spans: spans})
+	require.NoError(t, err)
+	Assert(t, name, trace)
+}
+
+func compare(t testing.TB, name string, actual []byte) {
+	t.Helper()
+	path := filepath.Join(Dir, name+".json")
+	if *update {
+		require.NoError(t, os.MkdirAll(Dir, 0o755))
+		require.NoError(t, os.WriteFile(path, actual, 0o644))
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		require.Failf(t, "missing golden file", "%s does not exist, run the test with -update to create it", path)
+		return
+	}
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual), "the output differs from %s, run the test with -update to update it if the change is intended", path)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package golden
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// recorder records the failures of a test instead of failing it.
+type recorder struct {
+	testing.TB
+
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) FailNow() {}
+
+func TestMarshal(t *testing.T) {
+	data, err := Marshal(&commonv1.KeyValue{
+		Key:   "http.method",
+		Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: "GET"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "key": "http.method",
+  "value": {
+    "stringValue": "GET"
+  }
+}
+`, string(data))
+}
+
+func TestAssert(t *testing.T) {
+	t.Chdir(t.TempDir())
+	msg := &commonv1.KeyValue{Key: "k"}
+
+	// a missing golden file fails
+	r := &recorder{TB: t}
+	Assert(r, "kv", msg)
+	require.Len(t, r.failures, 1)
+	assert.Contains(t, r.failures[0], "-update")
+
+	*update = true
+	Assert(t, "kv", msg)
+	AssertJSON(t, "json", map[string]int{"a": 1})
+	AssertSpans(t, "spans", []*model.Span{{
+		TraceID:       model.NewTraceID(1, 2),
+		SpanID:        model.NewSpanID(3),
+		OperationName: "GET /users",
+		StartTime:     time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC),
+		Duration:      time.Second,
+		Process:       model.NewProcess("frontend", nil),
+	}})
+	*update = false
+	data, err := os.ReadFile(filepath.Join(Dir, "json.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"a\": 1\n}\n", string(data))
+
+	Assert(t, "kv", msg)
+	AssertSpans(t, "spans", []*model.Span{{
+		TraceID:       model.NewTraceID(1, 2),
+		SpanID:        model.NewSpanID(3),
+		OperationName: "GET /users",
+		StartTime:     time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC),
+		Duration:      time.Second,
+		Process:       model.NewProcess("frontend", nil),
+	}})
+
+	// a different output fails
+	r = &recorder{TB: t}
+	Assert(r, "kv", &commonv1.KeyValue{Key: "other"})
+	require.Len(t, r.failures, 1)
+	assert.Contains(t, r.failures[0], filepath.Join(Dir, "kv.json"))
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package comparator diffs the structure of two traces, e.g. to compare
+// the same request before and after a deployment, and the latency and error
+// rate of operations between two time windows.
+package comparator
+
+import (
+	"time"
+
+	"github.com/jaegertracing/jaeger-idl/model/trace"
+)
+
+// Status describes how a node of the diff relates the two traces.
+type Status string
+
+const (
+	// Matched nodes exist in both traces.
+	Matched Status = "matched"
+	// Added nodes exist only in the second trace.
+	Added Status = "added"
+	// Removed nodes exist only in the first trace.
+	Removed Status = "removed"
+)
+
+// Node is a node of the operation tree present in either or both traces.
+type Node struct {
+	Service   string
+	Operation string
+	Status    Status
+	// DurationA and DurationB are the span durations in the first and second
+	// trace respectively, zero when the span is absent from that trace.
+	DurationA time.Duration
+	DurationB time.Duration
+	Children  []*Node
+}
+
+// Delta returns the change of duration from the first to the second trace.
+func (n *Node) Delta() time.Duration {
+	return n.DurationB - n.DurationA
+}
+
+// Diff is the structural difference between two traces.
+type Diff struct {
+	Roots   []*Node
+	Matched int
+	Added   int
+	Removed int
+}
+
+// Compare diffs two traces by their operation trees.
+//
+// Spans are matched by (service, operation) among the children of matched
+// parents: the n-th child with a given key in the first trace is matched to
+// the n-th child with the same key in the second trace. Unmatched spans are
+// reported, together with their whole subtree, as removed or added.
+func Compare(a, b *trace.Tree) *Diff {
+	d := &Diff{}
+	d.Roots = d.compareNodes(a.Roots, b.Roots)
+	return d
+}
+
+type nodeKey struct {
+	service   string
+	operation string
+}
+
+func keyOf(n *trace.Node) nodeKey {
+	key := nodeKey{operation: n.Span.OperationName}
+	if n.Span.Process != nil {
+		key.service = n.Span.Process.ServiceName
+	}
+	return key
+}
+
+func (d *Diff) compareNodes(as, bs []*trace.Node) []*Node {
+	unmatched := make(map[nodeKey][]*trace.Node)
+	for _, b := range bs {
+		key := keyOf(b)
+		unmatched[key] = append(unmatched[key], b)
+	}
+	matched := make(map[*trace.Node]bool, len(bs))
+
+	var result []*Node
+	for _, a := range as {
+		key := keyOf(a)
+		candidates := unmatched[key]
+		if len(candidates) == 0 {
+			result = append(result, d.subtree(a, Removed))
+			continue
+		}
+		b := candidates[0]
+		unmatched[key] = candidates[1:]
+		matched[b] = true
+		d.Matched++
+		result = append(result, &Node{
+			Service:   key.service,
+			Operation: key.operation,
+			Status:    Matched,
+			DurationA: a.Span.Duration,
+			DurationB: b.Span.Duration,
+			Children:  d.compareNodes(a.Children, b.Children),
+		})
+	}
+	for _, b := range bs {
+		if !matched[b] {
+			result = append(result, d.subtree(b, Added))
+		}
+	}
+	return result
+}
+
+// subtree converts a subtree present in only one of the traces.
+func (d *Diff) subtree(n *trace.Node, status Status) *Node {
+	key := keyOf(n)
+	node := &Node{Service: key.service, Operation: key.operation, Status: status}
+	if status == Added {
+		d.Added++
+		node.DurationB = n.Span.Duration
+	} else {
+		d.Removed++
+		node.DurationA = n.Span.Duration
+	}
+	for _, child := range n.Children {
+		node.Children = append(node.Children, d.subtree(child, status))
+	}
+	return node
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package comparator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/model/trace"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+var testTraceID = model.NewTraceID(1, 2)
+
+func newSpan(id, parent uint64, service, operation string, duration time.Duration) *model.Span {
+	return &model.Span{
+		TraceID:       testTraceID,
+		SpanID:        model.NewSpanID(id),
+		OperationName: operation,
+		StartTime:     time.Unix(0, int64(id)),
+		Duration:      duration,
+		Process:       &model.Process{ServiceName: service},
+		References:    model.MaybeAddParentSpanID(testTraceID, model.NewSpanID(parent), nil),
+	}
+}
+
+func TestCompare(t *testing.T) {
+	ms := time.Millisecond
+	before := trace.NewTree([]*model.Span{
+		newSpan(1, 0, "frontend", "GET /users", 100*ms),
+		newSpan(2, 1, "auth", "authenticate", 40*ms),
+		newSpan(3, 1, "db", "SELECT", 10*ms),
+		newSpan(4, 1, "db", "SELECT", 10*ms),
+		newSpan(5, 2, "cache", "get", 5*ms),
+	})
+	after := trace.NewTree([]*model.Span{
+		newSpan(1, 0, "frontend", "GET /users", 150*ms),
+		newSpan(2, 1, "auth", "authenticate", 30*ms),
+		newSpan(3, 1, "db", "SELECT", 60*ms),
+		newSpan(4, 1, "search", "query", 20*ms),
+	})
+
+	diff := Compare(before, after)
+	assert.Equal(t, 3, diff.Matched)
+	assert.Equal(t, 1, diff.Added)
+	assert.Equal(t, 2, diff.Removed)
+
+	require.Len(t, diff.Roots, 1)
+	root := diff.Roots[0]
+	assert.Equal(t, Matched, root.Status)
+	assert.Equal(t, 50*ms, root.Delta())
+
+	expected := []*Node{
+		{
+			Service: "auth", Operation: "authenticate", Status: Matched,
+			DurationA: 40 * ms, DurationB: 30 * ms,
+			Children: []*Node{
+				{Service: "cache", Operation: "get", Status: Removed, DurationA: 5 * ms},
+			},
+		},
+		{Service: "db", Operation: "SELECT", Status: Matched, DurationA: 10 * ms, DurationB: 60 * ms},
+		{Service: "db", Operation: "SELECT", Status: Removed, DurationA: 10 * ms},
+		{Service: "search", Operation: "query", Status: Added, DurationB: 20 * ms},
+	}
+	assert.Equal(t, expected, root.Children)
+	assert.Equal(t, -10*ms, root.Children[0].Delta())
+}
+
+func TestCompareEmpty(t *testing.T) {
+	diff := Compare(trace.NewTree(nil), trace.NewTree([]*model.Span{
+		{SpanID: 1, OperationName: "orphan-process"},
+	}))
+	require.Len(t, diff.Roots, 1)
+	assert.Equal(t, Added, diff.Roots[0].Status)
+	assert.Empty(t, diff.Roots[0].Service)
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package comparator
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// MinSamples is the number of spans an operation needs in each window for
+// its differences to be tested, below it the p-values are 1.
+const MinSamples = 8
+
+// Operation identifies the spans of an operation of a service.
+type Operation struct {
+	Service   string
+	Operation string
+}
+
+// Sample is the outcome of the spans of an operation in a time window.
+type Sample struct {
+	Durations []time.Duration
+	Errors    int
+}
+
+// Window collects the spans of each operation in a time window.
+type Window map[Operation]*Sample
+
+// Add records a span of the operation.
+func (w Window) Add(service, operation string, duration time.Duration, isError bool) {
+	key := Operation{Service: service, Operation: operation}
+	s, ok := w[key]
+	if !ok {
+		s = &Sample{}
+		w[key] = s
+	}
+	s.Durations = append(s.Durations, duration)
+	if isError {
+		s.Errors++
+	}
+}
+
+// LatencyStats summarizes the spans of an operation in a time window.
+type LatencyStats struct {
+	Count     int
+	Errors    int
+	ErrorRate float64
+	P50       time.Duration
+	P95       time.Duration
+	P99       time.Duration
+}
+
+// OperationDelta compares an operation between two time windows.
+type OperationDelta struct {
+	Operation
+	Before LatencyStats
+	After  LatencyStats
+	// LatencyPValue is the two-sided p-value of the Mann-Whitney U test of
+	// the hypothesis that the latencies of both windows have the same
+	// distribution.
+	LatencyPValue float64
+	// ErrorRatePValue is the two-sided p-value of the two-proportion z-test
+	// of the hypothesis that both windows have the same error rate.
+	ErrorRatePValue float64
+}
+
+// CompareWindows compares the operations found in either window, sorted by
+// service and operation.
+func CompareWindows(before, after Window) []OperationDelta {
+	keys := make(map[Operation]bool)
+	for key := range before {
+		keys[key] = true
+	}
+	for key := range after {
+		keys[key] = true
+	}
+	deltas := make([]OperationDelta, 0, len(keys))
+	for key := range keys {
+		b, a := before[key], after[key]
+		if b == nil {
+			b = &Sample{}
+		}
+		if a == nil {
+			a = &Sample{}
+		}
+		d := OperationDelta{
+			Operation:       key,
+			Before:          b.Stats(),
+			After:           a.Stats(),
+			LatencyPValue:   1,
+			ErrorRatePValue: 1,
+		}
+		if len(b.Durations) >= MinSamples && len(a.Durations) >= MinSamples {
+			d.LatencyPValue = mannWhitneyPValue(b.Durations, a.Durations)
+			d.ErrorRatePValue = proportionPValue(b.Errors, len(b.Durations), a.Errors, len(a.Durations))
+		}
+		deltas = append(deltas, d)
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		if deltas[i].Service != deltas[j].Service {
+			return deltas[i].Service < deltas[j].Service
+		}
+		return deltas[i].Operation.Operation < deltas[j].Operation.Operation
+	})
+	return deltas
+}
+
+// Stats summarizes the sample.
+func (s *Sample) Stats() LatencyStats {
+	stats := LatencyStats{Count: len(s.Durations), Errors: s.Errors}
+	if stats.Count == 0 {
+		return stats
+	}
+	sorted := slices.Clone(s.Durations)
+	slices.Sort(sorted)
+	stats.ErrorRate = float64(s.Errors) / float64(stats.Count)
+	stats.P50 = Percentile(sorted, 50)
+	stats.P95 = Percentile(sorted, 95)
+	stats.P99 = Percentile(sorted, 99)
+	return stats
+}
+
+// Percentile returns the p-th percentile of sorted, using the nearest-rank
+// method. sorted must not be empty.
+func Percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// mannWhitneyPValue returns the p-value of the Mann-Whitney U test, using
+// the normal approximation with tie and continuity corrections.
+func mannWhitneyPValue(x, y []time.Duration) float64 {
+	type value struct {
+		d     time.Duration
+		fromX bool
+	}
+	values := make([]value, 0, len(x)+len(y))
+	for _, d := range x {
+		values = append(values, value{d, true})
+	}
+	for _, d := range y {
+		values = append(values, value{d, false})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].d < values[j].d })
+
+	// sum the ranks of x, ties getting the average of their ranks
+	var rankSumX, tieTerm float64
+	for i := 0; i < len(values); {
+		j := i
+		for j < len(values) && values[j].d == values[i].d {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if values[k].fromX {
+				rankSumX += rank
+			}
+		}
+		t := float64(j - i)
+		tieTerm += t*t*t - t
+		i = j
+	}
+
+	n1, n2 := float64(len(x)), float64(len(y))
+	n := n1 + n2
+	u := rankSumX - n1*(n1+1)/2
+	mean := n1 * n2 / 2
+	variance := n1 * n2 / 12 * ((n + 1) - tieTerm/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+	z := math.Max(math.Abs(u-mean)-0.5, 0) / math.Sqrt(variance)
+	return math.Erfc(z / math.Sqrt2)
+}
+
+// proportionPValue returns the p-value of the pooled two-proportion z-test
+// of k1 out of n1 against k2 out of n2.
+func proportionPValue(k1, n1, k2, n2 int) float64 {
+	p1, p2 := float64(k1)/float64(n1), float64(k2)/float64(n2)
+	pooled := float64(k1+k2) / float64(n1+n2)
+	se := math.Sqrt(pooled * (1 - pooled) * (1/float64(n1) + 1/float64(n2)))
+	if se == 0 {
+		return 1
+	}
+	return math.Erfc(math.Abs(p1-p2) / se / math.Sqrt2)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package comparator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareWindows(t *testing.T) {
+	ms := time.Millisecond
+	before, after := make(Window), make(Window)
+	for i := range 20 {
+		before.Add("api", "GET /users", time.Duration(10+i%5)*ms, false)
+		after.Add("api", "GET /users", time.Duration(20+i%5)*ms, i%2 == 0)
+		before.Add("api", "GET /health", ms, false)
+		after.Add("api", "GET /health", ms, false)
+	}
+	before.Add("db", "SELECT", ms, true)
+	after.Add("cache", "GET", ms, false)
+
+	deltas := CompareWindows(before, after)
+	require.Len(t, deltas, 4)
+	assert.Equal(t, []Operation{
+		{"api", "GET /health"},
+		{"api", "GET /users"},
+		{"cache", "GET"},
+		{"db", "SELECT"},
+	}, []Operation{deltas[0].Operation, deltas[1].Operation, deltas[2].Operation, deltas[3].Operation})
+
+	health := deltas[0]
+	assert.Equal(t, 1.0, health.LatencyPValue)
+	assert.Equal(t, 1.0, health.ErrorRatePValue)
+
+	users := deltas[1]
+	assert.Equal(t, LatencyStats{Count: 20, P50: 12 * ms, P95: 14 * ms, P99: 14 * ms}, users.Before)
+	assert.Equal(t, LatencyStats{Count: 20, Errors: 10, ErrorRate: 0.5, P50: 22 * ms, P95: 24 * ms, P99: 24 * ms}, users.After)
+	assert.Less(t, users.LatencyPValue, 0.001)
+	assert.Less(t, users.ErrorRatePValue, 0.001)
+
+	// too few spans to be tested
+	for _, d := range deltas[2:] {
+		assert.Equal(t, 1.0, d.LatencyPValue, d.Operation)
+		assert.Equal(t, 1.0, d.ErrorRatePValue, d.Operation)
+	}
+	assert.Zero(t, deltas[2].Before.Count)
+	assert.Equal(t, 1.0, deltas[3].Before.ErrorRate)
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 10)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+	assert.Equal(t, 5*time.Millisecond, Percentile(sorted, 50))
+	assert.Equal(t, 10*time.Millisecond, Percentile(sorted, 95))
+	assert.Equal(t, time.Millisecond, Percentile(sorted, 0))
+	assert.Equal(t, time.Millisecond, Percentile(sorted[:1], 99))
+}
+
+func TestMannWhitneyPValue(t *testing.T) {
+	var x, y []time.Duration
+	for i := range 8 {
+		x = append(x, time.Duration(i+1))
+		y = append(y, time.Duration(i+9))
+	}
+	// U = 0, z = (32 - 0.5) / sqrt(64 * 17 / 12)
+	assert.InDelta(t, 0.00094, mannWhitneyPValue(x, y), 1e-5)
+	assert.InDelta(t, 0.00094, mannWhitneyPValue(y, x), 1e-5)
+	assert.Equal(t, 1.0, mannWhitneyPValue(x, x))
+}
+
+func TestProportionPValue(t *testing.T) {
+	// z = 0.2 / sqrt(0.2 * 0.8 * 2 / 100) = 3.54
+	assert.InDelta(t, 0.0004, proportionPValue(10, 100, 30, 100), 1e-4)
+	assert.Equal(t, 1.0, proportionPValue(0, 10, 0, 20))
+	assert.Equal(t, 1.0, proportionPValue(5, 10, 10, 20))
+}
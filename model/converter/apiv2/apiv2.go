@@ -0,0 +1,152 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apiv2
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// SpansToProto converts domain model spans into api_v2 spans.
+func SpansToProto(spans []*model.Span) ([]*api_v2.Span, error) {
+	result := make([]*api_v2.Span, 0, len(spans))
+	for _, span := range spans {
+		protoSpan, err := SpanToProto(span)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, protoSpan)
+	}
+	return result, nil
+}
+
+// SpanToProto converts a domain model span into an api_v2 span.
+func SpanToProto(span *model.Span) (*api_v2.Span, error) {
+	protoSpan := &api_v2.Span{}
+	if err := toProto(span, protoSpan); err != nil {
+		return nil, fmt.Errorf("cannot convert span %s: %w", span.SpanID, err)
+	}
+	return protoSpan, nil
+}
+
+// SpansFromProto converts api_v2 spans into domain model spans.
+func SpansFromProto(spans []*api_v2.Span) ([]*model.Span, error) {
+	result := make([]*model.Span, 0, len(spans))
+	for _, protoSpan := range spans {
+		span, err := SpanFromProto(protoSpan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, span)
+	}
+	return result, nil
+}
+
+// SpanFromProto converts an api_v2 span into a domain model span.
+func SpanFromProto(protoSpan *api_v2.Span) (*model.Span, error) {
+	span := &model.Span{}
+	if err := fromProto(protoSpan, span); err != nil {
+		return nil, fmt.Errorf("cannot convert span: %w", err)
+	}
+	return span, nil
+}
+
+// BatchToProto converts a domain model batch into an api_v2 batch.
+func BatchToProto(batch *model.Batch) (*api_v2.Batch, error) {
+	protoBatch := &api_v2.Batch{}
+	if err := toProto(batch, protoBatch); err != nil {
+		return nil, fmt.Errorf("cannot convert batch: %w", err)
+	}
+	return protoBatch, nil
+}
+
+// BatchFromProto converts an api_v2 batch into a domain model batch.
+func BatchFromProto(protoBatch *api_v2.Batch) (*model.Batch, error) {
+	batch := &model.Batch{}
+	if err := fromProto(protoBatch, batch); err != nil {
+		return nil, fmt.Errorf("cannot convert batch: %w", err)
+	}
+	return batch, nil
+}
+
+// TraceToProto converts a domain model trace into an api_v2 trace.
+func TraceToProto(trace *model.Trace) (*api_v2.Trace, error) {
+	protoTrace := &api_v2.Trace{}
+	if err := toProto(trace, protoTrace); err != nil {
+		return nil, fmt.Errorf("cannot convert trace: %w", err)
+	}
+	return protoTrace, nil
+}
+
+// TraceFromProto converts an api_v2 trace into a domain model trace.
+func TraceFromProto(protoTrace *api_v2.Trace) (*model.Trace, error) {
+	trace := &model.Trace{}
+	if err := fromProto(protoTrace, trace); err != nil {
+		return nil, fmt.Errorf("cannot convert trace: %w", err)
+	}
+	return trace, nil
+}
+
+// DependencyLinksToProto converts domain model dependency links into api_v2
+// dependency links.
+func DependencyLinksToProto(links []model.DependencyLink) ([]*api_v2.DependencyLink, error) {
+	result := make([]*api_v2.DependencyLink, 0, len(links))
+	for i := range links {
+		protoLink := &api_v2.DependencyLink{}
+		if err := toProto(&links[i], protoLink); err != nil {
+			return nil, fmt.Errorf("cannot convert dependency link %s -> %s: %w", links[i].Parent, links[i].Child, err)
+		}
+		result = append(result, protoLink)
+	}
+	return result, nil
+}
+
+// DependencyLinksFromProto converts api_v2 dependency links into domain
+// model dependency links.
+func DependencyLinksFromProto(protoLinks []*api_v2.DependencyLink) ([]model.DependencyLink, error) {
+	result := make([]model.DependencyLink, len(protoLinks))
+	for i, protoLink := range protoLinks {
+		if err := fromProto(protoLink, &result[i]); err != nil {
+			return nil, fmt.Errorf("cannot convert dependency link %s -> %s: %w", protoLink.GetParent(), protoLink.GetChild(), err)
+		}
+	}
+	return result, nil
+}
+
+// gogoMessage is a domain model message with the marshalers generated by
+// gogo/protobuf.
+type gogoMessage interface {
+	Marshal() ([]byte, error)
+	Unmarshal(data []byte) error
+}
+
+// toProto converts m into the api_v2 message p generated from the same
+// message of model.proto.
+func toProto(m gogoMessage, p proto.Message) error {
+	data, err := m.Marshal()
+	if err != nil {
+		return fmt.Errorf("cannot encode: %w", err)
+	}
+	if err := proto.Unmarshal(data, p); err != nil {
+		return fmt.Errorf("cannot decode: %w", err)
+	}
+	return nil
+}
+
+// fromProto converts the api_v2 message p into m generated from the same
+// message of model.proto.
+func fromProto(p proto.Message, m gogoMessage) error {
+	data, err := proto.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("cannot encode: %w", err)
+	}
+	if err := m.Unmarshal(data); err != nil {
+		return fmt.Errorf("cannot decode: %w", err)
+	}
+	return nil
+}
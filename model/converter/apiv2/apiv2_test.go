@@ -0,0 +1,121 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apiv2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+func TestSpanRoundTrip(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	traceID := model.NewTraceID(0x0102030405060708, 0x090a0b0c0d0e0f10)
+	span := &model.Span{
+		TraceID:       traceID,
+		SpanID:        model.NewSpanID(2),
+		OperationName: "get /users",
+		References:    []model.SpanRef{model.NewChildOfRef(traceID, model.NewSpanID(1))},
+		Flags:         model.Flags(1),
+		StartTime:     start,
+		Duration:      1500 * time.Microsecond,
+		Tags:          []model.KeyValue{model.String("http.method", "GET"), model.Int64("http.status_code", 200)},
+		Logs: []model.Log{{
+			Timestamp: start.Add(time.Millisecond),
+			Fields:    []model.KeyValue{model.String("event", "retry")},
+		}},
+		Process:  model.NewProcess("frontend", []model.KeyValue{model.String("hostname", "host-1")}),
+		Warnings: []string{"clock skew"},
+	}
+
+	protoSpans, err := SpansToProto([]*model.Span{span})
+	require.NoError(t, err)
+	require.Len(t, protoSpans, 1)
+	protoSpan := protoSpans[0]
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, protoSpan.TraceId)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 2}, protoSpan.SpanId)
+	assert.Equal(t, "get /users", protoSpan.OperationName)
+	assert.Equal(t, start, protoSpan.StartTime.AsTime())
+	assert.Equal(t, 1500*time.Microsecond, protoSpan.Duration.AsDuration())
+	assert.Equal(t, api_v2.SpanRefType_CHILD_OF, protoSpan.References[0].RefType)
+	assert.Equal(t, "frontend", protoSpan.Process.ServiceName)
+	assert.Equal(t, int64(200), protoSpan.Tags[1].VInt64)
+
+	spans, err := SpansFromProto(protoSpans)
+	require.NoError(t, err)
+	require.Len(t, spans, 1)
+	assert.Equal(t, span, spans[0])
+}
+
+func TestSpanFromProtoInvalidID(t *testing.T) {
+	_, err := SpanFromProto(&api_v2.Span{TraceId: []byte{1, 2, 3}})
+	require.Error(t, err)
+}
+
+func TestBatchRoundTrip(t *testing.T) {
+	batch := &model.Batch{
+		Spans: []*model.Span{{
+			TraceID:       model.NewTraceID(0, 1),
+			SpanID:        model.NewSpanID(2),
+			OperationName: "get /users",
+			StartTime:     time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC),
+		}},
+		Process: model.NewProcess("frontend", []model.KeyValue{model.String("hostname", "host-1")}),
+	}
+
+	protoBatch, err := BatchToProto(batch)
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", protoBatch.Process.ServiceName)
+	assert.Equal(t, "get /users", protoBatch.Spans[0].OperationName)
+
+	got, err := BatchFromProto(protoBatch)
+	require.NoError(t, err)
+	assert.Equal(t, batch, got)
+}
+
+func TestTraceRoundTrip(t *testing.T) {
+	trace := &model.Trace{
+		Spans: []*model.Span{{
+			TraceID:   model.NewTraceID(0, 1),
+			SpanID:    model.NewSpanID(2),
+			ProcessID: "p1",
+		}},
+		ProcessMap: []model.Trace_ProcessMapping{{ProcessID: "p1", Process: *model.NewProcess("frontend", nil)}},
+		Warnings:   []string{"clock skew"},
+	}
+
+	protoTrace, err := TraceToProto(trace)
+	require.NoError(t, err)
+	assert.Equal(t, "p1", protoTrace.ProcessMap[0].ProcessId)
+	assert.Equal(t, "frontend", protoTrace.ProcessMap[0].Process.ServiceName)
+
+	got, err := TraceFromProto(protoTrace)
+	require.NoError(t, err)
+	assert.Equal(t, trace, got)
+
+	_, err = TraceFromProto(&api_v2.Trace{Spans: []*api_v2.Span{{SpanId: []byte{1}}}})
+	require.Error(t, err)
+}
+
+func TestDependencyLinksRoundTrip(t *testing.T) {
+	links := []model.DependencyLink{
+		{Parent: "frontend", Child: "backend", CallCount: 10, Source: "jaeger"},
+		{Parent: "backend", Child: "db", CallCount: 3},
+	}
+
+	protoLinks, err := DependencyLinksToProto(links)
+	require.NoError(t, err)
+	require.Len(t, protoLinks, 2)
+	assert.Equal(t, "backend", protoLinks[0].Child)
+	assert.Equal(t, uint64(10), protoLinks[0].CallCount)
+
+	got, err := DependencyLinksFromProto(protoLinks)
+	require.NoError(t, err)
+	assert.Equal(t, links, got)
+}
@@ -0,0 +1,9 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package apiv2 converts between the Jaeger domain model and the api_v2
+// protobuf types generated with protoc-gen-go. Both are generated from
+// model.proto, so spans, batches, traces and dependency links are converted
+// through their wire encoding. These shims let code using the gogo/protobuf
+// domain model exchange messages with the api_v2 gRPC stubs.
+package apiv2
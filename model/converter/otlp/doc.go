@@ -0,0 +1,6 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package otlp converts between OpenTelemetry OTLP trace data
+// and the Jaeger domain model.
+package otlp
@@ -0,0 +1,271 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"time"
+
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// FromDomain converts Jaeger domain model spans into OTLP traces data.
+// Spans are grouped by process and instrumentation scope, and the tags that
+// ToDomain derives from OTLP fields (span kind, status, scope, trace state)
+// are mapped back to those fields.
+//
+// The first child-of reference within the trace becomes the parent span ID,
+// all other references become links. Span warnings are stored in the
+// WarningsKey attribute.
+func FromDomain(spans []*model.Span) *tracev1.TracesData {
+	td := &tracev1.TracesData{}
+	resources := make(map[uint64][]*resourceGroup)
+	for _, span := range spans {
+		process := span.Process
+		if process == nil {
+			process = &model.Process{}
+		}
+		group := findResourceGroup(resources, process)
+		if group == nil {
+			group = &resourceGroup{
+				process:       process,
+				resourceSpans: &tracev1.ResourceSpans{Resource: ProcessToResource(process)},
+				scopes:        make(map[scopeKey]*tracev1.ScopeSpans),
+			}
+			hash, _ := model.HashCode(process)
+			resources[hash] = append(resources[hash], group)
+			td.ResourceSpans = append(td.ResourceSpans, group.resourceSpans)
+		}
+		otlpSpan, scope := SpanFromDomain(span)
+		key := scopeKey{name: scope.GetName(), version: scope.GetVersion()}
+		ss, ok := group.scopes[key]
+		if !ok {
+			ss = &tracev1.ScopeSpans{Scope: scope}
+			group.scopes[key] = ss
+			group.resourceSpans.ScopeSpans = append(group.resourceSpans.ScopeSpans, ss)
+		}
+		ss.Spans = append(ss.Spans, otlpSpan)
+	}
+	return td
+}
+
+type resourceGroup struct {
+	process       *model.Process
+	resourceSpans *tracev1.ResourceSpans
+	scopes        map[scopeKey]*tracev1.ScopeSpans
+}
+
+type scopeKey struct {
+	name    string
+	version string
+}
+
+func findResourceGroup(resources map[uint64][]*resourceGroup, process *model.Process) *resourceGroup {
+	hash, _ := model.HashCode(process)
+	for _, group := range resources[hash] {
+		if group.process == process || group.process.Equal(process) {
+			return group
+		}
+	}
+	return nil
+}
+
+// ProcessToResource converts a Jaeger process into an OTLP resource.
+// The service name becomes the service.name attribute, unless it is NoServiceName.
+func ProcessToResource(process *model.Process) *resourcev1.Resource {
+	resource := &resourcev1.Resource{}
+	if name := process.GetServiceName(); name != "" && name != NoServiceName {
+		resource.Attributes = append(resource.Attributes, &commonv1.KeyValue{
+			Key:   ServiceNameKey,
+			Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: name}},
+		})
+	}
+	resource.Attributes = append(resource.Attributes, TagsToAttributes(process.GetTags())...)
+	return resource
+}
+
+// SpanFromDomain converts a single Jaeger domain model span into an OTLP span
+// and the instrumentation scope recorded in its tags, which may be nil.
+func SpanFromDomain(span *model.Span) (*tracev1.Span, *commonv1.InstrumentationScope) {
+	otlpSpan := &tracev1.Span{
+		TraceId: traceIDToBytes(span.TraceID),
+		SpanId:  spanIDToBytes(span.SpanID),
+		Name:    span.OperationName,
+		Flags:   uint32(span.Flags),
+		Events:  logsToEvents(span.Logs),
+	}
+	if !span.StartTime.IsZero() {
+		otlpSpan.StartTimeUnixNano = timeToUnixNano(span.StartTime)
+		otlpSpan.EndTimeUnixNano = timeToUnixNano(span.StartTime.Add(span.Duration))
+	}
+
+	parentFound := false
+	for _, ref := range span.References {
+		if !parentFound && ref.RefType == model.ChildOf && ref.TraceID == span.TraceID {
+			otlpSpan.ParentSpanId = spanIDToBytes(ref.SpanID)
+			parentFound = true
+			continue
+		}
+		otlpSpan.Links = append(otlpSpan.Links, &tracev1.Span_Link{
+			TraceId: traceIDToBytes(ref.TraceID),
+			SpanId:  spanIDToBytes(ref.SpanID),
+		})
+	}
+
+	var scope *commonv1.InstrumentationScope
+	var status tracev1.Status
+	var isError bool
+	attrs := make([]*commonv1.KeyValue, 0, len(span.Tags))
+	for i := range span.Tags {
+		tag := &span.Tags[i]
+		switch tag.Key {
+		case model.SpanKindKey:
+			if kind, err := model.SpanKindFromString(tag.AsString()); err == nil {
+				otlpSpan.Kind = spanKindFromDomain(kind)
+				continue
+			}
+		case ScopeNameKey:
+			if scope == nil {
+				scope = &commonv1.InstrumentationScope{}
+			}
+			scope.Name = tag.AsString()
+			continue
+		case ScopeVersionKey:
+			if scope == nil {
+				scope = &commonv1.InstrumentationScope{}
+			}
+			scope.Version = tag.AsString()
+			continue
+		case StatusCodeKey:
+			switch tag.AsString() {
+			case StatusCodeOK:
+				status.Code = tracev1.Status_STATUS_CODE_OK
+				continue
+			case StatusCodeError:
+				status.Code = tracev1.Status_STATUS_CODE_ERROR
+				continue
+			}
+		case StatusDescriptionKey:
+			status.Message = tag.AsString()
+			continue
+		case TraceStateKey:
+			otlpSpan.TraceState = tag.AsString()
+			continue
+		case ErrorKey:
+			if tag.AsString() == "true" {
+				isError = true
+				continue
+			}
+		}
+		attrs = append(attrs, tagToAttribute(tag))
+	}
+	if isError && status.Code == tracev1.Status_STATUS_CODE_UNSET {
+		status.Code = tracev1.Status_STATUS_CODE_ERROR
+	}
+	if status.Code != tracev1.Status_STATUS_CODE_UNSET || status.Message != "" {
+		otlpSpan.Status = &status
+	}
+	if len(span.Warnings) > 0 {
+		values := make([]*commonv1.AnyValue, 0, len(span.Warnings))
+		for _, w := range span.Warnings {
+			values = append(values, &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: w}})
+		}
+		attrs = append(attrs, &commonv1.KeyValue{
+			Key:   WarningsKey,
+			Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_ArrayValue{ArrayValue: &commonv1.ArrayValue{Values: values}}},
+		})
+	}
+	if len(attrs) > 0 {
+		otlpSpan.Attributes = attrs
+	}
+	return otlpSpan, scope
+}
+
+func spanKindFromDomain(kind model.SpanKind) tracev1.Span_SpanKind {
+	switch kind {
+	case model.SpanKindClient:
+		return tracev1.Span_SPAN_KIND_CLIENT
+	case model.SpanKindServer:
+		return tracev1.Span_SPAN_KIND_SERVER
+	case model.SpanKindProducer:
+		return tracev1.Span_SPAN_KIND_PRODUCER
+	case model.SpanKindConsumer:
+		return tracev1.Span_SPAN_KIND_CONSUMER
+	case model.SpanKindInternal:
+		return tracev1.Span_SPAN_KIND_INTERNAL
+	default:
+		return tracev1.Span_SPAN_KIND_UNSPECIFIED
+	}
+}
+
+func logsToEvents(logs []model.Log) []*tracev1.Span_Event {
+	if len(logs) == 0 {
+		return nil
+	}
+	events := make([]*tracev1.Span_Event, 0, len(logs))
+	for _, l := range logs {
+		event := &tracev1.Span_Event{TimeUnixNano: timeToUnixNano(l.Timestamp)}
+		for i := range l.Fields {
+			field := &l.Fields[i]
+			if field.Key == EventNameKey && event.Name == "" && field.VType == model.StringType {
+				event.Name = field.VStr
+				continue
+			}
+			event.Attributes = append(event.Attributes, tagToAttribute(field))
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// TagsToAttributes converts Jaeger tags into OTLP attributes.
+func TagsToAttributes(tags []model.KeyValue) []*commonv1.KeyValue {
+	if len(tags) == 0 {
+		return nil
+	}
+	attrs := make([]*commonv1.KeyValue, 0, len(tags))
+	for i := range tags {
+		attrs = append(attrs, tagToAttribute(&tags[i]))
+	}
+	return attrs
+}
+
+func tagToAttribute(tag *model.KeyValue) *commonv1.KeyValue {
+	value := &commonv1.AnyValue{}
+	switch tag.VType {
+	case model.BoolType:
+		value.Value = &commonv1.AnyValue_BoolValue{BoolValue: tag.Bool()}
+	case model.Int64Type:
+		value.Value = &commonv1.AnyValue_IntValue{IntValue: tag.Int64()}
+	case model.Float64Type:
+		value.Value = &commonv1.AnyValue_DoubleValue{DoubleValue: tag.Float64()}
+	case model.BinaryType:
+		value.Value = &commonv1.AnyValue_BytesValue{BytesValue: tag.Binary()}
+	default:
+		value.Value = &commonv1.AnyValue_StringValue{StringValue: tag.VStr}
+	}
+	return &commonv1.KeyValue{Key: tag.Key, Value: value}
+}
+
+func traceIDToBytes(id model.TraceID) []byte {
+	b := make([]byte, 16)
+	id.MarshalTo(b)
+	return b
+}
+
+func spanIDToBytes(id model.SpanID) []byte {
+	b := make([]byte, 8)
+	id.MarshalTo(b)
+	return b
+}
+
+func timeToUnixNano(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jaegertracing/jaeger-idl/internal/golden"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+func TestFromDomain(t *testing.T) {
+	traceID := model.NewTraceID(0x0102030405060708, 0x090a0b0c0d0e0f10)
+	otherTraceID := model.NewTraceID(0, 7)
+	span := &model.Span{
+		TraceID:       traceID,
+		SpanID:        model.NewSpanID(2),
+		OperationName: "GET /users",
+		References: []model.SpanRef{
+			model.NewFollowsFromRef(traceID, model.NewSpanID(3)),
+			model.NewChildOfRef(traceID, model.NewSpanID(1)),
+			model.NewChildOfRef(otherTraceID, model.NewSpanID(4)),
+		},
+		Flags:     1,
+		StartTime: testStartTime,
+		Duration:  time.Second,
+		Tags: []model.KeyValue{
+			model.String("span.kind", "client"),
+			model.Bool("error", true),
+			model.String("http.method", "GET"),
+			model.Int64("http.status_code", 500),
+			model.Float64("ratio", 0.5),
+			model.Binary("payload", []byte{1, 2}),
+			model.String("otel.scope.name", "net/http"),
+			model.String("w3c.tracestate", "k=v"),
+		},
+		Logs: []model.Log{
+			{
+				Timestamp: testStartTime.Add(time.Millisecond),
+				Fields:    []model.KeyValue{model.String("message", "retry"), model.String("event", "retrying")},
+			},
+		},
+		Process:  &model.Process{ServiceName: "frontend", Tags: []model.KeyValue{model.String("host.name", "fe-1")}},
+		Warnings: []string{"dropped"},
+	}
+
+	td := FromDomain([]*model.Span{span})
+	require.Len(t, td.ResourceSpans, 1)
+	rs := td.ResourceSpans[0]
+	assert.Equal(t, []*commonv1.KeyValue{
+		stringAttr("service.name", "frontend"),
+		stringAttr("host.name", "fe-1"),
+	}, rs.Resource.Attributes)
+	require.Len(t, rs.ScopeSpans, 1)
+	assert.Equal(t, "net/http", rs.ScopeSpans[0].Scope.Name)
+	require.Len(t, rs.ScopeSpans[0].Spans, 1)
+
+	otlpSpan := rs.ScopeSpans[0].Spans[0]
+	assert.Equal(t, testTraceIDBytes, otlpSpan.TraceId)
+	assert.Equal(t, testSpanIDBytes, otlpSpan.SpanId)
+	assert.Equal(t, testParentIDBytes, otlpSpan.ParentSpanId)
+	assert.Equal(t, []*tracev1.Span_Link{
+		{TraceId: testTraceIDBytes, SpanId: []byte{0, 0, 0, 0, 0, 0, 0, 3}},
+		{TraceId: []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 7}, SpanId: []byte{0, 0, 0, 0, 0, 0, 0, 4}},
+	}, otlpSpan.Links)
+	assert.Equal(t, "GET /users", otlpSpan.Name)
+	assert.Equal(t, tracev1.Span_SPAN_KIND_CLIENT, otlpSpan.Kind)
+	assert.Equal(t, uint32(1), otlpSpan.Flags)
+	assert.Equal(t, "k=v", otlpSpan.TraceState)
+	assert.Equal(t, uint64(testStartTime.UnixNano()), otlpSpan.StartTimeUnixNano)
+	assert.Equal(t, uint64(testStartTime.Add(time.Second).UnixNano()), otlpSpan.EndTimeUnixNano)
+	assert.Equal(t, tracev1.Status_STATUS_CODE_ERROR, otlpSpan.Status.Code)
+	assert.Equal(t, []*commonv1.KeyValue{
+		stringAttr("http.method", "GET"),
+		{Key: "http.status_code", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_IntValue{IntValue: 500}}},
+		{Key: "ratio", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_DoubleValue{DoubleValue: 0.5}}},
+		{Key: "payload", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_BytesValue{BytesValue: []byte{1, 2}}}},
+		{Key: WarningsKey, Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_ArrayValue{ArrayValue: &commonv1.ArrayValue{
+			Values: []*commonv1.AnyValue{{Value: &commonv1.AnyValue_StringValue{StringValue: "dropped"}}},
+		}}}},
+	}, otlpSpan.Attributes)
+	require.Len(t, otlpSpan.Events, 1)
+	assert.Equal(t, "retrying", otlpSpan.Events[0].Name)
+	assert.Equal(t, []*commonv1.KeyValue{stringAttr("message", "retry")}, otlpSpan.Events[0].Attributes)
+	golden.Assert(t, "from_domain", td)
+}
+
+func TestFromDomainGroupsByProcessAndScope(t *testing.T) {
+	newSpan := func(id uint64, service, scope string) *model.Span {
+		span := &model.Span{
+			TraceID: model.NewTraceID(0, 1),
+			SpanID:  model.NewSpanID(id),
+			Process: &model.Process{ServiceName: service},
+		}
+		if scope != "" {
+			span.Tags = []model.KeyValue{model.String(ScopeNameKey, scope)}
+		}
+		return span
+	}
+	td := FromDomain([]*model.Span{
+		newSpan(1, "frontend", ""),
+		newSpan(2, "backend", "db"),
+		newSpan(3, "frontend", "http"),
+		newSpan(4, "frontend", ""),
+		{TraceID: model.NewTraceID(0, 1), SpanID: model.NewSpanID(5)},
+	})
+
+	require.Len(t, td.ResourceSpans, 3)
+	assert.Equal(t, "frontend", td.ResourceSpans[0].Resource.Attributes[0].Value.GetStringValue())
+	require.Len(t, td.ResourceSpans[0].ScopeSpans, 2)
+	assert.Nil(t, td.ResourceSpans[0].ScopeSpans[0].Scope)
+	assert.Len(t, td.ResourceSpans[0].ScopeSpans[0].Spans, 2)
+	assert.Equal(t, "http", td.ResourceSpans[0].ScopeSpans[1].Scope.Name)
+	assert.Equal(t, "backend", td.ResourceSpans[1].Resource.Attributes[0].Value.GetStringValue())
+	assert.Empty(t, td.ResourceSpans[2].Resource.Attributes)
+}
+
+func TestFromDomainRoundTrip(t *testing.T) {
+	td := &tracev1.TracesData{
+		ResourceSpans: []*tracev1.ResourceSpans{
+			{
+				Resource: ProcessToResource(&model.Process{
+					ServiceName: "frontend",
+					Tags:        []model.KeyValue{model.String("host.name", "fe-1")},
+				}),
+				ScopeSpans: []*tracev1.ScopeSpans{
+					{
+						Scope: &commonv1.InstrumentationScope{Name: "net/http", Version: "1.0"},
+						Spans: []*tracev1.Span{
+							{
+								TraceId:           testTraceIDBytes,
+								SpanId:            testSpanIDBytes,
+								ParentSpanId:      testParentIDBytes,
+								TraceState:        "k=v",
+								Flags:             1,
+								Name:              "GET /users",
+								Kind:              tracev1.Span_SPAN_KIND_SERVER,
+								StartTimeUnixNano: uint64(testStartTime.UnixNano()),
+								EndTimeUnixNano:   uint64(testStartTime.Add(time.Second).UnixNano()),
+								Attributes:        []*commonv1.KeyValue{stringAttr("http.method", "GET")},
+								Events: []*tracev1.Span_Event{
+									{
+										TimeUnixNano: uint64(testStartTime.Add(time.Millisecond).UnixNano()),
+										Name:         "retry",
+										Attributes:   []*commonv1.KeyValue{stringAttr("attempt", "2")},
+									},
+								},
+								Links:  []*tracev1.Span_Link{{TraceId: testTraceIDBytes, SpanId: testParentIDBytes}},
+								Status: &tracev1.Status{Code: tracev1.Status_STATUS_CODE_ERROR, Message: "boom"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	roundTrip := FromDomain(ToDomain(td))
+	assert.True(t, proto.Equal(td, roundTrip), "expected %v, got %v", td, roundTrip)
+}
+
+// benchmarkSpans returns n spans of a trace of 5 services, with the tags,
+// references and logs of an instrumented HTTP server.
+func benchmarkSpans(n int) []*model.Span {
+	traceID := model.NewTraceID(1, 2)
+	processes := make([]*model.Process, 5)
+	for i := range processes {
+		processes[i] = model.NewProcess(fmt.Sprintf("service-%d", i), []model.KeyValue{
+			model.String("hostname", fmt.Sprintf("host-%d", i)),
+			model.String("jaeger.version", "Go-2.30.0"),
+		})
+	}
+	spans := make([]*model.Span, n)
+	for i := range spans {
+		spans[i] = &model.Span{
+			TraceID:       traceID,
+			SpanID:        model.NewSpanID(uint64(i + 1)),
+			OperationName: fmt.Sprintf("GET /api/%d", i%10),
+			References:    model.MaybeAddParentSpanID(traceID, model.NewSpanID(uint64(i/4)), nil),
+			StartTime:     testStartTime.Add(time.Duration(i) * time.Millisecond),
+			Duration:      time.Millisecond,
+			Tags: []model.KeyValue{
+				model.String("span.kind", "server"),
+				model.String("http.method", "GET"),
+				model.Int64("http.status_code", int64(200+100*(i%4))),
+				model.Bool("error", i%4 == 3),
+			},
+			Logs: []model.Log{{
+				Timestamp: testStartTime.Add(time.Duration(i) * time.Millisecond),
+				Fields:    []model.KeyValue{model.String("event", "request")},
+			}},
+			Process: processes[i%len(processes)],
+		}
+	}
+	return spans
+}
+
+func BenchmarkFromDomain(b *testing.B) {
+	for _, n := range []int{10, 1_000, 100_000} {
+		b.Run(fmt.Sprintf("spans=%d", n), func(b *testing.B) {
+			spans := benchmarkSpans(n)
+			b.ReportAllocs()
+			for b.Loop() {
+				FromDomain(spans)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// Tag keys used to carry OTLP fields that have no dedicated place in the Jaeger model.
+const (
+	ServiceNameKey       = "service.name"
+	ScopeNameKey         = "otel.scope.name"
+	ScopeVersionKey      = "otel.scope.version"
+	StatusCodeKey        = "otel.status_code"
+	StatusDescriptionKey = "otel.status_description"
+	TraceStateKey        = "w3c.tracestate"
+	ErrorKey             = "error"
+	EventNameKey         = "event"
+	// WarningsKey is the span attribute carrying the span warnings, as a
+	// list of strings, as in Jaeger v2.
+	WarningsKey = "@jaeger@warnings"
+
+	StatusCodeOK    = "OK"
+	StatusCodeError = "ERROR"
+
+	// NoServiceName is used as the service name of spans whose resource does not have one.
+	NoServiceName = "OTLPResourceNoServiceName"
+)
+
+// ToDomain converts OTLP traces data into a flat list of Jaeger domain model spans.
+// Spans from different traces are returned together in the order of the input.
+func ToDomain(td *tracev1.TracesData) []*model.Span {
+	var spans []*model.Span
+	for _, rs := range td.GetResourceSpans() {
+		process := ResourceToProcess(rs.GetResource())
+		for _, ss := range rs.GetScopeSpans() {
+			for _, span := range ss.GetSpans() {
+				spans = append(spans, SpanToDomain(span, process, ss.GetScope()))
+			}
+		}
+	}
+	return spans
+}
+
+// SpanToDomain converts a single OTLP span into a Jaeger domain model span.
+// The process is shared, not copied, and the scope may be nil.
+func SpanToDomain(span *tracev1.Span, process *model.Process, scope *commonv1.InstrumentationScope) *model.Span {
+	var warnings []string
+	traceID, err := model.TraceIDFromBytes(span.GetTraceId())
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("invalid trace ID: %v", err))
+	}
+	spanID, err := model.SpanIDFromBytes(span.GetSpanId())
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("invalid span ID: %v", err))
+	}
+
+	var refs []model.SpanRef
+	if len(span.GetParentSpanId()) > 0 {
+		if parentID, err := model.SpanIDFromBytes(span.GetParentSpanId()); err == nil {
+			refs = model.MaybeAddParentSpanID(traceID, parentID, refs)
+		} else {
+			warnings = append(warnings, fmt.Sprintf("invalid parent span ID: %v", err))
+		}
+	}
+	for _, link := range span.GetLinks() {
+		linkTraceID, err := model.TraceIDFromBytes(link.GetTraceId())
+		if err != nil {
+			continue
+		}
+		linkSpanID, err := model.SpanIDFromBytes(link.GetSpanId())
+		if err != nil {
+			continue
+		}
+		refs = append(refs, model.NewFollowsFromRef(linkTraceID, linkSpanID))
+	}
+
+	start := unixNanoToTime(span.GetStartTimeUnixNano())
+	var duration time.Duration
+	if end := span.GetEndTimeUnixNano(); end > span.GetStartTimeUnixNano() {
+		duration = time.Duration(end - span.GetStartTimeUnixNano())
+	}
+
+	attrs := span.GetAttributes()
+	for i, attr := range attrs {
+		if attr.GetKey() == WarningsKey {
+			for _, v := range attr.GetValue().GetArrayValue().GetValues() {
+				warnings = append(warnings, v.GetStringValue())
+			}
+			attrs = append(attrs[:i:i], attrs[i+1:]...)
+			break
+		}
+	}
+	tags := AttributesToTags(attrs)
+	if kind := spanKindToDomain(span.GetKind()); kind != model.SpanKindUnspecified {
+		tags = append(tags, model.SpanKindTag(kind))
+	}
+	tags = append(tags, statusToTags(span.GetStatus())...)
+	if scope.GetName() != "" {
+		tags = append(tags, model.String(ScopeNameKey, scope.GetName()))
+	}
+	if scope.GetVersion() != "" {
+		tags = append(tags, model.String(ScopeVersionKey, scope.GetVersion()))
+	}
+	if span.GetTraceState() != "" {
+		tags = append(tags, model.String(TraceStateKey, span.GetTraceState()))
+	}
+
+	return &model.Span{
+		TraceID:       traceID,
+		SpanID:        spanID,
+		OperationName: span.GetName(),
+		References:    refs,
+		Flags:         model.Flags(span.GetFlags() & 0xff),
+		StartTime:     start,
+		Duration:      duration,
+		Tags:          tags,
+		Logs:          eventsToLogs(span.GetEvents()),
+		Process:       process,
+		Warnings:      warnings,
+	}
+}
+
+// ResourceToProcess converts an OTLP resource into a Jaeger process.
+// The service.name attribute becomes the service name, all others become tags.
+func ResourceToProcess(resource *resourcev1.Resource) *model.Process {
+	process := &model.Process{ServiceName: NoServiceName}
+	for _, attr := range resource.GetAttributes() {
+		if attr.GetKey() == ServiceNameKey {
+			if name := attr.GetValue().GetStringValue(); name != "" {
+				process.ServiceName = name
+			}
+			continue
+		}
+		process.Tags = append(process.Tags, attributeToTag(attr))
+	}
+	return process
+}
+
+func spanKindToDomain(kind tracev1.Span_SpanKind) model.SpanKind {
+	switch kind {
+	case tracev1.Span_SPAN_KIND_CLIENT:
+		return model.SpanKindClient
+	case tracev1.Span_SPAN_KIND_SERVER:
+		return model.SpanKindServer
+	case tracev1.Span_SPAN_KIND_PRODUCER:
+		return model.SpanKindProducer
+	case tracev1.Span_SPAN_KIND_CONSUMER:
+		return model.SpanKindConsumer
+	case tracev1.Span_SPAN_KIND_INTERNAL:
+		return model.SpanKindInternal
+	default:
+		return model.SpanKindUnspecified
+	}
+}
+
+func statusToTags(status *tracev1.Status) []model.KeyValue {
+	var tags []model.KeyValue
+	switch status.GetCode() {
+	case tracev1.Status_STATUS_CODE_OK:
+		tags = append(tags, model.String(StatusCodeKey, StatusCodeOK))
+	case tracev1.Status_STATUS_CODE_ERROR:
+		tags = append(tags,
+			model.Bool(ErrorKey, true),
+			model.String(StatusCodeKey, StatusCodeError),
+		)
+	default:
+		return nil
+	}
+	if status.GetMessage() != "" {
+		tags = append(tags, model.String(StatusDescriptionKey, status.GetMessage()))
+	}
+	return tags
+}
+
+func eventsToLogs(events []*tracev1.Span_Event) []model.Log {
+	if len(events) == 0 {
+		return nil
+	}
+	logs := make([]model.Log, 0, len(events))
+	for _, event := range events {
+		fields := make([]model.KeyValue, 0, len(event.GetAttributes())+1)
+		if event.GetName() != "" {
+			fields = append(fields, model.String(EventNameKey, event.GetName()))
+		}
+		fields = append(fields, AttributesToTags(event.GetAttributes())...)
+		logs = append(logs, model.Log{
+			Timestamp: unixNanoToTime(event.GetTimeUnixNano()),
+			Fields:    fields,
+		})
+	}
+	return logs
+}
+
+// AttributesToTags converts OTLP attributes into Jaeger tags.
+// Arrays and maps are represented as JSON strings.
+func AttributesToTags(attrs []*commonv1.KeyValue) []model.KeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	tags := make([]model.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		tags = append(tags, attributeToTag(attr))
+	}
+	return tags
+}
+
+func attributeToTag(attr *commonv1.KeyValue) model.KeyValue {
+	key := attr.GetKey()
+	switch v := attr.GetValue().GetValue().(type) {
+	case *commonv1.AnyValue_StringValue:
+		return model.String(key, v.StringValue)
+	case *commonv1.AnyValue_BoolValue:
+		return model.Bool(key, v.BoolValue)
+	case *commonv1.AnyValue_IntValue:
+		return model.Int64(key, v.IntValue)
+	case *commonv1.AnyValue_DoubleValue:
+		return model.Float64(key, v.DoubleValue)
+	case *commonv1.AnyValue_BytesValue:
+		return model.Binary(key, v.BytesValue)
+	case nil:
+		return model.String(key, "")
+	default:
+		b, _ := json.Marshal(anyValueToRaw(attr.GetValue()))
+		return model.String(key, string(b))
+	}
+}
+
+// anyValueToRaw converts an OTLP value into a value suitable for encoding/json.
+func anyValueToRaw(value *commonv1.AnyValue) any {
+	switch v := value.GetValue().(type) {
+	case *commonv1.AnyValue_StringValue:
+		return v.StringValue
+	case *commonv1.AnyValue_BoolValue:
+		return v.BoolValue
+	case *commonv1.AnyValue_IntValue:
+		return v.IntValue
+	case *commonv1.AnyValue_DoubleValue:
+		return v.DoubleValue
+	case *commonv1.AnyValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(v.BytesValue)
+	case *commonv1.AnyValue_ArrayValue:
+		values := make([]any, 0, len(v.ArrayValue.GetValues()))
+		for _, item := range v.ArrayValue.GetValues() {
+			values = append(values, anyValueToRaw(item))
+		}
+		return values
+	case *commonv1.AnyValue_KvlistValue:
+		values := make(map[string]any, len(v.KvlistValue.GetValues()))
+		for _, kv := range v.KvlistValue.GetValues() {
+			values[kv.GetKey()] = anyValueToRaw(kv.GetValue())
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func unixNanoToTime(nanos uint64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(nanos)).UTC()
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jaegertracing/jaeger-idl/internal/golden"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+var (
+	testTraceIDBytes  = []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	testSpanIDBytes   = []byte{0, 0, 0, 0, 0, 0, 0, 2}
+	testParentIDBytes = []byte{0, 0, 0, 0, 0, 0, 0, 1}
+	testStartTime     = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+)
+
+func stringAttr(key, value string) *commonv1.KeyValue {
+	return &commonv1.KeyValue{
+		Key:   key,
+		Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+func TestToDomain(t *testing.T) {
+	td := &tracev1.TracesData{
+		ResourceSpans: []*tracev1.ResourceSpans{
+			{
+				Resource: &resourcev1.Resource{
+					Attributes: []*commonv1.KeyValue{
+						stringAttr("service.name", "frontend"),
+						stringAttr("host.name", "frontend-01"),
+					},
+				},
+				ScopeSpans: []*tracev1.ScopeSpans{
+					{
+						Scope: &commonv1.InstrumentationScope{Name: "net/http", Version: "1.0"},
+						Spans: []*tracev1.Span{
+							{
+								TraceId:           testTraceIDBytes,
+								SpanId:            testSpanIDBytes,
+								ParentSpanId:      testParentIDBytes,
+								TraceState:        "k=v",
+								Flags:             1,
+								Name:              "GET /users",
+								Kind:              tracev1.Span_SPAN_KIND_SERVER,
+								StartTimeUnixNano: uint64(testStartTime.UnixNano()),
+								EndTimeUnixNano:   uint64(testStartTime.Add(time.Second).UnixNano()),
+								Attributes:        []*commonv1.KeyValue{stringAttr("http.method", "GET")},
+								Events: []*tracev1.Span_Event{
+									{
+										TimeUnixNano: uint64(testStartTime.Add(time.Millisecond).UnixNano()),
+										Name:         "retry",
+										Attributes:   []*commonv1.KeyValue{stringAttr("attempt", "2")},
+									},
+								},
+								Links: []*tracev1.Span_Link{
+									{TraceId: testTraceIDBytes, SpanId: testParentIDBytes},
+									{TraceId: []byte{1}, SpanId: testParentIDBytes},
+								},
+								Status: &tracev1.Status{Code: tracev1.Status_STATUS_CODE_ERROR, Message: "boom"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	spans := ToDomain(td)
+	require.Len(t, spans, 1)
+	span := spans[0]
+
+	traceID := model.NewTraceID(0x0102030405060708, 0x090a0b0c0d0e0f10)
+	assert.Equal(t, traceID, span.TraceID)
+	assert.Equal(t, model.NewSpanID(2), span.SpanID)
+	assert.Equal(t, model.NewSpanID(1), span.ParentSpanID())
+	assert.Equal(t, []model.SpanRef{
+		model.NewChildOfRef(traceID, model.NewSpanID(1)),
+		model.NewFollowsFromRef(traceID, model.NewSpanID(1)),
+	}, span.References)
+	assert.Equal(t, "GET /users", span.OperationName)
+	assert.Equal(t, model.Flags(1), span.Flags)
+	assert.Equal(t, testStartTime, span.StartTime)
+	assert.Equal(t, time.Second, span.Duration)
+	assert.Equal(t, &model.Process{
+		ServiceName: "frontend",
+		Tags:        []model.KeyValue{model.String("host.name", "frontend-01")},
+	}, span.Process)
+	assert.Equal(t, []model.KeyValue{
+		model.String("http.method", "GET"),
+		model.String("span.kind", "server"),
+		model.Bool("error", true),
+		model.String("otel.status_code", "ERROR"),
+		model.String("otel.status_description", "boom"),
+		model.String("otel.scope.name", "net/http"),
+		model.String("otel.scope.version", "1.0"),
+		model.String("w3c.tracestate", "k=v"),
+	}, span.Tags)
+	assert.Equal(t, []model.Log{
+		{
+			Timestamp: testStartTime.Add(time.Millisecond),
+			Fields: []model.KeyValue{
+				model.String("event", "retry"),
+				model.String("attempt", "2"),
+			},
+		},
+	}, span.Logs)
+	assert.Empty(t, span.Warnings)
+	golden.AssertSpans(t, "to_domain", spans)
+}
+
+func TestToDomainWarnings(t *testing.T) {
+	warnings := &commonv1.AnyValue{Value: &commonv1.AnyValue_ArrayValue{ArrayValue: &commonv1.ArrayValue{
+		Values: []*commonv1.AnyValue{
+			{Value: &commonv1.AnyValue_StringValue{StringValue: "clock skew"}},
+			{Value: &commonv1.AnyValue_StringValue{StringValue: "truncated"}},
+		},
+	}}}
+	span := SpanToDomain(&tracev1.Span{
+		TraceId:    testTraceIDBytes,
+		SpanId:     testSpanIDBytes,
+		Attributes: []*commonv1.KeyValue{stringAttr("a", "b"), {Key: WarningsKey, Value: warnings}},
+	}, &model.Process{}, nil)
+	assert.Equal(t, []string{"clock skew", "truncated"}, span.Warnings)
+	assert.Equal(t, []model.KeyValue{model.String("a", "b")}, span.Tags)
+
+	otlpSpan, _ := SpanFromDomain(span)
+	assert.Equal(t, []*commonv1.KeyValue{stringAttr("a", "b"), {Key: WarningsKey, Value: warnings}}, otlpSpan.Attributes)
+}
+
+func TestToDomainInvalidIDs(t *testing.T) {
+	td := &tracev1.TracesData{
+		ResourceSpans: []*tracev1.ResourceSpans{
+			{
+				ScopeSpans: []*tracev1.ScopeSpans{
+					{
+						Spans: []*tracev1.Span{
+							{TraceId: []byte{1}, SpanId: []byte{2}, ParentSpanId: []byte{3}},
+						},
+					},
+				},
+			},
+		},
+	}
+	spans := ToDomain(td)
+	require.Len(t, spans, 1)
+	assert.Equal(t, NoServiceName, spans[0].Process.ServiceName)
+	assert.Len(t, spans[0].Warnings, 3)
+	assert.Empty(t, spans[0].References)
+	assert.True(t, spans[0].StartTime.IsZero())
+}
+
+func TestStatusToTags(t *testing.T) {
+	assert.Nil(t, statusToTags(nil))
+	assert.Equal(t, []model.KeyValue{model.String("otel.status_code", "OK")},
+		statusToTags(&tracev1.Status{Code: tracev1.Status_STATUS_CODE_OK}))
+}
+
+func TestSpanKindToDomain(t *testing.T) {
+	tests := map[tracev1.Span_SpanKind]model.SpanKind{
+		tracev1.Span_SPAN_KIND_UNSPECIFIED: model.SpanKindUnspecified,
+		tracev1.Span_SPAN_KIND_INTERNAL:    model.SpanKindInternal,
+		tracev1.Span_SPAN_KIND_SERVER:      model.SpanKindServer,
+		tracev1.Span_SPAN_KIND_CLIENT:      model.SpanKindClient,
+		tracev1.Span_SPAN_KIND_PRODUCER:    model.SpanKindProducer,
+		tracev1.Span_SPAN_KIND_CONSUMER:    model.SpanKindConsumer,
+	}
+	for in, expected := range tests {
+		assert.Equal(t, expected, spanKindToDomain(in), in.String())
+	}
+}
+
+func TestAttributesToTags(t *testing.T) {
+	attrs := []*commonv1.KeyValue{
+		{Key: "s", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: "v"}}},
+		{Key: "b", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_BoolValue{BoolValue: true}}},
+		{Key: "i", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_IntValue{IntValue: 42}}},
+		{Key: "d", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_DoubleValue{DoubleValue: 1.5}}},
+		{Key: "bin", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_BytesValue{BytesValue: []byte{1}}}},
+		{Key: "empty"},
+		{Key: "arr", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_ArrayValue{
+			ArrayValue: &commonv1.ArrayValue{Values: []*commonv1.AnyValue{
+				{Value: &commonv1.AnyValue_IntValue{IntValue: 1}},
+				{Value: &commonv1.AnyValue_BytesValue{BytesValue: []byte{1}}},
+				{Value: &commonv1.AnyValue_DoubleValue{DoubleValue: 0.5}},
+				{Value: &commonv1.AnyValue_BoolValue{BoolValue: false}},
+				{},
+			}},
+		}}},
+		{Key: "map", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_KvlistValue{
+			KvlistValue: &commonv1.KeyValueList{Values: []*commonv1.KeyValue{stringAttr("k", "v")}},
+		}}},
+	}
+	assert.Equal(t, []model.KeyValue{
+		model.String("s", "v"),
+		model.Bool("b", true),
+		model.Int64("i", 42),
+		model.Float64("d", 1.5),
+		model.Binary("bin", []byte{1}),
+		model.String("empty", ""),
+		model.String("arr", `[1,"AQ==",0.5,false,null]`),
+		model.String("map", `{"k":"v"}`),
+	}, AttributesToTags(attrs))
+	assert.Nil(t, AttributesToTags(nil))
+}
+
+func BenchmarkToDomain(b *testing.B) {
+	for _, n := range []int{10, 1_000, 100_000} {
+		b.Run(fmt.Sprintf("spans=%d", n), func(b *testing.B) {
+			td := FromDomain(benchmarkSpans(n))
+			b.ReportAllocs()
+			for b.Loop() {
+				ToDomain(td)
+			}
+		})
+	}
+}
+
+func FuzzToDomain(f *testing.F) {
+	seed, err := proto.Marshal(FromDomain(benchmarkSpans(3)))
+	require.NoError(f, err)
+	f.Add(seed)
+	f.Fuzz(func(_ *testing.T, data []byte) {
+		td := &tracev1.TracesData{}
+		if proto.Unmarshal(data, td) != nil {
+			return
+		}
+		ToDomain(td)
+	})
+}
@@ -0,0 +1,7 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package roundtrip verifies that spans survive the conversions between the
+// Jaeger domain model, OTLP, api_v2 and their JSON encodings, reporting the
+// fields that are lost or altered.
+package roundtrip
@@ -0,0 +1,238 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package roundtrip
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/jaegertracing/jaeger-idl/gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/apiv2"
+	"github.com/jaegertracing/jaeger-idl/model/converter/otlp"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// absent is the value of a field missing on one side of a Difference.
+const absent = "<absent>"
+
+// Conversion is a round trip of spans through other representations.
+type Conversion struct {
+	Name    string
+	Convert func(spans []*model.Span) ([]*model.Span, error)
+}
+
+// Conversions returns the round trips checked by Verify.
+func Conversions() []Conversion {
+	return []Conversion{
+		{Name: "model→OTLP→model", Convert: viaOTLP},
+		{Name: "model→OTLP→OTLP JSON→OTLP→model", Convert: viaOTLPJSON},
+		{Name: "model→api_v2→model", Convert: viaAPIv2},
+		{Name: "model→api_v2→api_v2 JSON→api_v2→model", Convert: viaAPIv2JSON},
+	}
+}
+
+// Result is the outcome of a round trip.
+type Result struct {
+	Conversion string
+	// Err is set if the conversion failed, in which case there are no Differences.
+	Err         error
+	Differences []Difference
+}
+
+// Difference is a field of a span that changed in a round trip.
+type Difference struct {
+	// Span is the span as traceID:spanID.
+	Span string
+	// Field is the path of the field, e.g. tags[http.status_code].
+	Field  string
+	Before string
+	After  string
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s %s: %s → %s", d.Span, d.Field, d.Before, d.After)
+}
+
+// Verify runs the spans through every round trip of Conversions.
+func Verify(spans []*model.Span) []Result {
+	var results []Result
+	for _, c := range Conversions() {
+		result := Result{Conversion: c.Name}
+		converted, err := c.Convert(spans)
+		if err != nil {
+			result.Err = err
+		} else {
+			result.Differences = Diff(spans, converted)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func viaOTLP(spans []*model.Span) ([]*model.Span, error) {
+	return otlp.ToDomain(otlp.FromDomain(spans)), nil
+}
+
+func viaOTLPJSON(spans []*model.Span) ([]*model.Span, error) {
+	b, err := protojson.Marshal(otlp.FromDomain(spans))
+	if err != nil {
+		return nil, err
+	}
+	td := &tracev1.TracesData{}
+	if err := protojson.Unmarshal(b, td); err != nil {
+		return nil, err
+	}
+	return otlp.ToDomain(td), nil
+}
+
+func viaAPIv2(spans []*model.Span) ([]*model.Span, error) {
+	protoSpans, err := apiv2.SpansToProto(spans)
+	if err != nil {
+		return nil, err
+	}
+	return apiv2.SpansFromProto(protoSpans)
+}
+
+func viaAPIv2JSON(spans []*model.Span) ([]*model.Span, error) {
+	protoSpans, err := apiv2.SpansToProto(spans)
+	if err != nil {
+		return nil, err
+	}
+	b, err := protojson.Marshal(&api_v2.SpansResponseChunk{Spans: protoSpans})
+	if err != nil {
+		return nil, err
+	}
+	chunk := &api_v2.SpansResponseChunk{}
+	if err := protojson.Unmarshal(b, chunk); err != nil {
+		return nil, err
+	}
+	return apiv2.SpansFromProto(chunk.Spans)
+}
+
+// Diff returns the differences between the spans before and after a
+// conversion. Spans are matched by trace and span ID, in order when several
+// have the same IDs. The order of the tags does not matter.
+func Diff(before, after []*model.Span) []Difference {
+	remaining := make(map[string][]*model.Span)
+	for _, span := range after {
+		key := spanKey(span)
+		remaining[key] = append(remaining[key], span)
+	}
+	var diffs []Difference
+	for _, a := range before {
+		key := spanKey(a)
+		if len(remaining[key]) == 0 {
+			diffs = append(diffs, Difference{Span: key, Field: "span", Before: "present", After: absent})
+			continue
+		}
+		b := remaining[key][0]
+		remaining[key] = remaining[key][1:]
+		diffs = append(diffs, diffSpan(key, a, b)...)
+	}
+	for _, b := range after {
+		key := spanKey(b)
+		if len(remaining[key]) > 0 && remaining[key][0] == b {
+			remaining[key] = remaining[key][1:]
+			diffs = append(diffs, Difference{Span: key, Field: "span", Before: absent, After: "present"})
+		}
+	}
+	return diffs
+}
+
+func spanKey(span *model.Span) string {
+	return span.TraceID.String() + ":" + span.SpanID.String()
+}
+
+func diffSpan(key string, a, b *model.Span) []Difference {
+	var diffs []Difference
+	add := func(field, before, after string) {
+		if before != after {
+			diffs = append(diffs, Difference{Span: key, Field: field, Before: before, After: after})
+		}
+	}
+	add("operationName", a.OperationName, b.OperationName)
+	add("references", formatRefs(a.References), formatRefs(b.References))
+	add("flags", fmt.Sprint(a.Flags), fmt.Sprint(b.Flags))
+	if !a.StartTime.Equal(b.StartTime) {
+		add("startTime", a.StartTime.Format(time.RFC3339Nano), b.StartTime.Format(time.RFC3339Nano))
+	}
+	add("duration", a.Duration.String(), b.Duration.String())
+	diffs = append(diffs, diffKeyValues(key, "tags", a.Tags, b.Tags)...)
+	if len(a.Logs) != len(b.Logs) {
+		add("logs", fmt.Sprintf("%d logs", len(a.Logs)), fmt.Sprintf("%d logs", len(b.Logs)))
+	} else {
+		for i := range a.Logs {
+			field := fmt.Sprintf("logs[%d]", i)
+			if !a.Logs[i].Timestamp.Equal(b.Logs[i].Timestamp) {
+				add(field+".timestamp", a.Logs[i].Timestamp.Format(time.RFC3339Nano), b.Logs[i].Timestamp.Format(time.RFC3339Nano))
+			}
+			diffs = append(diffs, diffKeyValues(key, field+".fields", a.Logs[i].Fields, b.Logs[i].Fields)...)
+		}
+	}
+	add("process.serviceName", a.Process.GetServiceName(), b.Process.GetServiceName())
+	diffs = append(diffs, diffKeyValues(key, "process.tags", a.Process.GetTags(), b.Process.GetTags())...)
+	add("warnings", strings.Join(a.Warnings, "; "), strings.Join(b.Warnings, "; "))
+	return diffs
+}
+
+func formatRefs(refs []model.SpanRef) string {
+	formatted := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		formatted = append(formatted, fmt.Sprintf("%s %s:%s", ref.RefType, ref.TraceID, ref.SpanID))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// diffKeyValues compares the values of each key, ignoring the order of the tags.
+func diffKeyValues(key, field string, a, b []model.KeyValue) []Difference {
+	group := func(kvs []model.KeyValue) map[string][]string {
+		values := make(map[string][]string)
+		for i := range kvs {
+			kv := &kvs[i]
+			values[kv.Key] = append(values[kv.Key], fmt.Sprintf("%s(%s)", strings.ToLower(kv.VType.String()), kv.AsString()))
+		}
+		for _, v := range values {
+			slices.Sort(v)
+		}
+		return values
+	}
+	before, after := group(a), group(b)
+	keys := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		keys = append(keys, k)
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var diffs []Difference
+	for _, k := range keys {
+		if slices.Equal(before[k], after[k]) {
+			continue
+		}
+		diffs = append(diffs, Difference{
+			Span:   key,
+			Field:  fmt.Sprintf("%s[%s]", field, k),
+			Before: formatValues(before[k]),
+			After:  formatValues(after[k]),
+		})
+	}
+	return diffs
+}
+
+func formatValues(values []string) string {
+	if len(values) == 0 {
+		return absent
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package roundtrip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+var testTraceID = model.NewTraceID(1, 2)
+
+const testKey = "00000000000000010000000000000002:0000000000000002"
+
+func newSpan() *model.Span {
+	return &model.Span{
+		TraceID:       testTraceID,
+		SpanID:        model.NewSpanID(2),
+		OperationName: "GET /users",
+		References: []model.SpanRef{
+			model.NewChildOfRef(testTraceID, model.NewSpanID(1)),
+			model.NewFollowsFromRef(testTraceID, model.NewSpanID(3)),
+		},
+		Flags:     1,
+		StartTime: time.Unix(1767322800, 123456789).UTC(),
+		Duration:  1500 * time.Microsecond,
+		Tags: []model.KeyValue{
+			model.String("span.kind", "client"),
+			model.Int64("http.status_code", 200),
+			model.Float64("ratio", 0.5),
+			model.Binary("payload", []byte{1, 2}),
+		},
+		Logs: []model.Log{{
+			Timestamp: time.Unix(1767322800, 200000000).UTC(),
+			Fields:    []model.KeyValue{model.String("event", "retry"), model.Int64("attempt", 2)},
+		}},
+		Process: &model.Process{ServiceName: "frontend", Tags: []model.KeyValue{model.String("hostname", "web-1")}},
+	}
+}
+
+func TestVerify(t *testing.T) {
+	results := Verify([]*model.Span{newSpan()})
+	require.Len(t, results, len(Conversions()))
+	for _, r := range results {
+		require.NoError(t, r.Err, r.Conversion)
+		assert.Empty(t, r.Differences, r.Conversion)
+	}
+}
+
+func TestVerifyLossy(t *testing.T) {
+	span := newSpan()
+	span.Tags = append(span.Tags, model.Bool("error", true))
+	results := Verify([]*model.Span{span})
+
+	// the error tag is carried by the OTLP status, which adds a tag on the way back
+	statusTag := []Difference{{Span: testKey, Field: "tags[otel.status_code]", Before: "<absent>", After: "string(ERROR)"}}
+	assert.Equal(t, "model→OTLP→model", results[0].Conversion)
+	assert.Equal(t, statusTag, results[0].Differences)
+	assert.Equal(t, statusTag, results[1].Differences)
+	assert.Empty(t, results[2].Differences)
+	assert.Empty(t, results[3].Differences)
+}
+
+func TestDiff(t *testing.T) {
+	before := newSpan()
+	after := newSpan()
+	after.OperationName = "GET /accounts"
+	after.References = after.References[:1]
+	after.StartTime = after.StartTime.Add(time.Microsecond)
+	after.Tags = []model.KeyValue{
+		model.String("http.status_code", "200"),
+		model.Float64("ratio", 0.5),
+		model.Binary("payload", []byte{1, 2}),
+		model.String("span.kind", "client"),
+		model.String("extra", "x"),
+	}
+	after.Logs[0].Fields = after.Logs[0].Fields[:1]
+	after.Process = &model.Process{ServiceName: "web"}
+	after.Warnings = []string{"clock skew"}
+
+	assert.Equal(t, []Difference{
+		{testKey, "operationName", "GET /users", "GET /accounts"},
+		{
+			testKey, "references",
+			"CHILD_OF 00000000000000010000000000000002:0000000000000001, FOLLOWS_FROM 00000000000000010000000000000002:0000000000000003",
+			"CHILD_OF 00000000000000010000000000000002:0000000000000001",
+		},
+		{testKey, "startTime", "2026-01-02T03:00:00.123456789Z", "2026-01-02T03:00:00.123457789Z"},
+		{testKey, "tags[extra]", "<absent>", "string(x)"},
+		{testKey, "tags[http.status_code]", "int64(200)", "string(200)"},
+		{testKey, "logs[0].fields[attempt]", "int64(2)", "<absent>"},
+		{testKey, "process.serviceName", "frontend", "web"},
+		{testKey, "process.tags[hostname]", "string(web-1)", "<absent>"},
+		{testKey, "warnings", "", "clock skew"},
+	}, Diff([]*model.Span{before}, []*model.Span{after}))
+}
+
+func TestDiffSpans(t *testing.T) {
+	a, b, c := newSpan(), newSpan(), newSpan()
+	b.SpanID = model.NewSpanID(5)
+	c.SpanID = model.NewSpanID(6)
+	// the duplicate of a is matched in order
+	diffs := Diff([]*model.Span{a, b, a}, []*model.Span{a, c, a})
+	assert.Equal(t, []Difference{
+		{"00000000000000010000000000000002:0000000000000005", "span", "present", "<absent>"},
+		{"00000000000000010000000000000002:0000000000000006", "span", "<absent>", "present"},
+	}, diffs)
+	assert.Equal(t, "00000000000000010000000000000002:0000000000000005 span: present → <absent>", diffs[0].String())
+}
+
+func TestDiffLogs(t *testing.T) {
+	before, after := newSpan(), newSpan()
+	after.Logs[0].Timestamp = after.Logs[0].Timestamp.Add(time.Millisecond)
+	assert.Equal(t, []Difference{
+		{testKey, "logs[0].timestamp", "2026-01-02T03:00:00.2Z", "2026-01-02T03:00:00.201Z"},
+	}, Diff([]*model.Span{before}, []*model.Span{after}))
+
+	after.Logs = nil
+	assert.Equal(t, []Difference{
+		{testKey, "logs", "1 logs", "0 logs"},
+	}, Diff([]*model.Span{before}, []*model.Span{after}))
+}
+
+func TestDiffTagOrder(t *testing.T) {
+	before, after := newSpan(), newSpan()
+	before.Tags = []model.KeyValue{model.Bool("error", true), model.String("a", "1"), model.Bool("error", false)}
+	after.Tags = []model.KeyValue{model.String("a", "1"), model.Bool("error", false), model.Bool("error", true)}
+	assert.Empty(t, Diff([]*model.Span{before}, []*model.Span{after}))
+}
@@ -0,0 +1,6 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jaeger converts spans in the jaeger.thrift format, as reported by
+// the legacy Jaeger client libraries, into the Jaeger domain model.
+package jaeger
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package jaeger
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+	"github.com/jaegertracing/jaeger-idl/thrift-gen/jaeger"
+)
+
+// ToDomain converts a batch of spans in the jaeger.thrift format, all
+// reported by the given process, into Jaeger domain model spans.
+func ToDomain(jSpans []*jaeger.Span, jProcess *jaeger.Process) []*model.Span {
+	process := ToDomainProcess(jProcess)
+	spans := make([]*model.Span, 0, len(jSpans))
+	for _, jSpan := range jSpans {
+		if jSpan == nil {
+			continue
+		}
+		spans = append(spans, toDomainSpan(jSpan, process))
+	}
+	return spans
+}
+
+// ToDomainSpan converts a single span in the jaeger.thrift format into a Jaeger domain model span.
+func ToDomainSpan(jSpan *jaeger.Span, jProcess *jaeger.Process) *model.Span {
+	return toDomainSpan(jSpan, ToDomainProcess(jProcess))
+}
+
+// ToDomainProcess converts a process in the jaeger.thrift format into a Jaeger domain model process.
+func ToDomainProcess(jProcess *jaeger.Process) *model.Process {
+	if jProcess == nil {
+		return &model.Process{}
+	}
+	return model.NewProcess(jProcess.ServiceName, tagsToDomain(jProcess.Tags))
+}
+
+func toDomainSpan(jSpan *jaeger.Span, process *model.Process) *model.Span {
+	traceID := model.NewTraceID(uint64(jSpan.TraceIdHigh), uint64(jSpan.TraceIdLow))
+	refs := referencesToDomain(jSpan.References)
+	// The parent span ID predates references and is still the only
+	// parent reference of spans reported by older clients.
+	refs = model.MaybeAddParentSpanID(traceID, model.NewSpanID(uint64(jSpan.ParentSpanId)), refs)
+	return &model.Span{
+		TraceID:       traceID,
+		SpanID:        model.NewSpanID(uint64(jSpan.SpanId)),
+		OperationName: jSpan.OperationName,
+		References:    refs,
+		Flags:         model.Flags(uint32(jSpan.Flags)),
+		StartTime:     microsToTime(jSpan.StartTime),
+		Duration:      time.Duration(jSpan.Duration) * time.Microsecond,
+		Tags:          tagsToDomain(jSpan.Tags),
+		Logs:          logsToDomain(jSpan.Logs),
+		Process:       process,
+	}
+}
+
+func referencesToDomain(jRefs []*jaeger.SpanRef) []model.SpanRef {
+	if len(jRefs) == 0 {
+		return nil
+	}
+	refs := make([]model.SpanRef, 0, len(jRefs))
+	for _, jRef := range jRefs {
+		refType := model.ChildOf
+		if jRef.RefType == jaeger.SpanRefType_FOLLOWS_FROM {
+			refType = model.FollowsFrom
+		}
+		refs = append(refs, model.SpanRef{
+			TraceID: model.NewTraceID(uint64(jRef.TraceIdHigh), uint64(jRef.TraceIdLow)),
+			SpanID:  model.NewSpanID(uint64(jRef.SpanId)),
+			RefType: refType,
+		})
+	}
+	return refs
+}
+
+func tagsToDomain(jTags []*jaeger.Tag) []model.KeyValue {
+	if len(jTags) == 0 {
+		return nil
+	}
+	tags := make([]model.KeyValue, 0, len(jTags))
+	for _, jTag := range jTags {
+		tags = append(tags, tagToDomain(jTag))
+	}
+	return tags
+}
+
+// tagToDomain converts a tag. A tag whose value does not match its type
+// becomes a string tag describing the problem, rather than being dropped.
+func tagToDomain(jTag *jaeger.Tag) model.KeyValue {
+	switch jTag.VType {
+	case jaeger.TagType_STRING:
+		if jTag.VStr != nil {
+			return model.String(jTag.Key, *jTag.VStr)
+		}
+	case jaeger.TagType_BOOL:
+		if jTag.VBool != nil {
+			return model.Bool(jTag.Key, *jTag.VBool)
+		}
+	case jaeger.TagType_LONG:
+		if jTag.VLong != nil {
+			return model.Int64(jTag.Key, *jTag.VLong)
+		}
+	case jaeger.TagType_DOUBLE:
+		if jTag.VDouble != nil {
+			return model.Float64(jTag.Key, *jTag.VDouble)
+		}
+	case jaeger.TagType_BINARY:
+		return model.Binary(jTag.Key, jTag.VBinary)
+	default:
+		return model.String(jTag.Key, fmt.Sprintf("unknown tag type %d", jTag.VType))
+	}
+	return model.String(jTag.Key, fmt.Sprintf("missing value of type %s", jTag.VType))
+}
+
+func logsToDomain(jLogs []*jaeger.Log) []model.Log {
+	if len(jLogs) == 0 {
+		return nil
+	}
+	logs := make([]model.Log, 0, len(jLogs))
+	for _, jLog := range jLogs {
+		logs = append(logs, model.Log{
+			Timestamp: microsToTime(jLog.Timestamp),
+			Fields:    tagsToDomain(jLog.Fields),
+		})
+	}
+	return logs
+}
+
+func microsToTime(micros int64) time.Time {
+	if micros == 0 {
+		return time.Time{}
+	}
+	return time.UnixMicro(micros).UTC()
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package jaeger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/internal/golden"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+	"github.com/jaegertracing/jaeger-idl/thrift-gen/jaeger"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestToDomain(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	jProcess := &jaeger.Process{
+		ServiceName: "frontend",
+		Tags:        []*jaeger.Tag{{Key: "hostname", VType: jaeger.TagType_STRING, VStr: ptr("host-1")}},
+	}
+	jSpans := []*jaeger.Span{
+		{
+			TraceIdLow:    0x090a0b0c0d0e0f10,
+			TraceIdHigh:   0x0102030405060708,
+			SpanId:        2,
+			ParentSpanId:  1,
+			OperationName: "get /users",
+			References: []*jaeger.SpanRef{
+				{RefType: jaeger.SpanRefType_FOLLOWS_FROM, TraceIdLow: 7, SpanId: 3},
+			},
+			Flags:     3,
+			StartTime: start.UnixMicro(),
+			Duration:  1500,
+			Tags: []*jaeger.Tag{
+				{Key: "s", VType: jaeger.TagType_STRING, VStr: ptr("v")},
+				{Key: "b", VType: jaeger.TagType_BOOL, VBool: ptr(true)},
+				{Key: "l", VType: jaeger.TagType_LONG, VLong: ptr(int64(42))},
+				{Key: "d", VType: jaeger.TagType_DOUBLE, VDouble: ptr(1.5)},
+				{Key: "x", VType: jaeger.TagType_BINARY, VBinary: []byte{1, 2}},
+			},
+			Logs: []*jaeger.Log{{
+				Timestamp: start.Add(time.Millisecond).UnixMicro(),
+				Fields:    []*jaeger.Tag{{Key: "event", VType: jaeger.TagType_STRING, VStr: ptr("retry")}},
+			}},
+		},
+		nil,
+		{TraceIdLow: 7, SpanId: 3, OperationName: "root"},
+	}
+
+	spans := ToDomain(jSpans, jProcess)
+	require.Len(t, spans, 2)
+
+	traceID := model.NewTraceID(0x0102030405060708, 0x090a0b0c0d0e0f10)
+	span := spans[0]
+	assert.Equal(t, traceID, span.TraceID)
+	assert.Equal(t, model.NewSpanID(2), span.SpanID)
+	assert.Equal(t, "get /users", span.OperationName)
+	assert.Equal(t, []model.SpanRef{
+		model.NewChildOfRef(traceID, model.NewSpanID(1)),
+		model.NewFollowsFromRef(model.NewTraceID(0, 7), model.NewSpanID(3)),
+	}, span.References)
+	assert.True(t, span.Flags.IsSampled())
+	assert.True(t, span.Flags.IsDebug())
+	assert.Equal(t, start, span.StartTime)
+	assert.Equal(t, 1500*time.Microsecond, span.Duration)
+	assert.Equal(t, []model.KeyValue{
+		model.String("s", "v"),
+		model.Bool("b", true),
+		model.Int64("l", 42),
+		model.Float64("d", 1.5),
+		model.Binary("x", []byte{1, 2}),
+	}, span.Tags)
+	assert.Equal(t, []model.Log{{
+		Timestamp: start.Add(time.Millisecond),
+		Fields:    []model.KeyValue{model.String("event", "retry")},
+	}}, span.Logs)
+	assert.Equal(t, model.NewProcess("frontend", []model.KeyValue{model.String("hostname", "host-1")}), span.Process)
+
+	root := spans[1]
+	assert.Empty(t, root.References)
+	assert.True(t, root.StartTime.IsZero())
+	assert.Same(t, span.Process, root.Process, "spans of a batch share the process")
+	golden.AssertSpans(t, "to_domain", spans)
+}
+
+func TestToDomainSpanKeepsExistingParentReference(t *testing.T) {
+	span := ToDomainSpan(&jaeger.Span{
+		TraceIdLow:   1,
+		SpanId:       2,
+		ParentSpanId: 1,
+		References:   []*jaeger.SpanRef{{RefType: jaeger.SpanRefType_CHILD_OF, TraceIdLow: 1, SpanId: 1}},
+	}, nil)
+	assert.Equal(t, []model.SpanRef{model.NewChildOfRef(model.NewTraceID(0, 1), model.NewSpanID(1))}, span.References)
+	assert.Equal(t, &model.Process{}, span.Process)
+}
+
+func TestTagToDomainInvalidValues(t *testing.T) {
+	tests := []struct {
+		tag      *jaeger.Tag
+		expected model.KeyValue
+	}{
+		{
+			tag:      &jaeger.Tag{Key: "k", VType: jaeger.TagType_LONG},
+			expected: model.String("k", "missing value of type LONG"),
+		},
+		{
+			tag:      &jaeger.Tag{Key: "k", VType: jaeger.TagType(42), VStr: ptr("v")},
+			expected: model.String("k", "unknown tag type 42"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.expected.VStr, func(t *testing.T) {
+			assert.Equal(t, test.expected, tagToDomain(test.tag))
+		})
+	}
+}
+
+func FuzzToDomain(f *testing.F) {
+	batch := &jaeger.Batch{
+		Process: &jaeger.Process{ServiceName: "frontend"},
+		Spans: []*jaeger.Span{{
+			TraceIdLow:    7,
+			SpanId:        3,
+			OperationName: "root",
+			Tags:          []*jaeger.Tag{{Key: "s", VType: jaeger.TagType_STRING, VStr: ptr("v")}},
+			Logs:          []*jaeger.Log{{Fields: []*jaeger.Tag{{Key: "event", VType: jaeger.TagType_LONG}}}},
+		}},
+	}
+	transport := thrift.NewTMemoryBuffer()
+	require.NoError(f, batch.Write(context.Background(), thrift.NewTCompactProtocolConf(transport, &thrift.TConfiguration{})))
+	f.Add(transport.Bytes())
+	f.Fuzz(func(_ *testing.T, data []byte) {
+		transport := thrift.NewTMemoryBufferLen(len(data))
+		transport.Write(data)
+		batch := &jaeger.Batch{}
+		if batch.Read(context.Background(), thrift.NewTCompactProtocolConf(transport, &thrift.TConfiguration{})) != nil {
+			return
+		}
+		ToDomain(batch.GetSpans(), batch.GetProcess())
+	})
+}
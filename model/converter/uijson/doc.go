@@ -0,0 +1,7 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package uijson converts traces in the JSON format of the Jaeger UI, as
+// returned by the Jaeger HTTP query API and downloaded with the UI, into
+// the Jaeger domain model and back.
+package uijson
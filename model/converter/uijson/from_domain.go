@@ -0,0 +1,121 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package uijson
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// Response is the response of the Jaeger HTTP query API, which is also the
+// format of the traces downloaded from the Jaeger UI.
+type Response struct {
+	Data []*Trace `json:"data"`
+}
+
+// FromDomain converts Jaeger domain model spans into traces, in the order
+// of the first span of each trace. The processes of the spans of a trace
+// are deduplicated into its Processes, with the IDs p1, p2, ...
+func FromDomain(spans []*model.Span) []*Trace {
+	traces := []*Trace{}
+	byID := make(map[model.TraceID]*Trace)
+	processes := make(map[*Trace][]*model.Process)
+	for _, span := range spans {
+		if span == nil {
+			continue
+		}
+		trace, ok := byID[span.TraceID]
+		if !ok {
+			trace = &Trace{TraceID: span.TraceID.String(), Processes: make(map[string]*Process)}
+			byID[span.TraceID] = trace
+			traces = append(traces, trace)
+		}
+		processID := ""
+		for i, p := range processes[trace] {
+			if p.Equal(span.Process) {
+				processID = fmt.Sprintf("p%d", i+1)
+				break
+			}
+		}
+		if processID == "" {
+			processes[trace] = append(processes[trace], span.Process)
+			processID = fmt.Sprintf("p%d", len(processes[trace]))
+			trace.Processes[processID] = processFromDomain(span.Process)
+		}
+		trace.Spans = append(trace.Spans, SpanFromDomain(span, processID))
+	}
+	return traces
+}
+
+// SpanFromDomain converts a Jaeger domain model span, whose process has the given ID.
+func SpanFromDomain(span *model.Span, processID string) *Span {
+	s := &Span{
+		TraceID:       span.TraceID.String(),
+		SpanID:        span.SpanID.String(),
+		Flags:         uint32(span.Flags),
+		OperationName: span.OperationName,
+		References:    make([]Reference, 0, len(span.References)),
+		StartTime:     timeToMicros(span),
+		Duration:      uint64(span.Duration.Microseconds()),
+		Tags:          tagsFromDomain(span.Tags),
+		Logs:          make([]Log, 0, len(span.Logs)),
+		ProcessID:     processID,
+		Warnings:      span.Warnings,
+	}
+	for _, ref := range span.References {
+		refType := ChildOf
+		if ref.RefType == model.FollowsFrom {
+			refType = FollowsFrom
+		}
+		s.References = append(s.References, Reference{
+			RefType: refType,
+			TraceID: ref.TraceID.String(),
+			SpanID:  ref.SpanID.String(),
+		})
+	}
+	for _, l := range span.Logs {
+		s.Logs = append(s.Logs, Log{
+			Timestamp: uint64(l.Timestamp.UnixMicro()),
+			Fields:    tagsFromDomain(l.Fields),
+		})
+	}
+	return s
+}
+
+func timeToMicros(span *model.Span) uint64 {
+	if span.StartTime.IsZero() {
+		return 0
+	}
+	return uint64(span.StartTime.UnixMicro())
+}
+
+func processFromDomain(process *model.Process) *Process {
+	return &Process{ServiceName: process.GetServiceName(), Tags: tagsFromDomain(process.GetTags())}
+}
+
+func tagsFromDomain(tags []model.KeyValue) []KeyValue {
+	kvs := make([]KeyValue, 0, len(tags))
+	for i := range tags {
+		tag := &tags[i]
+		var typ string
+		switch tag.VType {
+		case model.BoolType:
+			typ = "bool"
+		case model.Int64Type:
+			typ = "int64"
+		case model.Float64Type:
+			typ = "float64"
+		case model.BinaryType:
+			typ = "binary"
+		default:
+			typ = "string"
+		}
+		// the values are a string, bool, int64, float64 or []byte, which cannot fail to marshal
+		value, _ := json.Marshal(tag.Value())
+		kvs = append(kvs, KeyValue{Key: tag.Key, Type: typ, Value: value})
+	}
+	return kvs
+}
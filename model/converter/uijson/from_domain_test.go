@@ -0,0 +1,82 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package uijson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/internal/golden"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+func TestFromDomain(t *testing.T) {
+	traces, err := ParseJSON([]byte(testTrace))
+	require.NoError(t, err)
+	spans, err := ToDomain(traces)
+	require.NoError(t, err)
+
+	converted := FromDomain(spans)
+	require.Len(t, converted, 1)
+	trace := converted[0]
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", trace.TraceID)
+	require.Len(t, trace.Spans, 2)
+	assert.Equal(t, map[string]*Process{
+		"p1": {ServiceName: "frontend", Tags: []KeyValue{{Key: "hostname", Type: "string", Value: json.RawMessage(`"web-1"`)}}},
+		"p2": {ServiceName: "db", Tags: []KeyValue{}},
+	}, trace.Processes)
+
+	root := trace.Spans[0]
+	assert.Equal(t, "0000000000000001", root.SpanID)
+	assert.Equal(t, "p1", root.ProcessID)
+	assert.Equal(t, uint32(1), root.Flags)
+	assert.Equal(t, uint64(1767322800000000), root.StartTime)
+	assert.Equal(t, uint64(1500), root.Duration)
+	assert.Equal(t, []KeyValue{
+		{Key: "span.kind", Type: "string", Value: json.RawMessage(`"server"`)},
+		{Key: "error", Type: "bool", Value: json.RawMessage(`true`)},
+		{Key: "http.status_code", Type: "int64", Value: json.RawMessage(`500`)},
+		{Key: "ratio", Type: "float64", Value: json.RawMessage(`0.5`)},
+		{Key: "payload", Type: "binary", Value: json.RawMessage(`"AQI="`)},
+	}, root.Tags)
+	assert.Equal(t, []Log{{
+		Timestamp: 1767322800000100,
+		Fields:    []KeyValue{{Key: "event", Type: "string", Value: json.RawMessage(`"retry"`)}},
+	}}, root.Logs)
+
+	child := trace.Spans[1]
+	assert.Equal(t, "p2", child.ProcessID)
+	assert.Equal(t, []Reference{
+		{RefType: ChildOf, TraceID: trace.TraceID, SpanID: "0000000000000001"},
+		{RefType: FollowsFrom, TraceID: trace.TraceID, SpanID: "0000000000000003"},
+	}, child.References)
+	golden.AssertJSON(t, "from_domain", converted)
+
+	// the converted traces convert back to the same spans
+	back, err := ToDomain(converted)
+	require.NoError(t, err)
+	assert.Equal(t, spans, back)
+}
+
+func TestFromDomainGroupsTraces(t *testing.T) {
+	process := &model.Process{ServiceName: "svc"}
+	spans := []*model.Span{
+		{TraceID: model.NewTraceID(0, 2), SpanID: model.NewSpanID(1), Process: process},
+		{TraceID: model.NewTraceID(0, 1), SpanID: model.NewSpanID(2), Process: process},
+		nil,
+		{TraceID: model.NewTraceID(0, 2), SpanID: model.NewSpanID(3), Process: &model.Process{ServiceName: "svc"}},
+	}
+	traces := FromDomain(spans)
+	require.Len(t, traces, 2)
+	assert.Equal(t, "0000000000000002", traces[0].TraceID)
+	require.Len(t, traces[0].Spans, 2)
+	assert.Equal(t, "0000000000000003", traces[0].Spans[1].SpanID)
+	assert.Equal(t, "p1", traces[0].Spans[1].ProcessID)
+	assert.Len(t, traces[0].Processes, 1)
+	assert.Equal(t, "0000000000000001", traces[1].TraceID)
+	assert.Equal(t, uint64(0), traces[1].Spans[0].StartTime)
+}
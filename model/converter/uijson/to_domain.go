@@ -0,0 +1,297 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package uijson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// Reference types.
+const (
+	ChildOf     = "CHILD_OF"
+	FollowsFrom = "FOLLOWS_FROM"
+)
+
+// Trace is a trace in the JSON format of the Jaeger UI.
+type Trace struct {
+	TraceID string  `json:"traceID"`
+	Spans   []*Span `json:"spans"`
+	// Processes are the processes of the spans, by ID.
+	Processes map[string]*Process `json:"processes"`
+	Warnings  []string            `json:"warnings,omitempty"`
+}
+
+// Span is a span in the JSON format of the Jaeger UI.
+type Span struct {
+	TraceID       string      `json:"traceID"`
+	SpanID        string      `json:"spanID"`
+	Flags         uint32      `json:"flags,omitempty"`
+	OperationName string      `json:"operationName"`
+	References    []Reference `json:"references"`
+	// StartTime is in microseconds since the epoch.
+	StartTime uint64 `json:"startTime"`
+	// Duration is in microseconds.
+	Duration uint64     `json:"duration"`
+	Tags     []KeyValue `json:"tags"`
+	Logs     []Log      `json:"logs"`
+	// ProcessID is the key of the process of the span in the processes of
+	// the trace, unless Process is set.
+	ProcessID string   `json:"processID,omitempty"`
+	Process   *Process `json:"process,omitempty"`
+	Warnings  []string `json:"warnings,omitempty"`
+}
+
+// Reference is a reference from a span to another span.
+type Reference struct {
+	RefType string `json:"refType"`
+	TraceID string `json:"traceID"`
+	SpanID  string `json:"spanID"`
+}
+
+// Process is the process emitting a set of spans.
+type Process struct {
+	ServiceName string     `json:"serviceName"`
+	Tags        []KeyValue `json:"tags"`
+}
+
+// Log is a timestamped event of a span.
+type Log struct {
+	// Timestamp is in microseconds since the epoch.
+	Timestamp uint64     `json:"timestamp"`
+	Fields    []KeyValue `json:"fields"`
+}
+
+// KeyValue is a typed tag. Type is one of string, bool, int64, float64 or
+// binary, for which Value is base64-encoded.
+type KeyValue struct {
+	Key   string          `json:"key"`
+	Type  string          `json:"type,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ParseJSON decodes traces in the JSON format of the Jaeger UI: either the
+// response of the HTTP query API, {"data": [traces...]}, as downloaded from
+// the UI, a list of traces or a single trace.
+func ParseJSON(data []byte) ([]*Trace, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		var traces []*Trace
+		if err := json.Unmarshal(data, &traces); err != nil {
+			return nil, fmt.Errorf("cannot parse Jaeger UI JSON traces: %w", err)
+		}
+		return traces, nil
+	}
+	var doc struct {
+		Trace
+		Data []*Trace `json:"data"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("cannot parse Jaeger UI JSON traces: %w", err)
+	}
+	if doc.Data != nil {
+		return doc.Data, nil
+	}
+	if doc.Spans == nil {
+		return nil, errors.New("cannot parse Jaeger UI JSON traces: neither data nor spans found")
+	}
+	return []*Trace{&doc.Trace}, nil
+}
+
+// ToDomain converts traces into Jaeger domain model spans.
+// It fails on the first span that cannot be converted.
+func ToDomain(traces []*Trace) ([]*model.Span, error) {
+	var result []*model.Span
+	for i, trace := range traces {
+		spans, err := TraceToDomain(trace)
+		if err != nil {
+			return nil, fmt.Errorf("trace %d: %w", i, err)
+		}
+		result = append(result, spans...)
+	}
+	return result, nil
+}
+
+// TraceToDomain converts the spans of a trace into Jaeger domain model spans.
+func TraceToDomain(trace *Trace) ([]*model.Span, error) {
+	if trace == nil {
+		return nil, errors.New("trace is null")
+	}
+	processes := make(map[string]*model.Process, len(trace.Processes))
+	for id, p := range trace.Processes {
+		process, err := processToDomain(p)
+		if err != nil {
+			return nil, fmt.Errorf("process %s: %w", id, err)
+		}
+		processes[id] = process
+	}
+	spans := make([]*model.Span, 0, len(trace.Spans))
+	for i, span := range trace.Spans {
+		domainSpan, err := SpanToDomain(span, processes)
+		if err != nil {
+			return nil, fmt.Errorf("span %d: %w", i, err)
+		}
+		spans = append(spans, domainSpan)
+	}
+	return spans, nil
+}
+
+// SpanToDomain converts a single span into a Jaeger domain model span, its
+// process being either embedded or found by ID in processes.
+func SpanToDomain(span *Span, processes map[string]*model.Process) (*model.Span, error) {
+	if span == nil {
+		return nil, errors.New("span is null")
+	}
+	traceID, err := model.TraceIDFromString(span.TraceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trace ID %q: %w", span.TraceID, err)
+	}
+	spanID, err := model.SpanIDFromString(span.SpanID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid span ID %q: %w", span.SpanID, err)
+	}
+	refs, err := referencesToDomain(span.References, traceID)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := tagsToDomain(span.Tags)
+	if err != nil {
+		return nil, err
+	}
+	logs := make([]model.Log, 0, len(span.Logs))
+	for _, l := range span.Logs {
+		fields, err := tagsToDomain(l.Fields)
+		if err != nil {
+			return nil, fmt.Errorf("log: %w", err)
+		}
+		logs = append(logs, model.Log{Timestamp: microsToTime(l.Timestamp), Fields: fields})
+	}
+	var process *model.Process
+	if span.Process != nil {
+		if process, err = processToDomain(span.Process); err != nil {
+			return nil, err
+		}
+	} else {
+		var ok bool
+		if process, ok = processes[span.ProcessID]; !ok {
+			return nil, fmt.Errorf("unknown process ID %q", span.ProcessID)
+		}
+	}
+	return &model.Span{
+		TraceID:       traceID,
+		SpanID:        spanID,
+		OperationName: span.OperationName,
+		References:    refs,
+		Flags:         model.Flags(span.Flags),
+		StartTime:     microsToTime(span.StartTime),
+		Duration:      time.Duration(span.Duration) * time.Microsecond,
+		Tags:          tags,
+		Logs:          logs,
+		Process:       process,
+		Warnings:      span.Warnings,
+	}, nil
+}
+
+// referencesToDomain converts the references, which default to the trace of the span.
+func referencesToDomain(refs []Reference, traceID model.TraceID) ([]model.SpanRef, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	result := make([]model.SpanRef, 0, len(refs))
+	for _, ref := range refs {
+		refTraceID := traceID
+		if ref.TraceID != "" {
+			var err error
+			if refTraceID, err = model.TraceIDFromString(ref.TraceID); err != nil {
+				return nil, fmt.Errorf("invalid reference trace ID %q: %w", ref.TraceID, err)
+			}
+		}
+		spanID, err := model.SpanIDFromString(ref.SpanID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reference span ID %q: %w", ref.SpanID, err)
+		}
+		switch strings.ToUpper(ref.RefType) {
+		case ChildOf:
+			result = append(result, model.NewChildOfRef(refTraceID, spanID))
+		case FollowsFrom:
+			result = append(result, model.NewFollowsFromRef(refTraceID, spanID))
+		default:
+			return nil, fmt.Errorf("unknown reference type %q", ref.RefType)
+		}
+	}
+	return result, nil
+}
+
+func processToDomain(process *Process) (*model.Process, error) {
+	if process == nil {
+		return nil, errors.New("process is null")
+	}
+	tags, err := tagsToDomain(process.Tags)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Process{ServiceName: process.ServiceName, Tags: tags}, nil
+}
+
+func tagsToDomain(kvs []KeyValue) ([]model.KeyValue, error) {
+	if len(kvs) == 0 {
+		return nil, nil
+	}
+	tags := make([]model.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		tag, err := tagToDomain(kv)
+		if err != nil {
+			return nil, fmt.Errorf("tag %s: %w", kv.Key, err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+func tagToDomain(kv KeyValue) (model.KeyValue, error) {
+	var err error
+	switch strings.ToLower(kv.Type) {
+	case "", "string":
+		var v string
+		if err = json.Unmarshal(kv.Value, &v); err == nil {
+			return model.String(kv.Key, v), nil
+		}
+	case "bool":
+		var v bool
+		if err = json.Unmarshal(kv.Value, &v); err == nil {
+			return model.Bool(kv.Key, v), nil
+		}
+	case "int64":
+		var v int64
+		if err = json.Unmarshal(kv.Value, &v); err == nil {
+			return model.Int64(kv.Key, v), nil
+		}
+	case "float64":
+		var v float64
+		if err = json.Unmarshal(kv.Value, &v); err == nil {
+			return model.Float64(kv.Key, v), nil
+		}
+	case "binary":
+		var v []byte
+		if err = json.Unmarshal(kv.Value, &v); err == nil {
+			return model.Binary(kv.Key, v), nil
+		}
+	default:
+		return model.KeyValue{}, fmt.Errorf("unknown type %q", kv.Type)
+	}
+	return model.KeyValue{}, fmt.Errorf("invalid %s value %s: %w", kv.Type, kv.Value, err)
+}
+
+func microsToTime(micros uint64) time.Time {
+	if micros == 0 {
+		return time.Time{}
+	}
+	return time.UnixMicro(int64(micros)).UTC()
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package uijson
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+const testTrace = `{
+  "traceID": "0102030405060708090a0b0c0d0e0f10",
+  "spans": [
+    {
+      "traceID": "0102030405060708090a0b0c0d0e0f10",
+      "spanID": "0000000000000001",
+      "flags": 1,
+      "operationName": "GET /users",
+      "references": [],
+      "startTime": 1767322800000000,
+      "duration": 1500,
+      "tags": [
+        {"key": "span.kind", "type": "string", "value": "server"},
+        {"key": "error", "type": "bool", "value": true},
+        {"key": "http.status_code", "type": "int64", "value": 500},
+        {"key": "ratio", "type": "float64", "value": 0.5},
+        {"key": "payload", "type": "binary", "value": "AQI="}
+      ],
+      "logs": [
+        {"timestamp": 1767322800000100, "fields": [{"key": "event", "type": "string", "value": "retry"}]}
+      ],
+      "processID": "p1",
+      "warnings": null
+    },
+    {
+      "traceID": "0102030405060708090a0b0c0d0e0f10",
+      "spanID": "0000000000000002",
+      "operationName": "SELECT",
+      "references": [
+        {"refType": "CHILD_OF", "traceID": "0102030405060708090a0b0c0d0e0f10", "spanID": "0000000000000001"},
+        {"refType": "FOLLOWS_FROM", "spanID": "0000000000000003"}
+      ],
+      "startTime": 1767322800000200,
+      "duration": 1000,
+      "tags": [],
+      "logs": [],
+      "process": {"serviceName": "db", "tags": []}
+    }
+  ],
+  "processes": {
+    "p1": {"serviceName": "frontend", "tags": [{"key": "hostname", "type": "string", "value": "web-1"}]}
+  },
+  "warnings": null
+}`
+
+func TestToDomain(t *testing.T) {
+	traces, err := ParseJSON([]byte(`{"data": [` + testTrace + `], "total": 0, "limit": 0, "offset": 0, "errors": null}`))
+	require.NoError(t, err)
+	spans, err := ToDomain(traces)
+	require.NoError(t, err)
+	require.Len(t, spans, 2)
+
+	start := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	traceID := model.NewTraceID(0x0102030405060708, 0x090a0b0c0d0e0f10)
+	root := spans[0]
+	assert.Equal(t, traceID, root.TraceID)
+	assert.Equal(t, model.NewSpanID(1), root.SpanID)
+	assert.Empty(t, root.References)
+	assert.True(t, root.Flags.IsSampled())
+	assert.Equal(t, "GET /users", root.OperationName)
+	assert.Equal(t, start, root.StartTime)
+	assert.Equal(t, 1500*time.Microsecond, root.Duration)
+	assert.Equal(t, []model.KeyValue{
+		model.String("span.kind", "server"),
+		model.Bool("error", true),
+		model.Int64("http.status_code", 500),
+		model.Float64("ratio", 0.5),
+		model.Binary("payload", []byte{1, 2}),
+	}, root.Tags)
+	assert.Equal(t, []model.Log{
+		{Timestamp: start.Add(100 * time.Microsecond), Fields: []model.KeyValue{model.String("event", "retry")}},
+	}, root.Logs)
+	assert.Equal(t, &model.Process{
+		ServiceName: "frontend",
+		Tags:        []model.KeyValue{model.String("hostname", "web-1")},
+	}, root.Process)
+
+	child := spans[1]
+	assert.Equal(t, []model.SpanRef{
+		model.NewChildOfRef(traceID, model.NewSpanID(1)),
+		model.NewFollowsFromRef(traceID, model.NewSpanID(3)),
+	}, child.References)
+	assert.Equal(t, model.NewSpanID(1), child.ParentSpanID())
+	assert.Equal(t, &model.Process{ServiceName: "db"}, child.Process)
+}
+
+func TestParseJSONForms(t *testing.T) {
+	for name, doc := range map[string]string{
+		"response": `{"data": [` + testTrace + `]}`,
+		"list":     `[` + testTrace + `]`,
+		"trace":    testTrace,
+	} {
+		t.Run(name, func(t *testing.T) {
+			traces, err := ParseJSON([]byte(doc))
+			require.NoError(t, err)
+			require.Len(t, traces, 1)
+			assert.Len(t, traces[0].Spans, 2)
+		})
+	}
+}
+
+func TestParseJSONInvalid(t *testing.T) {
+	_, err := ParseJSON([]byte(`{"data": {}}`))
+	require.ErrorContains(t, err, "cannot parse Jaeger UI JSON traces")
+	_, err = ParseJSON([]byte(`{"foo": 1}`))
+	require.ErrorContains(t, err, "neither data nor spans found")
+	_, err = ParseJSON([]byte(`[`))
+	require.ErrorContains(t, err, "cannot parse Jaeger UI JSON traces")
+}
+
+func TestToDomainErrors(t *testing.T) {
+	valid := func() *Span {
+		return &Span{
+			TraceID:   "1",
+			SpanID:    "2",
+			ProcessID: "p1",
+		}
+	}
+	tests := []struct {
+		name   string
+		mutate func(*Span)
+		err    string
+	}{
+		{"trace ID", func(s *Span) { s.TraceID = "x" }, `invalid trace ID "x"`},
+		{"span ID", func(s *Span) { s.SpanID = "x" }, `invalid span ID "x"`},
+		{"process ID", func(s *Span) { s.ProcessID = "p2" }, `unknown process ID "p2"`},
+		{"reference type", func(s *Span) { s.References = []Reference{{RefType: "PARENT", SpanID: "1"}} }, `unknown reference type "PARENT"`},
+		{"reference span ID", func(s *Span) { s.References = []Reference{{RefType: ChildOf, SpanID: "x"}} }, `invalid reference span ID "x"`},
+		{"tag type", func(s *Span) { s.Tags = []KeyValue{{Key: "k", Type: "map", Value: []byte(`{}`)}} }, `tag k: unknown type "map"`},
+		{"tag value", func(s *Span) { s.Tags = []KeyValue{{Key: "k", Type: "int64", Value: []byte(`"1"`)}} }, `tag k: invalid int64 value "1"`},
+		{"log field", func(s *Span) {
+			s.Logs = []Log{{Fields: []KeyValue{{Key: "k", Type: "bool", Value: []byte(`1`)}}}}
+		}, `log: tag k: invalid bool value 1`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			span := valid()
+			test.mutate(span)
+			_, err := ToDomain([]*Trace{{
+				Spans:     []*Span{span},
+				Processes: map[string]*Process{"p1": {ServiceName: "svc"}},
+			}})
+			require.ErrorContains(t, err, "trace 0: span 0: "+test.err)
+		})
+	}
+
+	_, err := ToDomain([]*Trace{nil})
+	require.ErrorContains(t, err, "trace is null")
+	_, err = TraceToDomain(&Trace{Spans: []*Span{nil}})
+	require.ErrorContains(t, err, "span 0: span is null")
+	_, err = TraceToDomain(&Trace{Processes: map[string]*Process{"p1": nil}})
+	require.ErrorContains(t, err, "process p1: process is null")
+}
+
+func FuzzToDomain(f *testing.F) {
+	f.Add([]byte(testTrace))
+	f.Add([]byte(`{"data": [{"spans": [{"traceID": "1", "spanID": "1", "processID": "p1"}]}]}`))
+	f.Fuzz(func(_ *testing.T, data []byte) {
+		traces, err := ParseJSON(data)
+		if err != nil {
+			return
+		}
+		ToDomain(traces)
+	})
+}
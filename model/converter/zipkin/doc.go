@@ -0,0 +1,6 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package zipkin converts spans in the Zipkin JSON v2 format
+// into the Jaeger domain model.
+package zipkin
@@ -0,0 +1,265 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package zipkin
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// Tag keys used for Zipkin fields that have no dedicated place in the Jaeger model.
+const (
+	PeerServiceKey  = "peer.service"
+	PeerIPv4Key     = "peer.ipv4"
+	PeerIPv6Key     = "peer.ipv6"
+	PeerPortKey     = "peer.port"
+	ProcessIPKey    = "ip"
+	ErrorKey        = "error"
+	ErrorMessageKey = "error.message"
+	AnnotationKey   = "event"
+
+	// UnknownServiceName is used as the service name of spans without a local endpoint.
+	UnknownServiceName = "unknown_service"
+)
+
+// Span is a span in the Zipkin JSON v2 format.
+// See https://zipkin.io/zipkin-api/#/default/post_spans.
+type Span struct {
+	TraceID  string `json:"traceId"`
+	ParentID string `json:"parentId,omitempty"`
+	ID       string `json:"id"`
+	// Kind is one of CLIENT, SERVER, PRODUCER or CONSUMER, or empty.
+	Kind string `json:"kind,omitempty"`
+	Name string `json:"name,omitempty"`
+	// Timestamp is the start of the span in microseconds since the epoch.
+	Timestamp uint64 `json:"timestamp,omitempty"`
+	// Duration is in microseconds.
+	Duration       uint64            `json:"duration,omitempty"`
+	Debug          bool              `json:"debug,omitempty"`
+	Shared         bool              `json:"shared,omitempty"`
+	LocalEndpoint  *Endpoint         `json:"localEndpoint,omitempty"`
+	RemoteEndpoint *Endpoint         `json:"remoteEndpoint,omitempty"`
+	Annotations    []Annotation      `json:"annotations,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+}
+
+// Endpoint is the network context of a node in the service graph.
+type Endpoint struct {
+	ServiceName string `json:"serviceName,omitempty"`
+	IPv4        string `json:"ipv4,omitempty"`
+	IPv6        string `json:"ipv6,omitempty"`
+	Port        int    `json:"port,omitempty"`
+}
+
+// Annotation is a timestamped event of a span.
+type Annotation struct {
+	// Timestamp is in microseconds since the epoch.
+	Timestamp uint64 `json:"timestamp"`
+	Value     string `json:"value"`
+}
+
+// ParseJSON decodes a list of spans in the Zipkin JSON v2 format.
+func ParseJSON(data []byte) ([]*Span, error) {
+	var spans []*Span
+	if err := json.Unmarshal(data, &spans); err != nil {
+		return nil, fmt.Errorf("cannot parse Zipkin JSON v2 spans: %w", err)
+	}
+	return spans, nil
+}
+
+// ToDomain converts Zipkin spans into Jaeger domain model spans.
+// It fails on the first span that cannot be converted.
+func ToDomain(spans []*Span) ([]*model.Span, error) {
+	result := make([]*model.Span, 0, len(spans))
+	for i, span := range spans {
+		domainSpan, err := SpanToDomain(span)
+		if err != nil {
+			return nil, fmt.Errorf("span %d: %w", i, err)
+		}
+		result = append(result, domainSpan)
+	}
+	return result, nil
+}
+
+// SpanToDomain converts a single Zipkin span into a Jaeger domain model span.
+//
+// Zipkin lets the server side of an RPC share the span ID of the client side,
+// marking it as shared. Jaeger requires unique span IDs, so a shared server
+// span gets a new ID derived from the original one and becomes a child of
+// the client span.
+func SpanToDomain(span *Span) (*model.Span, error) {
+	if span == nil {
+		return nil, errors.New("span is null")
+	}
+	traceID, err := model.TraceIDFromString(span.TraceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trace ID %q: %w", span.TraceID, err)
+	}
+	spanID, err := model.SpanIDFromString(span.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid span ID %q: %w", span.ID, err)
+	}
+	var refs []model.SpanRef
+	if span.ParentID != "" {
+		parentID, err := model.SpanIDFromString(span.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent ID %q: %w", span.ParentID, err)
+		}
+		refs = model.MaybeAddParentSpanID(traceID, parentID, refs)
+	}
+	kind, err := kindToDomain(span.Kind)
+	if err != nil {
+		return nil, err
+	}
+	if span.Shared && kind == model.SpanKindServer {
+		refs = []model.SpanRef{model.NewChildOfRef(traceID, spanID)}
+		spanID = sharedSpanID(traceID, spanID)
+	}
+
+	var flags model.Flags
+	flags.SetSampled()
+	if span.Debug {
+		flags.SetDebug()
+	}
+
+	tags := tagsToDomain(span.Tags)
+	if kind != model.SpanKindUnspecified {
+		tags = append(tags, model.SpanKindTag(kind))
+	}
+	tags = append(tags, remoteEndpointToTags(span.RemoteEndpoint)...)
+
+	return &model.Span{
+		TraceID:       traceID,
+		SpanID:        spanID,
+		OperationName: span.Name,
+		References:    refs,
+		Flags:         flags,
+		StartTime:     microsToTime(span.Timestamp),
+		Duration:      time.Duration(span.Duration) * time.Microsecond,
+		Tags:          tags,
+		Logs:          annotationsToLogs(span.Annotations),
+		Process:       localEndpointToProcess(span.LocalEndpoint),
+	}, nil
+}
+
+func kindToDomain(kind string) (model.SpanKind, error) {
+	switch strings.ToUpper(kind) {
+	case "":
+		return model.SpanKindUnspecified, nil
+	case "CLIENT":
+		return model.SpanKindClient, nil
+	case "SERVER":
+		return model.SpanKindServer, nil
+	case "PRODUCER":
+		return model.SpanKindProducer, nil
+	case "CONSUMER":
+		return model.SpanKindConsumer, nil
+	default:
+		return model.SpanKindUnspecified, fmt.Errorf("unknown span kind %q", kind)
+	}
+}
+
+// sharedSpanID derives a stable, non-zero span ID for the server side of a shared span.
+func sharedSpanID(traceID model.TraceID, spanID model.SpanID) model.SpanID {
+	var buf [24]byte
+	binary.BigEndian.PutUint64(buf[0:], traceID.High)
+	binary.BigEndian.PutUint64(buf[8:], traceID.Low)
+	binary.BigEndian.PutUint64(buf[16:], uint64(spanID))
+	h := fnv.New64a()
+	h.Write(buf[:])
+	id := model.SpanID(h.Sum64())
+	if id == 0 || id == spanID {
+		id++
+	}
+	return id
+}
+
+// tagsToDomain converts Zipkin tags, sorted by key. The error tag, whose value
+// is the error message in Zipkin, becomes the boolean Jaeger error tag.
+func tagsToDomain(zipkinTags map[string]string) []model.KeyValue {
+	keys := make([]string, 0, len(zipkinTags))
+	for key := range zipkinTags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tags := make([]model.KeyValue, 0, len(keys))
+	for _, key := range keys {
+		value := zipkinTags[key]
+		if key == ErrorKey {
+			tags = append(tags, model.Bool(ErrorKey, true))
+			if value != "" && value != "true" {
+				tags = append(tags, model.String(ErrorMessageKey, value))
+			}
+			continue
+		}
+		tags = append(tags, model.String(key, value))
+	}
+	return tags
+}
+
+func remoteEndpointToTags(endpoint *Endpoint) []model.KeyValue {
+	if endpoint == nil {
+		return nil
+	}
+	var tags []model.KeyValue
+	if endpoint.ServiceName != "" {
+		tags = append(tags, model.String(PeerServiceKey, endpoint.ServiceName))
+	}
+	if endpoint.IPv4 != "" {
+		tags = append(tags, model.String(PeerIPv4Key, endpoint.IPv4))
+	}
+	if endpoint.IPv6 != "" {
+		tags = append(tags, model.String(PeerIPv6Key, endpoint.IPv6))
+	}
+	if endpoint.Port != 0 {
+		tags = append(tags, model.Int64(PeerPortKey, int64(endpoint.Port)))
+	}
+	return tags
+}
+
+func localEndpointToProcess(endpoint *Endpoint) *model.Process {
+	process := &model.Process{ServiceName: UnknownServiceName}
+	if endpoint == nil {
+		return process
+	}
+	if endpoint.ServiceName != "" {
+		process.ServiceName = endpoint.ServiceName
+	}
+	switch {
+	case endpoint.IPv4 != "":
+		process.Tags = append(process.Tags, model.String(ProcessIPKey, endpoint.IPv4))
+	case endpoint.IPv6 != "":
+		process.Tags = append(process.Tags, model.String(ProcessIPKey, endpoint.IPv6))
+	}
+	return process
+}
+
+func annotationsToLogs(annotations []Annotation) []model.Log {
+	if len(annotations) == 0 {
+		return nil
+	}
+	logs := make([]model.Log, 0, len(annotations))
+	for _, annotation := range annotations {
+		logs = append(logs, model.Log{
+			Timestamp: microsToTime(annotation.Timestamp),
+			Fields:    []model.KeyValue{model.String(AnnotationKey, annotation.Value)},
+		})
+	}
+	return logs
+}
+
+func microsToTime(micros uint64) time.Time {
+	if micros == 0 {
+		return time.Time{}
+	}
+	return time.UnixMicro(int64(micros)).UTC()
+}
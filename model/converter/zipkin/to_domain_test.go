@@ -0,0 +1,144 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package zipkin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/internal/golden"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+const testJSON = `[
+  {
+    "traceId": "0102030405060708090a0b0c0d0e0f10",
+    "parentId": "0000000000000001",
+    "id": "0000000000000002",
+    "kind": "CLIENT",
+    "name": "get /users",
+    "timestamp": 1767322800000000,
+    "duration": 1500,
+    "debug": true,
+    "localEndpoint": {"serviceName": "frontend", "ipv4": "10.0.0.1", "port": 8080},
+    "remoteEndpoint": {"serviceName": "users", "ipv6": "::1", "port": 9000},
+    "annotations": [{"timestamp": 1767322800000100, "value": "ws"}],
+    "tags": {"http.path": "/users", "error": "connection reset"}
+  },
+  {
+    "traceId": "090a0b0c0d0e0f10",
+    "id": "0000000000000002",
+    "kind": "SERVER",
+    "shared": true,
+    "name": "get /users",
+    "timestamp": 1767322800000200,
+    "duration": 1000
+  }
+]`
+
+func TestToDomain(t *testing.T) {
+	zipkinSpans, err := ParseJSON([]byte(testJSON))
+	require.NoError(t, err)
+	spans, err := ToDomain(zipkinSpans)
+	require.NoError(t, err)
+	require.Len(t, spans, 2)
+
+	start := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	traceID := model.NewTraceID(0x0102030405060708, 0x090a0b0c0d0e0f10)
+	client := spans[0]
+	assert.Equal(t, traceID, client.TraceID)
+	assert.Equal(t, model.NewSpanID(2), client.SpanID)
+	assert.Equal(t, model.NewSpanID(1), client.ParentSpanID())
+	assert.Equal(t, "get /users", client.OperationName)
+	assert.True(t, client.Flags.IsSampled())
+	assert.True(t, client.Flags.IsDebug())
+	assert.Equal(t, start, client.StartTime)
+	assert.Equal(t, 1500*time.Microsecond, client.Duration)
+	assert.Equal(t, []model.KeyValue{
+		model.Bool("error", true),
+		model.String("error.message", "connection reset"),
+		model.String("http.path", "/users"),
+		model.String("span.kind", "client"),
+		model.String("peer.service", "users"),
+		model.String("peer.ipv6", "::1"),
+		model.Int64("peer.port", 9000),
+	}, client.Tags)
+	assert.Equal(t, []model.Log{
+		{Timestamp: start.Add(100 * time.Microsecond), Fields: []model.KeyValue{model.String("event", "ws")}},
+	}, client.Logs)
+	assert.Equal(t, &model.Process{
+		ServiceName: "frontend",
+		Tags:        []model.KeyValue{model.String("ip", "10.0.0.1")},
+	}, client.Process)
+
+	server := spans[1]
+	assert.Equal(t, model.NewTraceID(0, 0x090a0b0c0d0e0f10), server.TraceID)
+	assert.NotEqual(t, model.NewSpanID(2), server.SpanID)
+	assert.Equal(t, model.NewSpanID(2), server.ParentSpanID())
+	assert.False(t, server.Flags.IsDebug())
+	assert.Equal(t, []model.KeyValue{model.String("span.kind", "server")}, server.Tags)
+	assert.Equal(t, &model.Process{ServiceName: UnknownServiceName}, server.Process)
+	golden.AssertSpans(t, "to_domain", spans)
+
+	again, err := SpanToDomain(zipkinSpans[1])
+	require.NoError(t, err)
+	assert.Equal(t, server.SpanID, again.SpanID, "shared span IDs must be stable")
+}
+
+func TestToDomainErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		span *Span
+		err  string
+	}{
+		{name: "null span", err: "span 0: span is null"},
+		{
+			name: "invalid trace ID",
+			span: &Span{TraceID: "xyz", ID: "1"},
+			err:  `span 0: invalid trace ID "xyz"`,
+		},
+		{
+			name: "missing span ID",
+			span: &Span{TraceID: "1"},
+			err:  `span 0: invalid span ID ""`,
+		},
+		{
+			name: "invalid parent ID",
+			span: &Span{TraceID: "1", ID: "2", ParentID: "00000000000000000003"},
+			err:  `span 0: invalid parent ID "00000000000000000003"`,
+		},
+		{
+			name: "unknown kind",
+			span: &Span{TraceID: "1", ID: "2", Kind: "BROADCAST"},
+			err:  `span 0: unknown span kind "BROADCAST"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ToDomain([]*Span{tt.span})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestParseJSONInvalid(t *testing.T) {
+	_, err := ParseJSON([]byte(`{"traceId": "1"}`))
+	require.ErrorContains(t, err, "cannot parse Zipkin JSON v2 spans")
+}
+
+func FuzzToDomain(f *testing.F) {
+	f.Add([]byte(testJSON))
+	f.Add([]byte(`[{"traceId": "1", "id": "1", "timestamp": -1, "duration": -1}]`))
+	f.Fuzz(func(_ *testing.T, data []byte) {
+		zipkinSpans, err := ParseJSON(data)
+		if err != nil {
+			return
+		}
+		ToDomain(zipkinSpans)
+	})
+}
@@ -0,0 +1,12 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lint checks spans of the Jaeger domain model against the limits of
+// the validation package, the conventions followed by Jaeger, and the
+// structure of their traces, so that services embedding these types can find
+// problems before exporting the spans.
+//
+// Each problem is reported as a Finding of a Rule. The IDs of the rules and
+// the fields of the findings are stable, so that they can be matched or
+// stored by tools.
+package lint
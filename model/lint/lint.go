@@ -0,0 +1,241 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/jaegertracing/jaeger-idl/model/trace"
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+	"github.com/jaegertracing/jaeger-idl/model/validation"
+)
+
+// Severity is how serious a finding is.
+type Severity string
+
+const (
+	// Error means storage backends or the UI cannot handle the span.
+	Error Severity = "error"
+	// Warning means the span breaks a convention, and is displayed or
+	// searched differently than intended.
+	Warning Severity = "warning"
+	// Info means the span is likely fine, e.g. its parent is exported by another service.
+	Info Severity = "info"
+)
+
+// IDs of the rules. The validation rules have the IDs of the validation
+// reasons, the structure rules the IDs of the issue kinds of the trace package.
+const (
+	ZeroTraceID        = string(validation.ZeroTraceID)
+	ZeroSpanID         = string(validation.ZeroSpanID)
+	MissingStartTime   = string(validation.MissingStartTime)
+	NegativeDuration   = string(validation.NegativeDuration)
+	OversizedAttribute = string(validation.OversizedAttribute)
+	TooManyAttributes  = string(validation.TooManyAttributes)
+	OversizedName      = string(validation.OversizedName)
+	MissingServiceName = "missing_service_name"
+	EmptyOperationName = "empty_operation_name"
+	InvalidUTF8        = "invalid_utf8"
+	DuplicateTagKey    = "duplicate_tag_key"
+	InvalidSpanKind    = "invalid_span_kind"
+	NonBooleanErrorTag = "non_boolean_error_tag"
+	LogOutsideSpan     = "log_outside_span"
+	DanglingParent     = string(trace.DanglingParent)
+	SelfReference      = string(trace.SelfReference)
+	ReferenceCycle     = string(trace.ReferenceCycle)
+)
+
+// Rule is a check of the linter.
+type Rule struct {
+	ID          string   `json:"id"`
+	Severity    Severity `json:"severity"`
+	Description string   `json:"description"`
+}
+
+var rules = []Rule{
+	{ZeroTraceID, Error, "the trace ID is zero"},
+	{ZeroSpanID, Error, "the span ID is zero"},
+	{MissingStartTime, Error, "the start time is not set"},
+	{NegativeDuration, Error, "the span ends before it starts"},
+	{OversizedAttribute, Error, "a tag key or value exceeds the size limits"},
+	{TooManyAttributes, Error, "the span, its process or a log has more tags than the limit"},
+	{OversizedName, Error, "the operation or service name exceeds the size limit"},
+	{MissingServiceName, Error, "the span has no process or its service name is empty"},
+	{EmptyOperationName, Warning, "the operation name is empty"},
+	{InvalidUTF8, Warning, "a name, tag key or string tag value is not valid UTF-8"},
+	{DuplicateTagKey, Warning, "several tags of the span have the same key"},
+	{InvalidSpanKind, Warning, "the span.kind tag is not client, server, producer, consumer or internal"},
+	{NonBooleanErrorTag, Warning, "the error tag is not a bool"},
+	{LogOutsideSpan, Warning, "a log is timestamped outside of the time range of the span"},
+	{DanglingParent, Info, "the parent span is not among the spans, e.g. because another service exports it"},
+	{SelfReference, Error, "the span references itself as parent"},
+	{ReferenceCycle, Error, "the parent references form a cycle"},
+}
+
+// Rules returns the rules of the linter.
+func Rules() []Rule {
+	return slices.Clone(rules)
+}
+
+func severity(ruleID string) Severity {
+	for _, r := range rules {
+		if r.ID == ruleID {
+			return r.Severity
+		}
+	}
+	return Error
+}
+
+// Finding is a problem found in a span.
+type Finding struct {
+	RuleID   string   `json:"ruleId"`
+	Severity Severity `json:"severity"`
+	// TraceID and SpanID are the hex IDs of the span.
+	TraceID string `json:"traceId"`
+	SpanID  string `json:"spanId"`
+	// Field is the path of the field of the span, e.g. "tags[2].value", or
+	// empty if the finding is about the span as a whole.
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	field := ""
+	if f.Field != "" {
+		field = " " + f.Field
+	}
+	return fmt.Sprintf("%s %s %s/%s%s: %s", f.Severity, f.RuleID, f.TraceID, f.SpanID, field, f.Message)
+}
+
+// Options controls the checks of the linter.
+type Options struct {
+	// Limits bounds the size of the tags, as in the validation package.
+	Limits validation.Limits
+	// Disabled lists the IDs of the rules to skip.
+	Disabled []string
+}
+
+// DefaultOptions returns the options with the default limits of the validation package.
+func DefaultOptions() Options {
+	return Options{Limits: validation.DefaultLimits()}
+}
+
+// Spans lints the spans, then the structure of their traces. The findings
+// of each span are in the order of the fields of the span.
+func Spans(spans []*model.Span, opts Options) []Finding {
+	l := &linter{opts: opts}
+	var traceIDs []model.TraceID
+	byTrace := make(map[model.TraceID][]*model.Span)
+	for _, span := range spans {
+		if span == nil {
+			continue
+		}
+		l.span(span)
+		if _, ok := byTrace[span.TraceID]; !ok {
+			traceIDs = append(traceIDs, span.TraceID)
+		}
+		byTrace[span.TraceID] = append(byTrace[span.TraceID], span)
+	}
+	for _, traceID := range traceIDs {
+		for _, issue := range trace.NewTree(byTrace[traceID]).Issues() {
+			l.add(issue.Span, string(issue.Kind), "references", issue.Warning())
+		}
+	}
+	return l.findings
+}
+
+// Span lints a single span, without the checks of the structure of its trace.
+func Span(span *model.Span, opts Options) []Finding {
+	l := &linter{opts: opts}
+	l.span(span)
+	return l.findings
+}
+
+type linter struct {
+	opts     Options
+	findings []Finding
+}
+
+func (l *linter) add(span *model.Span, ruleID, field, message string) {
+	if slices.Contains(l.opts.Disabled, ruleID) {
+		return
+	}
+	l.findings = append(l.findings, Finding{
+		RuleID:   ruleID,
+		Severity: severity(ruleID),
+		TraceID:  span.TraceID.String(),
+		SpanID:   span.SpanID.String(),
+		Field:    field,
+		Message:  message,
+	})
+}
+
+func (l *linter) span(span *model.Span) {
+	if err, ok := validation.Span(span, l.opts.Limits).(*validation.SpanError); ok {
+		for _, f := range err.Fields {
+			l.add(span, string(f.Reason), f.Field, f.Detail)
+		}
+	}
+
+	if span.OperationName == "" {
+		l.add(span, EmptyOperationName, "operationName", "operation name must not be empty")
+	} else if !utf8.ValidString(span.OperationName) {
+		l.add(span, InvalidUTF8, "operationName", fmt.Sprintf("operation name %q is not valid UTF-8", span.OperationName))
+	}
+	switch service := span.Process.GetServiceName(); {
+	case service == "":
+		l.add(span, MissingServiceName, "process.serviceName", "service name must not be empty")
+	case !utf8.ValidString(service):
+		l.add(span, InvalidUTF8, "process.serviceName", fmt.Sprintf("service name %q is not valid UTF-8", service))
+	}
+
+	l.tags(span, "tags", span.Tags)
+	seen := make(map[string]bool, len(span.Tags))
+	for i := range span.Tags {
+		tag := &span.Tags[i]
+		field := fmt.Sprintf("tags[%d]", i)
+		if seen[tag.Key] {
+			l.add(span, DuplicateTagKey, field+".key", fmt.Sprintf("tag %q is repeated", tag.Key))
+		}
+		seen[tag.Key] = true
+		switch tag.Key {
+		case "span.kind":
+			switch tag.AsString() {
+			case "client", "server", "producer", "consumer", "internal":
+			default:
+				l.add(span, InvalidSpanKind, field+".value", fmt.Sprintf("span kind %q is not client, server, producer, consumer or internal", tag.AsString()))
+			}
+		case "error":
+			if tag.VType != model.BoolType {
+				l.add(span, NonBooleanErrorTag, field+".value", fmt.Sprintf("error tag is a %s, not a bool", tag.VType))
+			}
+		}
+	}
+	end := span.StartTime.Add(span.Duration)
+	for i, log := range span.Logs {
+		field := fmt.Sprintf("logs[%d]", i)
+		if !span.StartTime.IsZero() && span.Duration >= 0 && (log.Timestamp.Before(span.StartTime) || log.Timestamp.After(end)) {
+			l.add(span, LogOutsideSpan, field+".timestamp", fmt.Sprintf("log at %v is outside of the span", log.Timestamp.Sub(span.StartTime)))
+		}
+		l.tags(span, field+".fields", log.Fields)
+	}
+	if span.Process != nil {
+		l.tags(span, "process.tags", span.Process.Tags)
+	}
+}
+
+// tags reports the keys and string values that are not valid UTF-8.
+func (l *linter) tags(span *model.Span, field string, tags []model.KeyValue) {
+	for i := range tags {
+		tag := &tags[i]
+		if !utf8.ValidString(tag.Key) {
+			l.add(span, InvalidUTF8, fmt.Sprintf("%s[%d].key", field, i), fmt.Sprintf("key %q is not valid UTF-8", tag.Key))
+		}
+		if tag.VType == model.StringType && !utf8.ValidString(tag.VStr) {
+			l.add(span, InvalidUTF8, fmt.Sprintf("%s[%d].value", field, i), fmt.Sprintf("value of %q is not valid UTF-8", tag.Key))
+		}
+	}
+}
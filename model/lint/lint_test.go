@@ -0,0 +1,141 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package lint
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+var testStart = time.Unix(1700000000, 0)
+
+func validSpan() *model.Span {
+	return &model.Span{
+		TraceID:       model.NewTraceID(1, 2),
+		SpanID:        model.NewSpanID(3),
+		OperationName: "GET /users",
+		StartTime:     testStart,
+		Duration:      time.Millisecond,
+		Tags: []model.KeyValue{
+			model.String("span.kind", "server"),
+			model.Bool("error", false),
+		},
+		Logs: []model.Log{
+			{Timestamp: testStart.Add(time.Microsecond), Fields: []model.KeyValue{model.String("event", "retry")}},
+		},
+		Process: &model.Process{ServiceName: "frontend", Tags: []model.KeyValue{model.String("hostname", "fe-1")}},
+	}
+}
+
+func TestSpanValid(t *testing.T) {
+	assert.Empty(t, Span(validSpan(), DefaultOptions()))
+	assert.Empty(t, Spans([]*model.Span{validSpan(), nil}, DefaultOptions()))
+}
+
+func TestSpanRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*model.Span)
+		rule   string
+		field  string
+	}{
+		{"zero trace ID", func(s *model.Span) { s.TraceID = model.TraceID{} }, ZeroTraceID, "traceID"},
+		{"negative duration", func(s *model.Span) { s.Duration = -time.Second }, NegativeDuration, "duration"},
+		{"oversized attribute", func(s *model.Span) { s.Tags = append(s.Tags, model.String("payload", string(make([]byte, 64<<10)))) }, OversizedAttribute, "tags[2].value"},
+		{"oversized name", func(s *model.Span) { s.OperationName = string(make([]byte, 2<<10)) }, OversizedName, "operationName"},
+		{"no process", func(s *model.Span) { s.Process = nil }, MissingServiceName, "process.serviceName"},
+		{"empty operation", func(s *model.Span) { s.OperationName = "" }, EmptyOperationName, "operationName"},
+		{"invalid operation", func(s *model.Span) { s.OperationName = "GET \xff" }, InvalidUTF8, "operationName"},
+		{"invalid service", func(s *model.Span) { s.Process.ServiceName = "\xfe" }, InvalidUTF8, "process.serviceName"},
+		{"invalid tag value", func(s *model.Span) { s.Logs[0].Fields[0].VStr = "\xff" }, InvalidUTF8, "logs[0].fields[0].value"},
+		{"invalid process tag key", func(s *model.Span) { s.Process.Tags[0].Key = "\xff" }, InvalidUTF8, "process.tags[0].key"},
+		{"duplicate tag", func(s *model.Span) { s.Tags = append(s.Tags, model.String("span.kind", "server")) }, DuplicateTagKey, "tags[2].key"},
+		{"span kind", func(s *model.Span) { s.Tags[0].VStr = "rpc" }, InvalidSpanKind, "tags[0].value"},
+		{"error tag", func(s *model.Span) { s.Tags[1] = model.String("error", "true") }, NonBooleanErrorTag, "tags[1].value"},
+		{"log before span", func(s *model.Span) { s.Logs[0].Timestamp = testStart.Add(-time.Second) }, LogOutsideSpan, "logs[0].timestamp"},
+		{"log after span", func(s *model.Span) { s.Logs[0].Timestamp = testStart.Add(time.Second) }, LogOutsideSpan, "logs[0].timestamp"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			span := validSpan()
+			test.modify(span)
+			findings := Span(span, DefaultOptions())
+			require.Len(t, findings, 1, findings)
+			assert.Equal(t, test.rule, findings[0].RuleID)
+			assert.Equal(t, test.field, findings[0].Field)
+			assert.Equal(t, severity(test.rule), findings[0].Severity)
+			assert.NotEmpty(t, findings[0].Message)
+		})
+	}
+}
+
+func TestSpansStructure(t *testing.T) {
+	root := validSpan()
+	child := validSpan()
+	child.SpanID = model.NewSpanID(4)
+	child.References = model.MaybeAddParentSpanID(child.TraceID, model.NewSpanID(9), nil)
+	self := validSpan()
+	self.TraceID = model.NewTraceID(1, 3)
+	self.References = model.MaybeAddParentSpanID(self.TraceID, self.SpanID, nil)
+
+	findings := Spans([]*model.Span{root, child, self}, DefaultOptions())
+	require.Len(t, findings, 2)
+	assert.Equal(t, Finding{
+		RuleID:   DanglingParent,
+		Severity: Info,
+		TraceID:  "00000000000000010000000000000002",
+		SpanID:   "0000000000000004",
+		Field:    "references",
+		Message:  "invalid parent span ID=0000000000000009; parent span is not in the trace",
+	}, findings[0])
+	assert.Equal(t, SelfReference, findings[1].RuleID)
+	assert.Equal(t, Error, findings[1].Severity)
+}
+
+func TestDisabled(t *testing.T) {
+	span := validSpan()
+	span.OperationName = ""
+	span.Tags[0].VStr = "rpc"
+	opts := DefaultOptions()
+	opts.Disabled = []string{EmptyOperationName}
+	findings := Span(span, opts)
+	require.Len(t, findings, 1)
+	assert.Equal(t, InvalidSpanKind, findings[0].RuleID)
+}
+
+func TestFindingSchema(t *testing.T) {
+	span := validSpan()
+	span.OperationName = ""
+	findings := Span(span, DefaultOptions())
+	require.Len(t, findings, 1)
+	b, err := json.Marshal(findings[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"ruleId": "empty_operation_name",
+		"severity": "warning",
+		"traceId": "00000000000000010000000000000002",
+		"spanId": "0000000000000003",
+		"field": "operationName",
+		"message": "operation name must not be empty"
+	}`, string(b))
+	assert.Equal(t, "warning empty_operation_name 00000000000000010000000000000002/0000000000000003 operationName: operation name must not be empty", findings[0].String())
+}
+
+func TestRules(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, r := range Rules() {
+		assert.False(t, seen[r.ID], r.ID)
+		seen[r.ID] = true
+		assert.Contains(t, []Severity{Error, Warning, Info}, r.Severity)
+		assert.NotEmpty(t, r.Description)
+	}
+	assert.Len(t, seen, 17)
+	assert.Equal(t, Error, severity("unknown"))
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"sort"
+	"time"
+)
+
+// Breakdown splits the wall-clock duration of a trace between its services.
+type Breakdown struct {
+	// Duration is the time between the earliest span start and the latest span end.
+	Duration time.Duration
+	// Services is the share of Duration attributed to each service.
+	Services map[string]time.Duration
+	// Idle is the part of Duration when no span is running.
+	Idle time.Duration
+}
+
+// ServiceBreakdown attributes every moment of the trace to the spans doing
+// their own work at that moment, i.e. running while none of their children
+// are. When several of them run in parallel the moment is shared equally
+// between them, so that, unlike the self time of Stats, the shares of the
+// services add up to the wall-clock time covered by the spans (up to
+// rounding to the nanosecond).
+func (t *Tree) ServiceBreakdown() Breakdown {
+	type event struct {
+		at      time.Time
+		service string
+		delta   int
+	}
+	var events []event
+	var all []interval
+	t.Walk(func(node *Node) bool {
+		span := interval{start: node.Span.StartTime, end: spanEnd(node.Span)}
+		all = append(all, span)
+		for _, self := range node.selfIntervals() {
+			service := serviceName(node.Span)
+			events = append(events, event{self.start, service, 1}, event{self.end, service, -1})
+		}
+		return true
+	})
+
+	b := Breakdown{Services: make(map[string]time.Duration)}
+	if len(all) == 0 {
+		return b
+	}
+	start, end := all[0].start, all[0].end
+	for _, iv := range all {
+		if iv.start.Before(start) {
+			start = iv.start
+		}
+		if iv.end.After(end) {
+			end = iv.end
+		}
+	}
+	b.Duration = end.Sub(start)
+	b.Idle = b.Duration - unionLength(all)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].at.Before(events[j].at)
+	})
+	active := make(map[string]int)
+	total := 0
+	for i, e := range events {
+		if i > 0 && total > 0 {
+			if length := e.at.Sub(events[i-1].at); length > 0 {
+				for service, count := range active {
+					b.Services[service] += length * time.Duration(count) / time.Duration(total)
+				}
+			}
+		}
+		active[e.service] += e.delta
+		total += e.delta
+		if active[e.service] == 0 {
+			delete(active, e.service)
+		}
+	}
+	return b
+}
+
+// selfIntervals returns the parts of the span's time range that are not
+// covered by any of its children, in chronological order.
+func (n *Node) selfIntervals() []interval {
+	start, end := n.Span.StartTime, spanEnd(n.Span)
+	children := make([]interval, 0, len(n.Children))
+	for _, child := range n.Children {
+		children = append(children, interval{start: child.Span.StartTime, end: spanEnd(child.Span)})
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].start.Before(children[j].start)
+	})
+	var self []interval
+	cursor := start
+	for _, c := range children {
+		if c.start.After(cursor) {
+			self = append(self, interval{start: cursor, end: minTime(c.start, end)})
+		}
+		if c.end.After(cursor) {
+			cursor = c.end
+		}
+		if !cursor.Before(end) {
+			return self
+		}
+	}
+	if end.After(cursor) {
+		self = append(self, interval{start: cursor, end: end})
+	}
+	return self
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+func TestServiceBreakdown(t *testing.T) {
+	ms := time.Millisecond
+	// 1 frontend: [0, 100)
+	//   2 auth:   [10, 50)
+	//   3 auth:   [30, 70) overlaps with 2
+	//     4 db:   [40, 60) in parallel with 2
+	// 5 orphan:   [90, 120) in parallel with 1
+	tree := NewTree([]*model.Span{
+		withService(withDuration(newSpan(1, 0, 0), 100*ms), "frontend"),
+		withService(withDuration(newSpan(2, 1, 10*ms), 40*ms), "auth"),
+		withService(withDuration(newSpan(3, 1, 30*ms), 40*ms), "auth"),
+		withService(withDuration(newSpan(4, 3, 40*ms), 20*ms), "db"),
+		withDuration(newSpan(5, 9, 90*ms), 30*ms),
+	})
+
+	assert.Equal(t, Breakdown{
+		Duration: 120 * ms,
+		Services: map[string]time.Duration{
+			"frontend": 35 * ms, // [0, 10), [70, 90), half of [90, 100)
+			"auth":     45 * ms, // [10, 40), half of [40, 50), [60, 70)
+			"db":       15 * ms, // half of [40, 50), [50, 60)
+			"":         25 * ms, // half of [90, 100), [100, 120)
+		},
+	}, tree.ServiceBreakdown())
+}
+
+func TestServiceBreakdownIdle(t *testing.T) {
+	ms := time.Millisecond
+	tree := NewTree([]*model.Span{
+		withService(withDuration(newSpan(1, 0, 0), 10*ms), "a"),
+		withService(withDuration(newSpan(2, 9, 20*ms), 10*ms), "b"),
+	})
+
+	assert.Equal(t, Breakdown{
+		Duration: 30 * ms,
+		Services: map[string]time.Duration{"a": 10 * ms, "b": 10 * ms},
+		Idle:     10 * ms,
+	}, tree.ServiceBreakdown())
+}
+
+func TestServiceBreakdownChildOutsideParent(t *testing.T) {
+	ms := time.Millisecond
+	// the child outlives its parent, its own time is counted in full
+	tree := NewTree([]*model.Span{
+		withService(withDuration(newSpan(1, 0, 0), 10*ms), "a"),
+		withService(withDuration(newSpan(2, 1, 5*ms), 15*ms), "b"),
+	})
+
+	assert.Equal(t, map[string]time.Duration{"a": 5 * ms, "b": 15 * ms}, tree.ServiceBreakdown().Services)
+}
+
+func TestServiceBreakdownEmpty(t *testing.T) {
+	b := NewTree(nil).ServiceBreakdown()
+	assert.Zero(t, b.Duration)
+	assert.Empty(t, b.Services)
+}
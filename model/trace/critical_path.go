@@ -0,0 +1,123 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"time"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// Segment is a section of a span's time that lies on the critical path.
+type Segment struct {
+	Node  *Node
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the segment.
+func (s Segment) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+type interval struct {
+	start, end time.Time
+}
+
+// CriticalPath returns the chain of span sections that determines the
+// end-to-end latency of the trace, in chronological order.
+//
+// The path starts at the root (or the earliest orphan when the trace has no
+// root) and, walking backwards from the end of each span, descends into the
+// child that finished last before the current point in time. Time not covered
+// by such children is attributed to the span itself. Children are clipped to
+// the time range of their parent, and children that lie completely outside of
+// it are ignored, which matches the behaviour of the Jaeger UI.
+func (t *Tree) CriticalPath() []Segment {
+	root := t.Root()
+	if root == nil {
+		if len(t.Roots) == 0 {
+			return nil
+		}
+		root = t.Roots[0]
+	}
+	bounds := make(map[*Node]interval)
+	clip(root, interval{start: root.Span.StartTime, end: spanEnd(root.Span)}, bounds)
+
+	var path []Segment
+	criticalPath(root, bounds[root].end, bounds, &path)
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// CriticalPathDurations sums the time each span contributes to the critical path.
+func CriticalPathDurations(path []Segment) map[model.SpanID]time.Duration {
+	durations := make(map[model.SpanID]time.Duration, len(path))
+	for _, segment := range path {
+		durations[segment.Node.Span.SpanID] += segment.Duration()
+	}
+	return durations
+}
+
+// clip records the time range of node and its descendants, clipped to the range of their parents.
+func clip(node *Node, bound interval, bounds map[*Node]interval) {
+	bounds[node] = bound
+	for _, child := range node.Children {
+		childBound := interval{start: child.Span.StartTime, end: spanEnd(child.Span)}
+		if !childBound.start.Before(bound.end) || !childBound.end.After(bound.start) {
+			continue
+		}
+		if childBound.start.Before(bound.start) {
+			childBound.start = bound.start
+		}
+		if childBound.end.After(bound.end) {
+			childBound.end = bound.end
+		}
+		clip(child, childBound, bounds)
+	}
+}
+
+// criticalPath appends, in reverse chronological order, the segments of the
+// critical path through node that end no later than cutoff.
+func criticalPath(node *Node, cutoff time.Time, bounds map[*Node]interval, path *[]Segment) {
+	for {
+		child := lastFinishingChild(node, cutoff, bounds)
+		if child == nil {
+			addSegment(path, node, bounds[node].start, cutoff)
+			return
+		}
+		childBound := bounds[child]
+		addSegment(path, node, childBound.end, cutoff)
+		criticalPath(child, childBound.end, bounds, path)
+		cutoff = childBound.start
+	}
+}
+
+func lastFinishingChild(node *Node, cutoff time.Time, bounds map[*Node]interval) *Node {
+	var last *Node
+	for _, child := range node.Children {
+		childBound, ok := bounds[child]
+		// requiring the child to start before cutoff guarantees progress
+		if !ok || childBound.end.After(cutoff) || !childBound.start.Before(cutoff) {
+			continue
+		}
+		if last == nil || childBound.end.After(bounds[last].end) {
+			last = child
+		}
+	}
+	return last
+}
+
+func addSegment(path *[]Segment, node *Node, start, end time.Time) {
+	if !end.After(start) {
+		return
+	}
+	*path = append(*path, Segment{Node: node, Start: start, End: end})
+}
+
+func spanEnd(span *model.Span) time.Time {
+	return span.StartTime.Add(span.Duration)
+}
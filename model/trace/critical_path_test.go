@@ -0,0 +1,114 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+type expectedSegment struct {
+	spanID     uint64
+	start, end time.Duration
+}
+
+func withDuration(span *model.Span, d time.Duration) *model.Span {
+	span.Duration = d
+	return span
+}
+
+func assertPath(t *testing.T, expected []expectedSegment, path []Segment) {
+	t.Helper()
+	actual := make([]expectedSegment, 0, len(path))
+	for _, s := range path {
+		actual = append(actual, expectedSegment{
+			spanID: uint64(s.Node.Span.SpanID),
+			start:  s.Start.Sub(testStartTime),
+			end:    s.End.Sub(testStartTime),
+		})
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestCriticalPathSequentialChildren(t *testing.T) {
+	// 1: [0, 100)
+	//   2: [10, 40)
+	//   3: [50, 90)
+	//     4: [60, 70)
+	tree := NewTree([]*model.Span{
+		withDuration(newSpan(1, 0, 0), 100*time.Millisecond),
+		withDuration(newSpan(2, 1, 10*time.Millisecond), 30*time.Millisecond),
+		withDuration(newSpan(3, 1, 50*time.Millisecond), 40*time.Millisecond),
+		withDuration(newSpan(4, 3, 60*time.Millisecond), 10*time.Millisecond),
+	})
+	path := tree.CriticalPath()
+	ms := time.Millisecond
+	assertPath(t, []expectedSegment{
+		{1, 0, 10 * ms},
+		{2, 10 * ms, 40 * ms},
+		{1, 40 * ms, 50 * ms},
+		{3, 50 * ms, 60 * ms},
+		{4, 60 * ms, 70 * ms},
+		{3, 70 * ms, 90 * ms},
+		{1, 90 * ms, 100 * ms},
+	}, path)
+
+	durations := CriticalPathDurations(path)
+	assert.Equal(t, map[model.SpanID]time.Duration{
+		1: 30 * ms,
+		2: 30 * ms,
+		3: 30 * ms,
+		4: 10 * ms,
+	}, durations)
+}
+
+func TestCriticalPathParallelChildren(t *testing.T) {
+	// 1: [0, 100)
+	//   2: [10, 80)
+	//   3: [20, 60) overlaps with 2 and finishes earlier, so it is not on the path
+	tree := NewTree([]*model.Span{
+		withDuration(newSpan(1, 0, 0), 100*time.Millisecond),
+		withDuration(newSpan(2, 1, 10*time.Millisecond), 70*time.Millisecond),
+		withDuration(newSpan(3, 1, 20*time.Millisecond), 40*time.Millisecond),
+	})
+	ms := time.Millisecond
+	assertPath(t, []expectedSegment{
+		{1, 0, 10 * ms},
+		{2, 10 * ms, 80 * ms},
+		{1, 80 * ms, 100 * ms},
+	}, tree.CriticalPath())
+}
+
+func TestCriticalPathClipsChildren(t *testing.T) {
+	// 1: [0, 50)
+	//   2: [40, 90) outlives the parent (e.g. async follows-from)
+	//   3: [60, 70) starts after the parent ended
+	//   4: [45, 45) zero duration
+	tree := NewTree([]*model.Span{
+		withDuration(newSpan(1, 0, 0), 50*time.Millisecond),
+		withDuration(newSpan(2, 1, 40*time.Millisecond), 50*time.Millisecond),
+		withDuration(newSpan(3, 1, 60*time.Millisecond), 10*time.Millisecond),
+		withDuration(newSpan(4, 1, 45*time.Millisecond), 0),
+	})
+	ms := time.Millisecond
+	assertPath(t, []expectedSegment{
+		{1, 0, 40 * ms},
+		{2, 40 * ms, 50 * ms},
+	}, tree.CriticalPath())
+}
+
+func TestCriticalPathOrphanRoot(t *testing.T) {
+	tree := NewTree([]*model.Span{
+		withDuration(newSpan(2, 10, 0), 10*time.Millisecond),
+	})
+	assertPath(t, []expectedSegment{{2, 0, 10 * time.Millisecond}}, tree.CriticalPath())
+}
+
+func TestCriticalPathEmpty(t *testing.T) {
+	assert.Empty(t, NewTree(nil).CriticalPath())
+}
@@ -0,0 +1,6 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package trace provides structural helpers over the spans of a single trace,
+// such as building the parent→children tree.
+package trace
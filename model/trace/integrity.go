@@ -0,0 +1,151 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// IssueKind classifies a broken parent reference.
+type IssueKind string
+
+const (
+	// DanglingParent means the parent span is not in the trace.
+	DanglingParent IssueKind = "dangling_parent"
+	// SelfReference means the span is its own parent.
+	SelfReference IssueKind = "self_reference"
+	// ReferenceCycle means the span is its own ancestor.
+	ReferenceCycle IssueKind = "reference_cycle"
+)
+
+// Placeholder spans synthesized by Repair for missing parents are marked
+// with these values, so that they can be told apart from real spans.
+const (
+	PlaceholderServiceName   = "missing-service"
+	PlaceholderOperationName = "missing-span"
+	PlaceholderTagKey        = "jaeger.placeholder"
+)
+
+// Issue is a broken parent reference of a span.
+type Issue struct {
+	Kind     IssueKind
+	Span     *model.Span
+	ParentID model.SpanID
+}
+
+// Warning returns the message describing the issue, in the style of
+// the span warnings displayed by Jaeger UI.
+func (i Issue) Warning() string {
+	switch i.Kind {
+	case DanglingParent:
+		return fmt.Sprintf("invalid parent span ID=%s; parent span is not in the trace", i.ParentID)
+	case SelfReference:
+		return fmt.Sprintf("invalid parent span ID=%s; span references itself as parent", i.ParentID)
+	default:
+		return fmt.Sprintf("invalid parent span ID=%s; parent references form a cycle", i.ParentID)
+	}
+}
+
+// Issues returns the broken parent references found while building the tree,
+// in the order of the orphans.
+func (t *Tree) Issues() []Issue {
+	issues := make([]Issue, 0, len(t.Orphans))
+	for _, orphan := range t.Orphans {
+		span := orphan.Span
+		issue := Issue{Span: span, ParentID: span.ParentSpanID()}
+		switch _, ok := t.nodes[issue.ParentID]; {
+		case issue.ParentID == span.SpanID:
+			issue.Kind = SelfReference
+		case !ok:
+			issue.Kind = DanglingParent
+		default:
+			issue.Kind = ReferenceCycle
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// RepairOptions controls how Repair fixes broken traces.
+type RepairOptions struct {
+	// SynthesizeRoots adds a placeholder span for every missing parent,
+	// so that its children render under a common root instead of as orphans.
+	SynthesizeRoots bool
+}
+
+// Repair detects the broken parent references of the spans of one trace,
+// adds the warning of each issue to the affected span, and fixes the issues:
+// references that make a span its own parent or ancestor are dropped, and with
+// opts.SynthesizeRoots the missing parents are replaced by placeholder spans
+// covering the time of their children.
+//
+// The spans are modified in place. Repair returns the spans, followed by the
+// placeholders, and the issues that were found.
+func Repair(spans []*model.Span, opts RepairOptions) ([]*model.Span, []Issue) {
+	issues := NewTree(spans).Issues()
+	placeholders := make(map[model.SpanID]*model.Span)
+	var order []model.SpanID
+	for _, issue := range issues {
+		span := issue.Span
+		span.Warnings = append(span.Warnings, issue.Warning())
+		switch issue.Kind {
+		case SelfReference, ReferenceCycle:
+			dropReferences(span, issue.ParentID)
+		case DanglingParent:
+			if !opts.SynthesizeRoots {
+				continue
+			}
+			placeholder, ok := placeholders[issue.ParentID]
+			if !ok {
+				placeholder = newPlaceholder(span.TraceID, issue.ParentID, span.StartTime)
+				placeholders[issue.ParentID] = placeholder
+				order = append(order, issue.ParentID)
+			}
+			extend(placeholder, span)
+		}
+	}
+	for _, id := range order {
+		spans = append(spans, placeholders[id])
+	}
+	return spans, issues
+}
+
+// dropReferences removes the references of the span to the given span of the same trace.
+func dropReferences(span *model.Span, id model.SpanID) {
+	refs := span.References[:0]
+	for _, ref := range span.References {
+		if ref.TraceID == span.TraceID && ref.SpanID == id {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	span.References = refs
+}
+
+func newPlaceholder(traceID model.TraceID, spanID model.SpanID, start time.Time) *model.Span {
+	return &model.Span{
+		TraceID:       traceID,
+		SpanID:        spanID,
+		OperationName: PlaceholderOperationName,
+		StartTime:     start,
+		Process:       &model.Process{ServiceName: PlaceholderServiceName},
+		Tags:          []model.KeyValue{model.Bool(PlaceholderTagKey, true)},
+		Warnings:      []string{"placeholder for a parent span that is not in the trace"},
+	}
+}
+
+// extend widens the placeholder to cover the time of the child.
+func extend(placeholder, child *model.Span) {
+	end := spanEnd(placeholder)
+	if child.StartTime.Before(placeholder.StartTime) {
+		placeholder.StartTime = child.StartTime
+	}
+	if e := spanEnd(child); e.After(end) {
+		end = e
+	}
+	placeholder.Duration = end.Sub(placeholder.StartTime)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+func brokenTrace() []*model.Span {
+	return []*model.Span{
+		newSpan(1, 0, 0),
+		newSpan(2, 3, time.Millisecond), // cycle with 3
+		newSpan(3, 2, 2*time.Millisecond),
+		newSpan(4, 4, 3*time.Millisecond),  // self-reference
+		newSpan(5, 9, 4*time.Millisecond),  // missing parent
+		newSpan(6, 9, 10*time.Millisecond), // missing parent
+	}
+}
+
+func TestTreeIssues(t *testing.T) {
+	spans := brokenTrace()
+	issues := NewTree(spans).Issues()
+
+	require.Len(t, issues, 4)
+	assert.Equal(t, Issue{Kind: ReferenceCycle, Span: spans[1], ParentID: 3}, issues[0])
+	assert.Equal(t, Issue{Kind: SelfReference, Span: spans[3], ParentID: 4}, issues[1])
+	assert.Equal(t, Issue{Kind: DanglingParent, Span: spans[4], ParentID: 9}, issues[2])
+	assert.Equal(t, Issue{Kind: DanglingParent, Span: spans[5], ParentID: 9}, issues[3])
+
+	assert.Equal(t, "invalid parent span ID=0000000000000003; parent references form a cycle", issues[0].Warning())
+	assert.Equal(t, "invalid parent span ID=0000000000000004; span references itself as parent", issues[1].Warning())
+	assert.Equal(t, "invalid parent span ID=0000000000000009; parent span is not in the trace", issues[2].Warning())
+}
+
+func TestTreeIssuesValidTrace(t *testing.T) {
+	tree := NewTree([]*model.Span{
+		newSpan(1, 0, 0),
+		newSpan(2, 1, time.Millisecond),
+	})
+	assert.Empty(t, tree.Issues())
+}
+
+func TestRepair(t *testing.T) {
+	spans, issues := Repair(brokenTrace(), RepairOptions{})
+	assert.Len(t, issues, 4)
+	require.Len(t, spans, 6)
+
+	assert.Equal(t, []string{issues[0].Warning()}, spans[1].Warnings)
+	assert.Empty(t, spans[1].References)
+	assert.Empty(t, spans[3].References)
+	assert.Equal(t, model.NewSpanID(9), spans[4].ParentSpanID())
+	assert.Empty(t, spans[0].Warnings)
+
+	tree := NewTree(spans)
+	assert.Equal(t, []model.SpanID{1, 2, 4, 5, 6}, spanIDs(tree.Roots))
+	assert.Equal(t, []model.SpanID{5, 6}, spanIDs(tree.Orphans))
+}
+
+func TestRepairSynthesizeRoots(t *testing.T) {
+	spans, _ := Repair(brokenTrace(), RepairOptions{SynthesizeRoots: true})
+	require.Len(t, spans, 7)
+
+	placeholder := spans[6]
+	assert.Equal(t, testTraceID, placeholder.TraceID)
+	assert.Equal(t, model.NewSpanID(9), placeholder.SpanID)
+	assert.Equal(t, PlaceholderOperationName, placeholder.OperationName)
+	assert.Equal(t, PlaceholderServiceName, placeholder.Process.ServiceName)
+	assert.Equal(t, testStartTime.Add(4*time.Millisecond), placeholder.StartTime)
+	assert.Equal(t, 7*time.Millisecond, placeholder.Duration)
+	tag, ok := model.KeyValues(placeholder.Tags).FindByKey(PlaceholderTagKey)
+	require.True(t, ok)
+	assert.True(t, tag.Bool())
+
+	tree := NewTree(spans)
+	assert.Empty(t, tree.Orphans)
+	node, ok := tree.FindNode(model.NewSpanID(9))
+	require.True(t, ok)
+	assert.Equal(t, []model.SpanID{5, 6}, spanIDs(node.Children))
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+// Relation is a structural relation from a span to other spans of the trace.
+type Relation int
+
+const (
+	// Descendant relates a span to all the spans of its subtree.
+	Descendant Relation = iota
+	// Child relates a span to its direct children.
+	Child
+)
+
+// Pair is an ancestor and a descendant node in a relation.
+type Pair struct {
+	Ancestor   *Node
+	Descendant *Node
+}
+
+// FindPairs returns the pairs of nodes in the relation rel, where the
+// ancestor node satisfies ancestor and the descendant node satisfies
+// descendant, e.g. the spans of a service calling, directly or not, a
+// failing span of another service. The pairs are in walk order of the
+// ancestors, then of the descendants.
+func (t *Tree) FindPairs(ancestor, descendant func(node *Node) bool, rel Relation) []Pair {
+	var pairs []Pair
+	t.Walk(func(node *Node) bool {
+		if !ancestor(node) {
+			return true
+		}
+		for _, child := range node.Children {
+			if rel == Child {
+				if descendant(child) {
+					pairs = append(pairs, Pair{Ancestor: node, Descendant: child})
+				}
+				continue
+			}
+			child.Walk(func(d *Node) bool {
+				if descendant(d) {
+					pairs = append(pairs, Pair{Ancestor: node, Descendant: d})
+				}
+				return true
+			})
+		}
+		return true
+	})
+	return pairs
+}
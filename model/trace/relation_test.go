@@ -0,0 +1,95 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+func inService(service string) func(*Node) bool {
+	return func(node *Node) bool {
+		return serviceName(node.Span) == service
+	}
+}
+
+func pairIDs(pairs []Pair) [][2]uint64 {
+	ids := make([][2]uint64, 0, len(pairs))
+	for _, p := range pairs {
+		ids = append(ids, [2]uint64{uint64(p.Ancestor.Span.SpanID), uint64(p.Descendant.Span.SpanID)})
+	}
+	return ids
+}
+
+func TestFindPairs(t *testing.T) {
+	// 1 frontend
+	//   2 auth
+	//     3 db error
+	//   4 db
+	// 5 frontend (orphan)
+	//   6 db error
+	tree := NewTree([]*model.Span{
+		withService(newSpan(1, 0, 0), "frontend"),
+		withService(newSpan(2, 1, 1), "auth"),
+		withTags(withService(newSpan(3, 2, 2), "db"), model.Bool("error", true)),
+		withService(newSpan(4, 1, 3), "db"),
+		withService(newSpan(5, 9, 4), "frontend"),
+		withTags(withService(newSpan(6, 5, 5), "db"), model.Bool("error", true)),
+	})
+	failingDB := func(node *Node) bool {
+		return serviceName(node.Span) == "db" && IsError(node.Span)
+	}
+
+	tests := []struct {
+		name       string
+		ancestor   func(*Node) bool
+		descendant func(*Node) bool
+		rel        Relation
+		expected   [][2]uint64
+	}{
+		{
+			name:       "descendants",
+			ancestor:   inService("frontend"),
+			descendant: inService("db"),
+			rel:        Descendant,
+			expected:   [][2]uint64{{1, 3}, {1, 4}, {5, 6}},
+		},
+		{
+			name:       "children",
+			ancestor:   inService("frontend"),
+			descendant: inService("db"),
+			rel:        Child,
+			expected:   [][2]uint64{{1, 4}, {5, 6}},
+		},
+		{
+			name:       "failing descendants",
+			ancestor:   inService("frontend"),
+			descendant: failingDB,
+			rel:        Descendant,
+			expected:   [][2]uint64{{1, 3}, {5, 6}},
+		},
+		{
+			name:       "not its own descendant",
+			ancestor:   inService("db"),
+			descendant: inService("db"),
+			rel:        Descendant,
+			expected:   [][2]uint64{},
+		},
+		{
+			name:       "no ancestor",
+			ancestor:   inService("auth"),
+			descendant: inService("frontend"),
+			rel:        Descendant,
+			expected:   [][2]uint64{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, pairIDs(tree.FindPairs(test.ancestor, test.descendant, test.rel)))
+		})
+	}
+}
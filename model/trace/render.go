@@ -0,0 +1,151 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultRenderWidth is the width of the waterfall bars when RenderOptions.Width is zero.
+const DefaultRenderWidth = 60
+
+// RenderStyle is the layout of a rendered trace.
+type RenderStyle int
+
+const (
+	// RenderTree prints a line per span, indented under its parent, with its
+	// duration and start time relative to the start of the trace.
+	RenderTree RenderStyle = iota
+	// RenderWaterfall prints a line per span like RenderTree, followed by a
+	// bar showing when the span ran within the time range of the trace.
+	RenderWaterfall
+)
+
+// RenderOptions controls how Render prints a trace.
+type RenderOptions struct {
+	Style RenderStyle
+	// Width is the number of columns of the waterfall bars.
+	Width int
+}
+
+// Render prints the trace as plain text for terminals, e.g. when no UI is
+// available. Spans are printed in walk order, as "service: operation", with
+// failed spans marked [error] and drawn with X in the waterfall, and orphans
+// marked [missing parent]. Nothing is printed for an empty tree.
+func (t *Tree) Render(w io.Writer, opts RenderOptions) error {
+	if t.Len() == 0 {
+		return nil
+	}
+	start, end := t.timeRange()
+	var lines []renderLine
+	for i, root := range t.Roots {
+		lines = t.renderLines(lines, root, "", i == len(t.Roots)-1, true)
+	}
+
+	bw := bufio.NewWriter(w)
+	switch opts.Style {
+	case RenderWaterfall:
+		width := opts.Width
+		if width <= 0 {
+			width = DefaultRenderWidth
+		}
+		labelWidth := 0
+		for _, line := range lines {
+			labelWidth = max(labelWidth, utf8.RuneCountInString(line.label))
+		}
+		total := end.Sub(start)
+		fmt.Fprintf(bw, "%s  0%*s\n", pad("", labelWidth), width+1, total)
+		for _, line := range lines {
+			span := line.node.Span
+			fmt.Fprintf(bw, "%s  |%s| %v  +%v%s\n",
+				pad(line.label, labelWidth), bar(span.StartTime.Sub(start), span.Duration, total, width, IsError(span)),
+				span.Duration, span.StartTime.Sub(start), line.marks)
+		}
+	default:
+		for _, line := range lines {
+			span := line.node.Span
+			fmt.Fprintf(bw, "%s  %v  +%v%s\n", line.label, span.Duration, span.StartTime.Sub(start), line.marks)
+		}
+	}
+	return bw.Flush()
+}
+
+// renderLine is a span of a rendered trace.
+type renderLine struct {
+	node *Node
+	// label is the span name, prefixed with the branches of the tree.
+	label string
+	marks string
+}
+
+// renderLines appends the lines of the node and its descendants, drawing the
+// branches of the tree with ASCII characters.
+func (t *Tree) renderLines(lines []renderLine, node *Node, prefix string, last, root bool) []renderLine {
+	branch, indent := "|-- ", "|   "
+	if last {
+		branch, indent = "`-- ", "    "
+	}
+	if root {
+		branch, indent = "", ""
+	}
+	var marks string
+	if IsError(node.Span) {
+		marks += " [error]"
+	}
+	if root && t.IsOrphan(node) {
+		marks += " [missing parent]"
+	}
+	lines = append(lines, renderLine{
+		node:  node,
+		label: prefix + branch + serviceName(node.Span) + ": " + node.Span.OperationName,
+		marks: marks,
+	})
+	for i, child := range node.Children {
+		lines = t.renderLines(lines, child, prefix+indent, i == len(node.Children)-1, false)
+	}
+	return lines
+}
+
+// timeRange returns the earliest start and the latest end of the spans.
+func (t *Tree) timeRange() (start, end time.Time) {
+	first := true
+	t.Walk(func(node *Node) bool {
+		s, e := node.Span.StartTime, spanEnd(node.Span)
+		if first || s.Before(start) {
+			start = s
+		}
+		if first || e.After(end) {
+			end = e
+		}
+		first = false
+		return true
+	})
+	return start, end
+}
+
+// bar draws the time range of a span within the total time range of the
+// trace on width columns, with at least one column for the shortest spans.
+func bar(offset, duration, total time.Duration, width int, isError bool) string {
+	from, to := 0, width
+	if total > 0 {
+		from = int(int64(offset) * int64(width) / int64(total))
+		to = int((int64(offset+duration)*int64(width) + int64(total) - 1) / int64(total))
+	}
+	from = min(from, width-1)
+	to = max(min(to, width), from+1)
+	fill := "="
+	if isError {
+		fill = "X"
+	}
+	return strings.Repeat(" ", from) + strings.Repeat(fill, to-from) + strings.Repeat(" ", width-to)
+}
+
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", width-utf8.RuneCountInString(s))
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+func newRenderTree() *Tree {
+	ms := time.Millisecond
+	return NewTree([]*model.Span{
+		withService(withDuration(newSpan(1, 0, 0), 100*ms), "frontend"),
+		withService(withDuration(newSpan(2, 1, 10*ms), 40*ms), "auth"),
+		withTags(withService(withDuration(newSpan(4, 2, 20*ms), 20*ms), "db"), model.Bool("error", true)),
+		withService(withDuration(newSpan(3, 1, 60*ms), 40*ms), "cache"),
+		withService(withDuration(newSpan(5, 9, 150*ms), 50*ms), "worker"),
+	})
+}
+
+func TestRenderTree(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, newRenderTree().Render(&sb, RenderOptions{}))
+	assert.Equal(t, strings.Join([]string{
+		"frontend: 0000000000000001  100ms  +0s",
+		"|-- auth: 0000000000000002  40ms  +10ms",
+		"|   `-- db: 0000000000000004  20ms  +20ms [error]",
+		"`-- cache: 0000000000000003  40ms  +60ms",
+		"worker: 0000000000000005  50ms  +150ms [missing parent]",
+		"",
+	}, "\n"), sb.String())
+}
+
+func TestRenderWaterfall(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, newRenderTree().Render(&sb, RenderOptions{Style: RenderWaterfall, Width: 20}))
+	assert.Equal(t, strings.Join([]string{
+		"                              0                200ms",
+		"frontend: 0000000000000001    |==========          | 100ms  +0s",
+		"|-- auth: 0000000000000002    | ====               | 40ms  +10ms",
+		"|   `-- db: 0000000000000004  |  XX                | 20ms  +20ms [error]",
+		"`-- cache: 0000000000000003   |      ====          | 40ms  +60ms",
+		"worker: 0000000000000005      |               =====| 50ms  +150ms [missing parent]",
+		"",
+	}, "\n"), sb.String())
+}
+
+func TestRenderBar(t *testing.T) {
+	// spans shorter than a column get one column, within the bounds
+	assert.Equal(t, "=   ", bar(0, 0, time.Second, 4, false))
+	assert.Equal(t, "   =", bar(time.Second, 0, time.Second, 4, false))
+	assert.Equal(t, "XXXX", bar(0, 0, 0, 4, true))
+}
+
+func TestRenderEmpty(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, NewTree(nil).Render(&sb, RenderOptions{Style: RenderWaterfall}))
+	assert.Empty(t, sb.String())
+}
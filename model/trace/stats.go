@@ -0,0 +1,126 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// Stats is an aggregate summary of a trace.
+type Stats struct {
+	SpanCount  int
+	ErrorCount int
+	// Services are the distinct service names, sorted.
+	Services []string
+	MaxDepth int
+	// Duration is the time between the earliest span start and the latest span end.
+	Duration time.Duration
+	// SelfTime is the time spent in the spans of each service that is not
+	// covered by the children of those spans.
+	SelfTime map[string]time.Duration
+}
+
+// Stats computes the summary of the trace.
+func (t *Tree) Stats() Stats {
+	stats := Stats{
+		SpanCount: t.Len(),
+		MaxDepth:  t.MaxDepth(),
+		SelfTime:  make(map[string]time.Duration),
+	}
+	var start, end time.Time
+	t.Walk(func(node *Node) bool {
+		span := node.Span
+		if IsError(span) {
+			stats.ErrorCount++
+		}
+		if start.IsZero() || span.StartTime.Before(start) {
+			start = span.StartTime
+		}
+		if e := spanEnd(span); e.After(end) {
+			end = e
+		}
+		stats.SelfTime[serviceName(span)] += node.SelfTime()
+		return true
+	})
+	for service := range stats.SelfTime {
+		stats.Services = append(stats.Services, service)
+	}
+	sort.Strings(stats.Services)
+	if end.After(start) {
+		stats.Duration = end.Sub(start)
+	}
+	return stats
+}
+
+// SelfTime returns the part of the span's duration that is not covered by any of its children.
+func (n *Node) SelfTime() time.Duration {
+	start, end := n.Span.StartTime, spanEnd(n.Span)
+	covered := make([]interval, 0, len(n.Children))
+	for _, child := range n.Children {
+		c := interval{start: child.Span.StartTime, end: spanEnd(child.Span)}
+		if c.start.Before(start) {
+			c.start = start
+		}
+		if c.end.After(end) {
+			c.end = end
+		}
+		if c.end.After(c.start) {
+			covered = append(covered, c)
+		}
+	}
+	return n.Span.Duration - unionLength(covered)
+}
+
+// unionLength returns the total length covered by the intervals.
+func unionLength(intervals []interval) time.Duration {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].start.Before(intervals[j].start)
+	})
+	var total time.Duration
+	var current interval
+	for i, iv := range intervals {
+		if i == 0 {
+			current = iv
+			continue
+		}
+		if iv.start.After(current.end) {
+			total += current.end.Sub(current.start)
+			current = iv
+			continue
+		}
+		if iv.end.After(current.end) {
+			current.end = iv.end
+		}
+	}
+	if len(intervals) > 0 {
+		total += current.end.Sub(current.start)
+	}
+	return total
+}
+
+// IsError reports whether the span is marked as failed with the `error` tag.
+func IsError(span *model.Span) bool {
+	tag, ok := model.KeyValues(span.Tags).FindByKey("error")
+	if !ok {
+		return false
+	}
+	switch tag.VType {
+	case model.BoolType:
+		return tag.Bool()
+	case model.StringType:
+		return tag.VStr == "true"
+	default:
+		return false
+	}
+}
+
+func serviceName(span *model.Span) string {
+	if span.Process == nil {
+		return ""
+	}
+	return span.Process.ServiceName
+}
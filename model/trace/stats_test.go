@@ -0,0 +1,89 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+func withService(span *model.Span, service string) *model.Span {
+	span.Process = &model.Process{ServiceName: service}
+	return span
+}
+
+func withTags(span *model.Span, tags ...model.KeyValue) *model.Span {
+	span.Tags = tags
+	return span
+}
+
+func TestTreeStats(t *testing.T) {
+	ms := time.Millisecond
+	// 1 frontend: [0, 100)
+	//   2 auth:   [10, 50)
+	//   3 auth:   [30, 70) overlaps with 2
+	//     4 db:   [40, 60) error
+	// 5 orphan:   [90, 120)
+	tree := NewTree([]*model.Span{
+		withService(withDuration(newSpan(1, 0, 0), 100*ms), "frontend"),
+		withService(withDuration(newSpan(2, 1, 10*ms), 40*ms), "auth"),
+		withService(withDuration(newSpan(3, 1, 30*ms), 40*ms), "auth"),
+		withTags(withService(withDuration(newSpan(4, 3, 40*ms), 20*ms), "db"), model.Bool("error", true)),
+		withDuration(newSpan(5, 9, 90*ms), 30*ms),
+	})
+
+	stats := tree.Stats()
+	assert.Equal(t, Stats{
+		SpanCount:  5,
+		ErrorCount: 1,
+		Services:   []string{"", "auth", "db", "frontend"},
+		MaxDepth:   2,
+		Duration:   120 * ms,
+		SelfTime: map[string]time.Duration{
+			"frontend": 40 * ms,
+			"auth":     60 * ms,
+			"db":       20 * ms,
+			"":         30 * ms,
+		},
+	}, stats)
+}
+
+func TestTreeStatsEmpty(t *testing.T) {
+	stats := NewTree(nil).Stats()
+	assert.Zero(t, stats.SpanCount)
+	assert.Zero(t, stats.Duration)
+	assert.Empty(t, stats.Services)
+}
+
+func TestSelfTimeClipsChildren(t *testing.T) {
+	ms := time.Millisecond
+	tree := NewTree([]*model.Span{
+		withDuration(newSpan(1, 0, 10*ms), 50*ms),
+		withDuration(newSpan(2, 1, 0), 20*ms),     // starts before the parent
+		withDuration(newSpan(3, 1, 50*ms), 30*ms), // ends after the parent
+		withDuration(newSpan(4, 1, 70*ms), 5*ms),  // entirely after the parent
+	})
+	assert.Equal(t, 30*ms, tree.Root().SelfTime())
+}
+
+func TestIsError(t *testing.T) {
+	tests := []struct {
+		tags     []model.KeyValue
+		expected bool
+	}{
+		{tags: nil, expected: false},
+		{tags: []model.KeyValue{model.Bool("error", true)}, expected: true},
+		{tags: []model.KeyValue{model.Bool("error", false)}, expected: false},
+		{tags: []model.KeyValue{model.String("error", "true")}, expected: true},
+		{tags: []model.KeyValue{model.String("error", "no")}, expected: false},
+		{tags: []model.KeyValue{model.Int64("error", 1)}, expected: false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsError(&model.Span{Tags: test.tags}), "%v", test.tags)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"sort"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// Node is a span placed in the trace tree.
+type Node struct {
+	Span     *model.Span
+	Parent   *Node
+	Children []*Node
+	// Depth is the distance from the root of the subtree, roots have depth 0.
+	Depth int
+}
+
+// Tree is a parent→children view of the spans of a single trace.
+// When the spans do not form a single tree (e.g. some parents are missing)
+// it holds a forest with multiple roots.
+type Tree struct {
+	// Roots are the nodes without a parent in the trace, sorted by start time.
+	Roots []*Node
+	// Orphans are the roots whose span references a parent that is not in the
+	// trace, or that were detached from their parent to break a reference cycle.
+	Orphans []*Node
+
+	nodes    map[model.SpanID]*Node
+	size     int
+	maxDepth int
+}
+
+// NewTree builds the tree from a flat list of spans.
+//
+// A span with the same ID as a previously seen span (e.g. the server side of
+// a Zipkin shared span) is attached as a child of the first span with that ID.
+// If the references form a cycle, the cycle is broken at the earliest span of
+// the cycle, which is then reported as an orphan.
+func NewTree(spans []*model.Span) *Tree {
+	t := &Tree{nodes: make(map[model.SpanID]*Node, len(spans))}
+	all := make([]*Node, 0, len(spans))
+	for _, span := range spans {
+		if span == nil {
+			continue
+		}
+		node := &Node{Span: span}
+		all = append(all, node)
+		if first, ok := t.nodes[span.SpanID]; ok {
+			node.Parent = first
+			continue
+		}
+		t.nodes[span.SpanID] = node
+	}
+	t.size = len(all)
+
+	for _, node := range all {
+		if node.Parent != nil {
+			continue
+		}
+		parentID := node.Span.ParentSpanID()
+		if parentID == 0 {
+			continue
+		}
+		if parent, ok := t.nodes[parentID]; ok && parent != node {
+			node.Parent = parent
+		} else {
+			t.Orphans = append(t.Orphans, node)
+		}
+	}
+
+	for _, node := range all {
+		if node.Parent != nil {
+			node.Parent.Children = append(node.Parent.Children, node)
+		} else {
+			t.Roots = append(t.Roots, node)
+		}
+	}
+	for _, node := range all {
+		sortNodes(node.Children)
+	}
+
+	visited := make(map[*Node]struct{}, len(all))
+	t.assignDepth(t.Roots, visited)
+	if len(visited) < len(all) {
+		// the remaining nodes are only reachable through a cycle
+		sortNodes(all)
+		for _, node := range all {
+			if _, ok := visited[node]; ok {
+				continue
+			}
+			node.Parent.removeChild(node)
+			node.Parent = nil
+			t.Roots = append(t.Roots, node)
+			t.Orphans = append(t.Orphans, node)
+			t.assignDepth([]*Node{node}, visited)
+		}
+	}
+	sortNodes(t.Roots)
+	sortNodes(t.Orphans)
+	return t
+}
+
+// assignDepth walks the subtrees of the given roots, setting the depth of
+// every node and recording it as visited.
+func (t *Tree) assignDepth(roots []*Node, visited map[*Node]struct{}) {
+	stack := make([]*Node, 0, len(roots))
+	for _, root := range roots {
+		root.Depth = 0
+		stack = append(stack, root)
+	}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		visited[node] = struct{}{}
+		if node.Depth > t.maxDepth {
+			t.maxDepth = node.Depth
+		}
+		for _, child := range node.Children {
+			child.Depth = node.Depth + 1
+			stack = append(stack, child)
+		}
+	}
+}
+
+func (n *Node) removeChild(child *Node) {
+	for i, c := range n.Children {
+		if c == child {
+			n.Children = append(n.Children[:i], n.Children[i+1:]...)
+			return
+		}
+	}
+}
+
+// Root returns the earliest root that is not an orphan, or nil if all roots are orphans.
+func (t *Tree) Root() *Node {
+	for _, root := range t.Roots {
+		if !t.IsOrphan(root) {
+			return root
+		}
+	}
+	return nil
+}
+
+// IsOrphan reports whether the node is a root only because its parent is missing.
+func (t *Tree) IsOrphan(node *Node) bool {
+	for _, orphan := range t.Orphans {
+		if orphan == node {
+			return true
+		}
+	}
+	return false
+}
+
+// FindNode returns the node of the first span with the given ID.
+func (t *Tree) FindNode(id model.SpanID) (*Node, bool) {
+	node, ok := t.nodes[id]
+	return node, ok
+}
+
+// Len returns the number of spans in the tree.
+func (t *Tree) Len() int {
+	return t.size
+}
+
+// MaxDepth returns the depth of the deepest node.
+func (t *Tree) MaxDepth() int {
+	return t.maxDepth
+}
+
+// Walk visits every node in depth-first pre-order, roots and children in
+// start time order. Returning false from fn skips the children of the node.
+func (t *Tree) Walk(fn func(node *Node) bool) {
+	for _, root := range t.Roots {
+		root.Walk(fn)
+	}
+}
+
+// Walk visits the node and its descendants in depth-first pre-order.
+// Returning false from fn skips the children of the node.
+func (n *Node) Walk(fn func(node *Node) bool) {
+	if !fn(n) {
+		return
+	}
+	for _, child := range n.Children {
+		child.Walk(fn)
+	}
+}
+
+// sortNodes orders nodes by span start time, then by span ID.
+func sortNodes(nodes []*Node) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i].Span, nodes[j].Span
+		if !a.StartTime.Equal(b.StartTime) {
+			return a.StartTime.Before(b.StartTime)
+		}
+		return a.SpanID < b.SpanID
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+var (
+	testTraceID   = model.NewTraceID(1, 2)
+	testStartTime = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+)
+
+// newSpan creates a span starting offset after testStartTime,
+// with a child-of reference to parent unless parent is zero.
+func newSpan(id, parent uint64, offset time.Duration) *model.Span {
+	return &model.Span{
+		TraceID:       testTraceID,
+		SpanID:        model.NewSpanID(id),
+		OperationName: model.NewSpanID(id).String(),
+		StartTime:     testStartTime.Add(offset),
+		Duration:      time.Millisecond,
+		References:    model.MaybeAddParentSpanID(testTraceID, model.NewSpanID(parent), nil),
+	}
+}
+
+func spanIDs(nodes []*Node) []model.SpanID {
+	ids := make([]model.SpanID, 0, len(nodes))
+	for _, n := range nodes {
+		ids = append(ids, n.Span.SpanID)
+	}
+	return ids
+}
+
+func TestNewTree(t *testing.T) {
+	tree := NewTree([]*model.Span{
+		newSpan(3, 1, 2*time.Millisecond),
+		newSpan(4, 3, 3*time.Millisecond),
+		newSpan(2, 1, time.Millisecond),
+		newSpan(1, 0, 0),
+	})
+
+	assert.Equal(t, 4, tree.Len())
+	assert.Equal(t, 2, tree.MaxDepth())
+	assert.Empty(t, tree.Orphans)
+	require.Len(t, tree.Roots, 1)
+
+	root := tree.Root()
+	require.NotNil(t, root)
+	assert.Equal(t, model.NewSpanID(1), root.Span.SpanID)
+	assert.Equal(t, []model.SpanID{2, 3}, spanIDs(root.Children))
+
+	node, ok := tree.FindNode(model.NewSpanID(4))
+	require.True(t, ok)
+	assert.Equal(t, 2, node.Depth)
+	assert.Equal(t, model.NewSpanID(3), node.Parent.Span.SpanID)
+
+	_, ok = tree.FindNode(model.NewSpanID(5))
+	assert.False(t, ok)
+}
+
+func TestNewTreeOrphans(t *testing.T) {
+	tree := NewTree([]*model.Span{
+		newSpan(1, 0, time.Millisecond),
+		newSpan(2, 10, 0),
+		newSpan(3, 2, time.Millisecond),
+		nil,
+	})
+
+	assert.Equal(t, 3, tree.Len())
+	assert.Equal(t, []model.SpanID{2, 1}, spanIDs(tree.Roots))
+	assert.Equal(t, []model.SpanID{2}, spanIDs(tree.Orphans))
+	assert.True(t, tree.IsOrphan(tree.Roots[0]))
+	assert.False(t, tree.IsOrphan(tree.Roots[1]))
+	assert.Equal(t, model.NewSpanID(1), tree.Root().Span.SpanID)
+}
+
+func TestNewTreeOnlyOrphans(t *testing.T) {
+	tree := NewTree([]*model.Span{newSpan(2, 10, 0)})
+	assert.Nil(t, tree.Root())
+	assert.Len(t, tree.Orphans, 1)
+}
+
+func TestNewTreeEmpty(t *testing.T) {
+	tree := NewTree(nil)
+	assert.Nil(t, tree.Root())
+	assert.Zero(t, tree.Len())
+	assert.Zero(t, tree.MaxDepth())
+}
+
+func TestNewTreeSharedSpanID(t *testing.T) {
+	client := newSpan(2, 1, time.Millisecond)
+	server := newSpan(2, 1, 2*time.Millisecond)
+	tree := NewTree([]*model.Span{newSpan(1, 0, 0), client, server})
+
+	node, ok := tree.FindNode(model.NewSpanID(2))
+	require.True(t, ok)
+	assert.Same(t, client, node.Span)
+	require.Len(t, node.Children, 1)
+	assert.Same(t, server, node.Children[0].Span)
+	assert.Equal(t, 2, tree.MaxDepth())
+}
+
+func TestNewTreeCycle(t *testing.T) {
+	tree := NewTree([]*model.Span{
+		newSpan(1, 0, 0),
+		newSpan(2, 3, time.Millisecond),
+		newSpan(3, 2, 2*time.Millisecond),
+		newSpan(4, 4, 3*time.Millisecond), // self-reference
+	})
+
+	assert.Equal(t, []model.SpanID{1, 2, 4}, spanIDs(tree.Roots))
+	assert.Equal(t, []model.SpanID{2, 4}, spanIDs(tree.Orphans))
+	node, ok := tree.FindNode(model.NewSpanID(3))
+	require.True(t, ok)
+	assert.Equal(t, 1, node.Depth)
+	assert.Equal(t, model.NewSpanID(2), node.Parent.Span.SpanID)
+}
+
+func TestTreeWalk(t *testing.T) {
+	tree := NewTree([]*model.Span{
+		newSpan(1, 0, 0),
+		newSpan(2, 1, time.Millisecond),
+		newSpan(3, 2, 2*time.Millisecond),
+		newSpan(4, 1, 3*time.Millisecond),
+		newSpan(5, 9, 4*time.Millisecond),
+	})
+
+	var visited []model.SpanID
+	tree.Walk(func(n *Node) bool {
+		visited = append(visited, n.Span.SpanID)
+		return n.Span.SpanID != 2
+	})
+	assert.Equal(t, []model.SpanID{1, 2, 4, 5}, visited)
+}
+
+// BenchmarkNewTree measures building the tree of a trace where every span
+// has four children, with the spans in reverse order.
+func BenchmarkNewTree(b *testing.B) {
+	for _, n := range []int{10, 1_000, 100_000} {
+		b.Run(fmt.Sprintf("spans=%d", n), func(b *testing.B) {
+			spans := make([]*model.Span, n)
+			for i := range spans {
+				id := uint64(n - i)
+				spans[i] = newSpan(id, id/4, time.Duration(id)*time.Microsecond)
+			}
+			b.ReportAllocs()
+			for b.Loop() {
+				NewTree(spans)
+			}
+		})
+	}
+}
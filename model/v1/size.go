@@ -0,0 +1,46 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+// SpanWireSize returns the number of bytes that the span takes in a message
+// holding it in a repeated field, such as the spans of a Batch or of an
+// api_v2 SpansResponseChunk: its encoded size, with the tag and the length
+// prefix of the field.
+func SpanWireSize(span *Span) int {
+	return fieldWireSize(span.Size())
+}
+
+// BatchWireSize returns the number of bytes that the batch takes in a
+// message holding it in a field, such as an api_v2 PostSpansRequest.
+func BatchWireSize(batch *Batch) int {
+	return fieldWireSize(batch.Size())
+}
+
+// fieldWireSize returns the size of an embedded message of the given size,
+// with a field number below 16 whose tag takes a single byte.
+func fieldWireSize(size int) int {
+	return 1 + sovModel(uint64(size)) + size
+}
+
+// SplitSpans splits the spans, in order, into groups whose encoded size as
+// a repeated field, e.g. of a SpansResponseChunk, is at most maxSize bytes,
+// so that each group can be sent in a message under the gRPC max message
+// size. A span larger than maxSize is alone in its group, which still
+// exceeds maxSize.
+func SplitSpans(spans []*Span, maxSize int) [][]*Span {
+	var groups [][]*Span
+	start, size := 0, 0
+	for i, span := range spans {
+		n := SpanWireSize(span)
+		if i > start && size+n > maxSize {
+			groups = append(groups, spans[start:i:i])
+			start, size = i, 0
+		}
+		size += n
+	}
+	if start < len(spans) {
+		groups = append(groups, spans[start:])
+	}
+	return groups
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+func sizeTestSpan(id uint64, operationLength int) *model.Span {
+	return &model.Span{
+		TraceID:       model.NewTraceID(1, 2),
+		SpanID:        model.NewSpanID(id),
+		OperationName: strings.Repeat("x", operationLength),
+	}
+}
+
+func TestWireSize(t *testing.T) {
+	spans := []*model.Span{sizeTestSpan(1, 10), sizeTestSpan(2, 200)}
+	batch := &model.Batch{Spans: spans, Process: model.NewProcess("frontend", nil)}
+	data, err := batch.Marshal()
+	require.NoError(t, err)
+	process, err := batch.Process.Marshal()
+	require.NoError(t, err)
+	// the batch is its spans and its process, both with a one-byte tag
+	assert.Len(t, data, model.SpanWireSize(spans[0])+model.SpanWireSize(spans[1])+2+len(process))
+	// a two-byte length prefix from 128 bytes
+	assert.Equal(t, spans[1].Size()+3, model.SpanWireSize(spans[1]))
+	assert.Equal(t, len(data)+3, model.BatchWireSize(batch))
+}
+
+func TestSplitSpans(t *testing.T) {
+	var spans []*model.Span
+	for i := range 5 {
+		spans = append(spans, sizeTestSpan(uint64(i+1), 50))
+	}
+	spans = append(spans, sizeTestSpan(6, 500))
+	size := model.SpanWireSize(spans[0])
+
+	groups := model.SplitSpans(spans, 2*size)
+	require.Len(t, groups, 4)
+	assert.Equal(t, spans[0:2], groups[0])
+	assert.Equal(t, spans[2:4], groups[1])
+	assert.Equal(t, spans[4:5], groups[2])
+	assert.Equal(t, spans[5:], groups[3], "the span larger than the max size is alone")
+
+	groups[0] = append(groups[0], spans[5])
+	assert.Equal(t, model.NewSpanID(3), spans[2].SpanID, "the groups do not share their capacity")
+
+	assert.Equal(t, [][]*model.Span{spans}, model.SplitSpans(spans, 1<<20))
+	assert.Empty(t, model.SplitSpans(nil, 1<<20))
+}
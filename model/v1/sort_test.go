@@ -5,6 +5,8 @@
 package model
 
 import (
+	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -127,3 +129,35 @@ func TestSortByTraceID(t *testing.T) {
 	SortTraceIDs(traces)
 	assert.Equal(t, tracesExpected, traces)
 }
+
+// BenchmarkSortTrace measures deep sorting traces whose spans and tags are in
+// reverse order, which is dominated by sorting the tags.
+func BenchmarkSortTrace(b *testing.B) {
+	keys := []string{"span.kind", "peer.service", "http.url", "http.status_code", "http.method", "error", "component"}
+	for _, n := range []int{10, 1_000, 100_000} {
+		b.Run(fmt.Sprintf("spans=%d", n), func(b *testing.B) {
+			trace := &Trace{Spans: make([]*Span, n)}
+			for i := range trace.Spans {
+				span := &Span{
+					SpanID:  SpanID(n - i),
+					Process: &Process{ServiceName: "service", Tags: []KeyValue{String("hostname", "host"), String("client-uuid", "1")}},
+				}
+				for _, key := range keys {
+					span.Tags = append(span.Tags, String(key, "value"))
+				}
+				trace.Spans[i] = span
+			}
+			b.ReportAllocs()
+			for b.Loop() {
+				b.StopTimer()
+				slices.Reverse(trace.Spans)
+				for _, span := range trace.Spans {
+					slices.Reverse(span.Tags)
+					slices.Reverse(span.Process.Tags)
+				}
+				b.StartTimer()
+				SortTrace(trace)
+			}
+		})
+	}
+}
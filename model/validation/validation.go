@@ -0,0 +1,180 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package validation checks spans for values that storage backends and
+// the UI cannot handle, so that they can be rejected on ingestion.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+// Reason classifies a validation failure.
+type Reason string
+
+const (
+	// ZeroTraceID means the span has no trace ID.
+	ZeroTraceID Reason = "zero_trace_id"
+	// ZeroSpanID means the span has no span ID.
+	ZeroSpanID Reason = "zero_span_id"
+	// MissingStartTime means the span has no start timestamp.
+	MissingStartTime Reason = "missing_start_time"
+	// NegativeDuration means the span ends before it starts.
+	NegativeDuration Reason = "negative_duration"
+	// OversizedAttribute means a tag key or value exceeds the configured limits.
+	OversizedAttribute Reason = "oversized_attribute"
+	// TooManyAttributes means the span, its process or a log has more tags than the configured limit.
+	TooManyAttributes Reason = "too_many_attributes"
+	// OversizedName means the operation or service name exceeds the configured limit.
+	OversizedName Reason = "oversized_name"
+)
+
+// ErrTooManySpans is wrapped by the errors of Batch.
+var ErrTooManySpans = errors.New("too many spans")
+
+// Limits bounds the size of spans and batches. Zero values disable the check.
+type Limits struct {
+	// MaxKeyLength is the maximum length of a tag key, in bytes.
+	MaxKeyLength int
+	// MaxValueLength is the maximum length of a string or binary tag value, in bytes.
+	MaxValueLength int
+	// MaxAttributes is the maximum number of tags of a span, of its process and of each log.
+	MaxAttributes int
+	// MaxNameLength is the maximum length of the operation and service names, in bytes.
+	MaxNameLength int
+	// MaxBatchSpans is the maximum number of spans of a batch.
+	MaxBatchSpans int
+}
+
+// DefaultLimits returns the limits used when none are configured.
+func DefaultLimits() Limits {
+	return Limits{
+		MaxKeyLength:   256,
+		MaxValueLength: 32 << 10,
+		MaxAttributes:  1024,
+		MaxNameLength:  1024,
+		MaxBatchSpans:  10_000,
+	}
+}
+
+// Batch checks the number of spans of a batch against limits, before the
+// spans are converted or validated. It returns an error wrapping
+// ErrTooManySpans if the batch is too large.
+func Batch(spans int, limits Limits) error {
+	if limits.MaxBatchSpans > 0 && spans > limits.MaxBatchSpans {
+		return fmt.Errorf("%w: batch of %d spans exceeds the limit of %d", ErrTooManySpans, spans, limits.MaxBatchSpans)
+	}
+	return nil
+}
+
+// FieldError describes a single problem with a field of a span.
+type FieldError struct {
+	// Field is the path to the invalid field, e.g. "traceID" or "logs[1].fields[0]".
+	Field  string
+	Reason Reason
+	Detail string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Detail)
+}
+
+// SpanError lists all problems found in a span.
+type SpanError struct {
+	TraceID model.TraceID
+	SpanID  model.SpanID
+	Fields  []*FieldError
+}
+
+func (e *SpanError) Error() string {
+	details := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		details[i] = f.Error()
+	}
+	return fmt.Sprintf("invalid span %s/%s: %s", e.TraceID, e.SpanID, strings.Join(details, "; "))
+}
+
+// Span validates the span against limits. It returns nil if the span is valid,
+// or a *SpanError otherwise.
+//
+// In the domain model the end of a span is derived from its start and duration,
+// so a span ending before its start is reported as NegativeDuration.
+func Span(span *model.Span, limits Limits) error {
+	v := validator{limits: limits}
+	if span.TraceID == (model.TraceID{}) {
+		v.add("traceID", ZeroTraceID, "trace ID must not be zero")
+	}
+	if span.SpanID == 0 {
+		v.add("spanID", ZeroSpanID, "span ID must not be zero")
+	}
+	if span.StartTime.IsZero() {
+		v.add("startTime", MissingStartTime, "start time must be set")
+	}
+	if span.Duration < 0 {
+		v.add("duration", NegativeDuration, fmt.Sprintf("span ends before it starts (duration %v)", span.Duration))
+	}
+	v.name("operationName", span.OperationName)
+	v.tags("tags", span.Tags)
+	for i, l := range span.Logs {
+		v.tags(fmt.Sprintf("logs[%d].fields", i), l.Fields)
+	}
+	if span.Process != nil {
+		v.name("process.serviceName", span.Process.ServiceName)
+		v.tags("process.tags", span.Process.Tags)
+	}
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return &SpanError{TraceID: span.TraceID, SpanID: span.SpanID, Fields: v.errors}
+}
+
+type validator struct {
+	limits Limits
+	errors []*FieldError
+}
+
+func (v *validator) add(field string, reason Reason, detail string) {
+	v.errors = append(v.errors, &FieldError{Field: field, Reason: reason, Detail: detail})
+}
+
+func (v *validator) name(field, name string) {
+	if limit := v.limits.MaxNameLength; limit > 0 && len(name) > limit {
+		v.add(field, OversizedName, fmt.Sprintf("name of %d bytes exceeds the limit of %d", len(name), limit))
+	}
+}
+
+func (v *validator) tags(field string, tags []model.KeyValue) {
+	if limit := v.limits.MaxAttributes; limit > 0 && len(tags) > limit {
+		// the tags are not checked one by one, to bound the number of errors
+		v.add(field, TooManyAttributes, fmt.Sprintf("%d tags exceed the limit of %d", len(tags), limit))
+		return
+	}
+	for i := range tags {
+		tag := &tags[i]
+		if limit := v.limits.MaxKeyLength; limit > 0 && len(tag.Key) > limit {
+			v.add(fmt.Sprintf("%s[%d].key", field, i), OversizedAttribute,
+				fmt.Sprintf("key of %d bytes exceeds the limit of %d", len(tag.Key), limit))
+		}
+		if limit := v.limits.MaxValueLength; limit > 0 {
+			if n := valueLength(tag); n > limit {
+				v.add(fmt.Sprintf("%s[%d].value", field, i), OversizedAttribute,
+					fmt.Sprintf("value of %q has %d bytes, exceeding the limit of %d", tag.Key, n, limit))
+			}
+		}
+	}
+}
+
+func valueLength(tag *model.KeyValue) int {
+	switch tag.VType {
+	case model.StringType:
+		return len(tag.VStr)
+	case model.BinaryType:
+		return len(tag.VBinary)
+	default:
+		return 0
+	}
+}
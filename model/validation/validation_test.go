@@ -0,0 +1,176 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger-idl/model/v1"
+)
+
+func validSpan() *model.Span {
+	return &model.Span{
+		TraceID:       model.NewTraceID(1, 2),
+		SpanID:        model.NewSpanID(3),
+		OperationName: "GET /users",
+		StartTime:     time.Unix(1700000000, 0),
+		Duration:      time.Millisecond,
+		Tags:          []model.KeyValue{model.String("http.method", "GET")},
+		Logs: []model.Log{
+			{Timestamp: time.Unix(1700000000, 0), Fields: []model.KeyValue{model.String("event", "retry")}},
+		},
+		Process: &model.Process{ServiceName: "frontend", Tags: []model.KeyValue{model.String("hostname", "fe-1")}},
+	}
+}
+
+func TestSpanValid(t *testing.T) {
+	require.NoError(t, Span(validSpan(), DefaultLimits()))
+}
+
+func TestSpanInvalid(t *testing.T) {
+	limits := Limits{MaxKeyLength: 8, MaxValueLength: 4, MaxAttributes: 2, MaxNameLength: 10}
+	tests := []struct {
+		name   string
+		modify func(*model.Span)
+		field  string
+		reason Reason
+	}{
+		{
+			name:   "zero trace ID",
+			modify: func(s *model.Span) { s.TraceID = model.TraceID{} },
+			field:  "traceID",
+			reason: ZeroTraceID,
+		},
+		{
+			name:   "zero span ID",
+			modify: func(s *model.Span) { s.SpanID = 0 },
+			field:  "spanID",
+			reason: ZeroSpanID,
+		},
+		{
+			name:   "missing start time",
+			modify: func(s *model.Span) { s.StartTime = time.Time{} },
+			field:  "startTime",
+			reason: MissingStartTime,
+		},
+		{
+			name:   "negative duration",
+			modify: func(s *model.Span) { s.Duration = -time.Second },
+			field:  "duration",
+			reason: NegativeDuration,
+		},
+		{
+			name:   "oversized tag key",
+			modify: func(s *model.Span) { s.Tags[0] = model.Bool("http.request.retried", true) },
+			field:  "tags[0].key",
+			reason: OversizedAttribute,
+		},
+		{
+			name:   "oversized tag value",
+			modify: func(s *model.Span) { s.Tags[0] = model.String("method", "DELETE") },
+			field:  "tags[0].value",
+			reason: OversizedAttribute,
+		},
+		{
+			name:   "oversized binary value",
+			modify: func(s *model.Span) { s.Tags[0] = model.Binary("body", []byte("payload")) },
+			field:  "tags[0].value",
+			reason: OversizedAttribute,
+		},
+		{
+			name:   "oversized log field",
+			modify: func(s *model.Span) { s.Logs[0].Fields[0] = model.String("event", "timeout") },
+			field:  "logs[0].fields[0].value",
+			reason: OversizedAttribute,
+		},
+		{
+			name:   "oversized process tag",
+			modify: func(s *model.Span) { s.Process.Tags[0] = model.String("host", "frontend-1") },
+			field:  "process.tags[0].value",
+			reason: OversizedAttribute,
+		},
+		{
+			name: "too many tags",
+			modify: func(s *model.Span) {
+				s.Tags = append(s.Tags, model.Bool("a", true), model.Bool("b", true))
+			},
+			field:  "tags",
+			reason: TooManyAttributes,
+		},
+		{
+			name: "too many log fields",
+			modify: func(s *model.Span) {
+				s.Logs[0].Fields = append(s.Logs[0].Fields, model.Bool("a", true), model.Bool("b", true))
+			},
+			field:  "logs[0].fields",
+			reason: TooManyAttributes,
+		},
+		{
+			name:   "oversized operation name",
+			modify: func(s *model.Span) { s.OperationName = "GET /users/1" },
+			field:  "operationName",
+			reason: OversizedName,
+		},
+		{
+			name:   "oversized service name",
+			modify: func(s *model.Span) { s.Process.ServiceName = "frontend-proxy" },
+			field:  "process.serviceName",
+			reason: OversizedName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span := validSpan()
+			span.Tags[0] = model.String("method", "GET")
+			span.Logs[0].Fields[0] = model.String("event", "ok")
+			span.Process.Tags[0] = model.String("host", "fe")
+			require.NoError(t, Span(span, limits))
+
+			tt.modify(span)
+			err := Span(span, limits)
+			var spanErr *SpanError
+			require.ErrorAs(t, err, &spanErr)
+			require.Len(t, spanErr.Fields, 1)
+			assert.Equal(t, tt.field, spanErr.Fields[0].Field)
+			assert.Equal(t, tt.reason, spanErr.Fields[0].Reason)
+			assert.Equal(t, span.TraceID, spanErr.TraceID)
+			assert.Equal(t, span.SpanID, spanErr.SpanID)
+		})
+	}
+}
+
+func TestSpanMultipleErrors(t *testing.T) {
+	span := validSpan()
+	span.TraceID = model.TraceID{}
+	span.SpanID = 0
+	span.Duration = -time.Second
+
+	err := Span(span, DefaultLimits())
+	var spanErr *SpanError
+	require.ErrorAs(t, err, &spanErr)
+	require.Len(t, spanErr.Fields, 3)
+	assert.Equal(t, "invalid span 0000000000000000/0000000000000000: traceID: trace ID must not be zero; "+
+		"spanID: span ID must not be zero; duration: span ends before it starts (duration -1s)", err.Error())
+}
+
+func TestSpanLimitsDisabled(t *testing.T) {
+	span := validSpan()
+	span.Tags = append(span.Tags, model.String(strings.Repeat("k", 1000), strings.Repeat("v", 1<<20)))
+	require.NoError(t, Span(span, Limits{}))
+	require.Error(t, Span(span, DefaultLimits()))
+}
+
+func TestBatch(t *testing.T) {
+	limits := Limits{MaxBatchSpans: 2}
+	require.NoError(t, Batch(2, limits))
+	err := Batch(3, limits)
+	require.ErrorIs(t, err, ErrTooManySpans)
+	assert.Equal(t, "too many spans: batch of 3 spans exceeds the limit of 2", err.Error())
+	require.NoError(t, Batch(3, Limits{}))
+}
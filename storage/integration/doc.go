@@ -0,0 +1,25 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package integration is a conformance test suite of the storage v2 remote
+// storage API, runnable against any backend implementing it: the spans are
+// written with the OTLP TraceService and read back with the TraceReader and
+// DependencyReader services.
+//
+// The suite checks the write and read round trips, the chunking rules of the
+// trace streams, the semantics of FindTraces and FindTraceIDs, the service
+// and operation lists, the dependencies and the retention. A backend test
+// connects the clients to the backend and runs all the tests:
+//
+//	func TestStorage(t *testing.T) {
+//		conn := ... // a connection to the backend
+//		s := &integration.Storage{
+//			TraceWriter:      collectortrace.NewTraceServiceClient(conn),
+//			TraceReader:      storagev2.NewTraceReaderClient(conn),
+//			DependencyReader: storagev2.NewDependencyReaderClient(conn),
+//			CleanUp:          func(t *testing.T) { ... }, // removes all the data
+//			SkipList:         []string{"FindTraces/ByDuration"},
+//		}
+//		s.RunAll(t)
+//	}
+package integration
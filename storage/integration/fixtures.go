@@ -0,0 +1,195 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration
+
+import (
+	"encoding/binary"
+	"time"
+
+	common "go.opentelemetry.io/proto/otlp/common/v1"
+	resource "go.opentelemetry.io/proto/otlp/resource/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// testSpan describes a span of a test trace.
+type testSpan struct {
+	service string
+	name    string
+	kind    trace.Span_SpanKind
+	// parent is the index of the parent span in the trace, -1 for the root.
+	parent     int
+	offset     time.Duration
+	duration   time.Duration
+	attributes []*common.KeyValue
+	failed     bool
+}
+
+// testTraceID returns the ID of the nth test trace.
+func testTraceID(n int) []byte {
+	return []byte{0xa5, 0xe7, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, byte(n >> 8), byte(n)}
+}
+
+func testSpanID(trace, span int) []byte {
+	id := []byte{0x5a, byte(trace >> 8), byte(trace), 0x00, 0x00, 0x00, 0x00, 0x00}
+	binary.BigEndian.PutUint32(id[4:], uint32(span+1))
+	return id
+}
+
+// buildTrace returns the nth test trace, starting at start, with a resource
+// per service in the order of their first spans.
+func buildTrace(n int, start time.Time, spans []testSpan) *trace.TracesData {
+	td := &trace.TracesData{}
+	scopes := make(map[string]*trace.ScopeSpans)
+	for i, s := range spans {
+		ss, ok := scopes[s.service]
+		if !ok {
+			ss = &trace.ScopeSpans{Scope: &common.InstrumentationScope{Name: "jaeger-idl/storage/integration", Version: "1.0.0"}}
+			scopes[s.service] = ss
+			td.ResourceSpans = append(td.ResourceSpans, &trace.ResourceSpans{
+				Resource: &resource.Resource{Attributes: []*common.KeyValue{
+					stringAttr("service.name", s.service),
+					stringAttr("host.name", s.service+"-host"),
+				}},
+				ScopeSpans: []*trace.ScopeSpans{ss},
+			})
+		}
+		spanStart := start.Add(s.offset)
+		span := &trace.Span{
+			TraceId:           testTraceID(n),
+			SpanId:            testSpanID(n, i),
+			Name:              s.name,
+			Kind:              s.kind,
+			StartTimeUnixNano: uint64(spanStart.UnixNano()),
+			EndTimeUnixNano:   uint64(spanStart.Add(s.duration).UnixNano()),
+			Attributes:        s.attributes,
+		}
+		if s.parent >= 0 {
+			span.ParentSpanId = testSpanID(n, s.parent)
+		}
+		if s.failed {
+			span.Status = &trace.Status{Code: trace.Status_STATUS_CODE_ERROR, Message: "failed"}
+		}
+		ss.Spans = append(ss.Spans, span)
+	}
+	return td
+}
+
+// Test traces of the find tests, relative to their base time.
+
+// dispatchTrace is a request of frontend to driver, which calls redis.
+func dispatchTrace(base time.Time) *trace.TracesData {
+	return buildTrace(1, base, []testSpan{
+		{service: "frontend", name: "GET /dispatch", kind: trace.Span_SPAN_KIND_SERVER, parent: -1, duration: 300 * time.Millisecond, attributes: []*common.KeyValue{
+			stringAttr("http.method", "GET"),
+			intAttr("http.status_code", 200),
+		}},
+		{service: "frontend", name: "FindNearest", kind: trace.Span_SPAN_KIND_CLIENT, parent: 0, offset: 10 * time.Millisecond, duration: 100 * time.Millisecond},
+		{service: "driver", name: "FindNearest", kind: trace.Span_SPAN_KIND_SERVER, parent: 1, offset: 20 * time.Millisecond, duration: 80 * time.Millisecond, attributes: []*common.KeyValue{
+			stringAttr("region", "eu"),
+		}},
+		{service: "driver", name: "GetDriver", kind: trace.Span_SPAN_KIND_CLIENT, parent: 2, offset: 30 * time.Millisecond, duration: 10 * time.Millisecond, attributes: []*common.KeyValue{
+			boolAttr("cache.hit", true),
+		}},
+		{service: "redis", name: "GetDriver", kind: trace.Span_SPAN_KIND_SERVER, parent: 3, offset: 31 * time.Millisecond, duration: 8 * time.Millisecond},
+	})
+}
+
+// configTrace is a failed request of frontend, ten minutes after the base.
+func configTrace(base time.Time) *trace.TracesData {
+	return buildTrace(2, base.Add(10*time.Minute), []testSpan{
+		{service: "frontend", name: "GET /config", kind: trace.Span_SPAN_KIND_SERVER, parent: -1, duration: 10 * time.Millisecond, failed: true, attributes: []*common.KeyValue{
+			stringAttr("http.method", "GET"),
+			intAttr("http.status_code", 500),
+		}},
+	})
+}
+
+// slowDispatchTrace is a slow request of frontend to customer, thirty
+// minutes before the base.
+func slowDispatchTrace(base time.Time) *trace.TracesData {
+	return buildTrace(3, base.Add(-30*time.Minute), []testSpan{
+		{service: "frontend", name: "GET /dispatch", kind: trace.Span_SPAN_KIND_SERVER, parent: -1, duration: 2 * time.Second, attributes: []*common.KeyValue{
+			stringAttr("http.method", "GET"),
+			intAttr("http.status_code", 200),
+		}},
+		{service: "customer", name: "GET /customer", kind: trace.Span_SPAN_KIND_SERVER, parent: 0, offset: 100 * time.Millisecond, duration: 1500 * time.Millisecond, attributes: []*common.KeyValue{
+			stringAttr("region", "us"),
+		}},
+	})
+}
+
+// richTrace has spans with attributes of all the types, events, links and
+// statuses, to check that the round trips are lossless.
+func richTrace(n int, start time.Time) *trace.TracesData {
+	td := buildTrace(n, start, []testSpan{
+		{service: "frontend", name: "GET /dispatch", kind: trace.Span_SPAN_KIND_SERVER, parent: -1, duration: time.Second, attributes: []*common.KeyValue{
+			stringAttr("http.method", "GET"),
+			intAttr("http.status_code", 200),
+			{Key: "http.duration_ratio", Value: &common.AnyValue{Value: &common.AnyValue_DoubleValue{DoubleValue: 0.25}}},
+			boolAttr("sampled", true),
+			{Key: "payload", Value: &common.AnyValue{Value: &common.AnyValue_BytesValue{BytesValue: []byte{0xde, 0xad, 0xbe, 0xef}}}},
+			{Key: "tags", Value: &common.AnyValue{Value: &common.AnyValue_ArrayValue{ArrayValue: &common.ArrayValue{
+				Values: []*common.AnyValue{{Value: &common.AnyValue_StringValue{StringValue: "a"}}, {Value: &common.AnyValue_StringValue{StringValue: "b"}}},
+			}}}},
+			{Key: "user", Value: &common.AnyValue{Value: &common.AnyValue_KvlistValue{KvlistValue: &common.KeyValueList{
+				Values: []*common.KeyValue{stringAttr("id", "42"), intAttr("age", 7)},
+			}}}},
+		}},
+		{service: "driver", name: "FindNearest", kind: trace.Span_SPAN_KIND_SERVER, parent: 0, offset: 100 * time.Millisecond, duration: 500 * time.Millisecond, failed: true},
+	})
+	root := td.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	root.TraceState = "vendor=value"
+	root.Events = []*trace.Span_Event{{
+		TimeUnixNano: root.StartTimeUnixNano + uint64(10*time.Millisecond),
+		Name:         "cache miss",
+		Attributes:   []*common.KeyValue{stringAttr("cache.key", "drivers")},
+	}}
+	root.Links = []*trace.Span_Link{{
+		TraceId:    testTraceID(n + 1),
+		SpanId:     testSpanID(n+1, 0),
+		Attributes: []*common.KeyValue{stringAttr("link.type", "follows_from")},
+	}}
+	return td
+}
+
+// largeTrace returns a trace of spans spans in a single service, in batches
+// of at most batch spans.
+func largeTrace(n int, start time.Time, spans, batch int) []*trace.TracesData {
+	specs := make([]testSpan, spans)
+	for i := range specs {
+		specs[i] = testSpan{
+			service:  "frontend",
+			name:     "GET /dispatch",
+			kind:     trace.Span_SPAN_KIND_INTERNAL,
+			parent:   (i - 1) / 2,
+			offset:   time.Duration(i) * time.Microsecond,
+			duration: time.Millisecond,
+		}
+	}
+	specs[0].kind, specs[0].parent = trace.Span_SPAN_KIND_SERVER, -1
+	all := buildTrace(n, start, specs).ResourceSpans[0]
+	var batches []*trace.TracesData
+	for i := 0; i < spans; i += batch {
+		batches = append(batches, &trace.TracesData{ResourceSpans: []*trace.ResourceSpans{{
+			Resource: all.Resource,
+			ScopeSpans: []*trace.ScopeSpans{{
+				Scope: all.ScopeSpans[0].Scope,
+				Spans: all.ScopeSpans[0].Spans[i:min(i+batch, spans)],
+			}},
+		}}})
+	}
+	return batches
+}
+
+func stringAttr(key, value string) *common.KeyValue {
+	return &common.KeyValue{Key: key, Value: &common.AnyValue{Value: &common.AnyValue_StringValue{StringValue: value}}}
+}
+
+func intAttr(key string, value int64) *common.KeyValue {
+	return &common.KeyValue{Key: key, Value: &common.AnyValue{Value: &common.AnyValue_IntValue{IntValue: value}}}
+}
+
+func boolAttr(key string, value bool) *common.KeyValue {
+	return &common.KeyValue{Key: key, Value: &common.AnyValue{Value: &common.AnyValue_BoolValue{BoolValue: value}}}
+}
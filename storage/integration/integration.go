@@ -0,0 +1,478 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	storagev2 "github.com/jaegertracing/jaeger-idl/gen/storage/v2"
+)
+
+// Storage is a storage backend under test, through the clients of its
+// remote storage API.
+type Storage struct {
+	// TraceWriter writes the spans of the tests.
+	TraceWriter collectortrace.TraceServiceClient
+	TraceReader storagev2.TraceReaderClient
+	// DependencyReader, if set, runs the dependency tests.
+	DependencyReader storagev2.DependencyReaderClient
+	// CleanUp removes all the data of the backend. It is called before each
+	// test, so that the tests do not see the data of the others.
+	CleanUp func(t *testing.T)
+	// Refresh, if set, is called after the writes, for the backends making
+	// the written data visible to the reads asynchronously.
+	Refresh func(t *testing.T)
+	// ApplyRetention, if set, removes the traces that started more than
+	// maxAge ago, and runs the retention tests.
+	ApplyRetention func(t *testing.T, maxAge time.Duration)
+	// SkipList lists regular expressions matching the names of the tests to
+	// skip, relative to the test running the suite, for the features that
+	// the backend does not support, e.g. "FindTraces/ByDuration".
+	SkipList []string
+}
+
+// RunAll runs all the tests of the suite.
+func (s *Storage) RunAll(t *testing.T) {
+	require.NotNil(t, s.TraceWriter, "TraceWriter must be set")
+	require.NotNil(t, s.TraceReader, "TraceReader must be set")
+	require.NotNil(t, s.CleanUp, "CleanUp must be set")
+	prefix := t.Name() + "/"
+	s.run(t, prefix, "GetTraces/RoundTrip", s.testGetTracesRoundTrip)
+	s.run(t, prefix, "GetTraces/Missing", s.testGetTracesMissing)
+	s.run(t, prefix, "GetTraces/Large", s.testGetTracesLarge)
+	s.run(t, prefix, "GetServices", s.testGetServices)
+	s.run(t, prefix, "GetOperations", s.testGetOperations)
+	s.run(t, prefix, "FindTraces/ByService", s.testFindTracesByService)
+	s.run(t, prefix, "FindTraces/ByOperation", s.testFindTracesByOperation)
+	s.run(t, prefix, "FindTraces/ByAttributes", s.testFindTracesByAttributes)
+	s.run(t, prefix, "FindTraces/ByTimeRange", s.testFindTracesByTimeRange)
+	s.run(t, prefix, "FindTraces/ByDuration", s.testFindTracesByDuration)
+	s.run(t, prefix, "FindTraces/SearchDepth", s.testFindTracesSearchDepth)
+	s.run(t, prefix, "FindTraceIDs", s.testFindTraceIDs)
+	s.run(t, prefix, "GetDependencies", s.testGetDependencies)
+	s.run(t, prefix, "Retention", s.testRetention)
+}
+
+// run runs a test of the suite, unless it is in the skip list, on a clean
+// backend.
+func (s *Storage) run(t *testing.T, prefix, name string, test func(t *testing.T)) {
+	t.Run(name, func(t *testing.T) {
+		for _, pattern := range s.SkipList {
+			if regexp.MustCompile(pattern).MatchString(strings.TrimPrefix(t.Name(), prefix)) {
+				t.Skipf("skipped by %q", pattern)
+			}
+		}
+		s.CleanUp(t)
+		test(t)
+	})
+}
+
+// write writes the traces, expecting all their spans to be accepted.
+func (s *Storage) write(t *testing.T, traces ...*trace.TracesData) {
+	t.Helper()
+	for _, td := range traces {
+		resp, err := s.TraceWriter.Export(t.Context(), &collectortrace.ExportTraceServiceRequest{ResourceSpans: td.ResourceSpans})
+		require.NoError(t, err)
+		require.Zero(t, resp.GetPartialSuccess().GetRejectedSpans(), resp.GetPartialSuccess().GetErrorMessage())
+	}
+	if s.Refresh != nil {
+		s.Refresh(t)
+	}
+}
+
+// foundTrace is a trace read from a stream of TracesData chunks.
+type foundTrace struct {
+	traceID string
+	// spans are the spans of the trace with their resources and scopes, by
+	// span ID.
+	spans map[string]*foundSpan
+}
+
+type foundSpan struct {
+	rs   *trace.ResourceSpans
+	ss   *trace.ScopeSpans
+	span *trace.Span
+}
+
+// readTraces reads a stream of traces, checking its chunking rules: each
+// chunk is non-empty and holds the spans of a single trace, and the chunks
+// of a trace are consecutive.
+func readTraces(stream grpc.ServerStreamingClient[trace.TracesData]) ([]*foundTrace, error) {
+	var traces []*foundTrace
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return traces, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var traceID []byte
+		n := 0
+		for _, rs := range chunk.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, span := range ss.Spans {
+					if traceID == nil {
+						traceID = span.TraceId
+					} else if !bytes.Equal(traceID, span.TraceId) {
+						return nil, fmt.Errorf("chunk with the spans of traces %x and %x", traceID, span.TraceId)
+					}
+					n++
+				}
+			}
+		}
+		if n == 0 {
+			return nil, errors.New("empty chunk")
+		}
+		id := hex.EncodeToString(traceID)
+		var current *foundTrace
+		if len(traces) > 0 && traces[len(traces)-1].traceID == id {
+			current = traces[len(traces)-1]
+		} else {
+			if slices.ContainsFunc(traces, func(ft *foundTrace) bool { return ft.traceID == id }) {
+				return nil, fmt.Errorf("non-consecutive chunks of trace %s", id)
+			}
+			current = &foundTrace{traceID: id, spans: make(map[string]*foundSpan)}
+			traces = append(traces, current)
+		}
+		for _, rs := range chunk.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, span := range ss.Spans {
+					current.spans[hex.EncodeToString(span.SpanId)] = &foundSpan{rs: rs, ss: ss, span: span}
+				}
+			}
+		}
+	}
+}
+
+func (s *Storage) getTraces(t *testing.T, params ...*storagev2.GetTraceParams) []*foundTrace {
+	t.Helper()
+	stream, err := s.TraceReader.GetTraces(t.Context(), &storagev2.GetTracesRequest{Query: params})
+	require.NoError(t, err)
+	traces, err := readTraces(stream)
+	require.NoError(t, err)
+	return traces
+}
+
+func (s *Storage) findTraces(t *testing.T, query *storagev2.TraceQueryParameters) []*foundTrace {
+	t.Helper()
+	stream, err := s.TraceReader.FindTraces(t.Context(), &storagev2.FindTracesRequest{Query: query})
+	require.NoError(t, err)
+	traces, err := readTraces(stream)
+	require.NoError(t, err)
+	return traces
+}
+
+// traceIDs returns the IDs of the traces.
+func traceIDs(traces []*foundTrace) []string {
+	ids := make([]string, len(traces))
+	for i, ft := range traces {
+		ids[i] = ft.traceID
+	}
+	return ids
+}
+
+// ids returns the hex IDs of the test traces.
+func ids(traces ...int) []string {
+	result := make([]string, len(traces))
+	for i, n := range traces {
+		result[i] = hex.EncodeToString(testTraceID(n))
+	}
+	return result
+}
+
+// assertSameSpans checks that the spans of td were read back as written,
+// with their resources and scopes.
+func assertSameSpans(t *testing.T, td *trace.TracesData, found *foundTrace) {
+	t.Helper()
+	expected := 0
+	for _, rs := range td.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				expected++
+				spanID := hex.EncodeToString(span.SpanId)
+				actual, ok := found.spans[spanID]
+				if !assert.True(t, ok, "span %s not found", spanID) {
+					continue
+				}
+				assert.True(t, proto.Equal(span, actual.span), "span %s differs:\nwritten %v\nread    %v", spanID, span, actual.span)
+				assert.True(t, proto.Equal(rs.Resource, actual.rs.Resource), "resource of span %s differs:\nwritten %v\nread    %v", spanID, rs.Resource, actual.rs.Resource)
+				assert.True(t, proto.Equal(ss.Scope, actual.ss.Scope), "scope of span %s differs:\nwritten %v\nread    %v", spanID, ss.Scope, actual.ss.Scope)
+			}
+		}
+	}
+	assert.Len(t, found.spans, expected)
+}
+
+// base is the base time of the find tests, leaving room for the traces
+// before and after it within the last hours.
+func base() time.Time {
+	return time.Now().Truncate(time.Millisecond).Add(-time.Hour)
+}
+
+// writeFindTraces writes the traces of the find tests.
+func (s *Storage) writeFindTraces(t *testing.T, base time.Time) {
+	s.write(t, dispatchTrace(base), configTrace(base), slowDispatchTrace(base))
+}
+
+func (s *Storage) testGetTracesRoundTrip(t *testing.T) {
+	start := base()
+	s.write(t, richTrace(1, start), richTrace(3, start))
+
+	found := s.getTraces(t, &storagev2.GetTraceParams{TraceId: testTraceID(1)})
+	require.Len(t, found, 1)
+	assert.Equal(t, ids(1)[0], found[0].traceID)
+	assertSameSpans(t, richTrace(1, start), found[0])
+
+	// the time range of the trace is a hint, with which it is found
+	found = s.getTraces(t,
+		&storagev2.GetTraceParams{TraceId: testTraceID(1), StartTime: timestamppb.New(start.Add(-time.Minute)), EndTime: timestamppb.New(start.Add(time.Minute))},
+		&storagev2.GetTraceParams{TraceId: testTraceID(3)},
+	)
+	assert.ElementsMatch(t, ids(1, 3), traceIDs(found))
+}
+
+func (s *Storage) testGetTracesMissing(t *testing.T) {
+	s.write(t, dispatchTrace(base()))
+	assert.Empty(t, s.getTraces(t, &storagev2.GetTraceParams{TraceId: testTraceID(100)}))
+	// the missing IDs are ignored
+	found := s.getTraces(t, &storagev2.GetTraceParams{TraceId: testTraceID(100)}, &storagev2.GetTraceParams{TraceId: testTraceID(1)})
+	assert.Equal(t, ids(1), traceIDs(found))
+}
+
+func (s *Storage) testGetTracesLarge(t *testing.T) {
+	const spans = 5_000
+	// the spans of the trace are written in several requests
+	s.write(t, largeTrace(1, base(), spans, 1_000)...)
+	found := s.getTraces(t, &storagev2.GetTraceParams{TraceId: testTraceID(1)})
+	require.Len(t, found, 1)
+	assert.Len(t, found[0].spans, spans)
+}
+
+func (s *Storage) testGetServices(t *testing.T) {
+	s.writeFindTraces(t, base())
+	resp, err := s.TraceReader.GetServices(t.Context(), &storagev2.GetServicesRequest{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"frontend", "driver", "redis", "customer"}, resp.Services)
+}
+
+func (s *Storage) testGetOperations(t *testing.T) {
+	s.writeFindTraces(t, base())
+	operations := func(service, kind string) []string {
+		resp, err := s.TraceReader.GetOperations(t.Context(), &storagev2.GetOperationsRequest{Service: service, SpanKind: kind})
+		require.NoError(t, err)
+		var ops []string
+		for _, op := range resp.Operations {
+			ops = append(ops, op.Name+" ("+op.SpanKind+")")
+		}
+		return ops
+	}
+	assert.ElementsMatch(t, []string{"GET /dispatch (server)", "GET /config (server)", "FindNearest (client)"}, operations("frontend", ""))
+	assert.ElementsMatch(t, []string{"GET /dispatch (server)", "GET /config (server)"}, operations("frontend", "server"))
+	assert.ElementsMatch(t, []string{"FindNearest (client)"}, operations("frontend", "client"))
+	assert.Empty(t, operations("unknown", ""))
+}
+
+func (s *Storage) testFindTracesByService(t *testing.T) {
+	base := base()
+	s.writeFindTraces(t, base)
+	for service, expected := range map[string][]string{
+		"frontend": ids(1, 2, 3),
+		"driver":   ids(1),
+		"customer": ids(3),
+		"unknown":  nil,
+	} {
+		found := s.findTraces(t, &storagev2.TraceQueryParameters{ServiceName: service})
+		assert.ElementsMatch(t, expected, traceIDs(found), service)
+	}
+	// the traces are returned whole
+	found := s.findTraces(t, &storagev2.TraceQueryParameters{ServiceName: "redis"})
+	require.Len(t, found, 1)
+	assertSameSpans(t, dispatchTrace(base), found[0])
+}
+
+func (s *Storage) testFindTracesByOperation(t *testing.T) {
+	s.writeFindTraces(t, base())
+	for _, tc := range []struct {
+		service, operation string
+		expected           []string
+	}{
+		{"frontend", "GET /dispatch", ids(1, 3)},
+		{"frontend", "GET /config", ids(2)},
+		{"driver", "FindNearest", ids(1)},
+		{"driver", "GET /dispatch", nil},
+	} {
+		found := s.findTraces(t, &storagev2.TraceQueryParameters{ServiceName: tc.service, OperationName: tc.operation})
+		assert.ElementsMatch(t, tc.expected, traceIDs(found), "%s %s", tc.service, tc.operation)
+	}
+}
+
+func (s *Storage) testFindTracesByAttributes(t *testing.T) {
+	s.writeFindTraces(t, base())
+	str := func(key, value string) *storagev2.KeyValue {
+		return &storagev2.KeyValue{Key: key, Value: &storagev2.AnyValue{Value: &storagev2.AnyValue_StringValue{StringValue: value}}}
+	}
+	integer := func(key string, value int64) *storagev2.KeyValue {
+		return &storagev2.KeyValue{Key: key, Value: &storagev2.AnyValue{Value: &storagev2.AnyValue_IntValue{IntValue: value}}}
+	}
+	boolean := func(key string, value bool) *storagev2.KeyValue {
+		return &storagev2.KeyValue{Key: key, Value: &storagev2.AnyValue{Value: &storagev2.AnyValue_BoolValue{BoolValue: value}}}
+	}
+	for _, tc := range []struct {
+		name       string
+		service    string
+		attributes []*storagev2.KeyValue
+		expected   []string
+	}{
+		{"string", "frontend", []*storagev2.KeyValue{str("http.method", "GET")}, ids(1, 2, 3)},
+		{"int", "frontend", []*storagev2.KeyValue{integer("http.status_code", 500)}, ids(2)},
+		{"bool", "driver", []*storagev2.KeyValue{boolean("cache.hit", true)}, ids(1)},
+		{"other service", "driver", []*storagev2.KeyValue{str("region", "us")}, nil},
+		{"all match", "frontend", []*storagev2.KeyValue{str("http.method", "GET"), integer("http.status_code", 200)}, ids(1, 3)},
+		{"one mismatch", "frontend", []*storagev2.KeyValue{str("http.method", "POST"), integer("http.status_code", 200)}, nil},
+		{"resource", "customer", []*storagev2.KeyValue{str("host.name", "customer-host")}, ids(3)},
+	} {
+		found := s.findTraces(t, &storagev2.TraceQueryParameters{ServiceName: tc.service, Attributes: tc.attributes})
+		assert.ElementsMatch(t, tc.expected, traceIDs(found), tc.name)
+	}
+}
+
+func (s *Storage) testFindTracesByTimeRange(t *testing.T) {
+	base := base()
+	s.writeFindTraces(t, base)
+	for _, tc := range []struct {
+		name     string
+		min, max time.Time
+		expected []string
+	}{
+		{"after", base.Add(5 * time.Minute), time.Time{}, ids(2)},
+		{"before", time.Time{}, base.Add(-10 * time.Minute), ids(3)},
+		{"between", base.Add(-time.Minute), base.Add(time.Minute), ids(1)},
+		{"none", base.Add(time.Hour), time.Time{}, nil},
+	} {
+		query := &storagev2.TraceQueryParameters{ServiceName: "frontend"}
+		if !tc.min.IsZero() {
+			query.StartTimeMin = timestamppb.New(tc.min)
+		}
+		if !tc.max.IsZero() {
+			query.StartTimeMax = timestamppb.New(tc.max)
+		}
+		assert.ElementsMatch(t, tc.expected, traceIDs(s.findTraces(t, query)), tc.name)
+	}
+}
+
+func (s *Storage) testFindTracesByDuration(t *testing.T) {
+	s.writeFindTraces(t, base())
+	for _, tc := range []struct {
+		name     string
+		min, max time.Duration
+		expected []string
+	}{
+		{"min", time.Second, 0, ids(3)},
+		{"max", 0, 100 * time.Millisecond, ids(2)},
+		{"between", 100 * time.Millisecond, time.Second, ids(1)},
+	} {
+		query := &storagev2.TraceQueryParameters{ServiceName: "frontend"}
+		if tc.min > 0 {
+			query.DurationMin = durationpb.New(tc.min)
+		}
+		if tc.max > 0 {
+			query.DurationMax = durationpb.New(tc.max)
+		}
+		assert.ElementsMatch(t, tc.expected, traceIDs(s.findTraces(t, query)), tc.name)
+	}
+}
+
+func (s *Storage) testFindTracesSearchDepth(t *testing.T) {
+	s.writeFindTraces(t, base())
+	found := s.findTraces(t, &storagev2.TraceQueryParameters{ServiceName: "frontend", SearchDepth: 2})
+	assert.Len(t, found, 2)
+	assert.Subset(t, ids(1, 2, 3), traceIDs(found))
+}
+
+func (s *Storage) testFindTraceIDs(t *testing.T) {
+	base := base()
+	s.writeFindTraces(t, base)
+	query := &storagev2.TraceQueryParameters{ServiceName: "frontend", OperationName: "GET /dispatch"}
+	resp, err := s.TraceReader.FindTraceIDs(t.Context(), &storagev2.FindTracesRequest{Query: query})
+	require.NoError(t, err)
+	var found []string
+	for _, id := range resp.TraceIds {
+		found = append(found, hex.EncodeToString(id.TraceId))
+		// the time range is a rough hint, it must at least be consistent
+		if id.Start != nil && id.End != nil {
+			assert.False(t, id.End.AsTime().Before(id.Start.AsTime()), "trace %x ends before it starts", id.TraceId)
+		}
+	}
+	assert.ElementsMatch(t, ids(1, 3), found)
+	assert.ElementsMatch(t, found, traceIDs(s.findTraces(t, query)))
+
+	resp, err = s.TraceReader.FindTraceIDs(t.Context(), &storagev2.FindTracesRequest{Query: &storagev2.TraceQueryParameters{ServiceName: "unknown"}})
+	require.NoError(t, err)
+	assert.Empty(t, resp.TraceIds)
+}
+
+func (s *Storage) testGetDependencies(t *testing.T) {
+	if s.DependencyReader == nil {
+		t.Skip("no DependencyReader")
+	}
+	base := base()
+	s.writeFindTraces(t, base)
+	dependencies := func(start, end time.Time) []string {
+		resp, err := s.DependencyReader.GetDependencies(t.Context(), &storagev2.GetDependenciesRequest{
+			StartTime: timestamppb.New(start),
+			EndTime:   timestamppb.New(end),
+		})
+		require.NoError(t, err)
+		var deps []string
+		for _, dep := range resp.Dependencies {
+			deps = append(deps, fmt.Sprintf("%s -> %s: %d", dep.Parent, dep.Child, dep.CallCount))
+		}
+		return deps
+	}
+	assert.ElementsMatch(t, []string{
+		"frontend -> driver: 1",
+		"driver -> redis: 1",
+		"frontend -> customer: 1",
+	}, dependencies(base.Add(-time.Hour), base.Add(time.Hour)))
+	assert.ElementsMatch(t, []string{
+		"frontend -> driver: 1",
+		"driver -> redis: 1",
+	}, dependencies(base.Add(-time.Minute), base.Add(time.Minute)))
+	assert.Empty(t, dependencies(base.Add(time.Hour), base.Add(2*time.Hour)))
+}
+
+func (s *Storage) testRetention(t *testing.T) {
+	if s.ApplyRetention == nil {
+		t.Skip("no ApplyRetention")
+	}
+	now := time.Now().Truncate(time.Millisecond)
+	s.write(t, dispatchTrace(now.Add(-2*time.Hour)), configTrace(now.Add(-20*time.Minute)))
+	s.ApplyRetention(t, time.Hour)
+	if s.Refresh != nil {
+		s.Refresh(t)
+	}
+	found := s.getTraces(t, &storagev2.GetTraceParams{TraceId: testTraceID(1)}, &storagev2.GetTraceParams{TraceId: testTraceID(2)})
+	assert.Equal(t, ids(2), traceIDs(found))
+	assert.Equal(t, ids(2), traceIDs(s.findTraces(t, &storagev2.TraceQueryParameters{ServiceName: "frontend"})))
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	trace "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// chunkStream is a stream of the chunks.
+type chunkStream struct {
+	grpc.ClientStream
+
+	chunks []*trace.TracesData
+}
+
+func (s *chunkStream) Recv() (*trace.TracesData, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return chunk, nil
+}
+
+func TestReadTraces(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	large := largeTrace(1, start, 10, 4)
+	require.Len(t, large, 3)
+
+	found, err := readTraces(&chunkStream{chunks: append(large, configTrace(start))})
+	require.NoError(t, err)
+	assert.Equal(t, ids(1, 2), traceIDs(found))
+	assert.Len(t, found[0].spans, 10)
+	assertSameSpans(t, configTrace(start), found[1])
+
+	for name, chunks := range map[string][]*trace.TracesData{
+		"empty chunk":           {{}},
+		"mixed traces":          {{ResourceSpans: append(configTrace(start).ResourceSpans, slowDispatchTrace(start).ResourceSpans...)}},
+		"non-consecutive trace": {large[0], configTrace(start), large[1]},
+	} {
+		_, err := readTraces(&chunkStream{chunks: chunks})
+		require.Error(t, err, name)
+	}
+}